@@ -6,8 +6,10 @@ package admin
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/dioneprotocol/dionego/api"
+	"github.com/dioneprotocol/dionego/database/snapshot"
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/utils/logging"
 	"github.com/dioneprotocol/dionego/utils/rpc"
@@ -29,6 +31,12 @@ type Client interface {
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) error
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	SnapshotDatabase(ctx context.Context, path string, options ...rpc.Option) (string, *snapshot.Manifest, error)
+	BanNode(ctx context.Context, nodeID ids.NodeID, reason string, duration time.Duration, options ...rpc.Option) error
+	UnbanNode(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error
+	BanSubnet(ctx context.Context, subnet string, reason string, duration time.Duration, options ...rpc.Option) error
+	UnbanSubnet(ctx context.Context, subnet string, options ...rpc.Option) error
+	GetBans(context.Context, ...rpc.Option) ([]Ban, error)
 }
 
 // Client implementation for the Dione Platform Info API Endpoint
@@ -139,3 +147,54 @@ func (c *client) GetConfig(ctx context.Context, options ...rpc.Option) (interfac
 	err := c.requester.SendRequest(ctx, "admin.getConfig", struct{}{}, &res, options...)
 	return res, err
 }
+
+func (c *client) SnapshotDatabase(ctx context.Context, path string, options ...rpc.Option) (string, *snapshot.Manifest, error) {
+	res := &SnapshotDatabaseReply{}
+	err := c.requester.SendRequest(ctx, "admin.snapshotDatabase", &SnapshotDatabaseArgs{
+		Path: path,
+	}, res, options...)
+	return res.Path, res.Manifest, err
+}
+
+func (c *client) BanNode(ctx context.Context, nodeID ids.NodeID, reason string, duration time.Duration, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.banNode", &BanNodeArgs{
+		NodeID:   nodeID,
+		Reason:   reason,
+		Duration: formatBanDuration(duration),
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) UnbanNode(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.unbanNode", &UnbanNodeArgs{
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) BanSubnet(ctx context.Context, subnet string, reason string, duration time.Duration, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.banSubnet", &BanSubnetArgs{
+		Subnet:   subnet,
+		Reason:   reason,
+		Duration: formatBanDuration(duration),
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) UnbanSubnet(ctx context.Context, subnet string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.unbanSubnet", &UnbanSubnetArgs{
+		Subnet: subnet,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetBans(ctx context.Context, options ...rpc.Option) ([]Ban, error) {
+	res := &GetBansReply{}
+	err := c.requester.SendRequest(ctx, "admin.getBans", struct{}{}, res, options...)
+	return res.Bans, err
+}
+
+// formatBanDuration formats [duration] so that a duration of 0 is a ban that
+// never expires
+func formatBanDuration(duration time.Duration) string {
+	if duration == 0 {
+		return ""
+	}
+	return duration.String()
+}
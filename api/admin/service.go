@@ -5,17 +5,22 @@ package admin
 
 import (
 	"errors"
+	"net"
 	"net/http"
 	"path"
-
-	"github.com/gorilla/rpc/v2"
+	"path/filepath"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/dioneprotocol/dionego/api"
 	"github.com/dioneprotocol/dionego/api/server"
 	"github.com/dioneprotocol/dionego/chains"
+	"github.com/dioneprotocol/dionego/database/manager"
+	"github.com/dioneprotocol/dionego/database/snapshot"
 	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/network/banlist"
 	"github.com/dioneprotocol/dionego/snow/engine/common"
 	"github.com/dioneprotocol/dionego/utils"
 	"github.com/dioneprotocol/dionego/utils/constants"
@@ -35,8 +40,11 @@ const (
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
-	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errAliasTooLong     = errors.New("alias length is too long")
+	errNoLogLevel       = errors.New("need to specify either displayLevel or logLevel")
+	errNoSnapshotPath   = errors.New("need to specify a snapshot path")
+	errSnapshotInFlight = errors.New("a database snapshot is already being taken")
+	errNegativeDuration = errors.New("ban duration must be non-negative")
 )
 
 type Config struct {
@@ -48,18 +56,26 @@ type Config struct {
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	// DBManager manages the databases that snapshots are taken of. If the
+	// node's databases are encrypted, these must be the databases that the
+	// encrypted databases wrap, so that snapshots never contain plaintext.
+	DBManager manager.Manager
+	BanList   banlist.List
 }
 
 // Admin is the API service for node admin management
 type Admin struct {
 	Config
 	profiler profiler.Profiler
+
+	// snapshotLock is held while a database snapshot is being taken
+	snapshotLock sync.Mutex
 }
 
 // NewService returns a new admin API service.
 // All of the fields in [config] must be set.
 func NewService(config Config) (*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+	newServer := json.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -314,3 +330,213 @@ func (a *Admin) LoadVMs(r *http.Request, _ *struct{}, reply *LoadVMsReply) error
 	reply.NewVMs, err = ids.GetRelevantAliases(a.VMManager, loadedVMs)
 	return err
 }
+
+// SnapshotDatabaseArgs are the arguments for calling SnapshotDatabase
+type SnapshotDatabaseArgs struct {
+	// Path to write the snapshot to. If the path ends in [snapshot.ArchiveExtension]
+	// the snapshot is written as a gzipped tarball, otherwise it is written
+	// to a directory that must either not exist or be empty.
+	Path string `json:"path"`
+}
+
+// SnapshotDatabaseReply describes the snapshot that was written
+type SnapshotDatabaseReply struct {
+	Path     string             `json:"path"`
+	Manifest *snapshot.Manifest `json:"manifest"`
+}
+
+// SnapshotDatabase writes a point-in-time consistent snapshot of all of the
+// node's databases to the provided path while the node keeps running.
+func (a *Admin) SnapshotDatabase(_ *http.Request, args *SnapshotDatabaseArgs, reply *SnapshotDatabaseReply) error {
+	a.Log.Debug("Admin: SnapshotDatabase called",
+		logging.UserString("path", args.Path),
+	)
+
+	if len(args.Path) == 0 {
+		return errNoSnapshotPath
+	}
+
+	// Snapshots are expensive, so only one is allowed to run at a time.
+	if !a.snapshotLock.TryLock() {
+		return errSnapshotInFlight
+	}
+	defer a.snapshotLock.Unlock()
+
+	snapshotPath, err := filepath.Abs(args.Path)
+	if err != nil {
+		return err
+	}
+
+	var manifest *snapshot.Manifest
+	if snapshot.IsArchive(snapshotPath) {
+		manifest, err = snapshot.CreateArchive(a.DBManager, snapshotPath)
+	} else {
+		manifest, err = snapshot.Create(a.DBManager, snapshotPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	a.Log.Info("wrote database snapshot",
+		zap.String("path", snapshotPath),
+		zap.Int("numDatabases", len(manifest.Databases)),
+	)
+	reply.Path = snapshotPath
+	reply.Manifest = manifest
+	return nil
+}
+
+// BanNodeArgs are the arguments for calling BanNode
+type BanNodeArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	Reason string     `json:"reason"`
+	// Duration of the ban, such as "24h". If empty, the ban never expires.
+	Duration string `json:"duration"`
+}
+
+// BanNode refuses to connect to the node until the ban expires. If the node is
+// currently connected, it's disconnected the next time it's pinged.
+func (a *Admin) BanNode(_ *http.Request, args *BanNodeArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("Admin: BanNode called",
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("reason", args.Reason),
+		logging.UserString("duration", args.Duration),
+	)
+
+	duration, err := parseBanDuration(args.Duration)
+	if err != nil {
+		return err
+	}
+	return a.BanList.BanNode(args.NodeID, args.Reason, duration)
+}
+
+// UnbanNodeArgs are the arguments for calling UnbanNode
+type UnbanNodeArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// UnbanNode lifts the ban of the node
+func (a *Admin) UnbanNode(_ *http.Request, args *UnbanNodeArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("Admin: UnbanNode called",
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	return a.BanList.UnbanNode(args.NodeID)
+}
+
+// BanSubnetArgs are the arguments for calling BanSubnet
+type BanSubnetArgs struct {
+	// IP range in CIDR notation, such as "192.0.2.0/24". A single IP bans
+	// only that IP.
+	Subnet string `json:"subnet"`
+	Reason string `json:"reason"`
+	// Duration of the ban, such as "24h". If empty, the ban never expires.
+	Duration string `json:"duration"`
+}
+
+// BanSubnet refuses to connect to the peers in the IP range until the ban
+// expires. Connected peers in the range are disconnected the next time they're
+// pinged.
+func (a *Admin) BanSubnet(_ *http.Request, args *BanSubnetArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("Admin: BanSubnet called",
+		logging.UserString("subnet", args.Subnet),
+		logging.UserString("reason", args.Reason),
+		logging.UserString("duration", args.Duration),
+	)
+
+	subnet, err := parseSubnet(args.Subnet)
+	if err != nil {
+		return err
+	}
+	duration, err := parseBanDuration(args.Duration)
+	if err != nil {
+		return err
+	}
+	return a.BanList.BanSubnet(subnet, args.Reason, duration)
+}
+
+// UnbanSubnetArgs are the arguments for calling UnbanSubnet
+type UnbanSubnetArgs struct {
+	Subnet string `json:"subnet"`
+}
+
+// UnbanSubnet lifts the ban of the IP range
+func (a *Admin) UnbanSubnet(_ *http.Request, args *UnbanSubnetArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("Admin: UnbanSubnet called",
+		logging.UserString("subnet", args.Subnet),
+	)
+
+	subnet, err := parseSubnet(args.Subnet)
+	if err != nil {
+		return err
+	}
+	return a.BanList.UnbanSubnet(subnet)
+}
+
+// Ban describes a ban of either a node or an IP range
+type Ban struct {
+	NodeID *ids.NodeID `json:"nodeID,omitempty"`
+	Subnet string      `json:"subnet,omitempty"`
+	Reason string      `json:"reason"`
+	// Unix time the ban expires at. 0 if the ban never expires.
+	Expiry json.Uint64 `json:"expiry"`
+}
+
+// GetBansReply are the results from calling GetBans
+type GetBansReply struct {
+	Bans []Ban `json:"bans"`
+}
+
+// GetBans returns the bans that haven't expired
+func (a *Admin) GetBans(_ *http.Request, _ *struct{}, reply *GetBansReply) error {
+	a.Log.Debug("Admin: GetBans called")
+
+	bans := a.BanList.Bans()
+	reply.Bans = make([]Ban, len(bans))
+	for i, ban := range bans {
+		reply.Bans[i] = Ban{
+			NodeID: ban.NodeID,
+			Reason: ban.Reason,
+		}
+		if ban.Subnet != nil {
+			reply.Bans[i].Subnet = ban.Subnet.String()
+		}
+		if !ban.Expiry.IsZero() {
+			reply.Bans[i].Expiry = json.Uint64(ban.Expiry.Unix())
+		}
+	}
+	return nil
+}
+
+// parseBanDuration parses [duration], where an empty string is a ban that
+// never expires
+func parseBanDuration(duration string) (time.Duration, error) {
+	if len(duration) == 0 {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, errNegativeDuration
+	}
+	return d, nil
+}
+
+// parseSubnet parses [subnet] in CIDR notation, or as a single IP
+func parseSubnet(subnet string) (*net.IPNet, error) {
+	if ip := net.ParseIP(subnet); ip != nil {
+		bits := net.IPv6len * 8
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+			bits = net.IPv4len * 8
+		}
+		return &net.IPNet{
+			IP:   ip,
+			Mask: net.CIDRMask(bits, bits),
+		}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(subnet)
+	return ipNet, err
+}
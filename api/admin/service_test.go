@@ -4,15 +4,26 @@
 package admin
 
 import (
+	"bytes"
+	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dioneprotocol/dionego/api"
+	"github.com/dioneprotocol/dionego/database/encdb"
+	"github.com/dioneprotocol/dionego/database/manager"
+	"github.com/dioneprotocol/dionego/database/memdb"
+	"github.com/dioneprotocol/dionego/database/snapshot"
 	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/network/banlist"
 	"github.com/dioneprotocol/dionego/utils/logging"
+	"github.com/dioneprotocol/dionego/version"
 	"github.com/dioneprotocol/dionego/vms"
 	"github.com/dioneprotocol/dionego/vms/registry"
 )
@@ -118,3 +129,123 @@ func TestLoadVMsGetAliasesFails(t *testing.T) {
 
 	require.Equal(t, err, errTest)
 }
+
+func TestSnapshotDatabase(t *testing.T) {
+	require := require.New(t)
+
+	dbManager := manager.NewMemDB(version.Semantic1_0_0)
+	require.NoError(dbManager.Current().Database.Put([]byte("key"), []byte("value")))
+
+	admin := &Admin{Config: Config{
+		Log:       logging.NoLog{},
+		DBManager: dbManager,
+	}}
+
+	for _, name := range []string{"snapshot", "snapshot" + snapshot.ArchiveExtension} {
+		reply := SnapshotDatabaseReply{}
+		err := admin.SnapshotDatabase(
+			&http.Request{},
+			&SnapshotDatabaseArgs{Path: filepath.Join(t.TempDir(), name)},
+			&reply,
+		)
+		require.NoError(err)
+		require.Len(reply.Manifest.Databases, 1)
+		require.Equal(uint64(1), reply.Manifest.Databases[0].NumKeys)
+	}
+
+	err := admin.SnapshotDatabase(&http.Request{}, &SnapshotDatabaseArgs{}, &SnapshotDatabaseReply{})
+	require.ErrorIs(err, errNoSnapshotPath)
+}
+
+func TestSnapshotEncryptedDatabase(t *testing.T) {
+	require := require.New(t)
+
+	keyring, err := encdb.NewKeyring(encdb.Key{1})
+	require.NoError(err)
+
+	storedDBManager := manager.NewMemDB(version.Semantic1_0_0)
+	dbManager, err := storedDBManager.NewEncryptedDBManager(keyring, logging.NoLog{})
+	require.NoError(err)
+
+	plaintext := []byte("plaintext value")
+	require.NoError(dbManager.Current().Database.Put([]byte("key"), plaintext))
+
+	// Snapshots must not be taken of the decrypted databases
+	admin := &Admin{Config: Config{
+		Log:       logging.NoLog{},
+		DBManager: dbManager,
+	}}
+	snapshotDir := filepath.Join(t.TempDir(), "snapshot")
+	err = admin.SnapshotDatabase(
+		&http.Request{},
+		&SnapshotDatabaseArgs{Path: snapshotDir},
+		&SnapshotDatabaseReply{},
+	)
+	require.ErrorIs(err, snapshot.ErrDecryptedDatabase)
+
+	admin.DBManager = storedDBManager
+	require.NoError(admin.SnapshotDatabase(
+		&http.Request{},
+		&SnapshotDatabaseArgs{Path: snapshotDir},
+		&SnapshotDatabaseReply{},
+	))
+
+	files, err := os.ReadDir(snapshotDir)
+	require.NoError(err)
+	require.NotEmpty(files)
+	for _, file := range files {
+		fileBytes, err := os.ReadFile(filepath.Join(snapshotDir, file.Name()))
+		require.NoError(err)
+		require.False(bytes.Contains(fileBytes, plaintext), "%s contains plaintext", file.Name())
+	}
+}
+
+func TestBans(t *testing.T) {
+	require := require.New(t)
+
+	banList, err := banlist.New(logging.NoLog{}, memdb.New())
+	require.NoError(err)
+	admin := &Admin{Config: Config{
+		Log:     logging.NoLog{},
+		BanList: banList,
+	}}
+
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(admin.BanNode(&http.Request{}, &BanNodeArgs{
+		NodeID:   nodeID,
+		Reason:   "spam",
+		Duration: "1h",
+	}, &api.EmptyReply{}))
+	require.NoError(admin.BanSubnet(&http.Request{}, &BanSubnetArgs{
+		Subnet: "192.0.2.1",
+		Reason: "spam",
+	}, &api.EmptyReply{}))
+	require.True(banList.IsNodeBanned(nodeID))
+	require.True(banList.IsIPBanned(net.ParseIP("192.0.2.1")))
+	require.False(banList.IsIPBanned(net.ParseIP("192.0.2.2")))
+
+	reply := GetBansReply{}
+	require.NoError(admin.GetBans(&http.Request{}, nil, &reply))
+	require.Len(reply.Bans, 2)
+	for _, ban := range reply.Bans {
+		require.Equal("spam", ban.Reason)
+		if ban.NodeID != nil {
+			require.Equal(nodeID, *ban.NodeID)
+			require.NotZero(ban.Expiry)
+		} else {
+			require.Equal("192.0.2.1/32", ban.Subnet)
+			require.Zero(ban.Expiry)
+		}
+	}
+
+	require.NoError(admin.UnbanNode(&http.Request{}, &UnbanNodeArgs{NodeID: nodeID}, &api.EmptyReply{}))
+	require.NoError(admin.UnbanSubnet(&http.Request{}, &UnbanSubnetArgs{Subnet: "192.0.2.1/32"}, &api.EmptyReply{}))
+	require.NoError(admin.GetBans(&http.Request{}, nil, &reply))
+	require.Empty(reply.Bans)
+
+	err = admin.BanNode(&http.Request{}, &BanNodeArgs{
+		NodeID:   nodeID,
+		Duration: "-1h",
+	}, &api.EmptyReply{})
+	require.ErrorIs(err, errNegativeDuration)
+}
@@ -10,13 +10,14 @@ import (
 	"fmt"
 	"net/http"
 	"path"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v4"
 
-	"github.com/gorilla/rpc/v2"
+	"golang.org/x/time/rate"
 
 	"github.com/dioneprotocol/dionego/utils/json"
 	"github.com/dioneprotocol/dionego/utils/logging"
@@ -34,6 +35,9 @@ const (
 
 	// defaultTokenLifespan is how long a token lives before it expires
 	defaultTokenLifespan = time.Hour * 12
+	// maxTokenLifespan is the longest lifespan a token can be requested with
+	// over the API
+	maxTokenLifespan = time.Hour * 24 * 365
 
 	maxEndpoints = 128
 )
@@ -53,6 +57,8 @@ var (
 	errNoPassword                  = errors.New("no password")
 	errNoEndpoints                 = errors.New("must name at least one endpoint")
 	errTooManyEndpoints            = fmt.Errorf("can only name at most %d endpoints", maxEndpoints)
+	errNoTokenID                   = errors.New("token ID not provided")
+	errTokenLifespanTooLong        = fmt.Errorf("token lifespan can be at most %s", maxTokenLifespan)
 
 	_ Auth = (*auth)(nil)
 )
@@ -63,6 +69,14 @@ type Auth interface {
 	// If one of the elements of [endpoints] is "*", all APIs are accessible.
 	NewToken(pw string, duration time.Duration, endpoints []string) (string, error)
 
+	// Create and return a new token that allows the API calls permitted by
+	// [policy].
+	NewTokenWithPolicy(pw string, policy Policy) (string, error)
+
+	// Returns the tokens issued since this node started, or since the password
+	// was last changed, that haven't expired.
+	ListTokens(pw string) ([]TokenInfo, error)
+
 	// Revokes [token]; it will not be accepted as authorization for future API
 	// calls. If the token is invalid, this is a no-op.  If a token is revoked
 	// and then the password is changed, and then changed back to the current
@@ -70,9 +84,20 @@ type Auth interface {
 	// re-used before previously revoked tokens have expired.
 	RevokeToken(pw, token string) error
 
+	// Revokes the token with ID [tokenID]. This allows revoking a listed token,
+	// or a token that was issued before this node restarted, without having
+	// the token itself. The same caveats as for RevokeToken apply.
+	RevokeTokenByID(pw, tokenID string) error
+
 	// Authenticates [token] for access to [url].
+	// If the token restricts which JSON-RPC methods can be called, it isn't
+	// authenticated; use AuthenticateRequest instead.
 	AuthenticateToken(token, url string) error
 
+	// Authenticates [token] for calling [methods] on [url], and counts the
+	// calls against the token's rate limit.
+	AuthenticateRequest(token, url string, methods []string) error
+
 	// Change the password required to create and revoke tokens.
 	// [oldPW] is the current password.
 	// [newPW] is the new password. It can't be the empty string and it can't be
@@ -85,7 +110,8 @@ type Auth interface {
 	CreateHandler() (http.Handler, error)
 
 	// WrapHandler wraps an http.Handler. Before passing a request to the
-	// provided handler, the auth token is authenticated.
+	// provided handler, the auth token is authenticated for the endpoint and
+	// the JSON-RPC methods of the request.
 	WrapHandler(h http.Handler) http.Handler
 }
 
@@ -101,12 +127,27 @@ type auth struct {
 	password password.Hash
 	// Set of token IDs that have been revoked
 	revoked set.Set[string]
+	// Token ID --> Token issued since the node started or since the password
+	// was last changed
+	issued map[string]*TokenInfo
+
+	limitersLock sync.Mutex
+	// Token ID --> Rate limiter of the token.
+	// Only contains tokens that have been used and have a rate limit.
+	limiters map[string]*tokenLimiter
+}
+
+type tokenLimiter struct {
+	limiter   *rate.Limiter
+	expiresAt time.Time
 }
 
 func New(log logging.Logger, endpoint, pw string) (Auth, error) {
 	a := &auth{
 		log:      log,
 		endpoint: endpoint,
+		issued:   make(map[string]*TokenInfo),
+		limiters: make(map[string]*tokenLimiter),
 	}
 	return a, a.password.Set(pw)
 }
@@ -116,28 +157,35 @@ func NewFromHash(log logging.Logger, endpoint string, pw password.Hash) Auth {
 		log:      log,
 		endpoint: endpoint,
 		password: pw,
+		issued:   make(map[string]*TokenInfo),
+		limiters: make(map[string]*tokenLimiter),
 	}
 }
 
 func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (string, error) {
+	return a.NewTokenWithPolicy(pw, Policy{
+		Duration:  duration,
+		Endpoints: endpoints,
+	})
+}
+
+func (a *auth) NewTokenWithPolicy(pw string, policy Policy) (string, error) {
 	if pw == "" {
 		return "", errNoPassword
 	}
-	if l := len(endpoints); l == 0 {
-		return "", errNoEndpoints
-	} else if l > maxEndpoints {
-		return "", errTooManyEndpoints
+	if err := policy.verify(); err != nil {
+		return "", err
 	}
 
-	a.lock.RLock()
-	defer a.lock.RUnlock()
+	a.lock.Lock()
+	defer a.lock.Unlock()
 
 	if !a.password.Check(pw) {
 		return "", errWrongPassword
 	}
 
 	canAccessAll := false
-	for _, endpoint := range endpoints {
+	for _, endpoint := range policy.Endpoints {
 		if endpoint == "*" {
 			canAccessAll = true
 			break
@@ -150,19 +198,66 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 	}
 	id := base64.RawURLEncoding.EncodeToString(idBytes[:])
 
+	now := a.clock.Time()
 	claims := endpointClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(a.clock.Time().Add(duration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(policy.Duration)),
 			ID:        id,
 		},
+		Methods:       policy.Methods,
+		DeniedMethods: policy.DeniedMethods,
 	}
 	if canAccessAll {
 		claims.Endpoints = []string{"*"}
 	} else {
-		claims.Endpoints = endpoints
+		claims.Endpoints = policy.Endpoints
+	}
+	if policy.RateLimit > 0 {
+		claims.RateLimit = policy.RateLimit
+		claims.RateBurst = policy.RateBurst
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
-	return token.SignedString(a.password.Password[:]) // Sign the token and return its string repr.
+	tokenStr, err := token.SignedString(a.password.Password[:]) // Sign the token and return its string repr.
+	if err != nil {
+		return "", err
+	}
+
+	a.pruneIssued(now)
+	a.issued[id] = &TokenInfo{
+		ID:            id,
+		Endpoints:     claims.Endpoints,
+		Methods:       claims.Methods,
+		DeniedMethods: claims.DeniedMethods,
+		RateLimit:     claims.RateLimit,
+		RateBurst:     claims.RateBurst,
+		IssuedAt:      claims.IssuedAt.Time,
+		ExpiresAt:     claims.ExpiresAt.Time,
+	}
+	return tokenStr, nil
+}
+
+func (a *auth) ListTokens(pw string) ([]TokenInfo, error) {
+	if pw == "" {
+		return nil, errNoPassword
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.password.Check(pw) {
+		return nil, errWrongPassword
+	}
+
+	a.pruneIssued(a.clock.Time())
+	tokens := make([]TokenInfo, 0, len(a.issued))
+	for _, token := range a.issued {
+		tokens = append(tokens, *token)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].IssuedAt.Before(tokens[j].IssuedAt)
+	})
+	return tokens, nil
 }
 
 func (a *auth) RevokeToken(tokenStr, pw string) error {
@@ -195,17 +290,85 @@ func (a *auth) RevokeToken(tokenStr, pw string) error {
 	if !ok {
 		return fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
 	}
-	a.revoked.Add(claims.ID)
+	a.revoke(claims.ID)
 	return nil
 }
 
+func (a *auth) RevokeTokenByID(pw, tokenID string) error {
+	if tokenID == "" {
+		return errNoTokenID
+	}
+	if pw == "" {
+		return errNoPassword
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.password.Check(pw) {
+		return errWrongPassword
+	}
+	a.revoke(tokenID)
+	return nil
+}
+
+// Assumes [a.lock] is held
+func (a *auth) revoke(tokenID string) {
+	a.revoked.Add(tokenID)
+	if token, ok := a.issued[tokenID]; ok {
+		token.Revoked = true
+	}
+}
+
 func (a *auth) AuthenticateToken(tokenStr, url string) error {
+	claims, err := a.authenticate(tokenStr, url)
+	if err != nil {
+		return err
+	}
+	if claims.restrictsMethods() {
+		return errMethodNotPermitted
+	}
+	return nil
+}
+
+func (a *auth) AuthenticateRequest(tokenStr, url string, methods []string) error {
+	claims, err := a.authenticate(tokenStr, url)
+	if err != nil {
+		return err
+	}
+	return a.authorizeCalls(claims, methods)
+}
+
+// Returns nil if the token with [claims] allows calling [methods] now
+func (a *auth) authorizeCalls(claims *endpointClaims, methods []string) error {
+	if claims.restrictsMethods() {
+		if err := verifyMethods(claims, methods); err != nil {
+			return err
+		}
+	}
+	if claims.RateLimit <= 0 {
+		return nil
+	}
+
+	// Each JSON-RPC call of a batch counts against the rate limit
+	numCalls := len(methods)
+	if numCalls == 0 {
+		numCalls = 1
+	}
+	if !a.tokenLimiter(claims).AllowN(a.clock.Time(), numCalls) {
+		return errRateLimitExceeded
+	}
+	return nil
+}
+
+// Returns the claims of [tokenStr] if it allows access to [url]
+func (a *auth) authenticate(tokenStr, url string) (*endpointClaims, error) {
 	a.lock.RLock()
 	defer a.lock.RUnlock()
 
 	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, a.getTokenKey)
 	if err != nil { // Probably because signature wrong
-		return err
+		return nil, err
 	}
 
 	// Make sure this token gives access to the requested endpoint
@@ -213,20 +376,57 @@ func (a *auth) AuthenticateToken(tokenStr, url string) error {
 	if !ok {
 		// Error is intentionally dropped here as there is nothing left to do
 		// with it.
-		return fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
+		return nil, fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
 	}
 
 	_, revoked := a.revoked[claims.ID]
 	if revoked {
-		return errTokenRevoked
+		return nil, errTokenRevoked
 	}
 
 	for _, endpoint := range claims.Endpoints {
 		if endpoint == "*" || strings.HasSuffix(url, endpoint) {
-			return nil
+			return claims, nil
+		}
+	}
+	return nil, errTokenInsufficientPermission
+}
+
+// Returns the rate limiter of the token with [claims], creating it if this is
+// the first time the token is used.
+func (a *auth) tokenLimiter(claims *endpointClaims) *rate.Limiter {
+	a.limitersLock.Lock()
+	defer a.limitersLock.Unlock()
+
+	if l, ok := a.limiters[claims.ID]; ok {
+		return l.limiter
+	}
+
+	// Stop tracking the limiters of expired tokens
+	now := a.clock.Time()
+	for tokenID, l := range a.limiters {
+		if !now.Before(l.expiresAt) {
+			delete(a.limiters, tokenID)
+		}
+	}
+
+	l := &tokenLimiter{
+		limiter: newTokenLimiter(claims),
+	}
+	if claims.ExpiresAt != nil {
+		l.expiresAt = claims.ExpiresAt.Time
+	}
+	a.limiters[claims.ID] = l
+	return l.limiter
+}
+
+// Removes expired tokens from [a.issued]. Assumes [a.lock] is held.
+func (a *auth) pruneIssued(now time.Time) {
+	for tokenID, token := range a.issued {
+		if !now.Before(token.ExpiresAt) {
+			delete(a.issued, tokenID)
 		}
 	}
-	return errTokenInsufficientPermission
 }
 
 func (a *auth) ChangePassword(oldPW, newPW string) error {
@@ -250,11 +450,12 @@ func (a *auth) ChangePassword(oldPW, newPW string) error {
 	// All the revoked tokens are now invalid; no need to mark specifically as
 	// revoked.
 	a.revoked.Clear()
+	a.issued = make(map[string]*TokenInfo)
 	return nil
 }
 
 func (a *auth) CreateHandler() (http.Handler, error) {
-	server := rpc.NewServer()
+	server := json.NewServer()
 	codec := json.NewCodec()
 	server.RegisterCodec(codec, "application/json")
 	server.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -287,7 +488,27 @@ func (a *auth) WrapHandler(h http.Handler) http.Handler {
 		// Returns actual auth token. Slice guaranteed to not go OOB
 		tokenStr := rawHeader[len(headerValStart):]
 
-		if err := a.AuthenticateToken(tokenStr, r.URL.Path); err != nil {
+		claims, err := a.authenticate(tokenStr, r.URL.Path)
+		if err != nil {
+			writeUnauthorizedResponse(w, err)
+			return
+		}
+
+		// Only read the body if the token's policy depends on it
+		var methods []string
+		if claims.restrictsMethods() || claims.RateLimit > 0 {
+			methods, err = json.RequestMethods(r, maxRequestBodySize)
+			if err != nil {
+				writeUnauthorizedResponse(w, err)
+				return
+			}
+		}
+		switch err := a.authorizeCalls(claims, methods); err {
+		case nil:
+		case errRateLimitExceeded:
+			writeTooManyRequestsResponse(w, err)
+			return
+		default:
 			writeUnauthorizedResponse(w, err)
 			return
 		}
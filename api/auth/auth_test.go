@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -343,3 +344,141 @@ func TestWrapHandlerInvalidSigningMethod(t *testing.T) {
 		require.Regexp(t, unAuthorizedResponseRegex, rr.Body.String())
 	}
 }
+
+func TestWrapHandlerMethodPolicy(t *testing.T) {
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+
+	tokenStr, err := auth.NewTokenWithPolicy(testPassword, Policy{
+		Duration:      defaultTokenLifespan,
+		Endpoints:     []string{"*"},
+		Methods:       []string{"platform.*", "info.getNodeID"},
+		DeniedMethods: []string{"platform.importKey"},
+	})
+	require.NoError(t, err)
+
+	wrappedHandler := auth.WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The handler can still read the body
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NotEmpty(t, body)
+	}))
+
+	tests := []struct {
+		body         string
+		expectedCode int
+	}{
+		{`{"jsonrpc":"2.0","id":1,"method":"platform.getCurrentValidators"}`, http.StatusOK},
+		{`{"jsonrpc":"2.0","id":1,"method":"Platform.GetHeight"}`, http.StatusOK},
+		{`{"jsonrpc":"2.0","id":1,"method":"info.getNodeID"}`, http.StatusOK},
+		{`{"jsonrpc":"2.0","id":1,"method":"info.peers"}`, http.StatusUnauthorized},
+		{`{"jsonrpc":"2.0","id":1,"method":"platform.importKey"}`, http.StatusUnauthorized},
+		{`{"jsonrpc":"2.0","id":1,"method":"platform.ImportKey"}`, http.StatusUnauthorized},
+		{`{"jsonrpc":"2.0","id":1,"method":"platform."}`, http.StatusUnauthorized},
+		{`[{"method":"platform.getHeight"},{"method":"info.getNodeID"}]`, http.StatusOK},
+		{`[{"method":"platform.getHeight"},{"method":"platform.importKey"}]`, http.StatusUnauthorized},
+		{`not json`, http.StatusUnauthorized},
+		{``, http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:9650/ext/P", strings.NewReader(test.body))
+		req.Header.Add("Authorization", "Bearer "+tokenStr)
+		rr := httptest.NewRecorder()
+		wrappedHandler.ServeHTTP(rr, req)
+		require.Equal(t, test.expectedCode, rr.Code, test.body)
+	}
+
+	// Tokens that restrict methods can't be authenticated without the methods
+	err = auth.AuthenticateToken(tokenStr, "/ext/P")
+	require.ErrorIs(t, err, errMethodNotPermitted)
+}
+
+func TestWrapHandlerRateLimit(t *testing.T) {
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+
+	now := time.Now()
+	auth.clock.Set(now)
+
+	tokenStr, err := auth.NewTokenWithPolicy(testPassword, Policy{
+		Duration:  defaultTokenLifespan,
+		Endpoints: []string{"*"},
+		RateLimit: 1,
+		RateBurst: 2,
+	})
+	require.NoError(t, err)
+
+	wrappedHandler := auth.WrapHandler(dummyHandler)
+	call := func(body string) int {
+		req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:9650/ext/info", strings.NewReader(body))
+		req.Header.Add("Authorization", "Bearer "+tokenStr)
+		rr := httptest.NewRecorder()
+		wrappedHandler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	require.Equal(t, http.StatusOK, call(`{"method":"info.getNodeID"}`))
+	require.Equal(t, http.StatusOK, call(`{"method":"info.getNodeID"}`))
+	require.Equal(t, http.StatusTooManyRequests, call(`{"method":"info.getNodeID"}`))
+
+	// Each call of a batch counts against the limit
+	auth.clock.Set(now.Add(2 * time.Second))
+	require.Equal(t, http.StatusTooManyRequests, call(`[{"method":"info.getNodeID"},{"method":"info.peers"},{"method":"info.uptime"}]`))
+	require.Equal(t, http.StatusOK, call(`[{"method":"info.getNodeID"},{"method":"info.peers"}]`))
+	require.Equal(t, http.StatusTooManyRequests, call(`{"method":"info.getNodeID"}`))
+
+	// Calls of a batch without a method count against the limit
+	auth.clock.Set(now.Add(4 * time.Second))
+	require.Equal(t, http.StatusTooManyRequests, call(`[1,{},{"method":"info.getNodeID"}]`))
+}
+
+func TestListTokensAndRevokeTokenByID(t *testing.T) {
+	require := require.New(t)
+
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+
+	now := time.Now()
+	auth.clock.Set(now)
+
+	tokenStr, err := auth.NewTokenWithPolicy(testPassword, Policy{
+		Duration:  time.Hour,
+		Endpoints: []string{"/ext/info"},
+		Methods:   []string{"info.*"},
+		RateLimit: 5,
+	})
+	require.NoError(err)
+	_, err = auth.NewToken(testPassword, 2*time.Hour, []string{"*"})
+	require.NoError(err)
+
+	_, err = auth.ListTokens("notThePassword")
+	require.ErrorIs(err, errWrongPassword)
+
+	tokens, err := auth.ListTokens(testPassword)
+	require.NoError(err)
+	require.Len(tokens, 2)
+	require.Equal([]string{"/ext/info"}, tokens[0].Endpoints)
+	require.Equal([]string{"info.*"}, tokens[0].Methods)
+	require.Equal(float64(5), tokens[0].RateLimit)
+	require.Equal(now.Add(time.Hour).Unix(), tokens[0].ExpiresAt.Unix())
+	require.False(tokens[0].Revoked)
+	require.Equal([]string{"*"}, tokens[1].Endpoints)
+
+	require.NoError(auth.RevokeTokenByID(testPassword, tokens[0].ID))
+	err = auth.AuthenticateRequest(tokenStr, "/ext/info", []string{"info.getNodeID"})
+	require.ErrorIs(err, errTokenRevoked)
+
+	tokens, err = auth.ListTokens(testPassword)
+	require.NoError(err)
+	require.True(tokens[0].Revoked)
+
+	// Expired tokens aren't listed
+	auth.clock.Set(now.Add(time.Hour))
+	tokens, err = auth.ListTokens(testPassword)
+	require.NoError(err)
+	require.Len(tokens, 1)
+
+	// Changing the password invalidates the listed tokens
+	newPassword := "fejhkefjhefjhefhje" // #nosec G101
+	require.NoError(auth.ChangePassword(testPassword, newPassword))
+	tokens, err = auth.ListTokens(newPassword)
+	require.NoError(err)
+	require.Empty(tokens)
+}
@@ -15,4 +15,25 @@ type endpointClaims struct {
 	// If endpoints has an element "*", allows access to all API endpoints
 	// In this case, "*" should be the only element of [endpoints]
 	Endpoints []string `json:"endpoints,omitempty"`
+
+	// If non-empty, the token only allows calling the JSON-RPC methods that
+	// match an element of [Methods]
+	Methods []string `json:"methods,omitempty"`
+
+	// The token doesn't allow calling the JSON-RPC methods that match an
+	// element of [DeniedMethods], even if they match an element of [Methods]
+	DeniedMethods []string `json:"deniedMethods,omitempty"`
+
+	// If positive, the max average number of JSON-RPC calls per second the
+	// token allows
+	RateLimit float64 `json:"rateLimit,omitempty"`
+
+	// Max number of JSON-RPC calls the token allows in a burst.
+	// Only used if [RateLimit] is positive.
+	RateBurst int `json:"rateBurst,omitempty"`
+}
+
+// Returns true if the token restricts which JSON-RPC methods can be called
+func (c *endpointClaims) restrictsMethods() bool {
+	return len(c.Methods) > 0 || len(c.DeniedMethods) > 0
 }
@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/dioneprotocol/dionego/utils/units"
+)
+
+const (
+	maxMethods = 128
+
+	// Max size of a request body that is read to find the JSON-RPC methods it
+	// calls
+	maxRequestBodySize = 16 * units.MiB
+)
+
+var (
+	errTooManyMethods     = fmt.Errorf("can only name at most %d methods", maxMethods)
+	errInvalidRateLimit   = errors.New("rate limit can't be negative")
+	errInvalidRateBurst   = errors.New("rate burst can't be negative")
+	errNoMethods          = errors.New("request doesn't call a JSON-RPC method")
+	errRateLimitExceeded  = errors.New("the provided auth token exceeded its rate limit")
+	errMethodNotPermitted = errors.New("the provided auth token does not allow calling this method")
+)
+
+// Policy restricts the API calls a token allows
+type Policy struct {
+	// Time the token is valid for
+	Duration time.Duration
+
+	// The token allows access to each API endpoint such that the API's path
+	// ends with an element of [Endpoints]. If one of the elements of
+	// [Endpoints] is "*", all APIs are accessible.
+	Endpoints []string
+
+	// If non-empty, the token only allows calling the JSON-RPC methods that
+	// match an element of [Methods]. An element matches a method if it's the
+	// method's name, like "platform.getCurrentValidators", or if it's "*" or
+	// "service.*" and the method is of the service, like "platform.*".
+	Methods []string
+
+	// The token doesn't allow calling the JSON-RPC methods that match an
+	// element of [DeniedMethods], even if they match an element of [Methods].
+	DeniedMethods []string
+
+	// If positive, the max average number of JSON-RPC calls per second the
+	// token allows
+	RateLimit float64
+
+	// Max number of JSON-RPC calls the token allows in a burst. If zero, the
+	// burst is [RateLimit], rounded up. Only used if [RateLimit] is positive.
+	RateBurst int
+}
+
+func (p *Policy) verify() error {
+	switch l := len(p.Endpoints); {
+	case l == 0:
+		return errNoEndpoints
+	case l > maxEndpoints:
+		return errTooManyEndpoints
+	case len(p.Methods) > maxMethods, len(p.DeniedMethods) > maxMethods:
+		return errTooManyMethods
+	case p.RateLimit < 0:
+		return errInvalidRateLimit
+	case p.RateBurst < 0:
+		return errInvalidRateBurst
+	default:
+		return nil
+	}
+}
+
+// TokenInfo describes a token issued by this node
+type TokenInfo struct {
+	ID            string    `json:"id"`
+	Endpoints     []string  `json:"endpoints"`
+	Methods       []string  `json:"methods,omitempty"`
+	DeniedMethods []string  `json:"deniedMethods,omitempty"`
+	RateLimit     float64   `json:"rateLimit,omitempty"`
+	RateBurst     int       `json:"rateBurst,omitempty"`
+	IssuedAt      time.Time `json:"issuedAt"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+	Revoked       bool      `json:"revoked"`
+}
+
+// Returns nil if the token with [claims] allows calling each of [methods]
+func verifyMethods(claims *endpointClaims, methods []string) error {
+	if len(methods) == 0 {
+		return errNoMethods
+	}
+	for _, method := range methods {
+		if len(claims.Methods) > 0 && !matchesMethod(claims.Methods, method) {
+			return fmt.Errorf("%w: %q", errMethodNotPermitted, method)
+		}
+		if matchesMethod(claims.DeniedMethods, method) {
+			return fmt.Errorf("%w: %q", errMethodNotPermitted, method)
+		}
+	}
+	return nil
+}
+
+// Returns true if an element of [patterns] matches [method].
+// Method names are case insensitive.
+func matchesMethod(patterns []string, method string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" || strings.EqualFold(pattern, method) {
+			return true
+		}
+		if !strings.HasSuffix(pattern, ".*") {
+			continue
+		}
+		// Includes the "."
+		service := pattern[:len(pattern)-1]
+		if len(method) > len(service) && strings.EqualFold(method[:len(service)], service) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the rate limiter of the token with [claims]
+func newTokenLimiter(claims *endpointClaims) *rate.Limiter {
+	burst := claims.RateBurst
+	if burst == 0 {
+		burst = int(math.Ceil(claims.RateLimit))
+	}
+	return rate.NewLimiter(rate.Limit(claims.RateLimit), burst)
+}
@@ -5,8 +5,10 @@ package auth
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/dioneprotocol/dionego/api"
+	"github.com/dioneprotocol/dionego/utils/json"
 )
 
 // Service that serves the Auth API functionality.
@@ -26,6 +28,23 @@ type NewTokenArgs struct {
 	// allows access to all API endpoints. [Endpoints] must have between 1 and
 	// [maxEndpoints] elements
 	Endpoints []string `json:"endpoints"`
+	// If non-empty, the token only allows calling these JSON-RPC methods e.g.
+	// ["platform.getCurrentValidators", "info.*"]. An element "service.*"
+	// allows every method of the service and an element "*" allows every
+	// method.
+	Methods []string `json:"methods"`
+	// JSON-RPC methods the token doesn't allow calling, even if they're in
+	// [Methods] e.g. ["platform.importKey"]. Supports the same patterns as
+	// [Methods].
+	DeniedMethods []string `json:"deniedMethods"`
+	// If non-zero, the max average number of JSON-RPC calls per second that can
+	// be made with the token
+	RateLimit json.Float64 `json:"rateLimit"`
+	// Max number of JSON-RPC calls that can be made with the token in a burst.
+	// Defaults to [RateLimit], rounded up.
+	RateBurst json.Uint32 `json:"rateBurst"`
+	// Number of seconds until the token expires. Defaults to 12 hours.
+	ExpiresIn json.Uint64 `json:"expiresIn"`
 }
 
 type Token struct {
@@ -35,8 +54,37 @@ type Token struct {
 func (s *Service) NewToken(_ *http.Request, args *NewTokenArgs, reply *Token) error {
 	s.auth.log.Debug("Auth: NewToken called")
 
+	duration := defaultTokenLifespan
+	if args.ExpiresIn > 0 {
+		if uint64(args.ExpiresIn) > uint64(maxTokenLifespan/time.Second) {
+			return errTokenLifespanTooLong
+		}
+		duration = time.Duration(args.ExpiresIn) * time.Second
+	}
+
+	var err error
+	reply.Token, err = s.auth.NewTokenWithPolicy(args.Password.Password, Policy{
+		Duration:      duration,
+		Endpoints:     args.Endpoints,
+		Methods:       args.Methods,
+		DeniedMethods: args.DeniedMethods,
+		RateLimit:     float64(args.RateLimit),
+		RateBurst:     int(args.RateBurst),
+	})
+	return err
+}
+
+type ListTokensReply struct {
+	Tokens []TokenInfo `json:"tokens"`
+}
+
+// ListTokens returns the unexpired tokens issued since the node started or
+// since the password was last changed
+func (s *Service) ListTokens(_ *http.Request, args *Password, reply *ListTokensReply) error {
+	s.auth.log.Debug("Auth: ListTokens called")
+
 	var err error
-	reply.Token, err = s.auth.NewToken(args.Password.Password, defaultTokenLifespan, args.Endpoints)
+	reply.Tokens, err = s.auth.ListTokens(args.Password)
 	return err
 }
 
@@ -51,6 +99,18 @@ func (s *Service) RevokeToken(_ *http.Request, args *RevokeTokenArgs, _ *api.Emp
 	return s.auth.RevokeToken(args.Token.Token, args.Password.Password)
 }
 
+type RevokeTokenByIDArgs struct {
+	Password
+	// ID of the token to revoke, as returned by ListTokens
+	ID string `json:"id"`
+}
+
+func (s *Service) RevokeTokenByID(_ *http.Request, args *RevokeTokenByIDArgs, _ *api.EmptyReply) error {
+	s.auth.log.Debug("Auth: RevokeTokenByID called")
+
+	return s.auth.RevokeTokenByID(args.Password.Password, args.ID)
+}
+
 type ChangePasswordArgs struct {
 	OldPassword string `json:"oldPassword"` // Current authorization password
 	NewPassword string `json:"newPassword"` // New authorization password
@@ -6,8 +6,6 @@ package ipcs
 import (
 	"net/http"
 
-	"github.com/gorilla/rpc/v2"
-
 	"go.uber.org/zap"
 
 	"github.com/dioneprotocol/dionego/api"
@@ -38,7 +36,7 @@ func NewService(log logging.Logger, chainManager chains.Manager, httpServer serv
 		ipcs: ipcs,
 	}
 
-	newServer := rpc.NewServer()
+	newServer := json.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
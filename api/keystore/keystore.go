@@ -9,8 +9,6 @@ import (
 	"net/http"
 	"sync"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/dioneprotocol/dionego/chains/atomic"
 	"github.com/dioneprotocol/dionego/database"
 	"github.com/dioneprotocol/dionego/database/encdb"
@@ -120,7 +118,7 @@ func New(log logging.Logger, dbManager manager.Manager) Keystore {
 }
 
 func (ks *keystore) CreateHandler() (http.Handler, error) {
-	newServer := rpc.NewServer()
+	newServer := json.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -13,9 +13,10 @@ import (
 )
 
 type metrics struct {
-	numProcessing *prometheus.GaugeVec
-	numCalls      *prometheus.CounterVec
-	totalDuration *prometheus.GaugeVec
+	numProcessing  *prometheus.GaugeVec
+	numCalls       *prometheus.CounterVec
+	totalDuration  *prometheus.GaugeVec
+	numRateLimited prometheus.Counter
 }
 
 func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, error) {
@@ -44,6 +45,13 @@ func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, e
 			},
 			[]string{"base"},
 		),
+		numRateLimited: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "calls_rate_limited",
+				Help:      "The number of calls this API has rejected because the client exceeded its rate limit",
+			},
+		),
 	}
 
 	errs := wrappers.Errs{}
@@ -51,6 +59,7 @@ func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, e
 		registerer.Register(m.numProcessing),
 		registerer.Register(m.numCalls),
 		registerer.Register(m.totalDuration),
+		registerer.Register(m.numRateLimited),
 	)
 	return m, errs.Err
 }
@@ -0,0 +1,240 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	rpc "github.com/gorilla/rpc/v2/json2"
+
+	"github.com/dioneprotocol/dionego/cache"
+	"github.com/dioneprotocol/dionego/utils/hashing"
+	"github.com/dioneprotocol/dionego/utils/timer/mockable"
+	"github.com/dioneprotocol/dionego/utils/units"
+
+	utilsjson "github.com/dioneprotocol/dionego/utils/json"
+)
+
+const (
+	// DefaultRateLimitMaxClients is a reasonable default value for the number
+	// of clients whose rate limits are tracked at once
+	DefaultRateLimitMaxClients = 10_000
+
+	// Cost of a call to a JSON-RPC method that isn't given a cost, or of a
+	// request that doesn't call a JSON-RPC method
+	defaultCallCost = 1
+
+	// Max size of a request body that is read to find the JSON-RPC methods it
+	// calls
+	maxRateLimitedBodySize = 16 * units.MiB
+
+	authHeaderKey      = "Authorization"
+	authHeaderValStart = "Bearer "
+)
+
+var (
+	errInvalidRate       = errors.New("rate limit can't be negative")
+	errInvalidBurst      = errors.New("rate limit burst must be positive")
+	errInvalidCost       = errors.New("method cost must be positive")
+	errInvalidMaxClients = errors.New("max rate limited clients must be positive")
+)
+
+// RateLimitConfig limits the rate at which each client can call the API.
+//
+// Each client has a budget that refills at [Rate] per second, up to [Burst].
+// Each JSON-RPC call costs its method's cost, so expensive methods can be
+// called less often than cheap ones.
+type RateLimitConfig struct {
+	// Average cost per second each client can spend. If zero, calls aren't
+	// rate limited.
+	Rate float64 `json:"rate"`
+
+	// Max cost each client can spend at once
+	Burst int `json:"burst"`
+
+	// Method --> Cost of calling the method, e.g. "avm.getAddressTxs" --> 10.
+	// Methods that aren't given a cost cost 1.
+	MethodCosts map[string]int `json:"methodCosts"`
+
+	// If true, requests with an auth token are limited per auth token, rather
+	// than per IP. Should only be set if auth tokens are required, otherwise
+	// a client can use a different token for each request.
+	KeyByToken bool `json:"keyByToken"`
+
+	// Max number of clients whose rate limits are tracked at once. If more
+	// clients call the API, the least recently seen client's limit is reset.
+	MaxClients int `json:"maxClients"`
+}
+
+func (c *RateLimitConfig) Verify() error {
+	switch {
+	case c.Rate < 0:
+		return errInvalidRate
+	case c.Rate == 0:
+		return nil
+	case c.Burst <= 0:
+		return errInvalidBurst
+	case c.MaxClients <= 0:
+		return errInvalidMaxClients
+	}
+	for method, cost := range c.MethodCosts {
+		if cost <= 0 {
+			return fmt.Errorf("%w: %q costs %d", errInvalidCost, method, cost)
+		}
+	}
+	return nil
+}
+
+type rateLimiter struct {
+	clock   mockable.Clock
+	config  RateLimitConfig
+	metrics *metrics
+	// Lowercased method --> Cost of calling the method
+	methodCosts map[string]int
+
+	lock sync.Mutex
+	// Client --> Rate limiter of the client
+	clients cache.LRU[string, *rate.Limiter]
+}
+
+func newRateLimiter(config RateLimitConfig, metrics *metrics) *rateLimiter {
+	r := &rateLimiter{
+		config:      config,
+		metrics:     metrics,
+		methodCosts: make(map[string]int, len(config.MethodCosts)),
+		clients:     cache.LRU[string, *rate.Limiter]{Size: config.MaxClients},
+	}
+	for method, cost := range config.MethodCosts {
+		r.methodCosts[strings.ToLower(method)] = cost
+	}
+	return r
+}
+
+// wrapHandler rejects requests from clients that have exceeded their rate
+// limit with http.StatusTooManyRequests, and a Retry-After header if the
+// request can be retried.
+func (r *rateLimiter) wrapHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		methods, err := utilsjson.RequestMethods(req, maxRateLimitedBodySize)
+		switch {
+		case errors.Is(err, utilsjson.ErrBodyTooLarge):
+			writeRateLimitedResponse(w, http.StatusRequestEntityTooLarge, err)
+			return
+		case err != nil:
+			writeRateLimitedResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		cost := r.cost(methods)
+		delay, ok := r.reserve(r.clientKey(req), cost)
+		switch {
+		case !ok:
+			r.metrics.numRateLimited.Inc()
+			writeRateLimitedResponse(w, http.StatusTooManyRequests, fmt.Errorf("call costs %d but the burst limit is %d", cost, r.config.Burst))
+		case delay > 0:
+			r.metrics.numRateLimited.Inc()
+			retryAfter := int(math.Ceil(delay.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			writeRateLimitedResponse(w, http.StatusTooManyRequests, fmt.Errorf("rate limit exceeded; retry after %ds", retryAfter))
+		default:
+			handler.ServeHTTP(w, req)
+		}
+	})
+}
+
+// Returns the cost of calling [methods]. Calls whose method couldn't be parsed
+// cost the default.
+func (r *rateLimiter) cost(methods []string) int {
+	if len(methods) == 0 {
+		return defaultCallCost
+	}
+	cost := 0
+	for _, method := range methods {
+		methodCost, ok := r.methodCosts[strings.ToLower(method)]
+		if !ok || method == "" {
+			methodCost = defaultCallCost
+		}
+		cost += methodCost
+	}
+	return cost
+}
+
+// Spends [cost] of [client]'s budget if [client] can afford it now. Otherwise,
+// returns how long until [client] can afford it, or false if [client] can never
+// afford it.
+func (r *rateLimiter) reserve(client string, cost int) (time.Duration, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	limiter, ok := r.clients.Get(client)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(r.config.Rate), r.config.Burst)
+		r.clients.Put(client, limiter)
+	}
+
+	now := r.clock.Time()
+	reservation := limiter.ReserveN(now, cost)
+	if !reservation.OK() {
+		return 0, false
+	}
+	delay := reservation.DelayFrom(now)
+	if delay > 0 {
+		// The call is rejected, so it shouldn't use up the client's budget
+		reservation.CancelAt(now)
+	}
+	return delay, true
+}
+
+// Returns the key the rate limit of [req]'s client is tracked by
+func (r *rateLimiter) clientKey(req *http.Request) string {
+	if r.config.KeyByToken {
+		if header := req.Header.Get(authHeaderKey); strings.HasPrefix(header, authHeaderValStart) {
+			// Don't keep the token in memory
+			tokenHash := hashing.ComputeHash256([]byte(header[len(authHeaderValStart):]))
+			return "token:" + string(tokenHash)
+		}
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// Write a JSON-RPC formatted error response with [statusCode].
+// Errors while writing are ignored.
+func writeRateLimitedResponse(w http.ResponseWriter, statusCode int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	// There isn't anything to do with the returned error, so it is dropped.
+	_ = json.NewEncoder(w).Encode(struct {
+		Version string `json:"jsonrpc"`
+		Err     struct {
+			Code    rpc.ErrorCode `json:"code"`
+			Message string        `json:"message"`
+		} `json:"error"`
+		ID interface{} `json:"id"`
+	}{
+		Version: rpc.Version,
+		Err: struct {
+			Code    rpc.ErrorCode `json:"code"`
+			Message string        `json:"message"`
+		}{
+			Code:    rpc.E_SERVER,
+			Message: err.Error(),
+		},
+	})
+}
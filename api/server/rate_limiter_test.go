@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+var errTest = errors.New("non-nil error")
+
+func TestRateLimiter(t *testing.T) {
+	require := require.New(t)
+
+	m, err := newMetrics("", prometheus.NewRegistry())
+	require.NoError(err)
+	config := RateLimitConfig{
+		Rate:  1,
+		Burst: 10,
+		MethodCosts: map[string]int{
+			"avm.getAddressTxs": 4,
+			"platform.getUTXOs": 20,
+		},
+		KeyByToken: true,
+		MaxClients: DefaultRateLimitMaxClients,
+	}
+	require.NoError(config.Verify())
+	limiter := newRateLimiter(config, m)
+	now := time.Unix(1000, 0)
+	limiter.clock.Set(now)
+
+	handler := &testHandler{}
+	wrapped := limiter.wrapHandler(handler)
+	call := func(remoteAddr, token, body string) *httptest.ResponseRecorder {
+		handler.called = false
+		req := httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(body))
+		req.RemoteAddr = remoteAddr
+		if token != "" {
+			req.Header.Set(authHeaderKey, authHeaderValStart+token)
+		}
+		w := httptest.NewRecorder()
+		wrapped.ServeHTTP(w, req)
+		require.Equal(w.Code == http.StatusOK, handler.called)
+		return w
+	}
+	const (
+		getAddressTxs = `{"jsonrpc":"2.0","id":1,"method":"avm.getAddressTxs"}`
+		getUTXOs      = `{"jsonrpc":"2.0","id":1,"method":"platform.getUTXOs"}`
+		batch         = `[{"jsonrpc":"2.0","id":1,"method":"AVM.getAddressTxs"},{"jsonrpc":"2.0","id":2,"method":"avm.getTx"}]`
+	)
+
+	// Calls spend the client's budget by their methods' costs
+	require.Equal(http.StatusOK, call("1.2.3.4:1", "", getAddressTxs).Code)
+	require.Equal(http.StatusOK, call("1.2.3.4:2", "", batch).Code)
+	w := call("1.2.3.4:1", "", getAddressTxs)
+	require.Equal(http.StatusTooManyRequests, w.Code)
+	require.Equal("3", w.Header().Get("Retry-After"))
+
+	// Rejected calls don't spend the client's budget
+	limiter.clock.Set(now.Add(3 * time.Second))
+	require.Equal(http.StatusOK, call("1.2.3.4:1", "", getAddressTxs).Code)
+
+	// Calls that cost more than the burst are never allowed
+	w = call("5.6.7.8:1", "", getUTXOs)
+	require.Equal(http.StatusTooManyRequests, w.Code)
+	require.Empty(w.Header().Get("Retry-After"))
+
+	// Each token has its own budget
+	require.Equal(http.StatusOK, call("1.2.3.4:1", "token1", getAddressTxs).Code)
+	require.Equal(http.StatusOK, call("1.2.3.4:1", "token2", getAddressTxs).Code)
+
+	// Requests that aren't JSON-RPC calls cost 1
+	require.Equal(http.StatusOK, call("9.9.9.9:1", "", "").Code)
+	require.Equal(http.StatusOK, call("9.9.9.9:1", "", "not json").Code)
+
+	// Each call of a batch whose method can't be parsed costs 1
+	w = call("9.9.9.9:2", "", `[1,2,3,4,5,6,7,8,9,10,{"jsonrpc":"2.0"}]`)
+	require.Equal(http.StatusTooManyRequests, w.Code)
+	require.Empty(w.Header().Get("Retry-After"))
+
+	// Requests whose body can't be read are rejected
+	req := httptest.NewRequest(http.MethodPost, "/ext/bc/X", iotest.ErrReader(errTest))
+	w = httptest.NewRecorder()
+	wrapped.ServeHTTP(w, req)
+	require.Equal(http.StatusBadRequest, w.Code)
+
+	// Requests whose body is too large are rejected
+	req = httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(strings.Repeat(" ", maxRateLimitedBodySize+1)))
+	w = httptest.NewRecorder()
+	wrapped.ServeHTTP(w, req)
+	require.Equal(http.StatusRequestEntityTooLarge, w.Code)
+
+	metric := &dto.Metric{}
+	require.NoError(m.numRateLimited.Write(metric))
+	require.Equal(float64(3), metric.Counter.GetValue())
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/dioneprotocol/dionego/trace"
 	"github.com/dioneprotocol/dionego/utils/constants"
 	"github.com/dioneprotocol/dionego/utils/ips"
+	"github.com/dioneprotocol/dionego/utils/json"
 	"github.com/dioneprotocol/dionego/utils/logging"
 )
 
@@ -95,6 +96,9 @@ type server struct {
 
 	metrics *metrics
 
+	// Max number of calls in a JSON-RPC batch request
+	maxBatchSize int
+
 	// Maps endpoints to handlers
 	router *router
 
@@ -114,6 +118,8 @@ func New(
 	tracer trace.Tracer,
 	namespace string,
 	registerer prometheus.Registerer,
+	maxBatchSize int,
+	rateLimitConfig RateLimitConfig,
 	wrappers ...Wrapper,
 ) (Server, error) {
 	m, err := newMetrics(namespace, registerer)
@@ -135,12 +141,18 @@ func New(
 		},
 	)
 
+	// Rate limit calls after the wrappers, such as authentication, accept them
+	if rateLimitConfig.Rate > 0 {
+		handler = newRateLimiter(rateLimitConfig, m).wrapHandler(handler)
+	}
+
 	for _, wrapper := range wrappers {
 		handler = wrapper.WrapHandler(handler)
 	}
 
 	log.Info("API created",
 		zap.Strings("allowedOrigins", allowedOrigins),
+		zap.Int("maxBatchSize", maxBatchSize),
 	)
 
 	return &server{
@@ -152,6 +164,7 @@ func New(
 		tracingEnabled:  tracingEnabled,
 		tracer:          tracer,
 		metrics:         m,
+		maxBatchSize:    maxBatchSize,
 		router:          router,
 		srv: &http.Server{
 			Handler:           handler,
@@ -262,10 +275,12 @@ func (s *server) addChainRoute(chainName string, handler *common.HTTPHandler, ct
 		zap.String("url", url),
 		zap.String("endpoint", endpoint),
 	)
+	batchRequests := supportsBatchRequests(handler)
 	if s.tracingEnabled {
 		handler = &common.HTTPHandler{
-			LockOptions: handler.LockOptions,
-			Handler:     api.TraceHandler(handler.Handler, chainName, s.tracer),
+			LockOptions:   handler.LockOptions,
+			Handler:       api.TraceHandler(handler.Handler, chainName, s.tracer),
+			BatchRequests: handler.BatchRequests,
 		}
 	}
 	// Apply middleware to grab/release chain's lock before/after calling API method
@@ -281,6 +296,9 @@ func (s *server) addChainRoute(chainName string, handler *common.HTTPHandler, ct
 	}
 	// Apply middleware to reject calls to the handler before the chain finishes bootstrapping
 	h = rejectMiddleware(h, ctx)
+	if batchRequests {
+		h = json.NewBatchHandler(h, s.maxBatchSize)
+	}
 	h = s.metrics.wrapHandler(chainName, h)
 	return s.router.AddRouter(url, endpoint, h)
 }
@@ -302,10 +320,12 @@ func (s *server) addRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base,
 		zap.String("endpoint", endpoint),
 	)
 
+	batchRequests := supportsBatchRequests(handler)
 	if s.tracingEnabled {
 		handler = &common.HTTPHandler{
-			LockOptions: handler.LockOptions,
-			Handler:     api.TraceHandler(handler.Handler, url, s.tracer),
+			LockOptions:   handler.LockOptions,
+			Handler:       api.TraceHandler(handler.Handler, url, s.tracer),
+			BatchRequests: handler.BatchRequests,
 		}
 	}
 
@@ -320,10 +340,26 @@ func (s *server) addRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base,
 	if err != nil {
 		return err
 	}
+	if batchRequests {
+		h = json.NewBatchHandler(h, s.maxBatchSize)
+	}
 	h = s.metrics.wrapHandler(base, h)
 	return s.router.AddRouter(url, endpoint, h)
 }
 
+// Returns true if the calls of batch requests sent to [handler] can be split
+// into their own requests. This is the case for JSON-RPC servers and for
+// handlers that opt in with [common.HTTPHandler.BatchRequests], such as VMs
+// serving JSON-RPC APIs over rpcchainvm. Other handlers receive batch requests
+// unchanged. Each call of a batch acquires the lock of the handler separately.
+func supportsBatchRequests(handler *common.HTTPHandler) bool {
+	if handler.BatchRequests {
+		return true
+	}
+	_, ok := handler.Handler.(*json.Server)
+	return ok
+}
+
 // Wraps a handler by grabbing and releasing a lock before calling the handler.
 func lockMiddleware(
 	handler http.Handler,
@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/snow/engine/common"
+	"github.com/dioneprotocol/dionego/utils/json"
+	"github.com/dioneprotocol/dionego/utils/logging"
+)
+
+type testService struct{}
+
+type EchoArgs struct {
+	Msg string `json:"msg"`
+}
+
+type EchoReply struct {
+	Msg string `json:"msg"`
+}
+
+func (*testService) Echo(_ *http.Request, args *EchoArgs, reply *EchoReply) error {
+	reply.Msg = args.Msg
+	return nil
+}
+
+type bodyHandler struct{ body string }
+
+func (h *bodyHandler) ServeHTTP(_ http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	h.body = string(body)
+}
+
+func TestAddRouteBatchRequests(t *testing.T) {
+	require := require.New(t)
+
+	m, err := newMetrics("", prometheus.NewRegistry())
+	require.NoError(err)
+	s := &server{
+		log:          logging.NoLog{},
+		metrics:      m,
+		maxBatchSize: 2,
+		router:       newRouter(),
+	}
+
+	rpcServer := json.NewServer()
+	rpcServer.RegisterCodec(json.NewCodec(), "application/json")
+	require.NoError(rpcServer.RegisterService(&testService{}, "test"))
+	otherHandler := &bodyHandler{}
+
+	lock := &sync.RWMutex{}
+	require.NoError(s.AddRoute(&common.HTTPHandler{Handler: rpcServer}, lock, "rpc", ""))
+	require.NoError(s.AddRoute(&common.HTTPHandler{Handler: otherHandler}, lock, "other", ""))
+	// Handlers that wrap a JSON-RPC server can opt in to batching
+	wrappedHandler := http.HandlerFunc(rpcServer.ServeHTTP)
+	require.NoError(s.AddRoute(&common.HTTPHandler{Handler: wrappedHandler, BatchRequests: true}, lock, "wrapped", ""))
+
+	const batch = `[{"jsonrpc":"2.0","id":1,"method":"test.echo","params":{"msg":"a"}},{"jsonrpc":"2.0","id":2,"method":"test.echo","params":{"msg":"b"}}]`
+	call := func(base string) *httptest.ResponseRecorder {
+		handler, err := s.router.GetHandler(baseURL+"/"+base, "")
+		require.NoError(err)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(batch))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// Batches to JSON-RPC servers are split into their calls
+	w := call("rpc")
+	require.Equal(http.StatusOK, w.Code)
+	require.JSONEq(`[{"jsonrpc":"2.0","result":{"msg":"a"},"id":1},{"jsonrpc":"2.0","result":{"msg":"b"},"id":2}]`, w.Body.String())
+
+	// Batches to handlers that opted in are split into their calls
+	w = call("wrapped")
+	require.Equal(http.StatusOK, w.Code)
+	require.JSONEq(`[{"jsonrpc":"2.0","result":{"msg":"a"},"id":1},{"jsonrpc":"2.0","result":{"msg":"b"},"id":2}]`, w.Body.String())
+
+	// Other handlers receive batches unchanged
+	call("other")
+	require.Equal(batch, otherHandler.body)
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dbcmd
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	startKey     = "start"
+	endKey       = "end"
+	namespaceKey = "namespace"
+)
+
+var errNamespaceAndRange = errors.New("--namespace can't be used with --start or --end")
+
+// runCompactRange compacts a key range, or every key in a namespace, of each
+// database version. The node must not be running on these databases.
+func runCompactRange(args []string, out io.Writer) error {
+	fs := newFlagSet("compact-range", out)
+	addDBFlags(fs)
+	fs.String(startKey, "", "Hex encoded first key of the range to compact. Defaults to the first key")
+	fs.String(endKey, "", "Hex encoded key to stop compacting before. Defaults to after the last key")
+	fs.String(namespaceKey, "", "Label or hex encoded prefix of a namespace to compact, as reported by inspect")
+	fs.StringSlice(chainsKey, nil, "Chains to label namespaces of, as alias=chainID or chainID. The P-chain is always labeled")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	startStr, _ := fs.GetString(startKey)
+	endStr, _ := fs.GetString(endKey)
+	namespace, _ := fs.GetString(namespaceKey)
+	chains, _ := fs.GetStringSlice(chainsKey)
+
+	var start, end []byte
+	if len(namespace) > 0 {
+		if len(startStr) > 0 || len(endStr) > 0 {
+			return errNamespaceAndRange
+		}
+		parsedChains, err := parseChains(chains)
+		if err != nil {
+			return err
+		}
+		start, err = newNamespaceLabels(parsedChains).resolve(namespace)
+		if err != nil {
+			return err
+		}
+		end = prefixSuccessor(start)
+	} else {
+		var err error
+		start, err = hex.DecodeString(startStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse --%s: %w", startKey, err)
+		}
+		end, err = hex.DecodeString(endStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse --%s: %w", endKey, err)
+		}
+	}
+
+	_, dbManager, err := openDBFlags(fs)
+	if err != nil {
+		return err
+	}
+	defer dbManager.Close()
+
+	for _, db := range dbManager.GetDatabases() {
+		startTime := time.Now()
+		if err := db.Database.Compact(start, end); err != nil {
+			return fmt.Errorf("couldn't compact %s: %w", db.Version, err)
+		}
+		fmt.Fprintf(out, "compacted %s in %s\n", db.Version, time.Since(startTime))
+	}
+	return nil
+}
+
+// prefixSuccessor returns the smallest key that is larger than every key
+// starting with [prefix], or nil if there is no such key.
+func prefixSuccessor(prefix []byte) []byte {
+	successor := make([]byte, len(prefix))
+	copy(successor, prefix)
+	for i := len(successor) - 1; i >= 0; i-- {
+		if successor[i] != 0xff {
+			successor[i]++
+			return successor[:i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dbcmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/database/leveldb"
+	"github.com/dioneprotocol/dionego/database/snapshot"
+	"github.com/dioneprotocol/dionego/version"
+)
+
+const (
+	toDBDirKey        = "to-db-dir"
+	toDBTypeKey       = "to-db-type"
+	toDBConfigFileKey = "to-db-config-file"
+	toVersionKey      = "to-version"
+
+	// copyBatchSize is the number of bytes written to the target database
+	// per batch.
+	copyBatchSize = 4 * 1024 * 1024
+)
+
+var errAmbiguousVersion = errors.New("--to-version requires exactly one source database")
+
+// runCopy copies every key of the source databases into databases of the same
+// version in another directory, possibly with a different backend. Neither
+// directory may be in use by a running node.
+func runCopy(args []string, out io.Writer) error {
+	fs := newFlagSet("copy", out)
+	addDBFlags(fs)
+	fs.String(toDBDirKey, "", "Database directory to copy into. Each versioned database directory in it must be empty")
+	fs.String(toDBTypeKey, leveldb.Name, "Type of the database to copy into")
+	fs.String(toDBConfigFileKey, "", "Path to the config file of the database to copy into")
+	fs.String(toVersionKey, "", "Version to give the copied database, e.g. v1.4.5. Requires a single source database. Defaults to the source version")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, toDBDirKey); err != nil {
+		return err
+	}
+
+	toDBDir, _ := fs.GetString(toDBDirKey)
+	toDBType, _ := fs.GetString(toDBTypeKey)
+	toDBConfigFile, _ := fs.GetString(toDBConfigFileKey)
+	toVersionStr, _ := fs.GetString(toVersionKey)
+
+	var toVersion *version.Semantic
+	if len(toVersionStr) > 0 {
+		var err error
+		toVersion, err = version.Parse(toVersionStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse --%s: %w", toVersionKey, err)
+		}
+	}
+
+	newDB, err := newDBFunc(toDBType, toDBConfigFile)
+	if err != nil {
+		return err
+	}
+
+	_, dbManager, err := openDBFlags(fs)
+	if err != nil {
+		return err
+	}
+	defer dbManager.Close()
+
+	dbs := dbManager.GetDatabases()
+	if toVersion != nil && len(dbs) != 1 {
+		return fmt.Errorf("%w but found %d", errAmbiguousVersion, len(dbs))
+	}
+
+	for _, db := range dbs {
+		dbVersion := db.Version
+		if toVersion != nil {
+			dbVersion = toVersion
+		}
+
+		path := filepath.Join(toDBDir, dbVersion.String())
+		if err := snapshot.CreateEmptyDir(path); err != nil {
+			return err
+		}
+		target, err := newDB(path)
+		if err != nil {
+			return fmt.Errorf("couldn't open db at %s: %w", path, err)
+		}
+
+		numKeys, err := copyDatabase(db.Database, target)
+		if closeErr := target.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't copy %s into %s: %w", db.Version, path, err)
+		}
+		fmt.Fprintf(out, "copied %d keys from %s into %s\n", numKeys, db.Version, path)
+	}
+	return nil
+}
+
+// copyDatabase writes every key of [source] into [target] and returns the
+// number of keys copied.
+func copyDatabase(source database.Iteratee, target database.Batcher) (uint64, error) {
+	it := source.NewIterator()
+	defer it.Release()
+
+	var (
+		numKeys uint64
+		batch   = target.NewBatch()
+	)
+	for it.Next() {
+		if err := batch.Put(it.Key(), it.Value()); err != nil {
+			return numKeys, err
+		}
+		numKeys++
+
+		if batch.Size() < copyBatchSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			return numKeys, err
+		}
+		batch.Reset()
+	}
+	if err := it.Error(); err != nil {
+		return numKeys, err
+	}
+	return numKeys, batch.Write()
+}
@@ -0,0 +1,248 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package dbcmd implements the database subcommands of the node binary, which
+// are invoked as `dionego db <command> [flags]`.
+package dbcmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/spf13/pflag"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/database/leveldb"
+	"github.com/dioneprotocol/dionego/database/manager"
+	"github.com/dioneprotocol/dionego/database/pebble"
+	"github.com/dioneprotocol/dionego/utils/logging"
+	"github.com/dioneprotocol/dionego/version"
+)
+
+// Name is the name of the subcommand that the database commands are grouped
+// under.
+const Name = "db"
+
+var (
+	errNoCommand      = errors.New("no command given")
+	errUnknownCommand = errors.New("unknown command")
+	errUnknownDBType  = errors.New("unknown database type")
+	errMissingFlag    = errors.New("missing required flag")
+	errNoDatabases    = errors.New("no versioned databases found")
+)
+
+type command struct {
+	description string
+	run         func(args []string, out io.Writer) error
+}
+
+var commands = map[string]command{
+	"snapshot": {
+		description: "Take a snapshot of the databases of a running node",
+		run:         runSnapshot,
+	},
+	"verify": {
+		description: "Verify a database snapshot without restoring it",
+		run:         runVerify,
+	},
+	"restore": {
+		description: "Restore a database snapshot into an empty database directory",
+		run:         runRestore,
+	},
+	"inspect": {
+		description: "Report key counts and sizes per prefixdb namespace",
+		run:         runInspect,
+	},
+	"stats": {
+		description: "Report key counts and sizes per database version",
+		run:         runStats,
+	},
+	"copy": {
+		description: "Copy databases into another directory, version or backend",
+		run:         runCopy,
+	},
+	"compact-range": {
+		description: "Compact a key range or namespace of the databases",
+		run:         runCompactRange,
+	},
+}
+
+// Run executes the database command named by the first element of [args]
+// with the remaining elements as its flags. Output is written to [out].
+func Run(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		printUsage(out)
+		return errNoCommand
+	}
+
+	name := args[0]
+	cmd, ok := commands[name]
+	if !ok {
+		printUsage(out)
+		return fmt.Errorf("%w: %q", errUnknownCommand, name)
+	}
+	return cmd.run(args[1:], out)
+}
+
+func printUsage(out io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(out, "Usage: dionego %s <command> [flags]\n\nCommands:\n", Name)
+	for _, name := range names {
+		fmt.Fprintf(out, "  %-16s %s\n", name, commands[name].description)
+	}
+}
+
+func newFlagSet(name string, out io.Writer) *pflag.FlagSet {
+	fs := pflag.NewFlagSet(fmt.Sprintf("%s %s", Name, name), pflag.ContinueOnError)
+	fs.SetOutput(out)
+	return fs
+}
+
+// requireFlags returns an error if any of the string flags named [names] are
+// empty.
+func requireFlags(fs *pflag.FlagSet, names ...string) error {
+	for _, name := range names {
+		value, err := fs.GetString(name)
+		if err != nil {
+			return err
+		}
+		if len(value) == 0 {
+			return fmt.Errorf("%w: --%s", errMissingFlag, name)
+		}
+	}
+	return nil
+}
+
+// newDBFunc returns a function that opens a database of type [dbType] using
+// the config in [dbConfigFile], if provided.
+func newDBFunc(dbType string, dbConfigFile string) (func(path string) (database.Database, error), error) {
+	var dbConfig []byte
+	if len(dbConfigFile) > 0 {
+		var err error
+		dbConfig, err = os.ReadFile(dbConfigFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var newDB func(string, []byte, logging.Logger, string, prometheus.Registerer) (database.Database, error)
+	switch dbType {
+	case leveldb.Name:
+		newDB = leveldb.New
+	case pebble.Name:
+		newDB = pebble.New
+	default:
+		return nil, fmt.Errorf(
+			"%w: %q should have been one of {%s}",
+			errUnknownDBType,
+			dbType,
+			strings.Join([]string{leveldb.Name, pebble.Name}, ", "),
+		)
+	}
+	return func(path string) (database.Database, error) {
+		return newDB(path, dbConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	}, nil
+}
+
+// addDBFlags adds the flags used to open the databases of a stopped node.
+func addDBFlags(fs *pflag.FlagSet) {
+	fs.String(dbDirKey, "", "Database directory of the network, e.g. ~/.dionego/db/mainnet")
+	fs.String(dbTypeKey, leveldb.Name, "Type of the database")
+	fs.String(dbConfigFileKey, "", "Path to the database config file")
+	fs.String(versionKey, "", "Only use the database with this version, e.g. v1.4.5. Defaults to all versions")
+}
+
+// openDBFlags opens the databases described by the flags added by
+// addDBFlags. The node must not be running on these databases.
+func openDBFlags(fs *pflag.FlagSet) (string, manager.Manager, error) {
+	if err := requireFlags(fs, dbDirKey); err != nil {
+		return "", nil, err
+	}
+
+	dbDir, _ := fs.GetString(dbDirKey)
+	dbType, _ := fs.GetString(dbTypeKey)
+	dbConfigFile, _ := fs.GetString(dbConfigFileKey)
+	onlyVersion, _ := fs.GetString(versionKey)
+
+	newDB, err := newDBFunc(dbType, dbConfigFile)
+	if err != nil {
+		return "", nil, err
+	}
+	dbManager, err := openDatabases(dbDir, newDB, onlyVersion)
+	return dbDir, dbManager, err
+}
+
+// openDatabases opens every existing versioned database in [dbDir]. Unlike
+// the database manager used by the node, a database for the current version
+// is never created. If [onlyVersion] is non-empty, only the database with that
+// version is opened.
+func openDatabases(dbDir string, newDB func(path string) (database.Database, error), onlyVersion string) (manager.Manager, error) {
+	entries, err := os.ReadDir(dbDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbs []*manager.VersionedDatabase
+	closeAll := func() {
+		for _, db := range dbs {
+			_ = db.Close()
+		}
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dbVersion, err := version.Parse(entry.Name())
+		if err != nil {
+			// Directories that don't match the version format aren't
+			// databases.
+			continue
+		}
+		if len(onlyVersion) > 0 && dbVersion.String() != onlyVersion {
+			continue
+		}
+
+		path := filepath.Join(dbDir, entry.Name())
+		db, err := newDB(path)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("couldn't open db at %s: %w", path, err)
+		}
+		dbs = append(dbs, &manager.VersionedDatabase{
+			Database: db,
+			Version:  dbVersion,
+		})
+	}
+	if len(dbs) == 0 {
+		return nil, fmt.Errorf("%w in %s", errNoDatabases, dbDir)
+	}
+
+	dbManager, err := manager.NewManagerFromDBs(dbs)
+	if err != nil {
+		closeAll()
+		return nil, err
+	}
+	return dbManager, nil
+}
+
+func printJSON(out io.Writer, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(b))
+	return err
+}
@@ -0,0 +1,222 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dbcmd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/database/leveldb"
+	"github.com/dioneprotocol/dionego/database/manager"
+	"github.com/dioneprotocol/dionego/database/pebble"
+	"github.com/dioneprotocol/dionego/database/prefixdb"
+	"github.com/dioneprotocol/dionego/database/snapshot"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/utils/constants"
+	"github.com/dioneprotocol/dionego/version"
+)
+
+func TestRunUnknownCommand(t *testing.T) {
+	require := require.New(t)
+
+	out := &bytes.Buffer{}
+	err := Run(nil, out)
+	require.ErrorIs(err, errNoCommand)
+
+	err = Run([]string{"unknown"}, out)
+	require.ErrorIs(err, errUnknownCommand)
+	require.Contains(out.String(), "restore")
+}
+
+func TestRunVerifyRestore(t *testing.T) {
+	require := require.New(t)
+
+	dbManager := manager.NewMemDB(version.Semantic1_0_0)
+	require.NoError(dbManager.Current().Database.Put([]byte("key"), []byte("value")))
+
+	archivePath := filepath.Join(t.TempDir(), "snapshot"+snapshot.ArchiveExtension)
+	_, err := snapshot.CreateArchive(dbManager, archivePath)
+	require.NoError(err)
+
+	out := &bytes.Buffer{}
+	require.NoError(Run([]string{"verify", "--snapshot", archivePath}, out))
+
+	err = Run([]string{"restore", "--snapshot", archivePath}, out)
+	require.ErrorIs(err, errMissingFlag)
+
+	dbDir := t.TempDir()
+	err = Run([]string{"restore", "--snapshot", archivePath, "--db-dir", dbDir, "--db-type", "unknown"}, out)
+	require.ErrorIs(err, errUnknownDBType)
+
+	require.NoError(Run([]string{"restore", "--snapshot", archivePath, "--db-dir", dbDir, "--db-type", pebble.Name}, out))
+
+	newDB, err := newDBFunc(pebble.Name, "")
+	require.NoError(err)
+	db, err := newDB(filepath.Join(dbDir, version.Semantic1_0_0.String()))
+	require.NoError(err)
+	defer db.Close()
+
+	value, err := db.Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+}
+
+// newTestDBDir writes a leveldb database with a P-chain namespace and a few
+// unprefixed keys into a new database directory.
+func newTestDBDir(t *testing.T) string {
+	require := require.New(t)
+
+	dbDir := t.TempDir()
+	newDB, err := newDBFunc(leveldb.Name, "")
+	require.NoError(err)
+	db, err := newDB(filepath.Join(dbDir, version.Semantic1_0_0.String()))
+	require.NoError(err)
+
+	chainDB := prefixdb.New(constants.PlatformChainID[:], db)
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(chainDB.Put([]byte(key), []byte("value")))
+	}
+	require.NoError(db.Put([]byte("x"), []byte("y")))
+	require.NoError(db.Close())
+	return dbDir
+}
+
+func TestRunStatsInspect(t *testing.T) {
+	require := require.New(t)
+
+	dbDir := newTestDBDir(t)
+
+	out := &bytes.Buffer{}
+	err := Run([]string{"stats"}, out)
+	require.ErrorIs(err, errMissingFlag)
+
+	err = Run([]string{"stats", "--db-dir", dbDir, "--version", "v9.9.9"}, out)
+	require.ErrorIs(err, errNoDatabases)
+
+	out.Reset()
+	require.NoError(Run([]string{"stats", "--db-dir", dbDir, "--json"}, out))
+	var stats []VersionStats
+	require.NoError(json.Unmarshal(out.Bytes(), &stats))
+	require.Len(stats, 1)
+	require.Equal(version.Semantic1_0_0.String(), stats[0].Version)
+	require.Equal(uint64(4), stats[0].NumKeys)
+	require.Positive(stats[0].DiskBytes)
+	require.Empty(stats[0].Namespaces)
+
+	out.Reset()
+	require.NoError(Run([]string{"inspect", "--db-dir", dbDir, "--json"}, out))
+	stats = nil
+	require.NoError(json.Unmarshal(out.Bytes(), &stats))
+	require.Len(stats, 1)
+	require.Len(stats[0].Namespaces, 2)
+
+	pChain := stats[0].Namespaces[0]
+	require.Equal("P", pChain.Label)
+	require.Equal(hex.EncodeToString(prefixdb.MakePrefix(constants.PlatformChainID[:])), pChain.Namespace)
+	require.Equal(uint64(3), pChain.NumKeys)
+
+	unprefixed := stats[0].Namespaces[1]
+	require.Equal(unprefixedNamespace, unprefixed.Namespace)
+	require.Equal(uint64(1), unprefixed.NumKeys)
+
+	out.Reset()
+	require.NoError(Run([]string{"inspect", "--db-dir", dbDir, "--limit", "1"}, out))
+	require.Contains(out.String(), "P ")
+	require.NotContains(out.String(), unprefixedNamespace)
+}
+
+func TestRunCopy(t *testing.T) {
+	require := require.New(t)
+
+	dbDir := newTestDBDir(t)
+	toDBDir := t.TempDir()
+
+	out := &bytes.Buffer{}
+	require.NoError(Run([]string{"copy", "--db-dir", dbDir, "--to-db-dir", toDBDir, "--to-db-type", pebble.Name, "--to-version", "v1.2.3"}, out))
+
+	// The target database must be empty.
+	err := Run([]string{"copy", "--db-dir", dbDir, "--to-db-dir", toDBDir, "--to-db-type", pebble.Name, "--to-version", "v1.2.3"}, out)
+	require.ErrorIs(err, snapshot.ErrNotEmpty)
+
+	out.Reset()
+	require.NoError(Run([]string{"stats", "--db-dir", toDBDir, "--db-type", pebble.Name, "--json"}, out))
+	var stats []VersionStats
+	require.NoError(json.Unmarshal(out.Bytes(), &stats))
+	require.Len(stats, 1)
+	require.Equal("v1.2.3", stats[0].Version)
+	require.Equal(uint64(4), stats[0].NumKeys)
+}
+
+func TestRunCompactRange(t *testing.T) {
+	require := require.New(t)
+
+	dbDir := newTestDBDir(t)
+
+	out := &bytes.Buffer{}
+	require.NoError(Run([]string{"compact-range", "--db-dir", dbDir}, out))
+	require.NoError(Run([]string{"compact-range", "--db-dir", dbDir, "--namespace", "P"}, out))
+	require.NoError(Run([]string{"compact-range", "--db-dir", dbDir, "--start", "00", "--end", "ff"}, out))
+
+	err := Run([]string{"compact-range", "--db-dir", dbDir, "--namespace", "P", "--start", "00"}, out)
+	require.ErrorIs(err, errNamespaceAndRange)
+}
+
+func TestPrefixSuccessor(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal([]byte{0x01, 0x03}, prefixSuccessor([]byte{0x01, 0x02}))
+	require.Equal([]byte{0x02}, prefixSuccessor([]byte{0x01, 0xff}))
+	require.Nil(prefixSuccessor([]byte{0xff, 0xff}))
+}
+
+func TestNamespaceLabels(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	labels := newNamespaceLabels(map[ids.ID]string{chainID: "X"})
+
+	// Build the chain's databases the same way the chain manager does
+	dbManager := manager.NewMemDB(version.Semantic1_0_0)
+	meterDBManager, err := dbManager.NewMeterDBManager("db", prometheus.NewRegistry())
+	require.NoError(err)
+	chainDBManager := meterDBManager.NewPrefixDBManager(chainID[:])
+	vmDBManager := chainDBManager.NewPrefixDBManager([]byte("vm"))
+	chainDB := chainDBManager.Current().Database
+
+	// Build the chain's block index the same way the indexer does
+	indexerDB := prefixdb.New(indexerPrefix, dbManager.Current().Database)
+	blockIndexDB := prefixdb.New(append(chainID[:], 0x03), indexerDB)
+
+	expectedLabels := map[string]database.KeyValueWriter{
+		"X":             chainDB,
+		"X/vm":          vmDBManager.Current().Database,
+		"X/vertex":      prefixdb.New([]byte("vertex"), chainDB),
+		"X/bs":          prefixdb.New([]byte("bs"), chainDB),
+		"X/index-block": blockIndexDB,
+	}
+	for label, db := range expectedLabels {
+		require.NoError(db.Put([]byte(label), nil))
+	}
+
+	it := dbManager.Current().Database.NewIterator()
+	defer it.Release()
+
+	numKeys := 0
+	for it.Next() {
+		label := labels.label(namespaceOf(it.Key()))
+		require.Contains(expectedLabels, label)
+		require.Equal(label, string(it.Key()[prefixdb.PrefixLen:]))
+		numKeys++
+	}
+	require.NoError(it.Error())
+	require.Len(expectedLabels, numKeys)
+}
@@ -0,0 +1,231 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dbcmd
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/dioneprotocol/dionego/database"
+)
+
+const (
+	chainsKey = "chains"
+	limitKey  = "limit"
+	jsonKey   = "json"
+)
+
+// Stats are the key counts and sizes of a set of keys.
+type Stats struct {
+	NumKeys    uint64 `json:"numKeys"`
+	KeyBytes   uint64 `json:"keyBytes"`
+	ValueBytes uint64 `json:"valueBytes"`
+}
+
+func (s *Stats) add(key, value []byte) {
+	s.NumKeys++
+	s.KeyBytes += uint64(len(key))
+	s.ValueBytes += uint64(len(value))
+}
+
+// NamespaceStats are the stats of a single prefixdb namespace.
+type NamespaceStats struct {
+	Stats
+	// Namespace is the hex encoded prefix of the namespace.
+	Namespace string `json:"namespace"`
+	// Label is the human readable name of the namespace, if it is known.
+	Label string `json:"label,omitempty"`
+}
+
+// VersionStats are the stats of a single versioned database.
+type VersionStats struct {
+	Stats
+	Version string `json:"version"`
+	// DiskBytes is the number of bytes the database occupies on disk,
+	// including data that hasn't been compacted yet.
+	DiskBytes uint64 `json:"diskBytes"`
+	// Namespaces are ordered from largest to smallest.
+	Namespaces []NamespaceStats `json:"namespaces,omitempty"`
+}
+
+// runInspect reports the stats of each prefixdb namespace in each database
+// version.
+func runInspect(args []string, out io.Writer) error {
+	fs := newFlagSet("inspect", out)
+	addDBFlags(fs)
+	fs.StringSlice(chainsKey, nil, "Chains to label namespaces of, as alias=chainID or chainID. The P-chain is always labeled")
+	fs.Int(limitKey, 0, "Maximum number of namespaces to report per version. 0 reports all namespaces")
+	fs.Bool(jsonKey, false, "Output JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	chains, _ := fs.GetStringSlice(chainsKey)
+	limit, _ := fs.GetInt(limitKey)
+	asJSON, _ := fs.GetBool(jsonKey)
+
+	parsedChains, err := parseChains(chains)
+	if err != nil {
+		return err
+	}
+	labels := newNamespaceLabels(parsedChains)
+
+	dbDir, dbManager, err := openDBFlags(fs)
+	if err != nil {
+		return err
+	}
+	defer dbManager.Close()
+
+	allStats := make([]VersionStats, 0, len(dbManager.GetDatabases()))
+	for _, db := range dbManager.GetDatabases() {
+		stats, err := collectStats(db.Database, labels)
+		if err != nil {
+			return err
+		}
+		stats.Version = db.Version.String()
+		stats.DiskBytes, err = dirSize(filepath.Join(dbDir, stats.Version))
+		if err != nil {
+			return err
+		}
+		if limit > 0 && len(stats.Namespaces) > limit {
+			stats.Namespaces = stats.Namespaces[:limit]
+		}
+		allStats = append(allStats, stats)
+	}
+
+	if asJSON {
+		return printJSON(out, allStats)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, stats := range allStats {
+		fmt.Fprintf(w, "%s\t%d keys\t%d key bytes\t%d value bytes\t%d disk bytes\n", stats.Version, stats.NumKeys, stats.KeyBytes, stats.ValueBytes, stats.DiskBytes)
+		fmt.Fprintln(w, "NAMESPACE\tKEYS\tKEY BYTES\tVALUE BYTES\t")
+		for _, ns := range stats.Namespaces {
+			name := ns.Namespace
+			if len(ns.Label) > 0 {
+				name = ns.Label
+			}
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t\n", name, ns.NumKeys, ns.KeyBytes, ns.ValueBytes)
+		}
+		fmt.Fprintln(w)
+	}
+	return w.Flush()
+}
+
+// runStats reports the totals of each database version.
+func runStats(args []string, out io.Writer) error {
+	fs := newFlagSet("stats", out)
+	addDBFlags(fs)
+	fs.Bool(jsonKey, false, "Output JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	asJSON, _ := fs.GetBool(jsonKey)
+
+	dbDir, dbManager, err := openDBFlags(fs)
+	if err != nil {
+		return err
+	}
+	defer dbManager.Close()
+
+	allStats := make([]VersionStats, 0, len(dbManager.GetDatabases()))
+	for _, db := range dbManager.GetDatabases() {
+		stats, err := collectStats(db.Database, nil)
+		if err != nil {
+			return err
+		}
+		stats.Version = db.Version.String()
+		stats.DiskBytes, err = dirSize(filepath.Join(dbDir, stats.Version))
+		if err != nil {
+			return err
+		}
+		allStats = append(allStats, stats)
+	}
+
+	if asJSON {
+		return printJSON(out, allStats)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tKEYS\tKEY BYTES\tVALUE BYTES\tDISK BYTES\t")
+	for _, stats := range allStats {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t\n", stats.Version, stats.NumKeys, stats.KeyBytes, stats.ValueBytes, stats.DiskBytes)
+	}
+	return w.Flush()
+}
+
+// collectStats iterates over [db] and returns its stats. If [labels] is
+// non-nil, the stats of each namespace are also returned.
+func collectStats(db database.Database, labels namespaceLabels) (VersionStats, error) {
+	var (
+		stats      VersionStats
+		namespaces = make(map[string]*NamespaceStats)
+		it         = db.NewIterator()
+	)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		value := it.Value()
+		stats.add(key, value)
+		if labels == nil {
+			continue
+		}
+
+		namespace := namespaceOf(key)
+		nsStats, ok := namespaces[namespace]
+		if !ok {
+			nsStats = &NamespaceStats{
+				Namespace: namespace,
+			}
+			if label := labels.label(namespace); label != namespace {
+				nsStats.Label = label
+			}
+			namespaces[namespace] = nsStats
+		}
+		nsStats.add(key, value)
+	}
+	if err := it.Error(); err != nil {
+		return stats, err
+	}
+
+	for _, nsStats := range namespaces {
+		stats.Namespaces = append(stats.Namespaces, *nsStats)
+	}
+	sort.Slice(stats.Namespaces, func(i, j int) bool {
+		iSize := stats.Namespaces[i].KeyBytes + stats.Namespaces[i].ValueBytes
+		jSize := stats.Namespaces[j].KeyBytes + stats.Namespaces[j].ValueBytes
+		if iSize != jSize {
+			return iSize > jSize
+		}
+		return stats.Namespaces[i].Namespace < stats.Namespaces[j].Namespace
+	})
+	return stats, nil
+}
+
+// dirSize returns the total size of the regular files in [dir].
+func dirSize(dir string) (uint64, error) {
+	var size uint64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size, err
+}
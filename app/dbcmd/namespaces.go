@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dbcmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/dioneprotocol/dionego/database/prefixdb"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/utils/constants"
+)
+
+const unprefixedNamespace = "(unprefixed)"
+
+var (
+	// chainSubPrefixes are the prefixes that the chain manager nests under
+	// each chain's prefix.
+	chainSubPrefixes = []string{"vm", "vertex", "vertex_bs", "tx_bs", "bs"}
+
+	// indexerPrefix is the prefix the node gives to the indexer's database.
+	indexerPrefix = []byte{0x00}
+
+	// indexSuffixes are the bytes the indexer appends to a chain ID to get the
+	// prefix of each of the chain's indices.
+	indexSuffixes = map[byte]string{
+		0x01: "index-tx",
+		0x02: "index-vtx",
+		0x03: "index-block",
+	}
+)
+
+// namespaceLabels maps the hex encoded prefixes used by the node to human
+// readable names. Prefixes are hashes, so they can only be labeled if the
+// inputs to the hash are known.
+type namespaceLabels map[string]string
+
+// newNamespaceLabels returns the labels of the node-level prefixes and the
+// prefixes of the P-chain and of each chain in [chains], which maps chain IDs
+// to their aliases.
+func newNamespaceLabels(chains map[ids.ID]string) namespaceLabels {
+	labels := namespaceLabels{}
+	labels.add("keystore", []byte("keystore"))
+	labels.add("indexer", indexerPrefix)
+
+	labels.addChain("P", constants.PlatformChainID)
+	for chainID, alias := range chains {
+		labels.addChain(alias, chainID)
+	}
+	return labels
+}
+
+// addChain adds the labels of the prefixes that the node uses for [chainID].
+//
+// A prefixdb created on top of another prefixdb hashes the parent's prefix
+// together with its own, so nested prefixes are derived from the parent's
+// hashed prefix.
+func (l namespaceLabels) addChain(alias string, chainID ids.ID) {
+	chainPrefix := prefixdb.MakePrefix(chainID[:])
+	l.add(alias, chainID[:])
+	for _, subPrefix := range chainSubPrefixes {
+		l.add(alias+"/"+subPrefix, joinBytes(chainPrefix, []byte(subPrefix)))
+	}
+
+	indexerDBPrefix := prefixdb.MakePrefix(indexerPrefix)
+	for suffix, name := range indexSuffixes {
+		l.add(alias+"/"+name, joinBytes(indexerDBPrefix, chainID[:], []byte{suffix}))
+	}
+}
+
+// add labels the namespace of a prefixdb created with [prefix] on top of an
+// unprefixed database.
+func (l namespaceLabels) add(label string, prefix []byte) {
+	l[hex.EncodeToString(prefixdb.MakePrefix(prefix))] = label
+}
+
+// label returns the label of [namespace], or [namespace] if it isn't known.
+func (l namespaceLabels) label(namespace string) string {
+	if label, ok := l[namespace]; ok {
+		return label
+	}
+	return namespace
+}
+
+// resolve returns the hex encoded prefix named by [nameOrHex], which is
+// either a label or a hex encoded prefix.
+func (l namespaceLabels) resolve(nameOrHex string) ([]byte, error) {
+	for namespace, label := range l {
+		if label == nameOrHex {
+			return hex.DecodeString(namespace)
+		}
+	}
+	prefix, err := hex.DecodeString(nameOrHex)
+	if err != nil {
+		return nil, fmt.Errorf("unknown namespace %q", nameOrHex)
+	}
+	return prefix, nil
+}
+
+// namespaceOf returns the hex encoded prefixdb namespace that [key] belongs
+// to. Keys that are too short to have been written through a prefixdb are
+// reported as unprefixed.
+func namespaceOf(key []byte) string {
+	if len(key) < prefixdb.PrefixLen {
+		return unprefixedNamespace
+	}
+	return hex.EncodeToString(key[:prefixdb.PrefixLen])
+}
+
+// parseChains parses a list of chains of the form "alias=chainID" or
+// "chainID" into a map of chain IDs to their aliases.
+func parseChains(chains []string) (map[ids.ID]string, error) {
+	parsed := make(map[ids.ID]string, len(chains))
+	for _, chain := range chains {
+		alias, chainIDStr, found := strings.Cut(chain, "=")
+		if !found {
+			chainIDStr = alias
+		}
+		chainID, err := ids.FromString(chainIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse chain %q: %w", chain, err)
+		}
+		parsed[chainID] = alias
+	}
+	return parsed, nil
+}
+
+func joinBytes(slices ...[]byte) []byte {
+	var joined []byte
+	for _, s := range slices {
+		joined = append(joined, s...)
+	}
+	return joined
+}
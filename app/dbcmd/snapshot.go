@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dbcmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dioneprotocol/dionego/api/admin"
+	"github.com/dioneprotocol/dionego/database/leveldb"
+	"github.com/dioneprotocol/dionego/database/snapshot"
+)
+
+const (
+	apiURIKey       = "api-uri"
+	pathKey         = "path"
+	snapshotKey     = "snapshot"
+	dbDirKey        = "db-dir"
+	dbTypeKey       = "db-type"
+	dbConfigFileKey = "db-config-file"
+	versionKey      = "version"
+
+	defaultAPIURI = "http://127.0.0.1:9650"
+)
+
+// runSnapshot asks a running node to snapshot its databases through the admin
+// API.
+func runSnapshot(args []string, out io.Writer) error {
+	fs := newFlagSet("snapshot", out)
+	fs.String(apiURIKey, defaultAPIURI, "URI of the node's HTTP API. The admin API must be enabled")
+	fs.String(pathKey, "", fmt.Sprintf("Path, on the node's machine, to write the snapshot to. Written as a gzipped tarball if it ends in %s", snapshot.ArchiveExtension))
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, pathKey); err != nil {
+		return err
+	}
+
+	uri, _ := fs.GetString(apiURIKey)
+	path, _ := fs.GetString(pathKey)
+
+	client := admin.NewClient(uri)
+	snapshotPath, manifest, err := client.SnapshotDatabase(context.Background(), path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "wrote snapshot to %s\n", snapshotPath)
+	return printJSON(out, manifest)
+}
+
+// runVerify verifies a snapshot directory or archive.
+func runVerify(args []string, out io.Writer) error {
+	fs := newFlagSet("verify", out)
+	fs.String(snapshotKey, "", "Path of the snapshot directory or archive to verify")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, snapshotKey); err != nil {
+		return err
+	}
+
+	snapshotPath, _ := fs.GetString(snapshotKey)
+	snapshotDir, cleanup, err := openSnapshot(snapshotPath)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	manifest, err := snapshot.Verify(snapshotDir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "snapshot %s is valid\n", snapshotPath)
+	return printJSON(out, manifest)
+}
+
+// runRestore verifies a snapshot and then writes it into a new database
+// directory. The node must not be running on the target directory.
+func runRestore(args []string, out io.Writer) error {
+	fs := newFlagSet("restore", out)
+	fs.String(snapshotKey, "", "Path of the snapshot directory or archive to restore")
+	fs.String(dbDirKey, "", "Database directory of the network to restore into, e.g. ~/.dionego/db/mainnet. Each versioned database directory in it must be empty")
+	fs.String(dbTypeKey, leveldb.Name, "Type of the database to restore into")
+	fs.String(dbConfigFileKey, "", "Path to the database config file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, snapshotKey, dbDirKey); err != nil {
+		return err
+	}
+
+	snapshotPath, _ := fs.GetString(snapshotKey)
+	dbDir, _ := fs.GetString(dbDirKey)
+	dbType, _ := fs.GetString(dbTypeKey)
+	dbConfigFile, _ := fs.GetString(dbConfigFileKey)
+
+	newDB, err := newDBFunc(dbType, dbConfigFile)
+	if err != nil {
+		return err
+	}
+
+	snapshotDir, cleanup, err := openSnapshot(snapshotPath)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	manifest, err := snapshot.Restore(snapshotDir, dbDir, newDB)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "restored snapshot %s into %s\n", snapshotPath, dbDir)
+	return printJSON(out, manifest)
+}
+
+// openSnapshot returns the directory containing the snapshot at [path]. If
+// [path] is an archive, it is extracted into a temporary directory that is
+// removed by the returned cleanup function.
+func openSnapshot(path string) (string, func(), error) {
+	if !snapshot.IsArchive(path) {
+		return path, func() {}, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+
+	dir, err := os.MkdirTemp("", "snapshot-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(dir)
+	}
+	if err := snapshot.ExtractArchive(f, dir); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return dir, cleanup, nil
+}
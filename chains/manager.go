@@ -205,9 +205,6 @@ type ManagerConfig struct {
 	ApricotPhase4Time            time.Time
 	ApricotPhase4MinPChainHeight uint64
 
-	// Tracks CPU/disk usage caused by each peer.
-	ResourceTracker timetracker.ResourceTracker
-
 	StateSyncBeacons []ids.NodeID
 
 	ChainDataDir string
@@ -511,6 +508,10 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 
 	bootstrapWeight := beacons.Weight()
 
+	// Accounts messages destined for the new chain to its subnet when
+	// throttling inbound messages.
+	resourceTracker := m.Net.RegisterChain(chainParams.ID, chainParams.SubnetID)
+
 	var chain *chain
 	switch vm := vm.(type) {
 	case vertex.DAGVM:
@@ -523,6 +524,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 			fxs,
 			bootstrapWeight,
 			sb,
+			resourceTracker,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error while creating new dione vm %w", err)
@@ -537,6 +539,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 			fxs,
 			bootstrapWeight,
 			sb,
+			resourceTracker,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error while creating new snowman vm %w", err)
@@ -567,6 +570,7 @@ func (m *manager) createDioneChain(
 	fxs []*common.Fx,
 	bootstrapWeight uint64,
 	sb subnets.Subnet,
+	resourceTracker timetracker.ResourceTracker,
 ) (*chain, error) {
 	ctx.Lock.Lock()
 	defer ctx.Lock.Unlock()
@@ -669,7 +673,7 @@ func (m *manager) createDioneChain(
 		vdrs,
 		msgChan,
 		m.ConsensusGossipFrequency,
-		m.ResourceTracker,
+		resourceTracker,
 		validators.UnhandledSubnetConnector, // dione chains don't use subnet connector
 		sb,
 	)
@@ -781,6 +785,7 @@ func (m *manager) createSnowmanChain(
 	fxs []*common.Fx,
 	bootstrapWeight uint64,
 	sb subnets.Subnet,
+	resourceTracker timetracker.ResourceTracker,
 ) (*chain, error) {
 	ctx.Lock.Lock()
 	defer ctx.Lock.Unlock()
@@ -937,7 +942,7 @@ func (m *manager) createSnowmanChain(
 		vdrs,
 		msgChan,
 		m.ConsensusGossipFrequency,
-		m.ResourceTracker,
+		resourceTracker,
 		subnetConnector,
 		sb,
 	)
@@ -14,13 +14,16 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 
+	"github.com/dioneprotocol/dionego/api/server"
 	"github.com/dioneprotocol/dionego/app/runner"
 	"github.com/dioneprotocol/dionego/chains"
+	"github.com/dioneprotocol/dionego/database/encdb"
 	"github.com/dioneprotocol/dionego/genesis"
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/ipcs"
@@ -37,6 +40,7 @@ import (
 	"github.com/dioneprotocol/dionego/staking"
 	"github.com/dioneprotocol/dionego/subnets"
 	"github.com/dioneprotocol/dionego/trace"
+	"github.com/dioneprotocol/dionego/utils/compression"
 	"github.com/dioneprotocol/dionego/utils/constants"
 	"github.com/dioneprotocol/dionego/utils/crypto/bls"
 	"github.com/dioneprotocol/dionego/utils/dynamicip"
@@ -76,11 +80,14 @@ var (
 	errStakeMaxConsumptionBelowMin   = errors.New("stake max consumption can't be less than min stake consumption")
 	errStakeMintingPeriodBelowMin    = errors.New("stake minting period can't be less than max stake duration")
 	errCannotTrackPrimaryNetwork     = errors.New("cannot track primary network")
+	errMissingDBEncryptionKey        = errors.New("missing database encryption key")
 	errStakingKeyContentUnset        = fmt.Errorf("%s key not set but %s set", StakingTLSKeyContentKey, StakingCertContentKey)
 	errStakingCertContentUnset       = fmt.Errorf("%s key set but %s not set", StakingTLSKeyContentKey, StakingCertContentKey)
 	errMissingStakingSigningKeyFile  = errors.New("missing staking signing key file")
 	errTracingEndpointEmpty          = fmt.Errorf("%s cannot be empty", TracingEndpointKey)
 	errPluginDirNotADirectory        = errors.New("plugin dir is not a directory")
+	errIPCsDisabled                  = fmt.Errorf("%s and %s can't both be false", IpcsSocketsEnabledKey, IpcsGRPCEnabledKey)
+	errIPCGRPCAddressNotLoopback     = fmt.Errorf("%s must be a loopback address", IpcsGRPCAddressKey)
 )
 
 func GetRunnerConfig(v *viper.Viper) runner.Config {
@@ -159,10 +166,38 @@ func getAPIAuthConfig(v *viper.Viper) (node.APIAuthConfig, error) {
 	return config, nil
 }
 
-func getIPCConfig(v *viper.Viper) node.IPCConfig {
+func getAPIRateLimitConfig(v *viper.Viper) (server.RateLimitConfig, error) {
+	config := server.RateLimitConfig{
+		Rate:        v.GetFloat64(APIRateLimitKey),
+		Burst:       int(v.GetUint(APIRateLimitBurstKey)),
+		MethodCosts: make(map[string]int),
+		KeyByToken:  v.GetBool(APIRateLimitKeyByTokenKey),
+		MaxClients:  int(v.GetUint(APIRateLimitMaxClientsKey)),
+	}
+	for method, costStr := range v.GetStringMapString(APIRateLimitMethodCostsKey) {
+		cost, err := strconv.Atoi(costStr)
+		if err != nil {
+			return server.RateLimitConfig{}, fmt.Errorf("couldn't parse %q cost of %q: %w", APIRateLimitMethodCostsKey, method, err)
+		}
+		config.MethodCosts[method] = cost
+	}
+	if config.KeyByToken && !v.GetBool(APIAuthRequiredKey) {
+		return server.RateLimitConfig{}, fmt.Errorf("%q requires %q", APIRateLimitKeyByTokenKey, APIAuthRequiredKey)
+	}
+	if err := config.Verify(); err != nil {
+		return server.RateLimitConfig{}, fmt.Errorf("invalid API rate limit config: %w", err)
+	}
+	return config, nil
+}
+
+func getIPCConfig(v *viper.Viper) (node.IPCConfig, error) {
 	config := node.IPCConfig{
-		IPCAPIEnabled: v.GetBool(IpcAPIEnabledKey),
-		IPCPath:       ipcs.DefaultBaseURL,
+		IPCAPIEnabled:     v.GetBool(IpcAPIEnabledKey),
+		IPCPath:           ipcs.DefaultBaseURL,
+		IPCSocketsEnabled: v.GetBool(IpcsSocketsEnabledKey),
+		IPCGRPCEnabled:    v.GetBool(IpcsGRPCEnabledKey),
+		IPCGRPCAddress:    v.GetString(IpcsGRPCAddressKey),
+		IPCGRPCBufferSize: v.GetUint64(IpcsGRPCBufferSizeKey),
 	}
 	if v.IsSet(IpcsChainIDsKey) {
 		config.IPCDefaultChainIDs = strings.Split(v.GetString(IpcsChainIDsKey), ",")
@@ -170,7 +205,54 @@ func getIPCConfig(v *viper.Viper) node.IPCConfig {
 	if v.IsSet(IpcsPathKey) {
 		config.IPCPath = GetExpandedArg(v, IpcsPathKey)
 	}
-	return config
+	if !config.IPCSocketsEnabled && !config.IPCGRPCEnabled {
+		return node.IPCConfig{}, errIPCsDisabled
+	}
+	if !config.IPCGRPCEnabled {
+		return config, nil
+	}
+	if config.IPCGRPCBufferSize == 0 {
+		return node.IPCConfig{}, fmt.Errorf("%q must be positive", IpcsGRPCBufferSizeKey)
+	}
+	// The IPC gRPC server isn't authenticated, so it must only be reachable
+	// from this host.
+	host, _, err := net.SplitHostPort(config.IPCGRPCAddress)
+	if err != nil {
+		return node.IPCConfig{}, fmt.Errorf("couldn't parse %q: %w", IpcsGRPCAddressKey, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return node.IPCConfig{}, fmt.Errorf("%w: %q", errIPCGRPCAddressNotLoopback, config.IPCGRPCAddress)
+	}
+	return config, nil
+}
+
+func getExporterConfig(v *viper.Viper) (node.ExporterConfig, error) {
+	if !v.GetBool(ExportEnabledKey) {
+		return node.ExporterConfig{}, nil
+	}
+
+	config := node.ExporterConfig{
+		ExportEnabled:        true,
+		ExportDir:            GetExpandedArg(v, ExportDirKey),
+		ExportMaxSegmentSize: v.GetUint64(ExportMaxSegmentSizeKey),
+		ExportKafkaAddress:   v.GetString(ExportKafkaAddressKey),
+		ExportKafkaTopic:     v.GetString(ExportKafkaTopicKey),
+		ExportKafkaPartition: v.GetInt32(ExportKafkaPartitionKey),
+		ExportKafkaTimeout:   v.GetDuration(ExportKafkaTimeoutKey),
+	}
+	switch {
+	case config.ExportMaxSegmentSize == 0:
+		return node.ExporterConfig{}, fmt.Errorf("%q must be positive", ExportMaxSegmentSizeKey)
+	case config.ExportKafkaAddress == "":
+		return config, nil
+	case config.ExportKafkaTopic == "":
+		return node.ExporterConfig{}, fmt.Errorf("%q must be specified with %q", ExportKafkaTopicKey, ExportKafkaAddressKey)
+	case config.ExportKafkaPartition < 0:
+		return node.ExporterConfig{}, fmt.Errorf("%q must be non-negative", ExportKafkaPartitionKey)
+	case config.ExportKafkaTimeout <= 0:
+		return node.ExporterConfig{}, fmt.Errorf("%q must be positive", ExportKafkaTimeoutKey)
+	}
+	return config, nil
 }
 
 func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
@@ -214,6 +296,8 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 			APIIndexerConfig: node.APIIndexerConfig{
 				IndexAPIEnabled:      v.GetBool(IndexEnabledKey),
 				IndexAllowIncomplete: v.GetBool(IndexAllowIncompleteKey),
+				IndexMaxContainers:   v.GetUint64(IndexMaxContainersKey),
+				IndexMaxAge:          v.GetDuration(IndexMaxAgeKey),
 			},
 			AdminAPIEnabled:    v.GetBool(AdminAPIEnabledKey),
 			InfoAPIEnabled:     v.GetBool(InfoAPIEnabledKey),
@@ -227,6 +311,7 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		HTTPSKey:          httpsKey,
 		HTTPSCert:         httpsCert,
 		APIAllowedOrigins: v.GetStringSlice(HTTPAllowedOrigins),
+		APIMaxBatchSize:   int(v.GetUint(APIMaxBatchSizeKey)),
 
 		ShutdownTimeout: v.GetDuration(HTTPShutdownTimeoutKey),
 		ShutdownWait:    v.GetDuration(HTTPShutdownWaitKey),
@@ -236,8 +321,12 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 	if err != nil {
 		return node.HTTPConfig{}, err
 	}
-	config.IPCConfig = getIPCConfig(v)
-	return config, nil
+	config.APIRateLimitConfig, err = getAPIRateLimitConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	config.IPCConfig, err = getIPCConfig(v)
+	return config, err
 }
 
 func getRouterHealthConfig(v *viper.Viper, halflife time.Duration) (router.HealthConfig, error) {
@@ -304,6 +393,16 @@ func getNetworkConfig(v *viper.Viper, stakingEnabled bool, halflife time.Duratio
 	upgradeCooldown := v.GetDuration(InboundConnUpgradeThrottlerCooldownKey)
 	upgradeCooldownInSeconds := upgradeCooldown.Seconds()
 	maxRecentConnsUpgraded := int(math.Ceil(maxInboundConnsPerSec * upgradeCooldownInSeconds))
+
+	compressionType := compression.TypeNone
+	if v.GetBool(NetworkCompressionEnabledKey) {
+		var err error
+		compressionType, err = compression.TypeFromString(v.GetString(NetworkCompressionTypeKey))
+		if err != nil {
+			return network.Config{}, fmt.Errorf("invalid %s: %w", NetworkCompressionTypeKey, err)
+		}
+	}
+
 	config := network.Config{
 		// Throttling
 		ThrottlerConfig: network.ThrottlerConfig{
@@ -330,6 +429,9 @@ func getNetworkConfig(v *viper.Viper, stakingEnabled bool, halflife time.Duratio
 				DiskThrottlerConfig: throttling.SystemThrottlerConfig{
 					MaxRecheckDelay: v.GetDuration(InboundThrottlerDiskMaxRecheckDelayKey),
 				},
+				SubnetThrottlerConfig: throttling.SubnetThrottlerConfig{
+					UnreservedShare: v.GetFloat64(InboundThrottlerUnreservedSubnetsShareKey),
+				},
 			},
 
 			OutboundMsgThrottlerConfig: throttling.MsgByteThrottlerConfig{
@@ -370,6 +472,7 @@ func getNetworkConfig(v *viper.Viper, stakingEnabled bool, halflife time.Duratio
 			PeerListNonValidatorGossipSize: v.GetUint32(NetworkPeerListNonValidatorGossipSizeKey),
 			PeerListPeersGossipSize:        v.GetUint32(NetworkPeerListPeersGossipSizeKey),
 			PeerListGossipFreq:             v.GetDuration(NetworkPeerListGossipFreqKey),
+			PeerListBloomResetFreq:         v.GetDuration(NetworkPeerListBloomResetFreqKey),
 		},
 
 		DelayConfig: network.DelayConfig{
@@ -378,7 +481,7 @@ func getNetworkConfig(v *viper.Viper, stakingEnabled bool, halflife time.Duratio
 		},
 
 		MaxClockDifference:           v.GetDuration(NetworkMaxClockDifferenceKey),
-		CompressionEnabled:           v.GetBool(NetworkCompressionEnabledKey),
+		CompressionType:              compressionType,
 		PingFrequency:                v.GetDuration(NetworkPingFrequencyKey),
 		AllowPrivateIPs:              v.GetBool(NetworkAllowPrivateIPsKey),
 		UptimeMetricFreq:             v.GetDuration(UptimeMetricFreqKey),
@@ -387,6 +490,13 @@ func getNetworkConfig(v *viper.Viper, stakingEnabled bool, halflife time.Duratio
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 		PeerReadBufferSize:        int(v.GetUint(NetworkPeerReadBufferSizeKey)),
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
+
+		PeerDBMaxRecords:      int(v.GetUint(NetworkPeerDBMaxRecordsKey)),
+		PeerDBCommitFrequency: v.GetDuration(NetworkPeerDBCommitFrequencyKey),
+
+		MaxProtocolViolations:        v.GetUint64(NetworkMaxProtocolViolationsKey),
+		ProtocolViolationWindow:      v.GetDuration(NetworkProtocolViolationWindowKey),
+		ProtocolViolationBanDuration: v.GetDuration(NetworkProtocolViolationBanDurationKey),
 	}
 
 	switch {
@@ -402,6 +512,8 @@ func getNetworkConfig(v *viper.Viper, stakingEnabled bool, halflife time.Duratio
 		return network.Config{}, fmt.Errorf("%q must be >= 0", OutboundConnectionTimeoutKey)
 	case config.PeerListGossipFreq < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPeerListGossipFreqKey)
+	case config.PeerListBloomResetFreq <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkPeerListBloomResetFreqKey)
 	case config.MaxReconnectDelay < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxReconnectDelayKey)
 	case config.InitialReconnectDelay < 0:
@@ -418,6 +530,14 @@ func getNetworkConfig(v *viper.Viper, stakingEnabled bool, halflife time.Duratio
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReadHandshakeTimeoutKey)
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
+	case config.PeerDBCommitFrequency <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkPeerDBCommitFrequencyKey)
+	case config.ProtocolViolationWindow <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkProtocolViolationWindowKey)
+	case config.ProtocolViolationBanDuration < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkProtocolViolationBanDurationKey)
+	case config.ThrottlerConfig.InboundMsgThrottlerConfig.UnreservedShare <= 0 || config.ThrottlerConfig.InboundMsgThrottlerConfig.UnreservedShare >= 1:
+		return network.Config{}, fmt.Errorf("%s must be in (0,1)", InboundThrottlerUnreservedSubnetsShareKey)
 	}
 	return config, nil
 }
@@ -885,16 +1005,48 @@ func getDatabaseConfig(v *viper.Viper, networkID uint32) (node.DatabaseConfig, e
 		}
 	}
 
+	keyring, err := getDatabaseKeyring(v)
+	if err != nil {
+		return node.DatabaseConfig{}, err
+	}
+
 	return node.DatabaseConfig{
 		Name: v.GetString(DBTypeKey),
 		Path: filepath.Join(
 			GetExpandedArg(v, DBPathKey),
 			constants.NetworkName(networkID),
 		),
-		Config: configBytes,
+		Config:  configBytes,
+		Keyring: keyring,
 	}, nil
 }
 
+// getDatabaseKeyring returns the keyring to encrypt the database with, or nil
+// if the database shouldn't be encrypted.
+func getDatabaseKeyring(v *viper.Viper) (*encdb.Keyring, error) {
+	keySource := v.GetString(DBEncryptionKeyKey)
+	previousKeySources := v.GetStringSlice(DBEncryptionPreviousKeysKey)
+	if len(keySource) == 0 {
+		if len(previousKeySources) > 0 {
+			return nil, fmt.Errorf("%w: %s requires %s", errMissingDBEncryptionKey, DBEncryptionPreviousKeysKey, DBEncryptionKeyKey)
+		}
+		return nil, nil
+	}
+
+	key, err := encdb.LoadKey(keySource)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load %s: %w", DBEncryptionKeyKey, err)
+	}
+	previousKeys := make([]encdb.KeySource, len(previousKeySources))
+	for i, source := range previousKeySources {
+		previousKeys[i], err = encdb.LoadKey(source)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't load %s: %w", DBEncryptionPreviousKeysKey, err)
+		}
+	}
+	return encdb.NewKeyring(key, previousKeys...)
+}
+
 func getAliases(v *viper.Viper, name string, contentKey string, fileKey string) (map[ids.ID][]string, error) {
 	var fileBytes []byte
 	if v.IsSet(contentKey) {
@@ -1450,6 +1602,11 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	nodeConfig.ExporterConfig, err = getExporterConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	nodeConfig.ChainDataDir = GetExpandedArg(v, ChainDataDirKey)
 
 	nodeConfig.ProvidedFlags = providedFlags(v)
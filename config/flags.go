@@ -13,11 +13,18 @@ import (
 
 	"github.com/spf13/viper"
 
+	"github.com/dioneprotocol/dionego/api/server"
+	"github.com/dioneprotocol/dionego/database/encdb"
 	"github.com/dioneprotocol/dionego/database/leveldb"
 	"github.com/dioneprotocol/dionego/database/memdb"
+	"github.com/dioneprotocol/dionego/database/pebble"
+	"github.com/dioneprotocol/dionego/exporter"
 	"github.com/dioneprotocol/dionego/genesis"
+	"github.com/dioneprotocol/dionego/ipcs"
 	"github.com/dioneprotocol/dionego/trace"
+	"github.com/dioneprotocol/dionego/utils/compression"
 	"github.com/dioneprotocol/dionego/utils/constants"
+	"github.com/dioneprotocol/dionego/utils/json"
 	"github.com/dioneprotocol/dionego/utils/ulimit"
 	"github.com/dioneprotocol/dionego/utils/units"
 )
@@ -36,6 +43,7 @@ var (
 	defaultDBDir                = filepath.Join(defaultUnexpandedDataDir, "db")
 	defaultLogDir               = filepath.Join(defaultUnexpandedDataDir, "logs")
 	defaultProfileDir           = filepath.Join(defaultUnexpandedDataDir, "profiles")
+	defaultExportDir            = filepath.Join(defaultUnexpandedDataDir, "exports")
 	defaultStakingPath          = filepath.Join(defaultUnexpandedDataDir, "staking")
 	defaultStakingTLSKeyPath    = filepath.Join(defaultStakingPath, "staker.key")
 	defaultStakingCertPath      = filepath.Join(defaultStakingPath, "staker.crt")
@@ -89,10 +97,12 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint64(AddSubnetDelegatorFeeKey, genesis.LocalParams.AddSubnetDelegatorFee, "Transaction fee, in nDIONE, for transactions that add new subnet delegators")
 
 	// Database
-	fs.String(DBTypeKey, leveldb.Name, fmt.Sprintf("Database type to use. Should be one of {%s, %s}", leveldb.Name, memdb.Name))
+	fs.String(DBTypeKey, leveldb.Name, fmt.Sprintf("Database type to use. Should be one of {%s, %s, %s}", leveldb.Name, memdb.Name, pebble.Name))
 	fs.String(DBPathKey, defaultDBDir, "Path to database directory")
 	fs.String(DBConfigFileKey, "", fmt.Sprintf("Path to database config file. Ignored if %s is specified", DBConfigContentKey))
 	fs.String(DBConfigContentKey, "", "Specifies base64 encoded database config content")
+	fs.String(DBEncryptionKeyKey, "", fmt.Sprintf("Source of the key to encrypt database values with. Should be of the form %s<path>, %s<name>, %s<path> or %s<name>. If empty, the database isn't encrypted. Can only be enabled on a new database, as existing unencrypted values aren't migrated", encdb.FileKeySourcePrefix, encdb.EnvKeySourcePrefix, encdb.PassphraseFileKeySourcePrefix, encdb.PassphraseEnvKeySourcePrefix))
+	fs.StringSlice(DBEncryptionPreviousKeysKey, nil, fmt.Sprintf("Sources, in the same form as %s, of keys the database was previously encrypted with. Values encrypted with them are re-encrypted with the current key in the background", DBEncryptionKeyKey))
 
 	// Logging
 	fs.String(LogsDirKey, defaultLogDir, "Logging directory for Dione")
@@ -119,6 +129,7 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint(NetworkPeerListNonValidatorGossipSizeKey, constants.DefaultNetworkPeerListNonValidatorGossipSize, gossipHelpMsg)
 	fs.Uint(NetworkPeerListPeersGossipSizeKey, constants.DefaultNetworkPeerListPeersGossipSize, gossipHelpMsg)
 	fs.Duration(NetworkPeerListGossipFreqKey, constants.DefaultNetworkPeerListGossipFreq, gossipHelpMsg)
+	fs.Duration(NetworkPeerListBloomResetFreqKey, constants.DefaultNetworkPeerListBloomResetFreq, "Frequency to recalculate the bloom filter used to request new peers from other nodes")
 
 	// Public IP Resolution
 	fs.String(PublicIPKey, "", "Public IP of this node for P2P communication. If empty, try to discover with NAT")
@@ -143,11 +154,17 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Duration(NetworkPingFrequencyKey, constants.DefaultPingFrequency, "Frequency of pinging other peers")
 
 	fs.Bool(NetworkCompressionEnabledKey, constants.DefaultNetworkCompressionEnabled, "If true, compress certain outbound messages. This node will be able to parse compressed inbound messages regardless of this flag's value")
+	fs.String(NetworkCompressionTypeKey, constants.DefaultNetworkCompressionType.String(), fmt.Sprintf("Preferred compression type of outbound messages. Messages are compressed with a type that the receiving peer supports. Must be one of %q, %q or %q. Ignored if %s is false", compression.TypeZstd, compression.TypeGzip, compression.TypeNone, NetworkCompressionEnabledKey))
 	fs.Duration(NetworkMaxClockDifferenceKey, constants.DefaultNetworkMaxClockDifference, "Max allowed clock difference value between this node and peers")
 	fs.Bool(NetworkAllowPrivateIPsKey, constants.DefaultNetworkAllowPrivateIPs, "Allows the node to initiate outbound connection attempts to peers with private IPs")
 	fs.Bool(NetworkRequireValidatorToConnectKey, constants.DefaultNetworkRequireValidatorToConnect, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
 	fs.Uint(NetworkPeerReadBufferSizeKey, constants.DefaultNetworkPeerReadBufferSize, "Size, in bytes, of the buffer that we read peer messages into (there is one buffer per peer)")
 	fs.Uint(NetworkPeerWriteBufferSizeKey, constants.DefaultNetworkPeerWriteBufferSize, "Size, in bytes, of the buffer that we write peer messages into (there is one buffer per peer)")
+	fs.Uint(NetworkPeerDBMaxRecordsKey, constants.DefaultNetworkPeerDBMaxRecords, "Maximum number of peers whose signed IPs and reputations are persisted across restarts. The peers that were seen least recently are forgotten first")
+	fs.Duration(NetworkPeerDBCommitFrequencyKey, constants.DefaultNetworkPeerDBCommitFrequency, "Frequency of writing the signed IPs and reputations of peers to disk")
+	fs.Uint64(NetworkMaxProtocolViolationsKey, constants.DefaultNetworkMaxProtocolViolations, fmt.Sprintf("Number of unparsable messages or invalid signed IPs a peer can send within %s before it is banned. Primary Network validators are never banned. If 0, peers are never banned for sending invalid messages", NetworkProtocolViolationWindowKey))
+	fs.Duration(NetworkProtocolViolationWindowKey, constants.DefaultNetworkProtocolViolationWindow, fmt.Sprintf("Duration over which the invalid messages counted by %s are tracked", NetworkMaxProtocolViolationsKey))
+	fs.Duration(NetworkProtocolViolationBanDurationKey, constants.DefaultNetworkProtocolViolationBanDuration, fmt.Sprintf("Duration a peer is banned for after sending %s invalid messages within %s", NetworkMaxProtocolViolationsKey, NetworkProtocolViolationWindowKey))
 
 	fs.Bool(NetworkTCPProxyEnabledKey, constants.DefaultNetworkTCPProxyEnabled, "Require all P2P connections to be initiated with a TCP proxy header")
 	// The PROXY protocol specification recommends setting this value to be at
@@ -186,6 +203,7 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint64(InboundThrottlerBandwidthMaxBurstSizeKey, constants.DefaultInboundThrottlerBandwidthMaxBurstSize, "Max inbound bandwidth a node can use at once. Must be at least the max message size. See BandwidthThrottler")
 	fs.Duration(InboundThrottlerCPUMaxRecheckDelayKey, constants.DefaultInboundThrottlerCPUMaxRecheckDelay, "In the CPU-based network throttler, check at least this often whether the node's CPU usage has fallen to an acceptable level")
 	fs.Duration(InboundThrottlerDiskMaxRecheckDelayKey, constants.DefaultInboundThrottlerDiskMaxRecheckDelay, "In the disk-based network throttler, check at least this often whether the node's disk usage has fallen to an acceptable level")
+	fs.Float64(InboundThrottlerUnreservedSubnetsShareKey, constants.DefaultInboundThrottlerUnreservedSubnetsShare, "Share of the inbound message byte allocation, bandwidth and CPU shared by the subnets without a reserved share. Only enforced if a tracked subnet reserves a share. Messages destined for the Primary Network are never dropped. Must be in (0, 1)")
 
 	// Outbound Throttling
 	fs.Uint64(OutboundThrottlerAtLargeAllocSizeKey, constants.DefaultOutboundThrottlerAtLargeAllocSize, "Size, in bytes, of at-large byte allocation in outbound message throttler")
@@ -208,6 +226,12 @@ func addNodeFlags(fs *flag.FlagSet) {
 		fmt.Sprintf("Password file used to initially create/validate API authorization tokens. Ignored if %s is specified. Leading and trailing whitespace is removed from the password. Can be changed via API call",
 			APIAuthPasswordKey))
 	fs.String(APIAuthPasswordKey, "", "Specifies password for API authorization tokens")
+	fs.Uint(APIMaxBatchSizeKey, json.DefaultMaxBatchSize, "Max number of calls in a JSON-RPC batch request. If 0, batch requests are rejected")
+	fs.Float64(APIRateLimitKey, 0, "Average cost per second each client can spend calling HTTP APIs. Each call to a JSON-RPC method costs the method's cost. If 0, calls aren't rate limited")
+	fs.Uint(APIRateLimitBurstKey, 100, "Max cost each client can spend calling HTTP APIs at once")
+	fs.StringToString(APIRateLimitMethodCostsKey, nil, "Cost of calling each JSON-RPC method. Methods that aren't given a cost cost 1. Example: avm.getAddressTxs=10,platform.getUTXOs=10")
+	fs.Bool(APIRateLimitKeyByTokenKey, false, fmt.Sprintf("If true, calls are rate limited per auth token rather than per IP. Requires %s", APIAuthRequiredKey))
+	fs.Uint(APIRateLimitMaxClientsKey, server.DefaultRateLimitMaxClients, "Max number of clients whose rate limits are tracked at once")
 
 	// Enable/Disable APIs
 	fs.Bool(AdminAPIEnabledKey, false, "If true, this node exposes the Admin API")
@@ -304,10 +328,25 @@ func addNodeFlags(fs *flag.FlagSet) {
 	// IPC
 	fs.String(IpcsChainIDsKey, "", "Comma separated list of chain ids to add to the IPC engine. Example: 11111111111111111111111111111111LpoYY,4R5p2RXDGLqaifZE4hHWH9owe34pfoBULn1DrQTWivjg8o4aH")
 	fs.String(IpcsPathKey, "", "The directory (Unix) or named pipe name prefix (Windows) for IPC sockets")
+	fs.Bool(IpcsSocketsEnabledKey, true, "If true, the events of published chains are written to IPC sockets. Can't be false if IPC gRPC is disabled")
+	fs.Bool(IpcsGRPCEnabledKey, false, "If true, the events of published chains are streamed by a gRPC server")
+	fs.String(IpcsGRPCAddressKey, "127.0.0.1:9652", "The address the IPC gRPC server listens on. Must be a loopback address, because the server isn't authenticated. Ignored if IPC gRPC is disabled")
+	fs.Uint64(IpcsGRPCBufferSizeKey, ipcs.DefaultStreamBufferSize, "The number of events of each type, per published chain, kept for replay by IPC gRPC subscribers. Ignored if IPC gRPC is disabled")
 
 	// Indexer
 	fs.Bool(IndexEnabledKey, false, "If true, index all accepted containers and transactions and expose them via an API")
 	fs.Bool(IndexAllowIncompleteKey, false, "If true, allow running the node in such a way that could cause an index to miss transactions. Ignored if index is disabled")
+	fs.Uint64(IndexMaxContainersKey, 0, "If non-zero, each index only keeps its last [index-max-containers] containers and prunes older ones. Ignored if index is disabled")
+	fs.Duration(IndexMaxAgeKey, 0, "If non-zero, each index prunes containers accepted more than [index-max-age] ago. Ignored if index is disabled")
+
+	// Exporter
+	fs.Bool(ExportEnabledKey, false, "If true, write the containers accepted on every chain to an append-only export log")
+	fs.String(ExportDirKey, defaultExportDir, "Path to the export log directory. Ignored if export is disabled")
+	fs.Uint64(ExportMaxSegmentSizeKey, exporter.DefaultMaxSegmentSize, "Size, in bytes, after which a new file of the export log is started. Ignored if export is disabled")
+	fs.String(ExportKafkaAddressKey, "", "If non-empty, exported events are also produced to the Kafka broker at this address, which must lead the partition. Ignored if export is disabled")
+	fs.String(ExportKafkaTopicKey, "", fmt.Sprintf("The Kafka topic exported events are produced to. Ignored if %s is empty", ExportKafkaAddressKey))
+	fs.Int32(ExportKafkaPartitionKey, 0, fmt.Sprintf("The partition of the Kafka topic exported events are produced to. Ignored if %s is empty", ExportKafkaAddressKey))
+	fs.Duration(ExportKafkaTimeoutKey, exporter.DefaultKafkaTimeout, fmt.Sprintf("Max time to wait for the Kafka broker to acknowledge exported events. Ignored if %s is empty", ExportKafkaAddressKey))
 
 	// Config Directories
 	fs.String(ChainConfigDirKey, defaultChainConfigDir, fmt.Sprintf("Chain specific configurations parent directory. Ignored if %s is specified", ChainConfigContentKey))
@@ -37,6 +37,8 @@ const (
 	DBPathKey                                          = "db-dir"
 	DBConfigFileKey                                    = "db-config-file"
 	DBConfigContentKey                                 = "db-config-file-content"
+	DBEncryptionKeyKey                                 = "db-encryption-key"
+	DBEncryptionPreviousKeysKey                        = "db-encryption-previous-keys"
 	PublicIPKey                                        = "public-ip"
 	PublicIPResolutionFreqKey                          = "public-ip-resolution-frequency"
 	PublicIPResolutionServiceKey                       = "public-ip-resolution-service"
@@ -57,6 +59,12 @@ const (
 	APIAuthRequiredKey                                 = "api-auth-required"
 	APIAuthPasswordKey                                 = "api-auth-password"
 	APIAuthPasswordFileKey                             = "api-auth-password-file"
+	APIMaxBatchSizeKey                                 = "api-max-batch-size"
+	APIRateLimitKey                                    = "api-rate-limit"
+	APIRateLimitBurstKey                               = "api-rate-limit-burst"
+	APIRateLimitMethodCostsKey                         = "api-rate-limit-method-costs"
+	APIRateLimitKeyByTokenKey                          = "api-rate-limit-key-by-token"
+	APIRateLimitMaxClientsKey                          = "api-rate-limit-max-clients"
 	StateSyncIPsKey                                    = "state-sync-ips"
 	StateSyncIDsKey                                    = "state-sync-ids"
 	BootstrapIPsKey                                    = "bootstrap-ips"
@@ -89,17 +97,24 @@ const (
 	NetworkPeerListNonValidatorGossipSizeKey           = "network-peer-list-non-validator-gossip-size"
 	NetworkPeerListPeersGossipSizeKey                  = "network-peer-list-peers-gossip-size"
 	NetworkPeerListGossipFreqKey                       = "network-peer-list-gossip-frequency"
+	NetworkPeerListBloomResetFreqKey                   = "network-peer-list-bloom-reset-frequency"
 	NetworkInitialReconnectDelayKey                    = "network-initial-reconnect-delay"
 	NetworkReadHandshakeTimeoutKey                     = "network-read-handshake-timeout"
 	NetworkPingTimeoutKey                              = "network-ping-timeout"
 	NetworkPingFrequencyKey                            = "network-ping-frequency"
 	NetworkMaxReconnectDelayKey                        = "network-max-reconnect-delay"
 	NetworkCompressionEnabledKey                       = "network-compression-enabled"
+	NetworkCompressionTypeKey                          = "network-compression-type"
 	NetworkMaxClockDifferenceKey                       = "network-max-clock-difference"
 	NetworkAllowPrivateIPsKey                          = "network-allow-private-ips"
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
 	NetworkPeerReadBufferSizeKey                       = "network-peer-read-buffer-size"
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
+	NetworkPeerDBMaxRecordsKey                         = "network-peer-db-max-records"
+	NetworkPeerDBCommitFrequencyKey                    = "network-peer-db-commit-frequency"
+	NetworkMaxProtocolViolationsKey                    = "network-max-protocol-violations"
+	NetworkProtocolViolationWindowKey                  = "network-protocol-violation-window"
+	NetworkProtocolViolationBanDurationKey             = "network-protocol-violation-ban-duration"
 	NetworkTCPProxyEnabledKey                          = "network-tcp-proxy-enabled"
 	NetworkTCPProxyReadTimeoutKey                      = "network-tcp-proxy-read-timeout"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
@@ -137,6 +152,10 @@ const (
 	IpcAPIEnabledKey                                   = "api-ipcs-enabled"
 	IpcsChainIDsKey                                    = "ipcs-chain-ids"
 	IpcsPathKey                                        = "ipcs-path"
+	IpcsSocketsEnabledKey                              = "ipcs-sockets-enabled"
+	IpcsGRPCEnabledKey                                 = "ipcs-grpc-enabled"
+	IpcsGRPCAddressKey                                 = "ipcs-grpc-address"
+	IpcsGRPCBufferSizeKey                              = "ipcs-grpc-buffer-size"
 	MeterVMsEnabledKey                                 = "meter-vms-enabled"
 	ConsensusGossipFrequencyKey                        = "consensus-gossip-frequency"
 	ConsensusGossipAcceptedFrontierValidatorSizeKey    = "consensus-accepted-frontier-gossip-validator-size"
@@ -153,6 +172,15 @@ const (
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
 	IndexAllowIncompleteKey                            = "index-allow-incomplete"
+	IndexMaxContainersKey                              = "index-max-containers"
+	IndexMaxAgeKey                                     = "index-max-age"
+	ExportEnabledKey                                   = "export-enabled"
+	ExportDirKey                                       = "export-dir"
+	ExportMaxSegmentSizeKey                            = "export-max-segment-size"
+	ExportKafkaAddressKey                              = "export-kafka-address"
+	ExportKafkaTopicKey                                = "export-kafka-topic"
+	ExportKafkaPartitionKey                            = "export-kafka-partition"
+	ExportKafkaTimeoutKey                              = "export-kafka-timeout"
 	RouterHealthMaxDropRateKey                         = "router-health-max-drop-rate"
 	RouterHealthMaxOutstandingRequestsKey              = "router-health-max-outstanding-requests"
 	HealthCheckFreqKey                                 = "health-check-frequency"
@@ -181,6 +209,7 @@ const (
 	InboundThrottlerBandwidthMaxBurstSizeKey           = "throttler-inbound-bandwidth-max-burst-size"
 	InboundThrottlerCPUMaxRecheckDelayKey              = "throttler-inbound-cpu-max-recheck-delay"
 	InboundThrottlerDiskMaxRecheckDelayKey             = "throttler-inbound-disk-max-recheck-delay"
+	InboundThrottlerUnreservedSubnetsShareKey          = "throttler-inbound-unreserved-subnets-share"
 	CPUVdrAllocKey                                     = "throttler-inbound-cpu-validator-alloc"
 	CPUMaxNonVdrUsageKey                               = "throttler-inbound-cpu-max-non-validator-usage"
 	CPUMaxNonVdrNodeUsageKey                           = "throttler-inbound-cpu-max-non-validator-node-usage"
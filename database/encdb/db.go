@@ -4,6 +4,7 @@
 package encdb
 
 import (
+	"bytes"
 	"context"
 	"crypto/cipher"
 	"crypto/rand"
@@ -29,13 +30,21 @@ var (
 	_ database.Iterator = (*iterator)(nil)
 )
 
+// valueCipher encrypts and decrypts the values stored under a key.
+type valueCipher interface {
+	encrypt(key, plaintext []byte) ([]byte, error)
+	decrypt(key, ciphertext []byte) ([]byte, error)
+}
+
 // Database encrypts all values that are provided
 type Database struct {
 	lock   sync.RWMutex
-	codec  codec.Manager
-	cipher cipher.AEAD
+	cipher valueCipher
 	db     database.Database
 	closed bool
+	// Keys in [db] with this prefix, if it isn't empty, hold unencrypted
+	// metadata and are skipped by iterators.
+	metadataPrefix []byte
 }
 
 // New returns a new encrypted database
@@ -48,9 +57,11 @@ func New(password []byte, db database.Database) (*Database, error) {
 	c := linearcodec.NewDefault()
 	manager := codec.NewDefaultManager()
 	return &Database{
-		codec:  manager,
-		cipher: aead,
-		db:     db,
+		cipher: &passwordCipher{
+			codec:  manager,
+			cipher: aead,
+		},
+		db: db,
 	}, manager.RegisterCodec(codecVersion, c)
 }
 
@@ -75,7 +86,7 @@ func (db *Database) Get(key []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	return db.decrypt(encVal)
+	return db.cipher.decrypt(key, encVal)
 }
 
 func (db *Database) Put(key, value []byte) error {
@@ -86,7 +97,7 @@ func (db *Database) Put(key, value []byte) error {
 		return database.ErrClosed
 	}
 
-	encValue, err := db.encrypt(value)
+	encValue, err := db.cipher.encrypt(key, value)
 	if err != nil {
 		return err
 	}
@@ -165,6 +176,11 @@ func (db *Database) isClosed() bool {
 	return db.closed
 }
 
+// isMetadata returns true if [key] holds unencrypted metadata.
+func (db *Database) isMetadata(key []byte) bool {
+	return len(db.metadataPrefix) > 0 && bytes.HasPrefix(key, db.metadataPrefix)
+}
+
 func (db *Database) HealthCheck(ctx context.Context) (interface{}, error) {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -187,7 +203,7 @@ func (b *batch) Put(key, value []byte) error {
 		Key:   slices.Clone(key),
 		Value: slices.Clone(value),
 	})
-	encValue, err := b.db.encrypt(value)
+	encValue, err := b.db.cipher.encrypt(key, value)
 	if err != nil {
 		return err
 	}
@@ -255,15 +271,18 @@ func (it *iterator) Next() bool {
 	}
 
 	next := it.Iterator.Next()
+	for next && it.db.isMetadata(it.Iterator.Key()) {
+		next = it.Iterator.Next()
+	}
 	if next {
-		encVal := it.Iterator.Value()
-		val, err := it.db.decrypt(encVal)
+		key := it.Iterator.Key()
+		val, err := it.db.cipher.decrypt(key, it.Iterator.Value())
 		if err != nil {
 			it.err = err
 			return false
 		}
 		it.val = val
-		it.key = it.Iterator.Key()
+		it.key = key
 	} else {
 		it.val = nil
 		it.key = nil
@@ -291,22 +310,28 @@ type encryptedValue struct {
 	Nonce      []byte `serialize:"true"`
 }
 
-func (db *Database) encrypt(plaintext []byte) ([]byte, error) {
+// passwordCipher encrypts values with a key derived from a password.
+type passwordCipher struct {
+	codec  codec.Manager
+	cipher cipher.AEAD
+}
+
+func (c *passwordCipher) encrypt(_, plaintext []byte) ([]byte, error) {
 	nonce := make([]byte, chacha20poly1305.NonceSizeX)
 	if _, err := rand.Read(nonce); err != nil {
 		return nil, err
 	}
-	ciphertext := db.cipher.Seal(nil, nonce, plaintext, nil)
-	return db.codec.Marshal(codecVersion, &encryptedValue{
+	ciphertext := c.cipher.Seal(nil, nonce, plaintext, nil)
+	return c.codec.Marshal(codecVersion, &encryptedValue{
 		Ciphertext: ciphertext,
 		Nonce:      nonce,
 	})
 }
 
-func (db *Database) decrypt(ciphertext []byte) ([]byte, error) {
+func (c *passwordCipher) decrypt(_, ciphertext []byte) ([]byte, error) {
 	val := encryptedValue{}
-	if _, err := db.codec.Unmarshal(ciphertext, &val); err != nil {
+	if _, err := c.codec.Unmarshal(ciphertext, &val); err != nil {
 		return nil, err
 	}
-	return db.cipher.Open(nil, val.Nonce, val.Ciphertext, nil)
+	return c.cipher.Open(nil, val.Nonce, val.Ciphertext, nil)
 }
@@ -0,0 +1,309 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/dioneprotocol/dionego/utils/hashing"
+)
+
+const (
+	// KeyLen is the length of an encryption key.
+	KeyLen = chacha20poly1305.KeySize
+
+	// FileKeySourcePrefix, EnvKeySourcePrefix, PassphraseFileKeySourcePrefix
+	// and PassphraseEnvKeySourcePrefix are the prefixes of the key sources
+	// accepted by LoadKey.
+	FileKeySourcePrefix           = "file:"
+	EnvKeySourcePrefix            = "env:"
+	PassphraseFileKeySourcePrefix = "passphrase-file:"
+	PassphraseEnvKeySourcePrefix  = "passphrase-env:"
+
+	fingerprintLen = 4
+
+	// keyringValueVersion is the first byte of every value written by a
+	// keyring cipher. It allows the format to be changed in the future and
+	// makes unencrypted values unlikely to be mistaken for encrypted ones.
+	keyringValueVersion byte = 0x01
+	keyringHeaderLen         = 1 + fingerprintLen + chacha20poly1305.NonceSizeX
+	keyringOverhead          = keyringHeaderLen + chacha20poly1305.Overhead
+
+	// Argon2id parameters used to derive keys from passphrases.
+	passphraseTime    = 3
+	passphraseMemory  = 64 * 1024
+	passphraseThreads = 4
+
+	// SaltLen is the length of the salt that passphrases are derived with.
+	SaltLen = 16
+)
+
+var (
+	// ErrWrongKey is returned when a value was encrypted with a key that isn't
+	// in the keyring.
+	ErrWrongKey = errors.New("value was encrypted with a key that isn't in the keyring")
+	// ErrNotEncrypted is returned when a value wasn't written by a keyring
+	// cipher.
+	ErrNotEncrypted = errors.New("value isn't encrypted")
+	// ErrCorrupted is returned when a value was encrypted with a key in the
+	// keyring but fails authentication.
+	ErrCorrupted = errors.New("encrypted value failed authentication")
+	// ErrPlaintextDatabase is returned when encryption is enabled on a
+	// database that already contains unencrypted values. Existing databases
+	// aren't migrated, so encryption can only be enabled on a new database.
+	ErrPlaintextDatabase = errors.New("encryption can only be enabled on an empty database")
+
+	errUnknownKeySource = errors.New("unknown key source")
+	errInvalidKeyLen    = errors.New("invalid key length")
+	errEmptyPassphrase  = errors.New("empty passphrase")
+	errInvalidSaltLen   = errors.New("invalid salt length")
+	errDuplicateKey     = errors.New("duplicate key")
+
+	fingerprintDomain = []byte("dionego/encdb/fingerprint")
+)
+
+// KeySource is either a Key or a Passphrase. Keys are derived from passphrases
+// with the salt of the database they encrypt, so equal passphrases result in
+// different keys for different databases.
+type KeySource interface {
+	deriveKey(salt []byte) (Key, error)
+	// sourceID uniquely identifies the source among the sources of a keyring.
+	sourceID() string
+}
+
+// Key is a 256-bit encryption key.
+type Key [KeyLen]byte
+
+func (k Key) deriveKey([]byte) (Key, error) {
+	return k, nil
+}
+
+func (k Key) sourceID() string {
+	return "key:" + string(k[:])
+}
+
+// Passphrase is a passphrase that a Key is derived from.
+type Passphrase []byte
+
+func (p Passphrase) deriveKey(salt []byte) (Key, error) {
+	return DeriveKey(p, salt)
+}
+
+func (p Passphrase) sourceID() string {
+	return "passphrase:" + string(p)
+}
+
+// fingerprint returns a short identifier of the key that is stored alongside
+// each value so the key that encrypted it can be found without trial
+// decryption.
+func (k Key) fingerprint() [fingerprintLen]byte {
+	preimage := make([]byte, 0, len(fingerprintDomain)+KeyLen)
+	preimage = append(preimage, fingerprintDomain...)
+	preimage = append(preimage, k[:]...)
+
+	var fp [fingerprintLen]byte
+	copy(fp[:], hashing.ComputeHash256(preimage))
+	return fp
+}
+
+// LoadKey loads a key source from [source], which has one of the forms:
+//
+//   - file:<path> reads a hex encoded key from the file at <path>.
+//   - env:<name> reads a hex encoded key from the environment variable <name>.
+//   - passphrase-file:<path> derives the key from the passphrase in the file
+//     at <path>.
+//   - passphrase-env:<name> derives the key from the passphrase in the
+//     environment variable <name>.
+func LoadKey(source string) (KeySource, error) {
+	switch {
+	case strings.HasPrefix(source, FileKeySourcePrefix):
+		contents, err := os.ReadFile(strings.TrimPrefix(source, FileKeySourcePrefix))
+		if err != nil {
+			return nil, err
+		}
+		return parseHexKey(string(contents))
+	case strings.HasPrefix(source, EnvKeySourcePrefix):
+		return parseHexKey(os.Getenv(strings.TrimPrefix(source, EnvKeySourcePrefix)))
+	case strings.HasPrefix(source, PassphraseFileKeySourcePrefix):
+		contents, err := os.ReadFile(strings.TrimPrefix(source, PassphraseFileKeySourcePrefix))
+		if err != nil {
+			return nil, err
+		}
+		// Allow the file to end with a newline.
+		return parsePassphrase(bytes.TrimRight(contents, "\r\n"))
+	case strings.HasPrefix(source, PassphraseEnvKeySourcePrefix):
+		return parsePassphrase([]byte(os.Getenv(strings.TrimPrefix(source, PassphraseEnvKeySourcePrefix))))
+	default:
+		return nil, fmt.Errorf("%w: %q should start with one of {%s, %s, %s, %s}",
+			errUnknownKeySource,
+			source,
+			FileKeySourcePrefix,
+			EnvKeySourcePrefix,
+			PassphraseFileKeySourcePrefix,
+			PassphraseEnvKeySourcePrefix,
+		)
+	}
+}
+
+// DeriveKey derives a key from [passphrase] and [salt] with Argon2id.
+func DeriveKey(passphrase, salt []byte) (Key, error) {
+	if len(passphrase) == 0 {
+		return Key{}, errEmptyPassphrase
+	}
+	if len(salt) != SaltLen {
+		return Key{}, fmt.Errorf("%w: expected %d bytes but got %d", errInvalidSaltLen, SaltLen, len(salt))
+	}
+	var key Key
+	copy(key[:], argon2.IDKey(passphrase, salt, passphraseTime, passphraseMemory, passphraseThreads, KeyLen))
+	return key, nil
+}
+
+func parseHexKey(s string) (KeySource, error) {
+	keyBytes, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	}
+	if len(keyBytes) != KeyLen {
+		return nil, fmt.Errorf("%w: expected %d bytes but got %d", errInvalidKeyLen, KeyLen, len(keyBytes))
+	}
+	var key Key
+	copy(key[:], keyBytes)
+	return key, nil
+}
+
+func parsePassphrase(passphrase []byte) (KeySource, error) {
+	if len(passphrase) == 0 {
+		return nil, errEmptyPassphrase
+	}
+	return Passphrase(passphrase), nil
+}
+
+// Keyring is the set of keys that a database may be encrypted with. New
+// values are always encrypted with the current key. Values encrypted with a
+// previous key can still be read until they are re-encrypted.
+type Keyring struct {
+	current  KeySource
+	previous []KeySource
+}
+
+// NewKeyring returns a keyring that encrypts with [current] and can decrypt
+// values encrypted with [current] or any of [previous].
+func NewKeyring(current KeySource, previous ...KeySource) (*Keyring, error) {
+	sourceIDs := map[string]struct{}{
+		current.sourceID(): {},
+	}
+	for i, source := range previous {
+		sourceID := source.sourceID()
+		if _, ok := sourceIDs[sourceID]; ok {
+			return nil, fmt.Errorf("%w: previous key %d", errDuplicateKey, i)
+		}
+		sourceIDs[sourceID] = struct{}{}
+	}
+	return &Keyring{
+		current:  current,
+		previous: previous,
+	}, nil
+}
+
+// deriveKeys returns the current and previous keys of the keyring for a
+// database with [salt].
+func (k *Keyring) deriveKeys(salt []byte) (Key, []Key, error) {
+	current, err := k.current.deriveKey(salt)
+	if err != nil {
+		return Key{}, nil, err
+	}
+	previous := make([]Key, len(k.previous))
+	for i, source := range k.previous {
+		previous[i], err = source.deriveKey(salt)
+		if err != nil {
+			return Key{}, nil, err
+		}
+	}
+	return current, previous, nil
+}
+
+// keyringCipher encrypts values with the current key of a keyring. Each value
+// is stored as:
+//
+//	version (1 byte) || key fingerprint (4 bytes) || nonce (24 bytes) || ciphertext
+//
+// The database key is authenticated along with the value, so values can't be
+// moved between keys without being detected.
+type keyringCipher struct {
+	currentFingerprint [fingerprintLen]byte
+	ciphers            map[[fingerprintLen]byte]cipher.AEAD
+}
+
+func newKeyringCipher(keyring *Keyring, salt []byte) (*keyringCipher, error) {
+	current, previous, err := keyring.deriveKeys(salt)
+	if err != nil {
+		return nil, err
+	}
+	c := &keyringCipher{
+		currentFingerprint: current.fingerprint(),
+		ciphers:            make(map[[fingerprintLen]byte]cipher.AEAD, 1+len(previous)),
+	}
+	for _, key := range append([]Key{current}, previous...) {
+		aead, err := chacha20poly1305.NewX(key[:])
+		if err != nil {
+			return nil, err
+		}
+		c.ciphers[key.fingerprint()] = aead
+	}
+	return c, nil
+}
+
+func (c *keyringCipher) encrypt(key, plaintext []byte) ([]byte, error) {
+	value := make([]byte, keyringHeaderLen, keyringOverhead+len(plaintext))
+	value[0] = keyringValueVersion
+	copy(value[1:], c.currentFingerprint[:])
+	nonce := value[1+fingerprintLen:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return c.ciphers[c.currentFingerprint].Seal(value, nonce, plaintext, key), nil
+}
+
+func (c *keyringCipher) decrypt(key, ciphertext []byte) ([]byte, error) {
+	fp, err := valueFingerprint(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	aead, ok := c.ciphers[fp]
+	if !ok {
+		return nil, fmt.Errorf("%w: key fingerprint %x", ErrWrongKey, fp)
+	}
+	plaintext, err := aead.Open(nil, ciphertext[1+fingerprintLen:keyringHeaderLen], ciphertext[keyringHeaderLen:], key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorrupted, err)
+	}
+	return plaintext, nil
+}
+
+// isCurrent returns true if [ciphertext] is encrypted with the current key.
+func (c *keyringCipher) isCurrent(ciphertext []byte) bool {
+	fp, err := valueFingerprint(ciphertext)
+	return err == nil && fp == c.currentFingerprint
+}
+
+// valueFingerprint returns the fingerprint of the key that encrypted
+// [ciphertext].
+func valueFingerprint(ciphertext []byte) ([fingerprintLen]byte, error) {
+	var fp [fingerprintLen]byte
+	if len(ciphertext) < keyringOverhead || ciphertext[0] != keyringValueVersion {
+		return fp, ErrNotEncrypted
+	}
+	copy(fp[:], ciphertext[1:])
+	return fp, nil
+}
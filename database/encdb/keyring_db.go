@@ -0,0 +1,280 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/utils/logging"
+)
+
+const (
+	// rotationBatchSize is the maximum number of values re-encrypted while
+	// holding the database lock.
+	rotationBatchSize = 256
+	// rotationScanSize is the maximum number of keys read by an iterator
+	// before it is released, so compactions aren't blocked for too long.
+	rotationScanSize = 4096
+)
+
+var (
+	_ database.Database = (*KeyringDatabase)(nil)
+
+	// metadataPrefix is the prefix of the keys that a KeyringDatabase stores
+	// unencrypted metadata under. These keys are reserved and are skipped by
+	// iterators.
+	metadataPrefix = []byte("\x00dionego/encdb/")
+	// saltKey is the key of the random salt that passphrases are derived with.
+	saltKey = append(slices.Clone(metadataPrefix), "salt"...)
+
+	errInvalidSalt = errors.New("invalid salt")
+)
+
+// KeyringDatabase encrypts all values with the current key of a Keyring.
+// Unlike Database, closing it closes the underlying database.
+//
+// Keys are stored in plaintext so that the iteration order is preserved.
+type KeyringDatabase struct {
+	*Database
+
+	cipher *keyringCipher
+	log    logging.Logger
+
+	rotationCancel context.CancelFunc
+	rotationDone   chan struct{}
+}
+
+// NewWithKeyring returns a database that encrypts the values of [db] with
+// [keyring]. If [db] already contains values, one of them is decrypted so
+// that a wrong key is reported now rather than on the first read. If the
+// keyring contains previous keys, values encrypted with them are re-encrypted
+// with the current key in the background.
+//
+// Passphrases in [keyring] are derived with a random salt that is generated
+// when [db] is first encrypted and stored unencrypted in [db].
+//
+// Existing unencrypted values aren't migrated, so if [db] has never been
+// encrypted, it must be empty. Otherwise, ErrPlaintextDatabase is returned.
+func NewWithKeyring(keyring *Keyring, db database.Database, log logging.Logger) (*KeyringDatabase, error) {
+	salt, err := db.Get(saltKey)
+	isNewSalt := err == database.ErrNotFound
+	switch {
+	case isNewSalt:
+		salt = make([]byte, SaltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case len(salt) != SaltLen:
+		return nil, fmt.Errorf("%w: expected %d bytes but got %d", errInvalidSalt, SaltLen, len(salt))
+	}
+
+	c, err := newKeyringCipher(keyring, salt)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkKey(c, db, isNewSalt); err != nil {
+		return nil, err
+	}
+	// The salt is only written once the database is known to be encrypted
+	// with [keyring], so that a failure doesn't modify [db].
+	if isNewSalt {
+		if err := db.Put(saltKey, salt); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Info("opened encrypted database",
+		zap.String("keyFingerprint", hex.EncodeToString(c.currentFingerprint[:])),
+		zap.Int("numPreviousKeys", len(keyring.previous)),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	kdb := &KeyringDatabase{
+		Database: &Database{
+			cipher:         c,
+			db:             db,
+			metadataPrefix: metadataPrefix,
+		},
+		cipher:         c,
+		log:            log,
+		rotationCancel: cancel,
+		rotationDone:   make(chan struct{}),
+	}
+	if len(keyring.previous) == 0 {
+		close(kdb.rotationDone)
+		return kdb, nil
+	}
+
+	go func() {
+		defer close(kdb.rotationDone)
+
+		start := time.Now()
+		numRotated, err := kdb.Rotate(ctx)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Error("failed to re-encrypt database",
+					zap.Int("numRotated", numRotated),
+					zap.Error(err),
+				)
+			}
+			return
+		}
+		log.Info("finished re-encrypting database",
+			zap.Int("numRotated", numRotated),
+			zap.Duration("duration", time.Since(start)),
+		)
+	}()
+	return kdb, nil
+}
+
+// checkKey returns an error if the first value in [db] can't be decrypted
+// with [c]. If [db] has never been encrypted, as indicated by [isNew], an
+// error is returned if [db] contains any value.
+func checkKey(c *keyringCipher, db database.Database, isNew bool) error {
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		if bytes.HasPrefix(it.Key(), metadataPrefix) {
+			continue
+		}
+		if isNew {
+			return ErrPlaintextDatabase
+		}
+		if _, err := c.decrypt(it.Key(), it.Value()); err != nil {
+			return fmt.Errorf("couldn't decrypt existing database: %w", err)
+		}
+		return nil
+	}
+	return it.Error()
+}
+
+// Rotate re-encrypts every value that isn't encrypted with the current key
+// and returns the number of values that were re-encrypted. It is safe to call
+// concurrently with other operations.
+func (db *KeyringDatabase) Rotate(ctx context.Context) (int, error) {
+	var (
+		numRotated int
+		start      []byte
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return numRotated, err
+		}
+
+		keys, next, err := db.staleKeys(start)
+		if err != nil {
+			return numRotated, err
+		}
+		for len(keys) > 0 {
+			batchSize := rotationBatchSize
+			if batchSize > len(keys) {
+				batchSize = len(keys)
+			}
+			n, err := db.reencrypt(keys[:batchSize])
+			numRotated += n
+			if err != nil {
+				return numRotated, err
+			}
+			keys = keys[batchSize:]
+		}
+		if next == nil {
+			return numRotated, nil
+		}
+		start = next
+	}
+}
+
+// staleKeys returns the keys, starting at [start], of values that aren't
+// encrypted with the current key. It also returns the key to continue from, or
+// nil if there are no more keys.
+func (db *KeyringDatabase) staleKeys(start []byte) ([][]byte, []byte, error) {
+	if db.isClosed() {
+		return nil, nil, database.ErrClosed
+	}
+
+	it := db.db.NewIteratorWithStart(start)
+	defer it.Release()
+
+	var keys [][]byte
+	for i := 0; i < rotationScanSize; i++ {
+		if !it.Next() {
+			return keys, nil, it.Error()
+		}
+		if !db.isMetadata(it.Key()) && !db.cipher.isCurrent(it.Value()) {
+			keys = append(keys, slices.Clone(it.Key()))
+		}
+	}
+	// The smallest key after the last key that was read.
+	next := append(slices.Clone(it.Key()), 0)
+	return keys, next, it.Error()
+}
+
+// reencrypt encrypts the current values of [keys] with the current key.
+func (db *KeyringDatabase) reencrypt(keys [][]byte) (int, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return 0, database.ErrClosed
+	}
+
+	var (
+		numRotated int
+		batch      = db.db.NewBatch()
+	)
+	for _, key := range keys {
+		// The value may have been changed since it was read by the iterator,
+		// so it must be read again while holding the lock.
+		value, err := db.db.Get(key)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if db.cipher.isCurrent(value) {
+			continue
+		}
+
+		plaintext, err := db.cipher.decrypt(key, value)
+		if err != nil {
+			return 0, err
+		}
+		value, err = db.cipher.encrypt(key, plaintext)
+		if err != nil {
+			return 0, err
+		}
+		if err := batch.Put(key, value); err != nil {
+			return 0, err
+		}
+		numRotated++
+	}
+	return numRotated, batch.Write()
+}
+
+// Close stops any background re-encryption and closes the underlying
+// database.
+func (db *KeyringDatabase) Close() error {
+	db.rotationCancel()
+	<-db.rotationDone
+
+	if err := db.Database.Close(); err != nil {
+		return err
+	}
+	return db.db.Close()
+}
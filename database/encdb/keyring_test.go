@@ -0,0 +1,219 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/database/memdb"
+	"github.com/dioneprotocol/dionego/utils/logging"
+)
+
+func newTestKeyring(t testing.TB, current byte, previous ...byte) *Keyring {
+	previousKeys := make([]KeySource, len(previous))
+	for i, b := range previous {
+		previousKeys[i] = Key{b}
+	}
+	keyring, err := NewKeyring(Key{current}, previousKeys...)
+	require.NoError(t, err)
+	return keyring
+}
+
+func TestKeyringInterface(t *testing.T) {
+	for _, test := range database.Tests {
+		db, err := NewWithKeyring(newTestKeyring(t, 1), memdb.New(), logging.NoLog{})
+		require.NoError(t, err)
+
+		test(t, db)
+	}
+}
+
+func TestKeyringWrongKey(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := NewWithKeyring(newTestKeyring(t, 1), baseDB, logging.NoLog{})
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+
+	_, err = NewWithKeyring(newTestKeyring(t, 2), baseDB, logging.NoLog{})
+	require.ErrorIs(err, ErrWrongKey)
+
+	// Values can't be moved between keys.
+	encValue, err := baseDB.Get([]byte("key"))
+	require.NoError(err)
+	require.NoError(baseDB.Put([]byte("other"), encValue))
+	_, err = db.Get([]byte("other"))
+	require.ErrorIs(err, ErrCorrupted)
+}
+
+func TestKeyringNotEncrypted(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	require.NoError(baseDB.Put([]byte("key"), []byte("value")))
+
+	_, err := NewWithKeyring(newTestKeyring(t, 1), baseDB, logging.NoLog{})
+	require.ErrorIs(err, ErrPlaintextDatabase)
+
+	// The database isn't modified by the failed attempt.
+	_, err = baseDB.Get(saltKey)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// An encrypted database that contains an unencrypted value is corrupted.
+	baseDB = memdb.New()
+	db, err := NewWithKeyring(newTestKeyring(t, 1), baseDB, logging.NoLog{})
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+	require.NoError(baseDB.Put([]byte("key"), []byte("value")))
+	_, err = NewWithKeyring(newTestKeyring(t, 1), baseDB, logging.NoLog{})
+	require.ErrorIs(err, ErrNotEncrypted)
+}
+
+func TestKeyringRotate(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := NewWithKeyring(newTestKeyring(t, 1), baseDB, logging.NoLog{})
+	require.NoError(err)
+
+	numKeys := rotationScanSize + 1
+	for i := 0; i < numKeys; i++ {
+		require.NoError(db.Put([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i))))
+	}
+
+	// Rotate synchronously rather than racing the background rotation.
+	db, err = NewWithKeyring(newTestKeyring(t, 2, 1), baseDB, logging.NoLog{})
+	require.NoError(err)
+	<-db.rotationDone
+
+	numRotated, err := db.Rotate(context.Background())
+	require.NoError(err)
+	require.Zero(numRotated)
+
+	// Only the new key is needed once every value has been rotated.
+	db, err = NewWithKeyring(newTestKeyring(t, 2), baseDB, logging.NoLog{})
+	require.NoError(err)
+	for i := 0; i < numKeys; i++ {
+		value, err := db.Get([]byte(fmt.Sprintf("key%d", i)))
+		require.NoError(err)
+		require.Equal([]byte(fmt.Sprintf("value%d", i)), value)
+	}
+}
+
+func TestKeyringCloseClosesUnderlying(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := NewWithKeyring(newTestKeyring(t, 1), baseDB, logging.NoLog{})
+	require.NoError(err)
+	require.NoError(db.Close())
+
+	_, err = baseDB.Get([]byte("key"))
+	require.ErrorIs(err, database.ErrClosed)
+}
+
+func TestNewKeyringDuplicateKey(t *testing.T) {
+	_, err := NewKeyring(Key{1}, Key{2}, Key{1})
+	require.ErrorIs(t, err, errDuplicateKey)
+
+	_, err = NewKeyring(Passphrase("passphrase"), Key{1}, Passphrase("passphrase"))
+	require.ErrorIs(t, err, errDuplicateKey)
+}
+
+func TestKeyringPassphraseSalt(t *testing.T) {
+	require := require.New(t)
+
+	keyring, err := NewKeyring(Passphrase("correct horse battery staple"))
+	require.NoError(err)
+
+	// Each database is salted independently.
+	baseDBs := []database.Database{memdb.New(), memdb.New()}
+	salts := make([][]byte, len(baseDBs))
+	for i, baseDB := range baseDBs {
+		db, err := NewWithKeyring(keyring, baseDB, logging.NoLog{})
+		require.NoError(err)
+		require.NoError(db.Put([]byte("key"), []byte("value")))
+
+		salts[i], err = baseDB.Get(saltKey)
+		require.NoError(err)
+		require.Len(salts[i], SaltLen)
+
+		// The salt isn't visible through the encrypted database.
+		it := db.NewIterator()
+		require.True(it.Next())
+		require.Equal([]byte("key"), it.Key())
+		require.False(it.Next())
+		require.NoError(it.Error())
+		it.Release()
+	}
+	require.NotEqual(salts[0], salts[1])
+
+	// The same passphrase derives a different key for each database, so values
+	// can't be moved between them.
+	encValue, err := baseDBs[0].Get([]byte("key"))
+	require.NoError(err)
+	require.NoError(baseDBs[1].Put([]byte("key"), encValue))
+	_, err = NewWithKeyring(keyring, baseDBs[1], logging.NoLog{})
+	require.ErrorIs(err, ErrWrongKey)
+
+	// The salt is read back when the database is reopened.
+	db, err := NewWithKeyring(keyring, baseDBs[0], logging.NoLog{})
+	require.NoError(err)
+	value, err := db.Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+	salt, err := baseDBs[0].Get(saltKey)
+	require.NoError(err)
+	require.Equal(salts[0], salt)
+}
+
+func TestLoadKey(t *testing.T) {
+	require := require.New(t)
+
+	expectedKey := Key{1, 2, 3}
+	keyHex := hex.EncodeToString(expectedKey[:])
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "key")
+	require.NoError(os.WriteFile(keyPath, []byte(keyHex+"\n"), 0o600))
+	key, err := LoadKey(FileKeySourcePrefix + keyPath)
+	require.NoError(err)
+	require.Equal(expectedKey, key)
+
+	t.Setenv("TEST_ENCDB_KEY", keyHex)
+	key, err = LoadKey(EnvKeySourcePrefix + "TEST_ENCDB_KEY")
+	require.NoError(err)
+	require.Equal(expectedKey, key)
+
+	t.Setenv("TEST_ENCDB_KEY", keyHex[2:])
+	_, err = LoadKey(EnvKeySourcePrefix + "TEST_ENCDB_KEY")
+	require.ErrorIs(err, errInvalidKeyLen)
+
+	passphrasePath := filepath.Join(dir, "passphrase")
+	require.NoError(os.WriteFile(passphrasePath, []byte("correct horse battery staple\n"), 0o600))
+	fileKey, err := LoadKey(PassphraseFileKeySourcePrefix + passphrasePath)
+	require.NoError(err)
+
+	t.Setenv("TEST_ENCDB_PASSPHRASE", "correct horse battery staple")
+	envKey, err := LoadKey(PassphraseEnvKeySourcePrefix + "TEST_ENCDB_PASSPHRASE")
+	require.NoError(err)
+	require.Equal(Passphrase("correct horse battery staple"), fileKey)
+	require.Equal(fileKey, envKey)
+
+	t.Setenv("TEST_ENCDB_PASSPHRASE", "")
+	_, err = LoadKey(PassphraseEnvKeySourcePrefix + "TEST_ENCDB_PASSPHRASE")
+	require.ErrorIs(err, errEmptyPassphrase)
+
+	_, err = LoadKey(keyHex)
+	require.ErrorIs(err, errUnknownKeySource)
+}
@@ -14,9 +14,11 @@ import (
 
 	"github.com/dioneprotocol/dionego/database"
 	"github.com/dioneprotocol/dionego/database/corruptabledb"
+	"github.com/dioneprotocol/dionego/database/encdb"
 	"github.com/dioneprotocol/dionego/database/leveldb"
 	"github.com/dioneprotocol/dionego/database/memdb"
 	"github.com/dioneprotocol/dionego/database/meterdb"
+	"github.com/dioneprotocol/dionego/database/pebble"
 	"github.com/dioneprotocol/dionego/database/prefixdb"
 	"github.com/dioneprotocol/dionego/utils"
 	"github.com/dioneprotocol/dionego/utils/logging"
@@ -64,6 +66,11 @@ type Manager interface {
 	// Note: calling this more than once with the same [namespace] will cause a
 	// conflict error for the [registerer].
 	NewCompleteMeterDBManager(namespace string, registerer prometheus.Registerer) (Manager, error)
+
+	// NewEncryptedDBManager returns a new database manager with each of its
+	// databases encrypted with [keyring]. Closing the returned manager closes
+	// the databases of this manager.
+	NewEncryptedDBManager(keyring *encdb.Keyring, log logging.Logger) (Manager, error)
 }
 
 type manager struct {
@@ -96,6 +103,29 @@ func NewLevelDB(
 	)
 }
 
+// NewPebbleDB creates a database manager of pebble instances at [filePath] by
+// creating a database instance from each directory with a version <=
+// [currentVersion]. If [includePreviousVersions], opens previous database
+// versions and includes them in the returned Manager.
+func NewPebbleDB(
+	dbDirPath string,
+	dbConfig []byte,
+	log logging.Logger,
+	currentVersion *version.Semantic,
+	namespace string,
+	reg prometheus.Registerer,
+) (Manager, error) {
+	return new(
+		pebble.New,
+		dbDirPath,
+		dbConfig,
+		log,
+		currentVersion,
+		namespace,
+		reg,
+	)
+}
+
 // new creates a database manager at [filePath] by creating a database instance
 // from each directory with a version <= [currentVersion]. If
 // [includePreviousVersions], opens previous database versions and includes them
@@ -303,6 +333,31 @@ func (m *manager) NewCompleteMeterDBManager(namespace string, registerer prometh
 	})
 }
 
+// NewEncryptedDBManager wraps each database instance with an encdb instance
+// keyed by [keyring]. An error is returned if any of the databases already
+// contain values that weren't encrypted with a key in [keyring], in which case
+// all of the databases are closed.
+func (m *manager) NewEncryptedDBManager(keyring *encdb.Keyring, log logging.Logger) (Manager, error) {
+	newManager := &manager{
+		databases: make([]*VersionedDatabase, len(m.databases)),
+	}
+	for i, vdb := range m.databases {
+		edb, err := encdb.NewWithKeyring(keyring, vdb.Database, log)
+		if err != nil {
+			// Closing the encrypted databases closes their underlying
+			// databases, so only the remaining databases must be closed.
+			copy(newManager.databases[i:], m.databases[i:])
+			_ = newManager.Close()
+			return nil, fmt.Errorf("couldn't open encrypted db %s: %w", vdb.Version, err)
+		}
+		newManager.databases[i] = &VersionedDatabase{
+			Database: edb,
+			Version:  vdb.Version,
+		}
+	}
+	return newManager, nil
+}
+
 // wrapManager returns a new database manager with each managed database wrapped
 // by the [wrap] function. If an error is returned by wrap, the error is
 // returned immediately. If [wrap] never returns an error, then wrapManager is
@@ -12,9 +12,12 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/database/encdb"
 	"github.com/dioneprotocol/dionego/database/leveldb"
 	"github.com/dioneprotocol/dionego/database/memdb"
 	"github.com/dioneprotocol/dionego/database/meterdb"
+	"github.com/dioneprotocol/dionego/database/pebble"
 	"github.com/dioneprotocol/dionego/database/prefixdb"
 	"github.com/dioneprotocol/dionego/utils/logging"
 	"github.com/dioneprotocol/dionego/version"
@@ -74,6 +77,36 @@ func TestNewCreatesSingleDB(t *testing.T) {
 	require.NoError(err)
 }
 
+func TestNewSinglePebbleDB(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	v1 := version.Semantic1_0_0
+
+	dbPath := filepath.Join(dir, v1.String())
+	db, err := pebble.New(dbPath, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	err = db.Close()
+	require.NoError(err)
+
+	manager, err := NewPebbleDB(dir, nil, logging.NoLog{}, v1, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	semDB := manager.Current()
+	cmp := semDB.Version.Compare(v1)
+	require.Equal(0, cmp, "incorrect version on current database")
+
+	_, exists := manager.Previous()
+	require.False(exists, "there should be no previous database")
+
+	dbs := manager.GetDatabases()
+	require.Len(dbs, 1)
+
+	err = manager.Close()
+	require.NoError(err)
+}
+
 func TestNewInvalidMemberPresent(t *testing.T) {
 	require := require.New(t)
 
@@ -366,6 +399,51 @@ func TestCompleteMeterDBManager(t *testing.T) {
 	require.Error(err)
 }
 
+func TestEncryptedDBManager(t *testing.T) {
+	require := require.New(t)
+
+	currentDB := memdb.New()
+	previousDB := memdb.New()
+	m := &manager{databases: []*VersionedDatabase{
+		{
+			Database: currentDB,
+			Version: &version.Semantic{
+				Major: 2,
+				Minor: 0,
+				Patch: 0,
+			},
+		},
+		{
+			Database: previousDB,
+			Version:  version.Semantic1_0_0,
+		},
+	}}
+
+	keyring, err := encdb.NewKeyring(encdb.Key{1})
+	require.NoError(err)
+	manager, err := m.NewEncryptedDBManager(keyring, logging.NoLog{})
+	require.NoError(err)
+
+	dbs := manager.GetDatabases()
+	require.Len(dbs, 2)
+	for _, db := range dbs {
+		_, ok := db.Database.(*encdb.KeyringDatabase)
+		require.True(ok)
+	}
+	require.NoError(dbs[1].Database.Put([]byte("key"), []byte("value")))
+
+	// Opening the databases with the wrong key should fail and close them.
+	wrongKeyring, err := encdb.NewKeyring(encdb.Key{2})
+	require.NoError(err)
+	_, err = m.NewEncryptedDBManager(wrongKeyring, logging.NoLog{})
+	require.ErrorIs(err, encdb.ErrWrongKey)
+
+	_, err = currentDB.Has([]byte("key"))
+	require.ErrorIs(err, database.ErrClosed)
+	_, err = previousDB.Has([]byte("key"))
+	require.ErrorIs(err, database.ErrClosed)
+}
+
 func TestNewManagerFromDBs(t *testing.T) {
 	require := require.New(t)
 
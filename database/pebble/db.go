@@ -0,0 +1,462 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebble
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/utils/logging"
+	"github.com/dioneprotocol/dionego/utils/set"
+	"github.com/dioneprotocol/dionego/utils/units"
+)
+
+const (
+	// Name is the name of this database for database switches
+	Name = "pebble"
+
+	// DefaultCacheSize is the number of bytes to use for block caching in
+	// pebble.
+	DefaultCacheSize = 512 * units.MiB
+
+	// DefaultBytesPerSync is the number of bytes of sstable data written
+	// before the OS is asked to sync it to disk in the background.
+	DefaultBytesPerSync = 512 * units.KiB
+
+	// DefaultWALBytesPerSync is the number of bytes of WAL data written before
+	// the OS is asked to sync it to disk in the background.
+	DefaultWALBytesPerSync = 0
+
+	// DefaultMemTableStopWritesThreshold is the number of memtables that may
+	// be queued for flushing before writes are stopped.
+	DefaultMemTableStopWritesThreshold = 8
+
+	// DefaultMemTableSize is the number of bytes to use for each memtable.
+	DefaultMemTableSize = 16 * units.MiB
+
+	// DefaultMaxOpenFiles is the number of files descriptors to cap pebble to
+	// use.
+	DefaultMaxOpenFiles = 4096
+
+	// DefaultMaxConcurrentCompactions is the number of compactions that may
+	// run in parallel.
+	DefaultMaxConcurrentCompactions = 1
+
+	// DefaultBitsPerKey is the number of bits to add to the bloom filter per
+	// key.
+	DefaultBitsPerKey = 10
+
+	// DefaultMetricUpdateFrequency is the frequency to poll the pebble
+	// metrics.
+	DefaultMetricUpdateFrequency = 10 * time.Second
+
+	// pebbleByteOverhead is the number of bytes of constant overhead that
+	// should be added to a batch size per operation.
+	pebbleByteOverhead = 8
+)
+
+var (
+	_ database.Database = (*Database)(nil)
+	_ database.Batch    = (*batch)(nil)
+	_ database.Iterator = (*iter)(nil)
+
+	errInvalidOperation = errors.New("invalid operation")
+)
+
+// Database is a persistent key-value store backed by pebble. Apart from basic
+// data storage functionality it also supports batch writes and iterating over
+// the keyspace in binary-alphabetical order.
+type Database struct {
+	// lock must be held for reading while using [pebbleDB] and for writing
+	// while closing it. Pebble panics if it is used after being closed.
+	lock     sync.RWMutex
+	pebbleDB *pebble.DB
+	closed   bool
+	// openIterators is the set of iterators that haven't been released yet.
+	// They are released when the database is closed.
+	openIterators set.Set[*iter]
+
+	// metrics is only initialized and used when [MetricUpdateFrequency] is > 0
+	// in the config
+	metrics   *metrics
+	closeOnce sync.Once
+	// closeCh is closed when Close() is called.
+	closeCh chan struct{}
+	// closeWg is used to wait for all goroutines created by New() to exit.
+	closeWg sync.WaitGroup
+}
+
+type config struct {
+	// CacheSize is the number of bytes of the block cache.
+	//
+	// The default value is 512MiB.
+	CacheSize int64 `json:"cacheSize"`
+	// BytesPerSync sets the number of bytes of sstable data written before
+	// a background sync is requested from the OS.
+	//
+	// The default value is 512KiB.
+	BytesPerSync int `json:"bytesPerSync"`
+	// WALBytesPerSync sets the number of bytes of WAL data written before a
+	// background sync is requested from the OS. 0 disables background syncing
+	// of the WAL.
+	//
+	// The default value is 0.
+	WALBytesPerSync int `json:"walBytesPerSync"`
+	// MemTableStopWritesThreshold is a hard limit on the number of queued
+	// memtables. Writes are stopped once this threshold is reached.
+	//
+	// The default value is 8.
+	MemTableStopWritesThreshold int `json:"memTableStopWritesThreshold"`
+	// MemTableSize is the size of each memtable.
+	//
+	// The default value is 16MiB.
+	MemTableSize int `json:"memTableSize"`
+	// MaxOpenFiles is a soft limit on the number of open files.
+	//
+	// The default value is 4096.
+	MaxOpenFiles int `json:"maxOpenFiles"`
+	// MaxConcurrentCompactions is the maximum number of compactions that may
+	// run concurrently.
+	//
+	// The default value is 1.
+	MaxConcurrentCompactions int `json:"maxConcurrentCompactions"`
+	// FilterBitsPerKey is the number of bits per key of the bloom filter
+	// attached to each sstable block.
+	//
+	// The default value is 10.
+	FilterBitsPerKey int `json:"filterBitsPerKey"`
+
+	// MetricUpdateFrequency is the frequency to poll pebble metrics.
+	// If <= 0, pebble metrics aren't polled.
+	MetricUpdateFrequency time.Duration `json:"metricUpdateFrequency"`
+}
+
+// New returns a wrapped pebble DB object.
+func New(file string, configBytes []byte, log logging.Logger, namespace string, reg prometheus.Registerer) (database.Database, error) {
+	parsedConfig := config{
+		CacheSize:                   DefaultCacheSize,
+		BytesPerSync:                DefaultBytesPerSync,
+		WALBytesPerSync:             DefaultWALBytesPerSync,
+		MemTableStopWritesThreshold: DefaultMemTableStopWritesThreshold,
+		MemTableSize:                DefaultMemTableSize,
+		MaxOpenFiles:                DefaultMaxOpenFiles,
+		MaxConcurrentCompactions:    DefaultMaxConcurrentCompactions,
+		FilterBitsPerKey:            DefaultBitsPerKey,
+		MetricUpdateFrequency:       DefaultMetricUpdateFrequency,
+	}
+	if len(configBytes) > 0 {
+		if err := json.Unmarshal(configBytes, &parsedConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse db config: %w", err)
+		}
+	}
+
+	log.Info("creating pebble",
+		zap.Reflect("config", parsedConfig),
+	)
+
+	cache := pebble.NewCache(parsedConfig.CacheSize)
+	// The cache is reference counted. The reference held by the DB is released
+	// when the DB is closed.
+	defer cache.Unref()
+
+	opts := &pebble.Options{
+		Cache:                       cache,
+		BytesPerSync:                parsedConfig.BytesPerSync,
+		Comparer:                    pebble.DefaultComparer,
+		WALBytesPerSync:             parsedConfig.WALBytesPerSync,
+		MemTableStopWritesThreshold: parsedConfig.MemTableStopWritesThreshold,
+		MemTableSize:                parsedConfig.MemTableSize,
+		MaxOpenFiles:                parsedConfig.MaxOpenFiles,
+		MaxConcurrentCompactions: func() int {
+			return parsedConfig.MaxConcurrentCompactions
+		},
+	}
+	opts.Experimental.ReadSamplingMultiplier = -1 // Disable seek compaction
+	opts.EnsureDefaults()
+	for i := range opts.Levels {
+		opts.Levels[i].FilterPolicy = bloom.FilterPolicy(parsedConfig.FilterBitsPerKey)
+		opts.Levels[i].FilterType = pebble.TableFilter
+	}
+
+	var metrics *metrics
+	if parsedConfig.MetricUpdateFrequency > 0 {
+		var err error
+		metrics, err = newMetrics(namespace, reg)
+		if err != nil {
+			return nil, err
+		}
+		opts.AddEventListener(metrics.eventListener())
+	}
+
+	db, err := pebble.Open(file, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedDB := &Database{
+		pebbleDB:      db,
+		openIterators: set.Set[*iter]{},
+		metrics:       metrics,
+		closeCh:       make(chan struct{}),
+	}
+	if metrics != nil {
+		wrappedDB.closeWg.Add(1)
+		go func() {
+			t := time.NewTicker(parsedConfig.MetricUpdateFrequency)
+			defer func() {
+				t.Stop()
+				wrappedDB.closeWg.Done()
+			}()
+
+			for {
+				if err := wrappedDB.updateMetrics(); err != nil {
+					log.Warn("failed to update pebble metrics",
+						zap.Error(err),
+					)
+				}
+
+				select {
+				case <-t.C:
+				case <-wrappedDB.closeCh:
+					return
+				}
+			}
+		}()
+	}
+	return wrappedDB, nil
+}
+
+// Has returns if the key is set in the database
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return false, database.ErrClosed
+	}
+
+	_, closer, err := db.pebbleDB.Get(key)
+	if err == pebble.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, updateError(err)
+	}
+	return true, closer.Close()
+}
+
+// Get returns the value the key maps to in the database
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	data, closer, err := db.pebbleDB.Get(key)
+	if err != nil {
+		return nil, updateError(err)
+	}
+	// [data] is only valid until [closer] is closed.
+	value := slices.Clone(data)
+	return value, closer.Close()
+}
+
+// Put sets the value of the provided key to the provided value
+func (db *Database) Put(key []byte, value []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return updateError(db.pebbleDB.Set(key, value, pebble.NoSync))
+}
+
+// Delete removes the key from the database
+func (db *Database) Delete(key []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return updateError(db.pebbleDB.Delete(key, pebble.NoSync))
+}
+
+// NewBatch creates a write/delete-only buffer that is atomically committed to
+// the database when write is called
+func (db *Database) NewBatch() database.Batch {
+	return &batch{
+		db:    db,
+		batch: db.pebbleDB.NewBatch(),
+	}
+}
+
+// NewIterator creates a lexicographically ordered iterator over the database
+func (db *Database) NewIterator() database.Iterator {
+	return db.newIterator(&pebble.IterOptions{})
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// database starting at the provided key
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.newIterator(&pebble.IterOptions{
+		LowerBound: start,
+	})
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// database ignoring keys that do not start with the provided prefix
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.newIterator(keyRange(nil, prefix))
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the database starting at start and ignoring keys that do not start with
+// the provided prefix
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.newIterator(keyRange(start, prefix))
+}
+
+func (db *Database) newIterator(opts *pebble.IterOptions) database.Iterator {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+
+	it := &iter{
+		db:   db,
+		iter: db.pebbleDB.NewIter(opts),
+	}
+	db.openIterators.Add(it)
+	return it
+}
+
+// Compact the underlying DB for the given key range.
+//
+// A nil start is treated as a key before all keys in the DB.
+// And a nil limit is treated as a key after all keys in the DB.
+// Therefore if both are nil then it will compact entire DB.
+func (db *Database) Compact(start []byte, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	if limit == nil {
+		// Pebble requires an explicit upper bound. Use the last key in the
+		// database to compact everything after [start].
+		it := db.pebbleDB.NewIter(&pebble.IterOptions{})
+		if !it.Last() {
+			// The database is empty, so there is nothing to compact.
+			return updateError(it.Close())
+		}
+		limit = slices.Clone(it.Key())
+		if err := it.Close(); err != nil {
+			return updateError(err)
+		}
+		// Pebble treats [limit] as exclusive, so extend it to include the
+		// last key.
+		limit = append(limit, 0)
+	}
+
+	if pebble.DefaultComparer.Compare(start, limit) >= 0 {
+		// Nothing to compact.
+		return nil
+	}
+	return updateError(db.pebbleDB.Compact(start, limit, true /*=parallelize*/))
+}
+
+func (db *Database) Close() error {
+	db.closeOnce.Do(func() {
+		close(db.closeCh)
+	})
+	db.closeWg.Wait()
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	db.closed = true
+
+	for it := range db.openIterators {
+		it.release()
+	}
+	db.openIterators.Clear()
+	return updateError(db.pebbleDB.Close())
+}
+
+func (db *Database) HealthCheck(context.Context) (interface{}, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	return nil, nil
+}
+
+// keyRange returns the iterator bounds over the keys that start with [prefix]
+// and are >= [start].
+func keyRange(start, prefix []byte) *pebble.IterOptions {
+	opts := &pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixToUpperBound(prefix),
+	}
+	if pebble.DefaultComparer.Compare(start, prefix) == 1 {
+		opts.LowerBound = start
+	}
+	return opts
+}
+
+// prefixToUpperBound returns the smallest key that is larger than all keys
+// starting with [prefix]. If no such key exists, nil is returned, which pebble
+// treats as an unbounded upper bound.
+func prefixToUpperBound(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xFF {
+			upperBound := slices.Clone(prefix[:i+1])
+			upperBound[i]++
+			return upperBound
+		}
+	}
+	return nil
+}
+
+func updateError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, pebble.ErrClosed):
+		return database.ErrClosed
+	case errors.Is(err, pebble.ErrNotFound):
+		return database.ErrNotFound
+	default:
+		return err
+	}
+}
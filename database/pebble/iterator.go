@@ -0,0 +1,123 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebble
+
+import (
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/dioneprotocol/dionego/database"
+)
+
+type iter struct {
+	// [lock] ensures that only one goroutine can access [iter] at a time.
+	// Note that [Database.Close] calls [iter.release] so [lock] can also be
+	// grabbed by the goroutine closing the database.
+	lock sync.Mutex
+
+	db   *Database
+	iter *pebble.Iterator
+
+	initialized bool
+	released    bool
+	err         error
+
+	key, val []byte
+}
+
+func (it *iter) Next() bool {
+	it.db.lock.RLock()
+	dbClosed := it.db.closed
+	it.db.lock.RUnlock()
+
+	it.lock.Lock()
+	defer it.lock.Unlock()
+
+	var hasNext bool
+	switch {
+	case it.err != nil:
+		hasNext = false
+	case dbClosed:
+		// Short-circuit and set an error if the underlying database has been
+		// closed.
+		hasNext = false
+		it.err = database.ErrClosed
+	case it.released:
+		hasNext = false
+	case !it.initialized:
+		hasNext = it.iter.First()
+		it.initialized = true
+	default:
+		hasNext = it.iter.Next()
+	}
+
+	if !hasNext {
+		it.key = nil
+		it.val = nil
+		return false
+	}
+
+	value, err := it.iter.ValueAndErr()
+	if err != nil {
+		it.key = nil
+		it.val = nil
+		it.err = err
+		return false
+	}
+
+	it.key = slices.Clone(it.iter.Key())
+	it.val = slices.Clone(value)
+	return true
+}
+
+func (it *iter) Error() error {
+	it.lock.Lock()
+	defer it.lock.Unlock()
+
+	if it.err != nil || it.released {
+		return it.err
+	}
+	return updateError(it.iter.Error())
+}
+
+func (it *iter) Key() []byte {
+	it.lock.Lock()
+	defer it.lock.Unlock()
+
+	return it.key
+}
+
+func (it *iter) Value() []byte {
+	it.lock.Lock()
+	defer it.lock.Unlock()
+
+	return it.val
+}
+
+func (it *iter) Release() {
+	it.db.lock.Lock()
+	defer it.db.lock.Unlock()
+
+	it.release()
+	it.db.openIterators.Remove(it)
+}
+
+// release closes the underlying pebble iterator.
+//
+// Assumes the database lock is held.
+func (it *iter) release() {
+	it.lock.Lock()
+	defer it.lock.Unlock()
+
+	if it.released {
+		return
+	}
+	it.released = true
+	// Pebble only reports errors from Close that were already reported by
+	// Error, so there is nothing to propagate here.
+	_ = it.iter.Close()
+}
@@ -0,0 +1,306 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebble
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/dioneprotocol/dionego/utils/wrappers"
+)
+
+var levelLabels = []string{"level"}
+
+type metrics struct {
+	// total number of writes that have been stalled due to compaction
+	writesStalledCount prometheus.Counter
+	// total amount of time (in ns) that writes have been stalled due to
+	// compaction
+	writesStalledDuration prometheus.Counter
+	// set to 1 if writes are currently being stalled due to compaction
+	writeIsStalled prometheus.Gauge
+
+	// number of currently alive snapshots
+	aliveSnapshots prometheus.Gauge
+	// number of currently alive iterators
+	aliveIterators prometheus.Gauge
+
+	// total amount of data written to the WAL
+	walWrite prometheus.Counter
+	// current size of the live data in the WAL
+	walSize prometheus.Gauge
+
+	// total number of bytes of cached data blocks
+	blockCacheSize prometheus.Gauge
+	// total number of block cache hits
+	blockCacheHits prometheus.Counter
+	// total number of block cache misses
+	blockCacheMisses prometheus.Counter
+	// current number of open tables
+	openTables prometheus.Gauge
+
+	// current size of the memtables
+	memTableSize prometheus.Gauge
+	// current number of memtables
+	memTableCount prometheus.Gauge
+	// current read amplification
+	readAmplification prometheus.Gauge
+
+	// number of tables per level
+	levelTableCount *prometheus.GaugeVec
+	// size of each level
+	levelSize *prometheus.GaugeVec
+	// amount of bytes read while compacting each level
+	levelReads *prometheus.CounterVec
+	// amount of bytes written while compacting each level
+	levelWrites *prometheus.CounterVec
+
+	// total number of compactions performed
+	compactions prometheus.Counter
+	// estimated number of bytes that still need to be compacted
+	compactionDebt prometheus.Gauge
+	// total number of flushes performed
+	flushes prometheus.Counter
+
+	// stallLock protects [stallStart]
+	stallLock  sync.Mutex
+	stallStart time.Time
+
+	priorStats *pebble.Metrics
+}
+
+func newMetrics(namespace string, reg prometheus.Registerer) (*metrics, error) {
+	m := &metrics{
+		writesStalledCount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "writes_stalled",
+			Help:      "number of cumulative writes that have been stalled due to compaction",
+		}),
+		writesStalledDuration: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "writes_stalled_duration",
+			Help:      "amount of time (in ns) that writes have been stalled due to compaction",
+		}),
+		writeIsStalled: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "write_stalled",
+			Help:      "1 if writes are currently being stalled due to compaction",
+		}),
+
+		aliveSnapshots: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "alive_snapshots",
+			Help:      "number of currently alive snapshots",
+		}),
+		aliveIterators: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "alive_iterators",
+			Help:      "number of currently alive iterators",
+		}),
+
+		walWrite: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "wal_write",
+			Help:      "cumulative amount of logical bytes written to the WAL",
+		}),
+		walSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "wal_size",
+			Help:      "size of the live data in the WAL",
+		}),
+
+		blockCacheSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "block_cache_size",
+			Help:      "total size of cached blocks",
+		}),
+		blockCacheHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "block_cache_hits",
+			Help:      "cumulative number of block cache hits",
+		}),
+		blockCacheMisses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "block_cache_misses",
+			Help:      "cumulative number of block cache misses",
+		}),
+		openTables: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "open_tables",
+			Help:      "number of currently opened tables",
+		}),
+
+		memTableSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "mem_table_size",
+			Help:      "number of bytes allocated by memtables",
+		}),
+		memTableCount: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "mem_table_count",
+			Help:      "number of currently allocated memtables",
+		}),
+		readAmplification: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "read_amplification",
+			Help:      "number of sublevels that a read may need to visit",
+		}),
+
+		levelTableCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "table_count",
+				Help:      "number of tables allocated by level",
+			},
+			levelLabels,
+		),
+		levelSize: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "size",
+				Help:      "amount of bytes allocated by level",
+			},
+			levelLabels,
+		),
+		levelReads: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "reads",
+				Help:      "amount of bytes read during compaction by level",
+			},
+			levelLabels,
+		),
+		levelWrites: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "writes",
+				Help:      "amount of bytes written during compaction by level",
+			},
+			levelLabels,
+		),
+
+		compactions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "comps",
+			Help:      "total number of compactions performed",
+		}),
+		compactionDebt: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "comp_debt",
+			Help:      "estimated number of bytes that need to be compacted",
+		}),
+		flushes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "flushes",
+			Help:      "total number of memtable flushes performed",
+		}),
+
+		priorStats: &pebble.Metrics{},
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.writesStalledCount),
+		reg.Register(m.writesStalledDuration),
+		reg.Register(m.writeIsStalled),
+
+		reg.Register(m.aliveSnapshots),
+		reg.Register(m.aliveIterators),
+
+		reg.Register(m.walWrite),
+		reg.Register(m.walSize),
+
+		reg.Register(m.blockCacheSize),
+		reg.Register(m.blockCacheHits),
+		reg.Register(m.blockCacheMisses),
+		reg.Register(m.openTables),
+
+		reg.Register(m.memTableSize),
+		reg.Register(m.memTableCount),
+		reg.Register(m.readAmplification),
+
+		reg.Register(m.levelTableCount),
+		reg.Register(m.levelSize),
+		reg.Register(m.levelReads),
+		reg.Register(m.levelWrites),
+
+		reg.Register(m.compactions),
+		reg.Register(m.compactionDebt),
+		reg.Register(m.flushes),
+	)
+	return m, errs.Err
+}
+
+// eventListener reports write stalls as they happen, as pebble doesn't expose
+// them through its polled metrics.
+func (m *metrics) eventListener() pebble.EventListener {
+	return pebble.EventListener{
+		WriteStallBegin: func(pebble.WriteStallBeginInfo) {
+			m.stallLock.Lock()
+			defer m.stallLock.Unlock()
+
+			m.stallStart = time.Now()
+			m.writesStalledCount.Inc()
+			m.writeIsStalled.Set(1)
+		},
+		WriteStallEnd: func() {
+			m.stallLock.Lock()
+			defer m.stallLock.Unlock()
+
+			m.writesStalledDuration.Add(float64(time.Since(m.stallStart)))
+			m.writeIsStalled.Set(0)
+		},
+	}
+}
+
+func (db *Database) updateMetrics() error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil
+	}
+
+	metrics := db.metrics
+	priorStats := metrics.priorStats
+	currentStats := db.pebbleDB.Metrics()
+
+	metrics.aliveSnapshots.Set(float64(currentStats.Snapshots.Count))
+	metrics.aliveIterators.Set(float64(db.openIterators.Len()))
+
+	metrics.walWrite.Add(float64(currentStats.WAL.BytesIn - priorStats.WAL.BytesIn))
+	metrics.walSize.Set(float64(currentStats.WAL.Size))
+
+	metrics.blockCacheSize.Set(float64(currentStats.BlockCache.Size))
+	metrics.blockCacheHits.Add(float64(currentStats.BlockCache.Hits - priorStats.BlockCache.Hits))
+	metrics.blockCacheMisses.Add(float64(currentStats.BlockCache.Misses - priorStats.BlockCache.Misses))
+	metrics.openTables.Set(float64(currentStats.TableCache.Count))
+
+	metrics.memTableSize.Set(float64(currentStats.MemTable.Size))
+	metrics.memTableCount.Set(float64(currentStats.MemTable.Count))
+	metrics.readAmplification.Set(float64(currentStats.ReadAmp()))
+
+	for level, levelStats := range currentStats.Levels {
+		levelStr := strconv.Itoa(level)
+		priorLevelStats := priorStats.Levels[level]
+		metrics.levelTableCount.WithLabelValues(levelStr).Set(float64(levelStats.NumFiles))
+		metrics.levelSize.WithLabelValues(levelStr).Set(float64(levelStats.Size))
+		metrics.levelReads.WithLabelValues(levelStr).Add(float64(levelStats.BytesRead - priorLevelStats.BytesRead))
+		metrics.levelWrites.WithLabelValues(levelStr).Add(float64(levelStats.BytesCompacted - priorLevelStats.BytesCompacted))
+	}
+
+	metrics.compactions.Add(float64(currentStats.Compact.Count - priorStats.Compact.Count))
+	metrics.compactionDebt.Set(float64(currentStats.Compact.EstimatedDebt))
+	metrics.flushes.Add(float64(currentStats.Flush.Count - priorStats.Flush.Count))
+
+	// update the priorStats to update the counters correctly next time this
+	// method is called
+	metrics.priorStats = currentStats
+	return nil
+}
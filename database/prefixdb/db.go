@@ -14,6 +14,10 @@ import (
 )
 
 const (
+	// PrefixLen is the number of bytes that a Database prepends to all of its
+	// keys in the underlying database.
+	PrefixLen = hashing.HashLen
+
 	defaultBufCap = 256
 )
 
@@ -54,7 +58,7 @@ func New(prefix []byte, db database.Database) *Database {
 // prefixes.
 func NewNested(prefix []byte, db database.Database) *Database {
 	return &Database{
-		dbPrefix: hashing.ComputeHash256(prefix),
+		dbPrefix: MakePrefix(prefix),
 		db:       db,
 		bufferPool: sync.Pool{
 			New: func() interface{} {
@@ -64,6 +68,12 @@ func NewNested(prefix []byte, db database.Database) *Database {
 	}
 }
 
+// MakePrefix returns the bytes that a Database created with
+// NewNested([prefix], db) prepends to all of its keys in db.
+func MakePrefix(prefix []byte) []byte {
+	return hashing.ComputeHash256(prefix)
+}
+
 // Assumes that it is OK for the argument to db.db.Has
 // to be modified after db.db.Has returns
 // [key] may be modified after this method returns.
@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dioneprotocol/dionego/database/manager"
+	"github.com/dioneprotocol/dionego/utils/perms"
+)
+
+// ArchiveExtension is the file extension of snapshot archives.
+const ArchiveExtension = ".tar.gz"
+
+// IsArchive returns true if [path] names a snapshot archive rather than a
+// snapshot directory.
+func IsArchive(path string) bool {
+	return strings.HasSuffix(path, ArchiveExtension)
+}
+
+// CreateArchive writes a snapshot of every database managed by [dbManager]
+// into the gzipped tarball [archivePath]. The snapshot is staged in a
+// temporary directory next to [archivePath].
+func CreateArchive(dbManager manager.Manager, archivePath string) (*Manifest, error) {
+	stagingDir, err := os.MkdirTemp(filepath.Dir(archivePath), "snapshot-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(stagingDir)
+
+	manifest, err := Create(dbManager, stagingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := perms.Create(archivePath, perms.ReadWrite)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if err := WriteArchive(stagingDir, f); err != nil {
+		return nil, err
+	}
+	return manifest, f.Close()
+}
+
+// WriteArchive writes the snapshot in [dir] to [w] as a gzipped tarball. The
+// snapshot's manifest is written first so that readers can inspect it without
+// reading the entire archive.
+func WriteArchive(dir string, w io.Writer) error {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	files := []string{ManifestFile}
+	for _, dbManifest := range manifest.Databases {
+		files = append(files, dbManifest.File)
+	}
+	for _, file := range files {
+		if err := writeArchiveFile(tarWriter, dir, file); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+// ExtractArchive extracts the gzipped tarball in [r] into [dir], which must
+// either not exist or be empty. The extracted snapshot is not verified.
+func ExtractArchive(r io.Reader, dir string) error {
+	if err := CreateEmptyDir(dir); err != nil {
+		return err
+	}
+
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Snapshots are flat, so any path separators in the archive are
+		// rejected to avoid writing outside of [dir].
+		if header.Typeflag != tar.TypeReg || header.Name != filepath.Base(header.Name) {
+			return fmt.Errorf("%w: %q", errInvalidFile, header.Name)
+		}
+
+		if err := extractArchiveFile(tarReader, filepath.Join(dir, header.Name)); err != nil {
+			return err
+		}
+	}
+}
+
+func writeArchiveFile(tarWriter *tar.Writer, dir string, file string) error {
+	f, err := os.Open(filepath.Join(dir, file))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = file
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tarWriter, f)
+	return err
+}
+
+func extractArchiveFile(r io.Reader, path string) error {
+	f, err := perms.Create(path, perms.ReadWrite)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,451 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package snapshot creates and restores point-in-time copies of the databases
+// managed by a database manager.
+//
+// A snapshot is a directory containing one file per database version, holding
+// every key/value pair of that database in key order, and a manifest
+// describing those files. The manifest is written last, so a directory without
+// a manifest is an incomplete snapshot.
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/database/encdb"
+	"github.com/dioneprotocol/dionego/database/manager"
+	"github.com/dioneprotocol/dionego/utils/perms"
+	"github.com/dioneprotocol/dionego/utils/units"
+	"github.com/dioneprotocol/dionego/version"
+)
+
+const (
+	// ManifestFile is the name of the file, inside of a snapshot directory,
+	// that describes the snapshot.
+	ManifestFile = "manifest.json"
+
+	// dataFileExtension is appended to the version of a database to get the
+	// name of the file its key/value pairs are written to.
+	dataFileExtension = ".kv"
+
+	// restoreBatchSize is the number of bytes to buffer before writing a batch
+	// during a restore.
+	restoreBatchSize = 4 * units.MiB
+
+	// maxRecordFieldLen is the maximum length of a key or value in a snapshot.
+	maxRecordFieldLen = 256 * units.MiB
+)
+
+var (
+	ErrMissingManifest   = errors.New("snapshot is missing its manifest")
+	ErrChecksumMismatch  = errors.New("snapshot checksum mismatch")
+	ErrNotEmpty          = errors.New("directory is not empty")
+	ErrDecryptedDatabase = errors.New("refusing to snapshot decrypted database")
+
+	errNoDatabases      = errors.New("snapshot doesn't contain any databases")
+	errInvalidFile      = errors.New("invalid snapshot file name")
+	errKeyCountMismatch = errors.New("snapshot key count mismatch")
+	errSizeMismatch     = errors.New("snapshot size mismatch")
+	errUnsortedKeys     = errors.New("snapshot keys are not sorted and unique")
+	errFieldTooLarge    = errors.New("snapshot record field too large")
+)
+
+// Manifest describes the contents of a snapshot.
+type Manifest struct {
+	// CreatedAt is the time the snapshot was started.
+	CreatedAt time.Time `json:"createdAt"`
+	// Databases are the snapshotted databases in order from the current to
+	// the oldest version.
+	Databases []DatabaseManifest `json:"databases"`
+}
+
+// DatabaseManifest describes the snapshot of a single versioned database.
+type DatabaseManifest struct {
+	// Version of the database.
+	Version string `json:"version"`
+	// File, relative to the snapshot directory, that contains the key/value
+	// pairs of the database.
+	File string `json:"file"`
+	// NumKeys is the number of key/value pairs in [File].
+	NumKeys uint64 `json:"numKeys"`
+	// Size is the total number of key and value bytes in [File].
+	Size uint64 `json:"size"`
+	// Checksum is the hex encoded SHA-256 hash of [File].
+	Checksum string `json:"checksum"`
+}
+
+// Create writes a snapshot of every database managed by [dbManager] into
+// [dir], which must either not exist or be empty.
+//
+// Each database is read through a single iterator, which observes a
+// consistent point-in-time view of the database, so the databases may
+// continue to be written to while the snapshot is being taken. Only the
+// current database is written to by a running node, so the snapshot is
+// consistent across all versions.
+//
+// Databases that decrypt their values are rejected, as the snapshot would
+// contain their plaintext. Encrypted databases should be snapshotted through
+// the databases that they wrap.
+func Create(dbManager manager.Manager, dir string) (*Manifest, error) {
+	for _, db := range dbManager.GetDatabases() {
+		switch db.Database.(type) {
+		case *encdb.Database, *encdb.KeyringDatabase:
+			return nil, fmt.Errorf("%w: %s", ErrDecryptedDatabase, db.Version)
+		}
+	}
+	if err := CreateEmptyDir(dir); err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		CreatedAt: time.Now().UTC(),
+	}
+	for _, db := range dbManager.GetDatabases() {
+		dbManifest, err := writeDatabase(db, dir)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't snapshot database %s: %w", db.Version, err)
+		}
+		manifest.Databases = append(manifest.Databases, dbManifest)
+	}
+	return manifest, writeManifest(manifest, dir)
+}
+
+// Verify checks that the snapshot in [dir] is complete and that none of its
+// files have been modified since it was created.
+func Verify(dir string) (*Manifest, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(manifest.Databases) == 0 {
+		return nil, errNoDatabases
+	}
+	for _, dbManifest := range manifest.Databases {
+		if err := readDatabase(dir, dbManifest, nil); err != nil {
+			return nil, fmt.Errorf("invalid snapshot of database %s: %w", dbManifest.Version, err)
+		}
+	}
+	return manifest, nil
+}
+
+// Restore verifies the snapshot in [dir] and then writes each of its databases
+// into [dbDir], in the same layout used by the database manager. [newDB] is
+// called with the path of each versioned database to create. None of the
+// versioned database directories may already contain data.
+//
+// The databases are restored into a staging directory next to [dbDir] and are
+// only moved into [dbDir] once every one of them has been restored, so a
+// failed restore doesn't leave partially restored databases in [dbDir].
+func Restore(dir string, dbDir string, newDB func(path string) (database.Database, error)) (*Manifest, error) {
+	manifest, err := Verify(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dbManifest := range manifest.Databases {
+		err := checkEmptyDir(filepath.Join(dbDir, dbManifest.Version))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	dbDir = filepath.Clean(dbDir)
+	parentDir := filepath.Dir(dbDir)
+	if err := os.MkdirAll(parentDir, perms.ReadWriteExecute); err != nil {
+		return nil, err
+	}
+	stagingDir, err := os.MkdirTemp(parentDir, "."+filepath.Base(dbDir)+".restore-")
+	if err != nil {
+		return nil, err
+	}
+	// The staging directory is empty once every database has been moved into
+	// [dbDir], otherwise it holds a failed restore.
+	defer os.RemoveAll(stagingDir)
+
+	for _, dbManifest := range manifest.Databases {
+		if err := restoreVersion(dir, stagingDir, dbManifest, newDB); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(dbDir, perms.ReadWriteExecute); err != nil {
+		return nil, err
+	}
+	for _, dbManifest := range manifest.Databases {
+		dbPath := filepath.Join(dbDir, dbManifest.Version)
+		// The directory was checked to be empty above. Removing it fails if
+		// data was written into it since then.
+		if err := os.Remove(dbPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		if err := os.Rename(filepath.Join(stagingDir, dbManifest.Version), dbPath); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// restoreVersion writes the database described by [dbManifest], from the
+// snapshot in [dir], into a new database in [dbDir].
+func restoreVersion(
+	dir string,
+	dbDir string,
+	dbManifest DatabaseManifest,
+	newDB func(path string) (database.Database, error),
+) error {
+	dbPath := filepath.Join(dbDir, dbManifest.Version)
+	if err := CreateEmptyDir(dbPath); err != nil {
+		return err
+	}
+
+	db, err := newDB(dbPath)
+	if err != nil {
+		return fmt.Errorf("couldn't create db at %s: %w", dbPath, err)
+	}
+
+	err = restoreDatabase(dir, dbManifest, db)
+	// Report the restore error over the close error.
+	closeErr := db.Close()
+	if err != nil {
+		return fmt.Errorf("couldn't restore database %s: %w", dbManifest.Version, err)
+	}
+	return closeErr
+}
+
+// ReadManifest returns the manifest of the snapshot in [dir] without verifying
+// the snapshot's data files.
+func ReadManifest(dir string) (*Manifest, error) {
+	manifestBytes, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrMissingManifest
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(manifestBytes, manifest); err != nil {
+		return nil, fmt.Errorf("couldn't parse snapshot manifest: %w", err)
+	}
+	for _, dbManifest := range manifest.Databases {
+		if _, err := version.Parse(dbManifest.Version); err != nil {
+			return nil, err
+		}
+		if dbManifest.File != filepath.Base(dbManifest.File) {
+			return nil, fmt.Errorf("%w: %q", errInvalidFile, dbManifest.File)
+		}
+	}
+	return manifest, nil
+}
+
+func writeManifest(manifest *Manifest, dir string) error {
+	manifestBytes, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	// Write the manifest to a temporary file first so that a partially
+	// written manifest is never mistaken for a complete snapshot.
+	tmpPath := filepath.Join(dir, ManifestFile+".tmp")
+	if err := perms.WriteFile(tmpPath, manifestBytes, perms.ReadWrite); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filepath.Join(dir, ManifestFile))
+}
+
+func writeDatabase(db *manager.VersionedDatabase, dir string) (DatabaseManifest, error) {
+	dbManifest := DatabaseManifest{
+		Version: db.Version.String(),
+		File:    db.Version.String() + dataFileExtension,
+	}
+
+	f, err := perms.Create(filepath.Join(dir, dbManifest.File), perms.ReadWrite)
+	if err != nil {
+		return dbManifest, err
+	}
+	defer f.Close()
+
+	var (
+		hasher = sha256.New()
+		writer = bufio.NewWriter(io.MultiWriter(f, hasher))
+		it     = db.Database.NewIterator()
+	)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		value := it.Value()
+		if err := writeRecord(writer, key, value); err != nil {
+			return dbManifest, err
+		}
+		dbManifest.NumKeys++
+		dbManifest.Size += uint64(len(key) + len(value))
+	}
+	if err := it.Error(); err != nil {
+		return dbManifest, err
+	}
+	if err := writer.Flush(); err != nil {
+		return dbManifest, err
+	}
+	if err := f.Sync(); err != nil {
+		return dbManifest, err
+	}
+
+	dbManifest.Checksum = hex.EncodeToString(hasher.Sum(nil))
+	return dbManifest, f.Close()
+}
+
+func restoreDatabase(dir string, dbManifest DatabaseManifest, db database.Database) error {
+	batch := db.NewBatch()
+	err := readDatabase(dir, dbManifest, func(key, value []byte) error {
+		if err := batch.Put(key, value); err != nil {
+			return err
+		}
+		if batch.Size() < restoreBatchSize {
+			return nil
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// readDatabase reads every record in the data file described by [dbManifest]
+// and verifies the file against [dbManifest]. If [onRecord] is non-nil, it is
+// called with every record before the checksum of the file is verified.
+func readDatabase(dir string, dbManifest DatabaseManifest, onRecord func(key, value []byte) error) error {
+	f, err := os.Open(filepath.Join(dir, dbManifest.File))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var (
+		hasher  = sha256.New()
+		reader  = bufio.NewReader(io.TeeReader(f, hasher))
+		numKeys uint64
+		size    uint64
+		lastKey []byte
+	)
+	for {
+		key, value, err := readRecord(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if numKeys > 0 && bytes.Compare(lastKey, key) >= 0 {
+			return errUnsortedKeys
+		}
+		if onRecord != nil {
+			if err := onRecord(key, value); err != nil {
+				return err
+			}
+		}
+		numKeys++
+		size += uint64(len(key) + len(value))
+		lastKey = key
+	}
+
+	if checksum := hex.EncodeToString(hasher.Sum(nil)); checksum != dbManifest.Checksum {
+		return fmt.Errorf("%w: expected %s but got %s", ErrChecksumMismatch, dbManifest.Checksum, checksum)
+	}
+	if numKeys != dbManifest.NumKeys {
+		return fmt.Errorf("%w: expected %d but got %d", errKeyCountMismatch, dbManifest.NumKeys, numKeys)
+	}
+	if size != dbManifest.Size {
+		return fmt.Errorf("%w: expected %d but got %d", errSizeMismatch, dbManifest.Size, size)
+	}
+	return nil
+}
+
+// writeRecord writes [key] and [value] to [w], each prefixed by its length.
+func writeRecord(w io.Writer, key, value []byte) error {
+	var lenBytes [binary.MaxVarintLen64]byte
+	for _, field := range [][]byte{key, value} {
+		n := binary.PutUvarint(lenBytes[:], uint64(len(field)))
+		if _, err := w.Write(lenBytes[:n]); err != nil {
+			return err
+		}
+		if _, err := w.Write(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readRecord reads a record written by writeRecord. io.EOF is only returned if
+// there are no bytes remaining.
+func readRecord(r *bufio.Reader) ([]byte, []byte, error) {
+	key, err := readField(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, err := readField(r)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return key, value, err
+}
+
+func readField(r *bufio.Reader) ([]byte, error) {
+	fieldLen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if fieldLen > maxRecordFieldLen {
+		return nil, fmt.Errorf("%w: %d > %d", errFieldTooLarge, fieldLen, maxRecordFieldLen)
+	}
+	field := make([]byte, fieldLen)
+	if _, err := io.ReadFull(r, field); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return field, nil
+}
+
+// CreateEmptyDir creates [dir] if it doesn't exist and errors if it exists and
+// isn't empty.
+func CreateEmptyDir(dir string) error {
+	err := checkEmptyDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return os.MkdirAll(dir, perms.ReadWriteExecute)
+	}
+	return err
+}
+
+// checkEmptyDir errors if [dir] exists and isn't empty. If [dir] doesn't
+// exist, the returned error wraps [os.ErrNotExist].
+func checkEmptyDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	switch {
+	case err != nil:
+		return err
+	case len(entries) > 0:
+		return fmt.Errorf("%w: %s", ErrNotEmpty, dir)
+	default:
+		return nil
+	}
+}
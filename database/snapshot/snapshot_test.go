@@ -0,0 +1,212 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/database/leveldb"
+	"github.com/dioneprotocol/dionego/database/manager"
+	"github.com/dioneprotocol/dionego/database/memdb"
+	"github.com/dioneprotocol/dionego/utils/logging"
+	"github.com/dioneprotocol/dionego/version"
+)
+
+func newTestManager(t *testing.T) manager.Manager {
+	require := require.New(t)
+
+	current := memdb.New()
+	require.NoError(current.Put([]byte("key1"), []byte("value1")))
+	require.NoError(current.Put([]byte("key2"), []byte("value2")))
+	require.NoError(current.Put([]byte("key3"), nil))
+
+	previous := memdb.New()
+	require.NoError(previous.Put([]byte("old"), []byte("value")))
+
+	dbManager, err := manager.NewManagerFromDBs([]*manager.VersionedDatabase{
+		{
+			Database: current,
+			Version:  version.Semantic1_0_0,
+		},
+		{
+			Database: previous,
+			Version: &version.Semantic{
+				Major: 0,
+				Minor: 1,
+				Patch: 0,
+			},
+		},
+	})
+	require.NoError(err)
+	return dbManager
+}
+
+func newLevelDB(path string) (database.Database, error) {
+	return leveldb.New(path, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+}
+
+func requireSameContents(t *testing.T, expected, actual database.Database) {
+	require := require.New(t)
+
+	expectedIt := expected.NewIterator()
+	defer expectedIt.Release()
+	actualIt := actual.NewIterator()
+	defer actualIt.Release()
+
+	for expectedIt.Next() {
+		require.True(actualIt.Next())
+		require.Equal(expectedIt.Key(), actualIt.Key())
+		require.True(bytes.Equal(expectedIt.Value(), actualIt.Value()))
+	}
+	require.False(actualIt.Next())
+	require.NoError(expectedIt.Error())
+	require.NoError(actualIt.Error())
+}
+
+func TestCreateRestore(t *testing.T) {
+	require := require.New(t)
+
+	dbManager := newTestManager(t)
+	snapshotDir := filepath.Join(t.TempDir(), "snapshot")
+
+	manifest, err := Create(dbManager, snapshotDir)
+	require.NoError(err)
+	require.Len(manifest.Databases, 2)
+	require.Equal(version.Semantic1_0_0.String(), manifest.Databases[0].Version)
+	require.Equal(uint64(3), manifest.Databases[0].NumKeys)
+	require.Equal(uint64(1), manifest.Databases[1].NumKeys)
+
+	verifiedManifest, err := Verify(snapshotDir)
+	require.NoError(err)
+	require.Equal(manifest.Databases, verifiedManifest.Databases)
+
+	dbDir := t.TempDir()
+	_, err = Restore(snapshotDir, dbDir, newLevelDB)
+	require.NoError(err)
+
+	restoredManager, err := manager.NewLevelDB(dbDir, nil, logging.NoLog{}, version.Semantic1_0_0, "", prometheus.NewRegistry())
+	require.NoError(err)
+	defer restoredManager.Close()
+
+	expectedDBs := dbManager.GetDatabases()
+	restoredDBs := restoredManager.GetDatabases()
+	require.Len(restoredDBs, len(expectedDBs))
+	for i, expectedDB := range expectedDBs {
+		require.Zero(expectedDB.Version.Compare(restoredDBs[i].Version))
+		requireSameContents(t, expectedDB.Database, restoredDBs[i].Database)
+	}
+}
+
+func TestCreateNonEmptyDir(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(dir, "file"), nil, 0o600))
+
+	_, err := Create(newTestManager(t), dir)
+	require.ErrorIs(err, ErrNotEmpty)
+}
+
+func TestVerifyMissingManifest(t *testing.T) {
+	_, err := Verify(t.TempDir())
+	require.ErrorIs(t, err, ErrMissingManifest)
+}
+
+func TestVerifyModifiedSnapshot(t *testing.T) {
+	require := require.New(t)
+
+	snapshotDir := filepath.Join(t.TempDir(), "snapshot")
+	manifest, err := Create(newTestManager(t), snapshotDir)
+	require.NoError(err)
+
+	dataPath := filepath.Join(snapshotDir, manifest.Databases[0].File)
+	data, err := os.ReadFile(dataPath)
+	require.NoError(err)
+	// Modify the first byte of the first value.
+	data[len("key1")+2] ^= 0xFF
+	require.NoError(os.WriteFile(dataPath, data, 0o600))
+
+	_, err = Verify(snapshotDir)
+	require.ErrorIs(err, ErrChecksumMismatch)
+
+	// A snapshot that fails verification must not be restored.
+	dbDir := t.TempDir()
+	_, err = Restore(snapshotDir, dbDir, newLevelDB)
+	require.ErrorIs(err, ErrChecksumMismatch)
+
+	entries, err := os.ReadDir(dbDir)
+	require.NoError(err)
+	require.Empty(entries)
+}
+
+func TestArchive(t *testing.T) {
+	require := require.New(t)
+
+	dbManager := newTestManager(t)
+	archivePath := filepath.Join(t.TempDir(), "snapshot"+ArchiveExtension)
+	require.True(IsArchive(archivePath))
+
+	manifest, err := CreateArchive(dbManager, archivePath)
+	require.NoError(err)
+
+	f, err := os.Open(archivePath)
+	require.NoError(err)
+	defer f.Close()
+
+	extractedDir := filepath.Join(t.TempDir(), "extracted")
+	require.NoError(ExtractArchive(f, extractedDir))
+
+	extractedManifest, err := Verify(extractedDir)
+	require.NoError(err)
+	require.Equal(manifest.Databases, extractedManifest.Databases)
+}
+
+func TestRestoreFailureLeavesDBDirUntouched(t *testing.T) {
+	require := require.New(t)
+
+	snapshotDir := filepath.Join(t.TempDir(), "snapshot")
+	_, err := Create(newTestManager(t), snapshotDir)
+	require.NoError(err)
+
+	// Fail to create the second, older, database after the current database
+	// has been restored.
+	errTest := errors.New("non-nil error")
+	numCreated := 0
+	failingNewDB := func(path string) (database.Database, error) {
+		numCreated++
+		if numCreated > 1 {
+			return nil, errTest
+		}
+		return newLevelDB(path)
+	}
+
+	parentDir := t.TempDir()
+	dbDir := filepath.Join(parentDir, "db")
+	_, err = Restore(snapshotDir, dbDir, failingNewDB)
+	require.ErrorIs(err, errTest)
+	require.Equal(2, numCreated)
+
+	// Neither [dbDir] nor the staging directory should remain.
+	entries, err := os.ReadDir(parentDir)
+	require.NoError(err)
+	require.Empty(entries)
+
+	// A later restore into the same directory should succeed.
+	_, err = Restore(snapshotDir, dbDir, newLevelDB)
+	require.NoError(err)
+
+	entries, err = os.ReadDir(parentDir)
+	require.NoError(err)
+	require.Len(entries, 1)
+	require.Equal("db", entries[0].Name())
+}
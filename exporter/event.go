@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/utils/hashing"
+	"github.com/dioneprotocol/dionego/utils/wrappers"
+)
+
+const (
+	eventVersion = uint16(0)
+
+	// Size, in bytes, of a serialized event, not including its container
+	eventOverhead = wrappers.ShortLen + // version
+		wrappers.LongLen + // offset
+		hashing.HashLen + // chain ID
+		wrappers.ByteLen + // event type
+		hashing.HashLen + // container ID
+		wrappers.LongLen + // timestamp
+		wrappers.IntLen // container length
+)
+
+var (
+	errUnknownEventVersion = errors.New("unknown event version")
+	errTrailingBytes       = errors.New("trailing bytes")
+)
+
+// EventType is the kind of acceptance an event records
+type EventType byte
+
+const (
+	// ConsensusEvent is emitted when a block or vertex is accepted
+	ConsensusEvent EventType = iota + 1
+	// DecisionEvent is emitted when a block or transaction is decided
+	DecisionEvent
+)
+
+func (t EventType) String() string {
+	switch t {
+	case ConsensusEvent:
+		return "consensus"
+	case DecisionEvent:
+		return "decision"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a container accepted on a chain
+type Event struct {
+	// Position of this event in the export log. Offsets start at 0 and are
+	// assigned in order of acceptance, across all chains.
+	Offset      uint64
+	ChainID     ids.ID
+	Type        EventType
+	ContainerID ids.ID
+	// Time this node accepted the container
+	Timestamp time.Time
+	Container []byte
+}
+
+// Bytes returns the serialized form of this event.
+//
+// The format, with all integers big-endian, is:
+//
+//	version      uint16 (0)
+//	offset       uint64
+//	chainID      [32]byte
+//	type         byte
+//	containerID  [32]byte
+//	timestamp    int64 (Unix nanoseconds)
+//	container    uint32 length followed by the container
+func (e *Event) Bytes() ([]byte, error) {
+	if len(e.Container) > math.MaxInt32-eventOverhead {
+		return nil, fmt.Errorf("container %s is too large to export", e.ContainerID)
+	}
+	p := wrappers.Packer{
+		MaxSize: eventOverhead + len(e.Container),
+		Bytes:   make([]byte, 0, eventOverhead+len(e.Container)),
+	}
+	p.PackShort(eventVersion)
+	p.PackLong(e.Offset)
+	p.PackFixedBytes(e.ChainID[:])
+	p.PackByte(byte(e.Type))
+	p.PackFixedBytes(e.ContainerID[:])
+	p.PackLong(uint64(e.Timestamp.UnixNano()))
+	p.PackBytes(e.Container)
+	return p.Bytes, p.Err
+}
+
+// ParseEvent parses an event serialized with [Event.Bytes]
+func ParseEvent(b []byte) (*Event, error) {
+	p := wrappers.Packer{Bytes: b}
+	if version := p.UnpackShort(); p.Err == nil && version != eventVersion {
+		return nil, fmt.Errorf("%w: %d", errUnknownEventVersion, version)
+	}
+	e := &Event{
+		Offset: p.UnpackLong(),
+	}
+	copy(e.ChainID[:], p.UnpackFixedBytes(hashing.HashLen))
+	e.Type = EventType(p.UnpackByte())
+	copy(e.ContainerID[:], p.UnpackFixedBytes(hashing.HashLen))
+	e.Timestamp = time.Unix(0, int64(p.UnpackLong()))
+	e.Container = p.UnpackBytes()
+	switch {
+	case p.Err != nil:
+		return nil, fmt.Errorf("couldn't parse event: %w", p.Err)
+	case p.Offset != len(b):
+		return nil, fmt.Errorf("couldn't parse event: %w", errTrailingBytes)
+	}
+	return e, nil
+}
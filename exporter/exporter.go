@@ -0,0 +1,512 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/dioneprotocol/dionego/api/health"
+	"github.com/dioneprotocol/dionego/chains"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/snow"
+	"github.com/dioneprotocol/dionego/snow/engine/common"
+	"github.com/dioneprotocol/dionego/utils/logging"
+	"github.com/dioneprotocol/dionego/utils/timer/mockable"
+	"github.com/dioneprotocol/dionego/utils/units"
+	"github.com/dioneprotocol/dionego/utils/wrappers"
+)
+
+const (
+	// DefaultMaxSegmentSize is a reasonable default value for the size, in
+	// bytes, of each file of the export log
+	DefaultMaxSegmentSize = 64 * units.MiB
+
+	exporterNamePrefix = "exporter-"
+	logDirName         = "log"
+	deadLetterDirName  = "dead_letter"
+	cursorFileName     = "cursor"
+
+	// Max number of events passed to the sink at a time
+	maxSinkBatchSize = 256
+	minRetryDelay    = time.Second
+	maxRetryDelay    = time.Minute
+
+	// The exporter is unhealthy once the sink has failed to accept events for
+	// this long
+	maxSinkFailureDuration = 5 * time.Minute
+)
+
+var (
+	errNoDir             = errors.New("export directory must be specified")
+	errZeroSegmentSize   = errors.New("max segment size must be positive")
+	errTooLargeSegments  = errors.New("max segment size is too large")
+	errForwardingStopped = errors.New("stopped forwarding exported events to sink")
+	errSinkFailing       = errors.New("sink is failing to accept exported events")
+
+	_ Exporter      = (*exporter)(nil)
+	_ snow.Acceptor = (*acceptor)(nil)
+)
+
+// Config for an exporter
+type Config struct {
+	// Directory the export log and the sink's cursor are kept in
+	Dir string
+	// Size, in bytes, after which a new file of the export log is started
+	MaxSegmentSize uint64
+	// If non-nil, exported events are also forwarded to [Sink]
+	Sink                   Sink
+	Log                    logging.Logger
+	Namespace              string
+	Registerer             prometheus.Registerer
+	DecisionAcceptorGroup  snow.AcceptorGroup
+	ConsensusAcceptorGroup snow.AcceptorGroup
+}
+
+// Exporter writes the containers accepted on every chain to an append-only
+// export log, and forwards them to a sink, if one is configured.
+//
+// An event is written to the export log before the container is committed as
+// accepted, and an event is only recorded as delivered to the sink once the
+// sink has accepted it. So, no event is lost or skipped across restarts, but
+// an event may be delivered more than once. When a sink is configured, the
+// files of the export log are deleted once all of their events have been
+// delivered to it. Events that the sink rejects are written to a dead letter
+// log, so that they don't block the events after them.
+//
+// The exporter is unhealthy if it stopped forwarding events to the sink, or if
+// the sink has been failing to accept events for a while.
+// Exporter is threadsafe.
+type Exporter interface {
+	chains.Registrant
+	health.Checker
+	// Close will do nothing and return nil after the first call
+	io.Closer
+}
+
+// NewExporter returns a new Exporter that exports the events of chains once
+// they are registered.
+func NewExporter(config Config) (Exporter, error) {
+	switch {
+	case config.Dir == "":
+		return nil, errNoDir
+	case config.MaxSegmentSize == 0:
+		return nil, errZeroSegmentSize
+	case config.MaxSegmentSize > math.MaxInt64:
+		return nil, errTooLargeSegments
+	}
+
+	e := &exporter{
+		log:                    config.Log,
+		sink:                   config.Sink,
+		cursorPath:             filepath.Join(config.Dir, cursorFileName),
+		decisionAcceptorGroup:  config.DecisionAcceptorGroup,
+		consensusAcceptorGroup: config.ConsensusAcceptorGroup,
+		chains:                 make(map[ids.ID]struct{}),
+		appended:               make(chan struct{}, 1),
+		stopForwarding:         make(chan struct{}),
+	}
+	if err := e.metrics.initialize(config.Namespace, config.Registerer); err != nil {
+		return nil, fmt.Errorf("couldn't initialize exporter metrics: %w", err)
+	}
+
+	log, err := openSegmentLog(filepath.Join(config.Dir, logDirName), int64(config.MaxSegmentSize))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open export log: %w", err)
+	}
+	e.eventLog = log
+	if e.sink == nil {
+		return e, nil
+	}
+
+	cursor, err := readCursor(e.cursorPath)
+	if err != nil {
+		_ = log.Close()
+		return nil, err
+	}
+	reader, err := log.NewReader(cursor)
+	if err != nil {
+		_ = log.Close()
+		return nil, fmt.Errorf("couldn't read export log from the sink's cursor: %w", err)
+	}
+	e.deadLetterLog, err = openSegmentLog(filepath.Join(config.Dir, deadLetterDirName), int64(config.MaxSegmentSize))
+	if err != nil {
+		_ = reader.Close()
+		_ = log.Close()
+		return nil, fmt.Errorf("couldn't open dead letter log: %w", err)
+	}
+	e.cursor = cursor
+	e.log.Info("forwarding exported events to sink",
+		zap.Uint64("cursor", cursor),
+		zap.Uint64("nextOffset", log.NextOffset()),
+	)
+	e.forwardingDone.Add(1)
+	go e.forward(reader)
+	return e, nil
+}
+
+type exporter struct {
+	clock      mockable.Clock
+	log        logging.Logger
+	metrics    metrics
+	eventLog   *segmentLog
+	sink       Sink
+	cursorPath string
+	// Events that [sink] rejected. nil if there isn't a sink.
+	deadLetterLog *segmentLog
+
+	decisionAcceptorGroup  snow.AcceptorGroup
+	consensusAcceptorGroup snow.AcceptorGroup
+
+	// Serializes appends, so that offsets are assigned in the order events are
+	// written
+	appendLock sync.Mutex
+
+	lock   sync.Mutex
+	closed bool
+	// Chains whose events are being exported
+	chains map[ids.ID]struct{}
+
+	// Signalled when an event is appended to [eventLog]
+	appended       chan struct{}
+	stopForwarding chan struct{}
+	forwardingDone sync.WaitGroup
+
+	forwardingLock sync.Mutex
+	// Offset of the first event that hasn't been delivered to [sink]
+	cursor uint64
+	// Time since which [sink] has been failing to accept events, or the zero
+	// time if the last write succeeded
+	sinkFailingSince time.Time
+	// Set if forwarding stopped because of an error
+	forwardingErr error
+}
+
+// RegisterChain starts exporting the events of the chain
+func (e *exporter) RegisterChain(chainName string, ctx *snow.ConsensusContext, _ common.VM) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.closed {
+		e.log.Debug("not registering chain to exporter",
+			zap.String("reason", "exporter is closed"),
+			zap.String("chainName", chainName),
+		)
+		return
+	}
+
+	chainID := ctx.ChainID
+	if _, ok := e.chains[chainID]; ok {
+		e.log.Warn("chain is already being exported",
+			zap.Stringer("chainID", chainID),
+		)
+		return
+	}
+
+	// The chain stops if its events can't be exported, so that events are
+	// never skipped.
+	name := exporterNamePrefix + chainID.String()
+	if err := e.consensusAcceptorGroup.RegisterAcceptor(chainID, name, &acceptor{exporter: e, eventType: ConsensusEvent}, true); err != nil {
+		e.log.Error("couldn't register chain to exporter",
+			zap.String("chainName", chainName),
+			zap.Error(err),
+		)
+		return
+	}
+	if err := e.decisionAcceptorGroup.RegisterAcceptor(chainID, name, &acceptor{exporter: e, eventType: DecisionEvent}, true); err != nil {
+		e.log.Error("couldn't register chain to exporter",
+			zap.String("chainName", chainName),
+			zap.Error(err),
+		)
+		if err := e.consensusAcceptorGroup.DeregisterAcceptor(chainID, name); err != nil {
+			e.log.Error("couldn't deregister chain from exporter",
+				zap.String("chainName", chainName),
+				zap.Error(err),
+			)
+		}
+		return
+	}
+	e.chains[chainID] = struct{}{}
+
+	e.log.Info("exporting chain",
+		zap.String("chainName", chainName),
+		zap.Stringer("chainID", chainID),
+	)
+}
+
+// Close stops exporting events. Assumes Close is only called after the node is
+// done making decisions.
+func (e *exporter) Close() error {
+	e.lock.Lock()
+	if e.closed {
+		e.lock.Unlock()
+		return nil
+	}
+	e.closed = true
+	chainIDs := make([]ids.ID, 0, len(e.chains))
+	for chainID := range e.chains {
+		chainIDs = append(chainIDs, chainID)
+	}
+	e.chains = nil
+	e.lock.Unlock()
+
+	// [e.lock] isn't held while deregistering because the acceptor groups may
+	// be calling Accept.
+	errs := wrappers.Errs{}
+	for _, chainID := range chainIDs {
+		name := exporterNamePrefix + chainID.String()
+		errs.Add(
+			e.consensusAcceptorGroup.DeregisterAcceptor(chainID, name),
+			e.decisionAcceptorGroup.DeregisterAcceptor(chainID, name),
+		)
+	}
+
+	close(e.stopForwarding)
+	e.forwardingDone.Wait()
+	if e.sink != nil {
+		errs.Add(
+			e.sink.Close(),
+			e.deadLetterLog.Close(),
+		)
+	}
+	errs.Add(e.eventLog.Close())
+	return errs.Err
+}
+
+// HealthCheck reports the number of events that haven't been delivered to the
+// sink, and returns an error if forwarding events to the sink has stalled.
+func (e *exporter) HealthCheck(context.Context) (interface{}, error) {
+	if e.sink == nil {
+		return nil, nil
+	}
+
+	e.forwardingLock.Lock()
+	defer e.forwardingLock.Unlock()
+
+	details := map[string]interface{}{
+		"undeliveredEvents": e.eventLog.NextOffset() - e.cursor,
+	}
+	if e.forwardingErr != nil {
+		return details, fmt.Errorf("%w: %s", errForwardingStopped, e.forwardingErr)
+	}
+	if !e.sinkFailingSince.IsZero() {
+		details["sinkFailingSince"] = e.sinkFailingSince
+		if failingFor := e.clock.Time().Sub(e.sinkFailingSince); failingFor > maxSinkFailureDuration {
+			return details, fmt.Errorf("%w: for %s", errSinkFailing, failingFor)
+		}
+	}
+	return details, nil
+}
+
+// Appends an event to the export log, and syncs it to disk
+func (e *exporter) export(event *Event) error {
+	e.appendLock.Lock()
+	event.Offset = e.eventLog.NextOffset()
+	eventBytes, err := event.Bytes()
+	if err != nil {
+		e.appendLock.Unlock()
+		return err
+	}
+	offset, err := e.eventLog.Append(eventBytes)
+	e.appendLock.Unlock()
+	if err != nil {
+		return fmt.Errorf("couldn't export %s event %s of %s: %w", event.Type, event.ContainerID, event.ChainID, err)
+	}
+
+	// [e.appendLock] isn't held while syncing, so that the events accepted on
+	// other chains in the meantime are synced together.
+	if err := e.eventLog.Sync(offset); err != nil {
+		return fmt.Errorf("couldn't sync %s event %s of %s: %w", event.Type, event.ContainerID, event.ChainID, err)
+	}
+
+	select {
+	case e.appended <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Delivers the events read by [reader] to the sink, recording the offset of
+// the next event to deliver after each batch. The segments of the export log
+// that have been delivered are deleted.
+func (e *exporter) forward(reader *segmentReader) {
+	defer e.forwardingDone.Done()
+	defer reader.Close()
+
+	for {
+		events, err := readEvents(reader)
+		if err != nil {
+			e.stopForwardingWithErr(fmt.Errorf("couldn't read events at offset %d: %w", reader.Offset(), err))
+			return
+		}
+		if len(events) == 0 {
+			select {
+			case <-e.appended:
+				continue
+			case <-e.stopForwarding:
+				return
+			}
+		}
+
+		if !e.deliver(events) {
+			return
+		}
+
+		if err := writeCursor(e.cursorPath, reader.Offset()); err != nil {
+			e.stopForwardingWithErr(fmt.Errorf("couldn't write cursor %d: %w", reader.Offset(), err))
+			return
+		}
+		e.forwardingLock.Lock()
+		e.cursor = reader.Offset()
+		e.forwardingLock.Unlock()
+
+		if err := e.eventLog.RemoveBefore(reader.Offset()); err != nil {
+			e.log.Warn("couldn't remove delivered events from export log",
+				zap.Uint64("offset", reader.Offset()),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// Writes [events] to the sink, retrying until the sink accepts them. If the
+// sink rejects [events], each event is written on its own, and the events that
+// are still rejected are written to the dead letter log. Returns false if
+// forwarding was stopped first.
+func (e *exporter) deliver(events []*Event) bool {
+	retryDelay := minRetryDelay
+	for {
+		err := e.sink.Write(events)
+		switch {
+		case err == nil:
+			e.metrics.eventsForwarded.Add(float64(len(events)))
+			e.setSinkFailing(false)
+			return true
+		case errors.Is(err, ErrRejected) && len(events) > 1:
+			for _, event := range events {
+				if !e.deliver([]*Event{event}) {
+					return false
+				}
+			}
+			return true
+		case errors.Is(err, ErrRejected):
+			event := events[0]
+			if err := e.deadLetter(event); err != nil {
+				e.stopForwardingWithErr(fmt.Errorf("couldn't write rejected event %d to the dead letter log: %w", event.Offset, err))
+				return false
+			}
+			e.log.Warn("sink rejected exported event",
+				zap.Uint64("offset", event.Offset),
+				zap.Stringer("chainID", event.ChainID),
+				zap.Stringer("containerID", event.ContainerID),
+				zap.Error(err),
+			)
+			e.metrics.eventsRejected.Inc()
+			e.setSinkFailing(false)
+			return true
+		}
+
+		e.log.Warn("couldn't forward exported events to sink",
+			zap.Uint64("offset", events[0].Offset),
+			zap.Int("numEvents", len(events)),
+			zap.Duration("retryDelay", retryDelay),
+			zap.Error(err),
+		)
+		e.metrics.sinkFailures.Inc()
+		e.setSinkFailing(true)
+		select {
+		case <-time.After(retryDelay):
+		case <-e.stopForwarding:
+			return false
+		}
+		retryDelay *= 2
+		if retryDelay > maxRetryDelay {
+			retryDelay = maxRetryDelay
+		}
+	}
+}
+
+// Appends [event] to the dead letter log, and syncs it to disk
+func (e *exporter) deadLetter(event *Event) error {
+	eventBytes, err := event.Bytes()
+	if err != nil {
+		return err
+	}
+	offset, err := e.deadLetterLog.Append(eventBytes)
+	if err != nil {
+		return err
+	}
+	return e.deadLetterLog.Sync(offset)
+}
+
+// Records whether the last write to the sink failed
+func (e *exporter) setSinkFailing(failing bool) {
+	e.forwardingLock.Lock()
+	defer e.forwardingLock.Unlock()
+
+	switch {
+	case !failing:
+		e.sinkFailingSince = time.Time{}
+	case e.sinkFailingSince.IsZero():
+		e.sinkFailingSince = e.clock.Time()
+	}
+}
+
+// Records that forwarding stopped because of [err]
+func (e *exporter) stopForwardingWithErr(err error) {
+	e.log.Error("stopped forwarding exported events to sink",
+		zap.Error(err),
+	)
+
+	e.forwardingLock.Lock()
+	defer e.forwardingLock.Unlock()
+
+	e.forwardingErr = err
+}
+
+// Returns up to [maxSinkBatchSize] of the next events of [reader]
+func readEvents(reader *segmentReader) ([]*Event, error) {
+	var events []*Event
+	for len(events) < maxSinkBatchSize {
+		eventBytes, ok, err := reader.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		event, err := ParseEvent(eventBytes)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// acceptor exports the events of a type accepted on a chain
+type acceptor struct {
+	exporter  *exporter
+	eventType EventType
+}
+
+func (a *acceptor) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container []byte) error {
+	return a.exporter.export(&Event{
+		ChainID:     ctx.ChainID,
+		Type:        a.eventType,
+		ContainerID: containerID,
+		Timestamp:   a.exporter.clock.Time(),
+		Container:   container,
+	})
+}
@@ -0,0 +1,270 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/snow"
+	"github.com/dioneprotocol/dionego/utils"
+	"github.com/dioneprotocol/dionego/utils/logging"
+)
+
+var errTestSink = errors.New("test sink error")
+
+type testSink struct {
+	lock   sync.Mutex
+	events []*Event
+	err    error
+	// Batches that contain a container of this size are rejected
+	rejectedSize int
+}
+
+func (s *testSink) Write(events []*Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	for _, event := range events {
+		if s.rejectedSize != 0 && len(event.Container) == s.rejectedSize {
+			return fmt.Errorf("%w: container is too large", ErrRejected)
+		}
+	}
+	s.events = append(s.events, events...)
+	return nil
+}
+
+func (*testSink) Close() error {
+	return nil
+}
+
+func (s *testSink) numEvents() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return len(s.events)
+}
+
+func TestExporter(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	ctx := snow.DefaultConsensusContextTest()
+	consensusAcceptorGroup := snow.NewAcceptorGroup(logging.NoLog{})
+	decisionAcceptorGroup := snow.NewAcceptorGroup(logging.NoLog{})
+	newExporter := func(sink Sink) Exporter {
+		e, err := NewExporter(Config{
+			Dir:                    dir,
+			MaxSegmentSize:         DefaultMaxSegmentSize,
+			Sink:                   sink,
+			Log:                    logging.NoLog{},
+			Registerer:             prometheus.NewRegistry(),
+			DecisionAcceptorGroup:  decisionAcceptorGroup,
+			ConsensusAcceptorGroup: consensusAcceptorGroup,
+		})
+		require.NoError(err)
+		e.RegisterChain("chain", ctx, nil)
+		return e
+	}
+
+	var expected []*Event
+	accept := func(eventType EventType) {
+		containerID := ids.GenerateTestID()
+		container := utils.RandomBytes(32)
+		acceptorGroup := consensusAcceptorGroup
+		if eventType == DecisionEvent {
+			acceptorGroup = decisionAcceptorGroup
+		}
+		require.NoError(acceptorGroup.Accept(ctx, containerID, container))
+		expected = append(expected, &Event{
+			Offset:      uint64(len(expected)),
+			ChainID:     ctx.ChainID,
+			Type:        eventType,
+			ContainerID: containerID,
+			Container:   container,
+		})
+	}
+	checkEvents := func(expected, events []*Event) {
+		require.Len(events, len(expected))
+		for i, event := range events {
+			require.Equal(expected[i].Offset, event.Offset)
+			require.Equal(expected[i].ChainID, event.ChainID)
+			require.Equal(expected[i].Type, event.Type)
+			require.Equal(expected[i].ContainerID, event.ContainerID)
+			require.Equal(expected[i].Container, event.Container)
+		}
+	}
+
+	sink := &testSink{}
+	e := newExporter(sink)
+	accept(ConsensusEvent)
+	accept(DecisionEvent)
+	accept(ConsensusEvent)
+	require.Eventually(func() bool {
+		return sink.numEvents() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	checkEvents(expected, sink.events)
+	require.NoError(e.Close())
+	require.NoError(e.Close())
+
+	// Events accepted after the exporter is closed aren't exported
+	require.NoError(consensusAcceptorGroup.Accept(ctx, ids.GenerateTestID(), utils.RandomBytes(32)))
+
+	// Events that can't be delivered are kept in the log
+	sink = &testSink{err: errTestSink}
+	e = newExporter(sink)
+	accept(DecisionEvent)
+	accept(ConsensusEvent)
+	require.NoError(e.Close())
+
+	// After a restart, delivery resumes from the first undelivered event
+	sink = &testSink{}
+	e = newExporter(sink)
+	accept(ConsensusEvent)
+	require.Eventually(func() bool {
+		return sink.numEvents() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	checkEvents(expected[3:], sink.events)
+	require.NoError(e.Close())
+
+	// Without a sink, events are only written to the log
+	e = newExporter(nil)
+	accept(DecisionEvent)
+	require.NoError(e.Close())
+
+	log, err := openSegmentLog(filepath.Join(dir, logDirName), DefaultMaxSegmentSize)
+	require.NoError(err)
+	reader, err := log.NewReader(0)
+	require.NoError(err)
+	events, err := readEvents(reader)
+	require.NoError(err)
+	checkEvents(expected, events)
+	require.NoError(reader.Close())
+	require.NoError(log.Close())
+}
+
+func TestExporterRejectedEvents(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	ctx := snow.DefaultConsensusContextTest()
+	decisionAcceptorGroup := snow.NewAcceptorGroup(logging.NoLog{})
+	sink := &testSink{rejectedSize: 64}
+	e, err := NewExporter(Config{
+		Dir:                    dir,
+		MaxSegmentSize:         DefaultMaxSegmentSize,
+		Sink:                   sink,
+		Log:                    logging.NoLog{},
+		Registerer:             prometheus.NewRegistry(),
+		DecisionAcceptorGroup:  decisionAcceptorGroup,
+		ConsensusAcceptorGroup: snow.NewAcceptorGroup(logging.NoLog{}),
+	})
+	require.NoError(err)
+	e.RegisterChain("chain", ctx, nil)
+
+	// The rejected event doesn't block the events after it
+	rejectedID := ids.GenerateTestID()
+	require.NoError(decisionAcceptorGroup.Accept(ctx, ids.GenerateTestID(), utils.RandomBytes(32)))
+	require.NoError(decisionAcceptorGroup.Accept(ctx, rejectedID, utils.RandomBytes(64)))
+	require.NoError(decisionAcceptorGroup.Accept(ctx, ids.GenerateTestID(), utils.RandomBytes(32)))
+	require.Eventually(func() bool {
+		return sink.numEvents() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(uint64(0), sink.events[0].Offset)
+	require.Equal(uint64(2), sink.events[1].Offset)
+	_, err = e.HealthCheck(context.Background())
+	require.NoError(err)
+	require.NoError(e.Close())
+
+	// The rejected event is written to the dead letter log
+	log, err := openSegmentLog(filepath.Join(dir, deadLetterDirName), DefaultMaxSegmentSize)
+	require.NoError(err)
+	reader, err := log.NewReader(0)
+	require.NoError(err)
+	events, err := readEvents(reader)
+	require.NoError(err)
+	require.Len(events, 1)
+	require.Equal(uint64(1), events[0].Offset)
+	require.Equal(rejectedID, events[0].ContainerID)
+	require.NoError(reader.Close())
+	require.NoError(log.Close())
+}
+
+func TestExporterHealthCheck(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	decisionAcceptorGroup := snow.NewAcceptorGroup(logging.NoLog{})
+	e, err := NewExporter(Config{
+		Dir:                    t.TempDir(),
+		MaxSegmentSize:         DefaultMaxSegmentSize,
+		Sink:                   &testSink{err: errTestSink},
+		Log:                    logging.NoLog{},
+		Registerer:             prometheus.NewRegistry(),
+		DecisionAcceptorGroup:  decisionAcceptorGroup,
+		ConsensusAcceptorGroup: snow.NewAcceptorGroup(logging.NoLog{}),
+	})
+	require.NoError(err)
+	e.RegisterChain("chain", ctx, nil)
+
+	now := time.Now()
+	e.(*exporter).clock.Set(now)
+	require.NoError(decisionAcceptorGroup.Accept(ctx, ids.GenerateTestID(), utils.RandomBytes(32)))
+
+	// A sink that just started failing is tolerated
+	require.Eventually(func() bool {
+		details, err := e.HealthCheck(context.Background())
+		require.NoError(err)
+		_, failing := details.(map[string]interface{})["sinkFailingSince"]
+		return failing
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A sink that keeps failing is reported
+	e.(*exporter).clock.Set(now.Add(maxSinkFailureDuration + time.Second))
+	details, err := e.HealthCheck(context.Background())
+	require.ErrorIs(err, errSinkFailing)
+	require.Equal(uint64(1), details.(map[string]interface{})["undeliveredEvents"])
+	require.NoError(e.Close())
+}
+
+func TestEventBytes(t *testing.T) {
+	require := require.New(t)
+
+	event := &Event{
+		Offset:      5,
+		ChainID:     ids.GenerateTestID(),
+		Type:        DecisionEvent,
+		ContainerID: ids.GenerateTestID(),
+		Timestamp:   time.Unix(123, 456),
+		Container:   []byte{1, 2, 3},
+	}
+	eventBytes, err := event.Bytes()
+	require.NoError(err)
+	parsedEvent, err := ParseEvent(eventBytes)
+	require.NoError(err)
+	require.Equal(event.Offset, parsedEvent.Offset)
+	require.Equal(event.ChainID, parsedEvent.ChainID)
+	require.Equal(event.Type, parsedEvent.Type)
+	require.Equal(event.ContainerID, parsedEvent.ContainerID)
+	require.True(event.Timestamp.Equal(parsedEvent.Timestamp))
+	require.Equal(event.Container, parsedEvent.Container)
+
+	_, err = ParseEvent(append(eventBytes, 0))
+	require.ErrorIs(err, errTrailingBytes)
+	_, err = ParseEvent(eventBytes[:len(eventBytes)-1])
+	require.Error(err)
+}
@@ -0,0 +1,348 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package exporter
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"net"
+	"time"
+
+	"github.com/dioneprotocol/dionego/utils/units"
+	"github.com/dioneprotocol/dionego/utils/wrappers"
+)
+
+// The subset of the Kafka protocol needed to produce to a single partition.
+// See https://kafka.apache.org/protocol and
+// https://kafka.apache.org/documentation/#recordbatch
+const (
+	// DefaultKafkaTimeout is a reasonable default value for the time to wait
+	// for a Kafka broker to acknowledge exported events
+	DefaultKafkaTimeout = 30 * time.Second
+
+	// DefaultKafkaMaxBatchSize is a reasonable default value for the total
+	// size of the containers produced to Kafka in one request. It's below the
+	// default max message size of Kafka brokers.
+	DefaultKafkaMaxBatchSize = 512 * units.KiB
+
+	kafkaClientID = "dionego-exporter"
+
+	produceAPIKey     = 0
+	produceAPIVersion = 3
+
+	recordBatchMagic = 2
+
+	// Size, in bytes, of a record batch's fields before its records
+	recordBatchOverhead = wrappers.LongLen + // base offset
+		wrappers.IntLen + // batch length
+		wrappers.IntLen + // partition leader epoch
+		wrappers.ByteLen + // magic
+		wrappers.IntLen + // crc
+		wrappers.ShortLen + // attributes
+		wrappers.IntLen + // last offset delta
+		wrappers.LongLen + // first timestamp
+		wrappers.LongLen + // max timestamp
+		wrappers.LongLen + // producer ID
+		wrappers.ShortLen + // producer epoch
+		wrappers.IntLen + // base sequence
+		wrappers.IntLen // number of records
+
+	// Offset, in a record batch, of the fields covered by its crc
+	recordBatchCRCStart = wrappers.LongLen + wrappers.IntLen + wrappers.IntLen + wrappers.ByteLen + wrappers.IntLen
+
+	// Max size, in bytes, of a produce response this sink reads
+	maxProduceResponseSize = 1024 * 1024
+
+	// Error codes with which a broker rejects a batch that it will never
+	// accept
+	messageTooLargeErrorCode    = 10
+	recordListTooLargeErrorCode = 18
+	invalidRecordErrorCode      = 87
+)
+
+var (
+	// Records are checksummed with CRC-32C
+	castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+	// Kafka encodes these as signed integers; -1 means "null" or "none"
+	noTransactionalID   = int16(-1)
+	noPartitionLeader   = int32(-1)
+	noProducerID        = int64(-1)
+	noProducerEpoch     = int16(-1)
+	noSequence          = int32(-1)
+	requiredAcksAllISRs = int16(-1)
+
+	errNoKafkaAddress         = errors.New("kafka address must be specified")
+	errNoKafkaTopic           = errors.New("kafka topic must be specified")
+	errWrongCorrelationID     = errors.New("response has the wrong correlation ID")
+	errMissingPartition       = errors.New("response is missing the partition")
+	errResponseTooLarge       = errors.New("response is too large")
+	errKafkaBrokerReturnedErr = errors.New("broker returned an error")
+
+	_ Sink = (*kafkaSink)(nil)
+)
+
+// KafkaConfig configures a Sink that produces exported events to a partition
+// of a Kafka topic.
+type KafkaConfig struct {
+	// Address of the broker that leads [Partition]
+	Address   string
+	Topic     string
+	Partition int32
+	// Max time to wait for the broker to acknowledge a batch of events
+	Timeout time.Duration
+	// Max total size, in bytes, of the containers produced in one request.
+	// Should be less than the broker's max message size.
+	MaxBatchSize int
+}
+
+// NewKafkaSink returns a Sink that produces exported events to the partition
+// of a Kafka topic, with the event's container ID as the key and the
+// serialized event as the value. Events are acknowledged by all in-sync
+// replicas before they're considered delivered.
+func NewKafkaSink(config KafkaConfig) (Sink, error) {
+	switch {
+	case config.Address == "":
+		return nil, errNoKafkaAddress
+	case config.Topic == "":
+		return nil, errNoKafkaTopic
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultKafkaTimeout
+	}
+	if config.MaxBatchSize <= 0 {
+		config.MaxBatchSize = DefaultKafkaMaxBatchSize
+	}
+	return &kafkaSink{config: config}, nil
+}
+
+type kafkaSink struct {
+	config KafkaConfig
+	// nil if not connected
+	conn          net.Conn
+	correlationID int32
+}
+
+func (k *kafkaSink) Write(events []*Event) error {
+	// Produce the events in batches of about [k.config.MaxBatchSize] bytes.
+	// A batch always has at least one event, so an event larger than
+	// [k.config.MaxBatchSize] is produced on its own.
+	for len(events) > 0 {
+		numEvents, batchSize := 1, len(events[0].Container)
+		for numEvents < len(events) && batchSize+len(events[numEvents].Container) <= k.config.MaxBatchSize {
+			batchSize += len(events[numEvents].Container)
+			numEvents++
+		}
+		if err := k.write(events[:numEvents]); err != nil {
+			return err
+		}
+		events = events[numEvents:]
+	}
+	return nil
+}
+
+func (k *kafkaSink) write(events []*Event) error {
+	batch, err := newRecordBatch(events)
+	if err != nil {
+		return err
+	}
+
+	if k.conn == nil {
+		conn, err := net.DialTimeout("tcp", k.config.Address, k.config.Timeout)
+		if err != nil {
+			return fmt.Errorf("couldn't connect to kafka broker %s: %w", k.config.Address, err)
+		}
+		k.conn = conn
+	}
+	if err := k.produce(batch); err != nil {
+		// The connection may be in an unknown state, so reconnect for the next
+		// write.
+		_ = k.conn.Close()
+		k.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (k *kafkaSink) Close() error {
+	if k.conn == nil {
+		return nil
+	}
+	err := k.conn.Close()
+	k.conn = nil
+	return err
+}
+
+// Sends a produce request of [batch] and waits for it to be acknowledged
+func (k *kafkaSink) produce(batch []byte) error {
+	k.correlationID++
+	correlationID := k.correlationID
+
+	size := wrappers.IntLen + // request size
+		wrappers.ShortLen + // api key
+		wrappers.ShortLen + // api version
+		wrappers.IntLen + // correlation ID
+		wrappers.ShortLen + len(kafkaClientID) +
+		wrappers.ShortLen + // transactional ID
+		wrappers.ShortLen + // acks
+		wrappers.IntLen + // timeout
+		wrappers.IntLen + // number of topics
+		wrappers.ShortLen + len(k.config.Topic) +
+		wrappers.IntLen + // number of partitions
+		wrappers.IntLen + // partition
+		wrappers.IntLen + len(batch)
+	p := wrappers.Packer{
+		MaxSize: size,
+		Bytes:   make([]byte, 0, size),
+	}
+	p.PackInt(uint32(size - wrappers.IntLen))
+	p.PackShort(produceAPIKey)
+	p.PackShort(produceAPIVersion)
+	p.PackInt(uint32(correlationID))
+	p.PackStr(kafkaClientID)
+	p.PackShort(uint16(noTransactionalID))
+	p.PackShort(uint16(requiredAcksAllISRs))
+	p.PackInt(uint32(k.config.Timeout.Milliseconds()))
+	p.PackInt(1)
+	p.PackStr(k.config.Topic)
+	p.PackInt(1)
+	p.PackInt(uint32(k.config.Partition))
+	p.PackBytes(batch)
+	if p.Err != nil {
+		return fmt.Errorf("couldn't pack produce request: %w", p.Err)
+	}
+
+	// Allow time to send the request and receive the response, in addition
+	// to the time the broker waits for replication.
+	if err := k.conn.SetDeadline(time.Now().Add(2 * k.config.Timeout)); err != nil {
+		return err
+	}
+	if _, err := k.conn.Write(p.Bytes); err != nil {
+		return fmt.Errorf("couldn't send produce request: %w", err)
+	}
+
+	sizeBytes := make([]byte, wrappers.IntLen)
+	if _, err := io.ReadFull(k.conn, sizeBytes); err != nil {
+		return fmt.Errorf("couldn't read produce response: %w", err)
+	}
+	responseSize := binary.BigEndian.Uint32(sizeBytes)
+	if responseSize > maxProduceResponseSize {
+		return fmt.Errorf("%w: %d bytes", errResponseTooLarge, responseSize)
+	}
+	response := make([]byte, responseSize)
+	if _, err := io.ReadFull(k.conn, response); err != nil {
+		return fmt.Errorf("couldn't read produce response: %w", err)
+	}
+	return k.parseProduceResponse(correlationID, response)
+}
+
+// Returns an error if [response] doesn't acknowledge the produced batch
+func (k *kafkaSink) parseProduceResponse(correlationID int32, response []byte) error {
+	p := wrappers.Packer{Bytes: response}
+	if responseID := int32(p.UnpackInt()); p.Err == nil && responseID != correlationID {
+		return fmt.Errorf("%w: expected %d but got %d", errWrongCorrelationID, correlationID, responseID)
+	}
+	numTopics := p.UnpackInt()
+	for i := uint32(0); i < numTopics && p.Err == nil; i++ {
+		topic := p.UnpackStr()
+		numPartitions := p.UnpackInt()
+		for j := uint32(0); j < numPartitions && p.Err == nil; j++ {
+			partition := int32(p.UnpackInt())
+			errorCode := int16(p.UnpackShort())
+			_ = p.UnpackLong() // base offset
+			_ = p.UnpackLong() // log append time
+			if p.Err != nil || topic != k.config.Topic || partition != k.config.Partition {
+				continue
+			}
+			switch errorCode {
+			case 0:
+				return nil
+			case messageTooLargeErrorCode, recordListTooLargeErrorCode, invalidRecordErrorCode:
+				return fmt.Errorf("%w: kafka broker returned error code %d for %s/%d", ErrRejected, errorCode, topic, partition)
+			default:
+				return fmt.Errorf("%w: error code %d for %s/%d", errKafkaBrokerReturnedErr, errorCode, topic, partition)
+			}
+		}
+	}
+	if p.Err != nil {
+		return fmt.Errorf("couldn't parse produce response: %w", p.Err)
+	}
+	return errMissingPartition
+}
+
+// Returns [events] as an uncompressed, non-transactional record batch
+func newRecordBatch(events []*Event) ([]byte, error) {
+	if len(events) == 0 {
+		return nil, errors.New("no events to produce")
+	}
+
+	firstTimestamp := events[0].Timestamp.UnixMilli()
+	maxTimestamp := firstTimestamp
+	var records []byte
+	for i, event := range events {
+		value, err := event.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		timestamp := event.Timestamp.UnixMilli()
+		if timestamp > maxTimestamp {
+			maxTimestamp = timestamp
+		}
+
+		var record []byte
+		record = append(record, 0) // attributes
+		record = appendVarint(record, timestamp-firstTimestamp)
+		record = appendVarint(record, int64(i)) // offset delta
+		record = appendVarint(record, int64(len(event.ContainerID)))
+		record = append(record, event.ContainerID[:]...)
+		record = appendVarint(record, int64(len(value)))
+		record = append(record, value...)
+		record = appendVarint(record, 0) // number of headers
+
+		records = appendVarint(records, int64(len(record)))
+		records = append(records, record...)
+	}
+	if len(records) > math.MaxInt32-recordBatchOverhead {
+		return nil, errors.New("too many events to produce in one batch")
+	}
+
+	size := recordBatchOverhead + len(records)
+	p := wrappers.Packer{
+		MaxSize: size,
+		Bytes:   make([]byte, 0, size),
+	}
+	p.PackLong(0)                                                // base offset
+	p.PackInt(uint32(size - wrappers.LongLen - wrappers.IntLen)) // batch length
+	p.PackInt(uint32(noPartitionLeader))
+	p.PackByte(recordBatchMagic)
+	p.PackInt(0)                       // crc, which is set below
+	p.PackShort(0)                     // attributes
+	p.PackInt(uint32(len(events) - 1)) // last offset delta
+	p.PackLong(uint64(firstTimestamp))
+	p.PackLong(uint64(maxTimestamp))
+	p.PackLong(uint64(noProducerID))
+	p.PackShort(uint16(noProducerEpoch))
+	p.PackInt(uint32(noSequence))
+	p.PackInt(uint32(len(events)))
+	p.PackFixedBytes(records)
+	if p.Err != nil {
+		return nil, fmt.Errorf("couldn't pack record batch: %w", p.Err)
+	}
+
+	batch := p.Bytes
+	crcOffset := recordBatchCRCStart - wrappers.IntLen
+	binary.BigEndian.PutUint32(batch[crcOffset:], crc32.Checksum(batch[recordBatchCRCStart:], castagnoliTable))
+	return batch, nil
+}
+
+// Appends the zig-zag encoded varint [x], as used by Kafka records
+func appendVarint(b []byte, x int64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutVarint(buf, x)
+	return append(b, buf[:n]...)
+}
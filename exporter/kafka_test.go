@@ -0,0 +1,211 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package exporter
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/utils"
+	"github.com/dioneprotocol/dionego/utils/wrappers"
+)
+
+const notLeaderForPartitionErrorCode = 6
+
+// testBroker is a Kafka broker that parses produce requests and responds with
+// [errorCode]
+type testBroker struct {
+	t         *testing.T
+	listener  net.Listener
+	errorCode utils.Atomic[int16]
+	// Receives the events of each produce request
+	produced chan []*Event
+}
+
+func newTestBroker(t *testing.T) *testBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	b := &testBroker{
+		t:        t,
+		listener: listener,
+		produced: make(chan []*Event, 16),
+	}
+	go b.serve()
+	return b
+}
+
+func (b *testBroker) serve() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go b.handle(conn)
+	}
+}
+
+func (b *testBroker) handle(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		sizeBytes := make([]byte, wrappers.IntLen)
+		if _, err := io.ReadFull(conn, sizeBytes); err != nil {
+			return
+		}
+		request := make([]byte, binary.BigEndian.Uint32(sizeBytes))
+		if _, err := io.ReadFull(conn, request); err != nil {
+			return
+		}
+
+		p := wrappers.Packer{Bytes: request}
+		require.Equal(b.t, uint16(produceAPIKey), p.UnpackShort())
+		require.Equal(b.t, uint16(produceAPIVersion), p.UnpackShort())
+		correlationID := p.UnpackInt()
+		require.Equal(b.t, kafkaClientID, p.UnpackStr())
+		require.Equal(b.t, uint16(noTransactionalID), p.UnpackShort())
+		require.Equal(b.t, uint16(requiredAcksAllISRs), p.UnpackShort())
+		_ = p.UnpackInt() // timeout
+		require.Equal(b.t, uint32(1), p.UnpackInt())
+		topic := p.UnpackStr()
+		require.Equal(b.t, uint32(1), p.UnpackInt())
+		partition := p.UnpackInt()
+		events := parseRecordBatch(b.t, p.UnpackBytes())
+		require.NoError(b.t, p.Err)
+
+		errorCode := b.errorCode.Get()
+		response := wrappers.Packer{MaxSize: 1024}
+		response.PackInt(0) // size, which is set below
+		response.PackInt(correlationID)
+		response.PackInt(1)
+		response.PackStr(topic)
+		response.PackInt(1)
+		response.PackInt(partition)
+		response.PackShort(uint16(errorCode))
+		response.PackLong(0)
+		response.PackLong(0)
+		response.PackInt(0) // throttle time
+		require.NoError(b.t, response.Err)
+		binary.BigEndian.PutUint32(response.Bytes, uint32(len(response.Bytes)-wrappers.IntLen))
+		if _, err := conn.Write(response.Bytes); err != nil {
+			return
+		}
+		if errorCode == 0 {
+			b.produced <- events
+		}
+	}
+}
+
+// Returns the events in the values of the records of [batch]
+func parseRecordBatch(t *testing.T, batch []byte) []*Event {
+	require := require.New(t)
+
+	p := wrappers.Packer{Bytes: batch}
+	_ = p.UnpackLong() // base offset
+	require.Equal(uint32(len(batch)-wrappers.LongLen-wrappers.IntLen), p.UnpackInt())
+	_ = p.UnpackInt() // partition leader epoch
+	require.Equal(byte(recordBatchMagic), p.UnpackByte())
+	require.Equal(crc32.Checksum(batch[recordBatchCRCStart:], castagnoliTable), p.UnpackInt())
+	require.Zero(p.UnpackShort()) // attributes
+	lastOffsetDelta := p.UnpackInt()
+	_ = p.UnpackLong()  // first timestamp
+	_ = p.UnpackLong()  // max timestamp
+	_ = p.UnpackLong()  // producer ID
+	_ = p.UnpackShort() // producer epoch
+	_ = p.UnpackInt()   // base sequence
+	numRecords := p.UnpackInt()
+	require.NoError(p.Err)
+	require.Equal(lastOffsetDelta+1, numRecords)
+
+	records := batch[p.Offset:]
+	readVarint := func() int64 {
+		x, n := binary.Varint(records)
+		require.Positive(n)
+		records = records[n:]
+		return x
+	}
+	events := make([]*Event, numRecords)
+	for i := range events {
+		recordLen := int(readVarint())
+		recordEnd := len(records) - recordLen
+		require.Zero(records[0]) // attributes
+		records = records[1:]
+		_ = readVarint() // timestamp delta
+		require.Equal(int64(i), readVarint())
+		keyLen := readVarint()
+		key := records[:keyLen]
+		records = records[keyLen:]
+		valueLen := readVarint()
+		event, err := ParseEvent(records[:valueLen])
+		require.NoError(err)
+		require.Equal(event.ContainerID[:], key)
+		records = records[valueLen:]
+		require.Zero(readVarint()) // headers
+		require.Len(records, recordEnd)
+		events[i] = event
+	}
+	require.Empty(records)
+	return events
+}
+
+func TestKafkaSink(t *testing.T) {
+	require := require.New(t)
+
+	broker := newTestBroker(t)
+	defer broker.listener.Close()
+
+	sink, err := NewKafkaSink(KafkaConfig{
+		Address:      broker.listener.Addr().String(),
+		Topic:        "accepted",
+		Partition:    2,
+		Timeout:      5 * time.Second,
+		MaxBatchSize: 64,
+	})
+	require.NoError(err)
+
+	var events []*Event
+	for i := 0; i < 5; i++ {
+		events = append(events, &Event{
+			Offset:      uint64(i),
+			ChainID:     ids.GenerateTestID(),
+			Type:        ConsensusEvent,
+			ContainerID: ids.GenerateTestID(),
+			Timestamp:   time.Unix(int64(i), 0),
+			Container:   make([]byte, 32),
+		})
+	}
+
+	// Errors returned by the broker are reported
+	broker.errorCode.Set(notLeaderForPartitionErrorCode)
+	err = sink.Write(events)
+	require.ErrorIs(err, errKafkaBrokerReturnedErr)
+
+	// Batches the broker will never accept are reported as rejected
+	broker.errorCode.Set(messageTooLargeErrorCode)
+	err = sink.Write(events)
+	require.ErrorIs(err, ErrRejected)
+
+	// The sink reconnects, and produces events in batches of at most
+	// [MaxBatchSize] bytes of containers
+	broker.errorCode.Set(0)
+	require.NoError(sink.Write(events))
+	for _, expected := range [][]*Event{events[:2], events[2:4], events[4:]} {
+		produced := <-broker.produced
+		require.Len(produced, len(expected))
+		for i, event := range produced {
+			require.Equal(expected[i].Offset, event.Offset)
+			require.Equal(expected[i].ContainerID, event.ContainerID)
+		}
+	}
+	require.NoError(sink.Close())
+
+	_, err = NewKafkaSink(KafkaConfig{Address: "127.0.0.1:9092"})
+	require.ErrorIs(err, errNoKafkaTopic)
+}
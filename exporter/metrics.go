@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/dioneprotocol/dionego/utils/wrappers"
+)
+
+type metrics struct {
+	eventsForwarded prometheus.Counter
+	eventsRejected  prometheus.Counter
+	sinkFailures    prometheus.Counter
+}
+
+func (m *metrics) initialize(namespace string, registerer prometheus.Registerer) error {
+	m.eventsForwarded = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_forwarded",
+		Help:      "Number of exported events delivered to the sink",
+	})
+	m.eventsRejected = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_rejected",
+		Help:      "Number of exported events the sink rejected, which were written to the dead letter log instead",
+	})
+	m.sinkFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sink_failures",
+		Help:      "Number of times the sink failed to accept exported events, which were then retried",
+	})
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.eventsForwarded),
+		registerer.Register(m.eventsRejected),
+		registerer.Register(m.sinkFailures),
+	)
+	return errs.Err
+}
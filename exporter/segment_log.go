@@ -0,0 +1,462 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package exporter
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dioneprotocol/dionego/utils/perms"
+	"github.com/dioneprotocol/dionego/utils/wrappers"
+)
+
+const (
+	segmentSuffix = ".log"
+
+	// Each record is prefixed by the length and the CRC-32 (IEEE) checksum of
+	// its payload
+	recordHeaderLen = 2 * wrappers.IntLen
+)
+
+var (
+	errCorruptRecord = errors.New("corrupt record")
+	errEmptyRecord   = errors.New("empty record")
+	errFutureOffset  = errors.New("offset hasn't been written")
+	errLogClosed     = errors.New("log closed")
+)
+
+// segmentLog is an append-only log of records, split across files of roughly
+// [maxSegmentSize] bytes.
+//
+// Each segment is named after the offset of its first record, zero-padded so
+// that the segments of a directory sort in order. Appended records survive a
+// crash once they have been synced to disk by Sync, and only synced records
+// are read. If the node crashes in the middle of an append, the partially
+// written record is discarded when the log is reopened.
+type segmentLog struct {
+	dir            string
+	maxSegmentSize int64
+
+	// Serializes syncs, so that concurrent calls to Sync share a single sync
+	syncLock sync.Mutex
+
+	lock sync.RWMutex
+	// Offset of the first record of each segment, in increasing order.
+	// The last segment is the one being appended to.
+	segments []uint64
+	active   *os.File
+	// Size, in bytes, of [active]
+	activeSize int64
+	// Segments that are no longer appended to, but haven't been synced yet.
+	// They're closed once they've been synced.
+	unsynced []*os.File
+	// Offset the next appended record will have
+	nextOffset uint64
+	// Offset of the first record that hasn't been synced
+	syncedOffset uint64
+	// Set if a sync failed, after which the records that weren't synced may
+	// not be on disk, so nothing more can be appended.
+	syncErr error
+}
+
+// openSegmentLog opens the log in [dir], creating it if it doesn't exist.
+func openSegmentLog(dir string, maxSegmentSize int64) (*segmentLog, error) {
+	if err := os.MkdirAll(dir, perms.ReadWriteExecute); err != nil {
+		return nil, fmt.Errorf("couldn't create %s: %w", dir, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read %s: %w", dir, err)
+	}
+
+	l := &segmentLog{
+		dir:            dir,
+		maxSegmentSize: maxSegmentSize,
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		firstOffset, err := strconv.ParseUint(strings.TrimSuffix(name, segmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		l.segments = append(l.segments, firstOffset)
+	}
+	sort.Slice(l.segments, func(i, j int) bool {
+		return l.segments[i] < l.segments[j]
+	})
+
+	if len(l.segments) == 0 {
+		return l, l.createSegment()
+	}
+
+	// Find the end of the last segment, discarding a record that was only
+	// partially written.
+	firstOffset := l.segments[len(l.segments)-1]
+	l.active, err = os.OpenFile(l.segmentPath(firstOffset), os.O_RDWR, perms.ReadWrite)
+	if err != nil {
+		return nil, err
+	}
+	numRecords, size, err := scanSegment(l.active)
+	if err != nil {
+		_ = l.active.Close()
+		return nil, err
+	}
+	if err := l.active.Truncate(size); err != nil {
+		_ = l.active.Close()
+		return nil, err
+	}
+	if _, err := l.active.Seek(size, io.SeekStart); err != nil {
+		_ = l.active.Close()
+		return nil, err
+	}
+	l.activeSize = size
+	l.nextOffset = firstOffset + numRecords
+	l.syncedOffset = l.nextOffset
+	return l, nil
+}
+
+// Append writes [payload] as the next record and returns its offset.
+// The record isn't read, or guaranteed to survive a crash, until it's synced.
+func (l *segmentLog) Append(payload []byte) (uint64, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.syncErr != nil {
+		return 0, l.syncErr
+	}
+	if l.active == nil {
+		return 0, errLogClosed
+	}
+	if len(payload) == 0 {
+		return 0, errEmptyRecord
+	}
+
+	record := make([]byte, recordHeaderLen+len(payload))
+	binary.BigEndian.PutUint32(record, uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[wrappers.IntLen:], crc32.ChecksumIEEE(payload))
+	copy(record[recordHeaderLen:], payload)
+
+	if l.activeSize > 0 && l.activeSize+int64(len(record)) > l.maxSegmentSize {
+		// The full segment is closed by the next sync
+		l.unsynced = append(l.unsynced, l.active)
+		l.active = nil
+		if err := l.createSegment(); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := l.active.Write(record); err != nil {
+		l.discardPartialRecord()
+		return 0, fmt.Errorf("couldn't write record %d: %w", l.nextOffset, err)
+	}
+	l.activeSize += int64(len(record))
+	offset := l.nextOffset
+	l.nextOffset++
+	return offset, nil
+}
+
+// Sync syncs the appended records to disk, unless the record at [offset] has
+// already been synced. A sync covers every record appended before it starts,
+// so appends that are waiting on a sync in progress share the next one.
+func (l *segmentLog) Sync(offset uint64) error {
+	l.syncLock.Lock()
+	defer l.syncLock.Unlock()
+
+	l.lock.Lock()
+	switch {
+	case l.syncErr != nil:
+		l.lock.Unlock()
+		return l.syncErr
+	case offset < l.syncedOffset:
+		l.lock.Unlock()
+		return nil
+	case l.active == nil:
+		l.lock.Unlock()
+		return errLogClosed
+	}
+	fullSegments := l.unsynced
+	l.unsynced = nil
+	active := l.active
+	nextOffset := l.nextOffset
+	l.lock.Unlock()
+
+	// [l.lock] isn't held while syncing, so that records can be appended in
+	// the meantime. [active] isn't closed in the meantime because Close holds
+	// [l.syncLock].
+	errs := wrappers.Errs{}
+	for _, file := range fullSegments {
+		errs.Add(file.Sync(), file.Close())
+	}
+	errs.Add(active.Sync())
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if errs.Err != nil {
+		l.syncErr = fmt.Errorf("couldn't sync records before %d: %w", nextOffset, errs.Err)
+		return l.syncErr
+	}
+	l.syncedOffset = nextOffset
+	return nil
+}
+
+// NextOffset returns the offset the next appended record will have
+func (l *segmentLog) NextOffset() uint64 {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return l.nextOffset
+}
+
+// Close syncs and closes the log. Readers that are already open can still be
+// read from.
+func (l *segmentLog) Close() error {
+	l.syncLock.Lock()
+	defer l.syncLock.Unlock()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	files := l.unsynced
+	if l.active != nil {
+		files = append(files, l.active)
+	}
+	errs := wrappers.Errs{}
+	for _, file := range files {
+		errs.Add(file.Sync(), file.Close())
+	}
+	l.unsynced = nil
+	l.active = nil
+	return errs.Err
+}
+
+// NewReader returns a reader of the records starting at [offset]
+func (l *segmentLog) NewReader(offset uint64) (*segmentReader, error) {
+	l.lock.RLock()
+	syncedOffset := l.syncedOffset
+	segments := l.segments
+	l.lock.RUnlock()
+
+	if offset > syncedOffset {
+		return nil, fmt.Errorf("%w: offset (%d) > synced offset (%d)", errFutureOffset, offset, syncedOffset)
+	}
+	firstOffset, ok := segmentContaining(segments, offset)
+	if !ok {
+		return nil, fmt.Errorf("no segment contains offset %d", offset)
+	}
+
+	r := &segmentReader{
+		log:    l,
+		offset: firstOffset,
+	}
+	if err := r.openSegment(firstOffset); err != nil {
+		return nil, err
+	}
+	for r.offset < offset {
+		if err := r.skip(); err != nil {
+			_ = r.Close()
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// RemoveBefore deletes the segments whose records all have offsets before
+// [offset]. The segment being appended to is never deleted. Readers must not
+// read records before [offset] afterwards.
+func (l *segmentLog) RemoveBefore(offset uint64) error {
+	l.lock.Lock()
+	numRemoved := 0
+	for numRemoved+1 < len(l.segments) && l.segments[numRemoved+1] <= offset {
+		numRemoved++
+	}
+	removed := l.segments[:numRemoved]
+	l.segments = l.segments[numRemoved:]
+	l.lock.Unlock()
+
+	// Segments are deleted in order, so that if a deletion fails, the
+	// remaining segments are still contiguous. A segment that isn't deleted is
+	// found again when the log is reopened, and is deleted by a later call.
+	for _, firstOffset := range removed {
+		if err := os.Remove(l.segmentPath(firstOffset)); err != nil {
+			return fmt.Errorf("couldn't remove segment %d: %w", firstOffset, err)
+		}
+	}
+	return nil
+}
+
+// Assumes [l.lock] is held and [l.active] is nil
+func (l *segmentLog) createSegment() error {
+	file, err := os.OpenFile(l.segmentPath(l.nextOffset), os.O_RDWR|os.O_CREATE|os.O_TRUNC, perms.ReadWrite)
+	if err != nil {
+		return fmt.Errorf("couldn't create segment %d: %w", l.nextOffset, err)
+	}
+	l.active = file
+	l.activeSize = 0
+	// Copy, rather than append to, the segments so that readers can use a
+	// previous slice without holding [l.lock].
+	segments := make([]uint64, len(l.segments), len(l.segments)+1)
+	copy(segments, l.segments)
+	l.segments = append(segments, l.nextOffset)
+	return nil
+}
+
+// Removes a record that wasn't fully appended, so that the next append doesn't
+// follow it. Assumes [l.lock] is held.
+func (l *segmentLog) discardPartialRecord() {
+	_ = l.active.Truncate(l.activeSize)
+	_, _ = l.active.Seek(l.activeSize, io.SeekStart)
+}
+
+func (l *segmentLog) segmentPath(firstOffset uint64) string {
+	return filepath.Join(l.dir, fmt.Sprintf("%020d%s", firstOffset, segmentSuffix))
+}
+
+// Returns the first offset of the last of [segments] that starts at or before
+// [offset], or false if there isn't one.
+func segmentContaining(segments []uint64, offset uint64) (uint64, bool) {
+	i := sort.Search(len(segments), func(i int) bool {
+		return segments[i] > offset
+	}) - 1
+	if i < 0 {
+		return 0, false
+	}
+	return segments[i], true
+}
+
+// scanSegment returns the number of valid records at the start of [file] and
+// the number of bytes they take up.
+func scanSegment(file *os.File) (uint64, int64, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, 0, err
+	}
+	var (
+		numRecords uint64
+		size       int64
+	)
+	for {
+		payload, err := readRecord(file)
+		switch {
+		case err == nil:
+			numRecords++
+			size += int64(recordHeaderLen + len(payload))
+		case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, errCorruptRecord):
+			return numRecords, size, nil
+		default:
+			return 0, 0, err
+		}
+	}
+}
+
+// readRecord reads the next record of [r] and returns its payload.
+// Returns [io.EOF] if [r] has no more bytes, and [io.ErrUnexpectedEOF] if the
+// record is incomplete.
+func readRecord(r io.Reader) ([]byte, error) {
+	header := make([]byte, recordHeaderLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	// Records are never empty, so a zeroed header is the unwritten tail of a
+	// segment rather than a record.
+	size := binary.BigEndian.Uint32(header)
+	if size == 0 {
+		return nil, errCorruptRecord
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[wrappers.IntLen:]) {
+		return nil, errCorruptRecord
+	}
+	return payload, nil
+}
+
+// segmentReader reads the records of a segmentLog in order.
+// A segmentReader only reads records that have been synced, so it can be used
+// concurrently with appends. A segmentReader isn't threadsafe.
+type segmentReader struct {
+	log *segmentLog
+	// Offset of the first record of the segment being read
+	segment uint64
+	file    *os.File
+	// Offset of the next record to read
+	offset uint64
+}
+
+// Next returns the payload of the next record, or false if every record that
+// has been synced has been read.
+func (r *segmentReader) Next() ([]byte, bool, error) {
+	r.log.lock.RLock()
+	syncedOffset := r.log.syncedOffset
+	segments := r.log.segments
+	r.log.lock.RUnlock()
+
+	if r.offset >= syncedOffset {
+		return nil, false, nil
+	}
+	// The next record is at the start of the next segment
+	if segment, ok := segmentContaining(segments, r.offset); ok && segment != r.segment {
+		if err := r.file.Close(); err != nil {
+			return nil, false, err
+		}
+		if err := r.openSegment(segment); err != nil {
+			return nil, false, err
+		}
+	}
+
+	payload, err := readRecord(r.file)
+	if err != nil {
+		return nil, false, fmt.Errorf("couldn't read record %d: %w", r.offset, err)
+	}
+	r.offset++
+	return payload, true, nil
+}
+
+// Offset returns the offset of the next record to be read
+func (r *segmentReader) Offset() uint64 {
+	return r.offset
+}
+
+func (r *segmentReader) Close() error {
+	return r.file.Close()
+}
+
+func (r *segmentReader) openSegment(firstOffset uint64) error {
+	file, err := os.Open(r.log.segmentPath(firstOffset))
+	if err != nil {
+		return fmt.Errorf("couldn't open segment %d: %w", firstOffset, err)
+	}
+	r.segment = firstOffset
+	r.file = file
+	return nil
+}
+
+// Skips the next record of the current segment without reading its payload
+func (r *segmentReader) skip() error {
+	header := make([]byte, recordHeaderLen)
+	if _, err := io.ReadFull(r.file, header); err != nil {
+		return fmt.Errorf("couldn't read record %d: %w", r.offset, err)
+	}
+	if _, err := r.file.Seek(int64(binary.BigEndian.Uint32(header)), io.SeekCurrent); err != nil {
+		return fmt.Errorf("couldn't skip record %d: %w", r.offset, err)
+	}
+	r.offset++
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/utils/perms"
+)
+
+func TestSegmentLog(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	// Each segment holds 2 records of 8 bytes
+	l, err := openSegmentLog(dir, 2*(recordHeaderLen+8))
+	require.NoError(err)
+
+	payload := func(offset uint64) []byte {
+		return []byte(fmt.Sprintf("record%02d", offset))
+	}
+	for i := uint64(0); i < 5; i++ {
+		offset, err := l.Append(payload(i))
+		require.NoError(err)
+		require.Equal(i, offset)
+	}
+	require.Equal(uint64(5), l.NextOffset())
+	require.Equal([]uint64{0, 2, 4}, l.segments)
+
+	// Records aren't read until they're synced
+	r, err := l.NewReader(0)
+	require.NoError(err)
+	_, ok, err := r.Next()
+	require.NoError(err)
+	require.False(ok)
+	require.NoError(r.Close())
+	_, err = l.NewReader(1)
+	require.ErrorIs(err, errFutureOffset)
+
+	// A sync covers every record appended before it
+	require.NoError(l.Sync(2))
+	require.NoError(l.Sync(0))
+	require.Empty(l.unsynced)
+
+	// Read from each offset, across segments
+	for start := uint64(0); start <= 5; start++ {
+		r, err := l.NewReader(start)
+		require.NoError(err)
+		for i := start; i < 5; i++ {
+			record, ok, err := r.Next()
+			require.NoError(err)
+			require.True(ok)
+			require.Equal(payload(i), record)
+		}
+		_, ok, err := r.Next()
+		require.NoError(err)
+		require.False(ok)
+
+		// Records appended after the reader is created are read
+		if start == 5 {
+			offset, err := l.Append(payload(5))
+			require.NoError(err)
+			require.NoError(l.Sync(offset))
+			record, ok, err := r.Next()
+			require.NoError(err)
+			require.True(ok)
+			require.Equal(payload(5), record)
+		}
+		require.NoError(r.Close())
+	}
+	_, err = l.NewReader(7)
+	require.ErrorIs(err, errFutureOffset)
+
+	_, err = l.Append(nil)
+	require.ErrorIs(err, errEmptyRecord)
+	require.NoError(l.Close())
+	_, err = l.Append(payload(6))
+	require.ErrorIs(err, errLogClosed)
+
+	// Simulate a crash in the middle of appending a record
+	segmentPath := filepath.Join(dir, fmt.Sprintf("%020d%s", 4, segmentSuffix))
+	file, err := os.OpenFile(segmentPath, os.O_WRONLY|os.O_APPEND, perms.ReadWrite)
+	require.NoError(err)
+	_, err = file.Write([]byte{0, 0, 0, 8, 1, 2})
+	require.NoError(err)
+	require.NoError(file.Close())
+
+	// The partial record is discarded when the log is reopened
+	l, err = openSegmentLog(dir, 2*(recordHeaderLen+8))
+	require.NoError(err)
+	require.Equal(uint64(6), l.NextOffset())
+	offset, err := l.Append(payload(6))
+	require.NoError(err)
+	require.Equal(uint64(6), offset)
+	require.NoError(l.Sync(offset))
+
+	r, err = l.NewReader(4)
+	require.NoError(err)
+	for i := uint64(4); i < 7; i++ {
+		record, ok, err := r.Next()
+		require.NoError(err)
+		require.True(ok)
+		require.Equal(payload(i), record)
+	}
+	require.NoError(r.Close())
+	require.NoError(l.Close())
+}
+
+func TestSegmentLogRemoveBefore(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	// Each segment holds 2 records of 8 bytes
+	l, err := openSegmentLog(dir, 2*(recordHeaderLen+8))
+	require.NoError(err)
+	for i := 0; i < 5; i++ {
+		offset, err := l.Append([]byte("record00"))
+		require.NoError(err)
+		require.NoError(l.Sync(offset))
+	}
+	require.Equal([]uint64{0, 2, 4}, l.segments)
+
+	// Only segments whose records are all before the offset are removed
+	require.NoError(l.RemoveBefore(3))
+	require.Equal([]uint64{2, 4}, l.segments)
+	_, err = os.Stat(filepath.Join(dir, fmt.Sprintf("%020d%s", 0, segmentSuffix)))
+	require.ErrorIs(err, os.ErrNotExist)
+	_, err = l.NewReader(1)
+	require.Error(err)
+
+	// The segment being appended to is kept
+	require.NoError(l.RemoveBefore(5))
+	require.Equal([]uint64{4}, l.segments)
+	r, err := l.NewReader(4)
+	require.NoError(err)
+	_, ok, err := r.Next()
+	require.NoError(err)
+	require.True(ok)
+	require.NoError(r.Close())
+	require.NoError(l.Close())
+
+	// Removed segments stay removed after the log is reopened
+	l, err = openSegmentLog(dir, 2*(recordHeaderLen+8))
+	require.NoError(err)
+	require.Equal([]uint64{4}, l.segments)
+	require.Equal(uint64(5), l.NextOffset())
+	require.NoError(l.Close())
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/dioneprotocol/dionego/utils/perms"
+)
+
+// ErrRejected is wrapped by the errors a Sink returns when it will never
+// accept the events it was passed, such as an event that is larger than the
+// sink allows.
+var ErrRejected = errors.New("sink rejected events")
+
+// Sink is a destination that exported events are forwarded to, in addition
+// to the export log.
+type Sink interface {
+	// Write delivers [events], which are in increasing order of offset.
+	// If Write returns an error, the same events are passed to Write again
+	// later. Since events are also redelivered if the node stops before
+	// recording their delivery, a Sink may receive an event more than once.
+	//
+	// If the error wraps [ErrRejected], each event is passed to Write again on
+	// its own, and the events that are still rejected are written to the
+	// dead letter log instead of being delivered.
+	Write(events []*Event) error
+
+	// Close is called once no more events will be written
+	Close() error
+}
+
+// Returns the offset of the first event that hasn't been delivered to the
+// sink, which is 0 if the cursor at [path] doesn't exist.
+func readCursor(path string) (uint64, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("couldn't read cursor: %w", err)
+	}
+	offset, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't parse cursor: %w", err)
+	}
+	return offset, nil
+}
+
+// Atomically replaces the cursor at [path] with [offset]
+func writeCursor(path string, offset uint64) error {
+	tmpPath := path + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perms.ReadWrite)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(strconv.FormatUint(offset, 10)); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filepath.Clean(path))
+}
@@ -6,6 +6,11 @@ package indexer
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
 
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/utils/formatting"
@@ -28,10 +33,18 @@ type Client interface {
 	GetLastAccepted(context.Context, ...rpc.Option) (Container, uint64, error)
 	// Returns 1 less than the number of containers accepted on this chain
 	GetIndex(ctx context.Context, containerID ids.ID, options ...rpc.Option) (uint64, error)
-	// Returns true if the given container is accepted
+	// Get the index of the earliest container that hasn't been pruned
+	GetEarliestIndex(context.Context, ...rpc.Option) (uint64, error)
+	// Returns true if the given container is accepted and hasn't been pruned
 	IsAccepted(ctx context.Context, containerID ids.ID, options ...rpc.Option) (bool, error)
 	// Get a container and its index by its ID
 	GetContainerByID(ctx context.Context, containerID ids.ID, options ...rpc.Option) (Container, uint64, error)
+	// Get the last container accepted at or before [timestamp] and its index
+	GetContainerByTimestamp(ctx context.Context, timestamp time.Time, options ...rpc.Option) (Container, uint64, error)
+	// Get the first [numToFetch] containers accepted in [startTime, endTime]
+	// with an index of at least [startIndex], in order of acceptance, and
+	// their indices
+	GetContainerRangeByTime(ctx context.Context, startTime, endTime time.Time, startIndex uint64, numToFetch int, options ...rpc.Option) ([]Container, []uint64, error)
 }
 
 // Client implementation for Dione Indexer API Endpoint
@@ -126,6 +139,12 @@ func (c *client) GetIndex(ctx context.Context, id ids.ID, options ...rpc.Option)
 	return uint64(index.Index), err
 }
 
+func (c *client) GetEarliestIndex(ctx context.Context, options ...rpc.Option) (uint64, error) {
+	var index GetEarliestIndexResponse
+	err := c.requester.SendRequest(ctx, "index.getEarliestIndex", struct{}{}, &index, options...)
+	return uint64(index.Index), err
+}
+
 func (c *client) IsAccepted(ctx context.Context, id ids.ID, options ...rpc.Option) (bool, error) {
 	var res IsAcceptedResponse
 	err := c.requester.SendRequest(ctx, "index.isAccepted", &IsAcceptedArgs{
@@ -154,3 +173,115 @@ func (c *client) GetContainerByID(ctx context.Context, id ids.ID, options ...rpc
 		Bytes:     containerBytes,
 	}, uint64(fc.Index), nil
 }
+
+func (c *client) GetContainerByTimestamp(ctx context.Context, timestamp time.Time, options ...rpc.Option) (Container, uint64, error) {
+	var fc FormattedContainer
+	err := c.requester.SendRequest(ctx, "index.getContainerByTimestamp", &GetContainerByTimestampArgs{
+		Timestamp: timestamp,
+		Encoding:  formatting.Hex,
+	}, &fc, options...)
+	if err != nil {
+		return Container{}, 0, err
+	}
+
+	containerBytes, err := formatting.Decode(fc.Encoding, fc.Bytes)
+	if err != nil {
+		return Container{}, 0, fmt.Errorf("couldn't decode container %s: %w", fc.ID, err)
+	}
+	return Container{
+		ID:        fc.ID,
+		Timestamp: fc.Timestamp.Unix(),
+		Bytes:     containerBytes,
+	}, uint64(fc.Index), nil
+}
+
+func (c *client) GetContainerRangeByTime(ctx context.Context, startTime, endTime time.Time, startIndex uint64, numToFetch int, options ...rpc.Option) ([]Container, []uint64, error) {
+	var fcs GetContainerRangeResponse
+	err := c.requester.SendRequest(ctx, "index.getContainerRangeByTime", &GetContainerRangeByTimeArgs{
+		StartTime:  startTime,
+		EndTime:    endTime,
+		StartIndex: json.Uint64(startIndex),
+		NumToFetch: json.Uint64(numToFetch),
+		Encoding:   formatting.Hex,
+	}, &fcs, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	containers := make([]Container, len(fcs.Containers))
+	indices := make([]uint64, len(fcs.Containers))
+	for i, resp := range fcs.Containers {
+		containerBytes, err := formatting.Decode(resp.Encoding, resp.Bytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't decode container %s: %w", resp.ID, err)
+		}
+		containers[i] = Container{
+			ID:        resp.ID,
+			Timestamp: resp.Timestamp.Unix(),
+			Bytes:     containerBytes,
+		}
+		indices[i] = uint64(resp.Index)
+	}
+	return containers, indices, nil
+}
+
+// Subscription receives the containers of an index, in order of acceptance,
+// as they are accepted.
+type Subscription struct {
+	conn *websocket.Conn
+}
+
+// Subscribe returns a Subscription to the index at [uri], which is the same
+// path given to NewClient, starting at the container at [startIndex].
+// To resume a Subscription that was closed, subscribe again with [startIndex]
+// set to the index after the last container received.
+func Subscribe(ctx context.Context, uri string, startIndex uint64) (*Subscription, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	}
+	u.Path += streamEndpoint
+	u.RawQuery = url.Values{
+		startIndexParam: []string{strconv.FormatUint(startIndex, 10)},
+		encodingParam:   []string{formatting.Hex.String()},
+	}.Encode()
+
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, u.String(), nil)
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't subscribe to %s: %w", u, err)
+	}
+	return &Subscription{conn: conn}, nil
+}
+
+// Next blocks until the next container is accepted, and returns it and its
+// index.
+func (s *Subscription) Next() (Container, uint64, error) {
+	var fc FormattedContainer
+	if err := s.conn.ReadJSON(&fc); err != nil {
+		return Container{}, 0, err
+	}
+
+	containerBytes, err := formatting.Decode(fc.Encoding, fc.Bytes)
+	if err != nil {
+		return Container{}, 0, fmt.Errorf("couldn't decode container %s: %w", fc.ID, err)
+	}
+	return Container{
+		ID:        fc.ID,
+		Timestamp: fc.Timestamp.Unix(),
+		Bytes:     containerBytes,
+	}, uint64(fc.Index), nil
+}
+
+// Close the Subscription. Calls to Next will return an error.
+func (s *Subscription) Close() error {
+	return s.conn.Close()
+}
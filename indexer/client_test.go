@@ -6,6 +6,7 @@ package indexer
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -44,6 +45,20 @@ func TestIndexClient(t *testing.T) {
 		require.NoError(err)
 		require.EqualValues(5, index)
 	}
+	{
+		// Test GetEarliestIndex
+		client.requester = &mockClient{
+			require:        require,
+			expectedMethod: "index.getEarliestIndex",
+			onSendRequestF: func(reply interface{}) error {
+				*(reply.(*GetEarliestIndexResponse)) = GetEarliestIndexResponse{Index: 3}
+				return nil
+			},
+		}
+		index, err := client.GetEarliestIndex(context.Background())
+		require.NoError(err)
+		require.EqualValues(3, index)
+	}
 	{
 		// Test GetLastAccepted
 		id := ids.GenerateTestID()
@@ -129,4 +144,54 @@ func TestIndexClient(t *testing.T) {
 		require.EqualValues(bytes, container.Bytes)
 		require.EqualValues(index, 10)
 	}
+	{
+		// Test GetContainerByTimestamp
+		id := ids.GenerateTestID()
+		bytes := utils.RandomBytes(10)
+		bytesStr, err := formatting.Encode(formatting.Hex, bytes)
+		require.NoError(err)
+		client.requester = &mockClient{
+			require:        require,
+			expectedMethod: "index.getContainerByTimestamp",
+			onSendRequestF: func(reply interface{}) error {
+				*(reply.(*FormattedContainer)) = FormattedContainer{
+					ID:    id,
+					Bytes: bytesStr,
+					Index: json.Uint64(10),
+				}
+				return nil
+			},
+		}
+		container, index, err := client.GetContainerByTimestamp(context.Background(), time.Now())
+		require.NoError(err)
+		require.EqualValues(id, container.ID)
+		require.EqualValues(bytes, container.Bytes)
+		require.EqualValues(index, 10)
+	}
+	{
+		// Test GetContainerRangeByTime
+		id := ids.GenerateTestID()
+		bytes := utils.RandomBytes(10)
+		bytesStr, err := formatting.Encode(formatting.Hex, bytes)
+		require.NoError(err)
+		client.requester = &mockClient{
+			require:        require,
+			expectedMethod: "index.getContainerRangeByTime",
+			onSendRequestF: func(reply interface{}) error {
+				*(reply.(*GetContainerRangeResponse)) = GetContainerRangeResponse{Containers: []FormattedContainer{{
+					ID:    id,
+					Bytes: bytesStr,
+					Index: json.Uint64(10),
+				}}}
+				return nil
+			},
+		}
+		now := time.Now()
+		containers, indices, err := client.GetContainerRangeByTime(context.Background(), now, now, 0, 10)
+		require.NoError(err)
+		require.Len(containers, 1)
+		require.EqualValues(id, containers[0].ID)
+		require.EqualValues(bytes, containers[0].Bytes)
+		require.Equal([]uint64{10}, indices)
+	}
 }
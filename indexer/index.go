@@ -4,13 +4,18 @@
 package indexer
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/dioneprotocol/dionego/codec"
 	"github.com/dioneprotocol/dionego/database"
 	"github.com/dioneprotocol/dionego/database/prefixdb"
@@ -18,7 +23,7 @@ import (
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/snow"
 	"github.com/dioneprotocol/dionego/utils/logging"
-	"github.com/dioneprotocol/dionego/utils/math"
+	safemath "github.com/dioneprotocol/dionego/utils/math"
 	"github.com/dioneprotocol/dionego/utils/timer/mockable"
 	"github.com/dioneprotocol/dionego/utils/wrappers"
 )
@@ -27,6 +32,9 @@ const (
 	// Maximum number of containers IDs that can be fetched at a time
 	// in a call to GetContainerRange
 	MaxFetchedByRange = 1024
+
+	// How often an index with a retention policy prunes its old containers
+	pruneFrequency = time.Minute
 )
 
 var (
@@ -34,8 +42,22 @@ var (
 	nextAcceptedIndexKey   = []byte{0x00}
 	indexToContainerPrefix = []byte{0x01}
 	containerToIDPrefix    = []byte{0x02}
-	errNoneAccepted        = errors.New("no containers have been accepted")
-	errNumToFetchZero      = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
+	timeToIndexPrefix      = []byte{0x03}
+	// Maps to the number of containers that are in [timeToIndexPrefix]
+	numTimeIndexedKey = []byte{0x04}
+	// Maps to the byte representation of the index of the earliest retained
+	// container
+	earliestIndexKey = []byte{0x05}
+	// Maps to the timestamp that the last container is indexed at in
+	// [timeToIndexPrefix]
+	lastTimestampKey = []byte{0x06}
+
+	errNoneAccepted          = errors.New("no containers have been accepted")
+	errNumToFetchZero        = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
+	errNoContainerBeforeTime = errors.New("no container was accepted at or before the given time")
+	errEndBeforeStart        = errors.New("end time is before start time")
+	errPruned                = errors.New("container was pruned")
+	errUnexpectedTimeIndex   = errors.New("unexpected index in time index")
 
 	_ Index = (*index)(nil)
 )
@@ -51,9 +73,42 @@ type Index interface {
 	GetLastAccepted() (Container, error)
 	GetIndex(id ids.ID) (uint64, error)
 	GetContainerByID(id ids.ID) (Container, error)
+	// GetContainerByTimestamp returns the last container accepted at or
+	// before [timestamp].
+	GetContainerByTimestamp(timestamp time.Time) (Container, error)
+	// GetContainerRangeByTime returns the first [numToFetch] containers
+	// accepted in [startTime, endTime] with an index of at least
+	// [startIndex], in order of acceptance.
+	GetContainerRangeByTime(startTime, endTime time.Time, startIndex, numToFetch uint64) ([]Container, error)
+	// GetLastAcceptedIndex returns the index of the last accepted container
+	// and true, or false if no containers have been accepted.
+	GetLastAcceptedIndex() (uint64, bool)
+	// GetEarliestIndex returns the index of the earliest container that
+	// hasn't been pruned and true, or false if no containers have been
+	// accepted.
+	GetEarliestIndex() (uint64, bool)
+	// NotifyAccepted returns a channel that is closed when the next container
+	// is accepted, or when the index is closed.
+	NotifyAccepted() <-chan struct{}
 	io.Closer
 }
 
+// RetentionPolicy bounds the containers kept by an index. Containers outside
+// of the policy are pruned, oldest first, in the background. The last
+// accepted container is always kept.
+// The zero value keeps every container.
+type RetentionPolicy struct {
+	// If non-zero, only the last [MaxContainers] containers are kept
+	MaxContainers uint64 `json:"maxContainers"`
+	// If non-zero, containers accepted more than [MaxAge] ago are pruned
+	MaxAge time.Duration `json:"maxAge"`
+}
+
+// Returns true if [p] prunes containers
+func (p RetentionPolicy) prunes() bool {
+	return p.MaxContainers != 0 || p.MaxAge != 0
+}
+
 // indexer indexes all accepted transactions by the order in which they were accepted
 type index struct {
 	codec codec.Manager
@@ -61,6 +116,9 @@ type index struct {
 	lock  sync.RWMutex
 	// The index of the next accepted transaction
 	nextAcceptedIndex uint64
+	// The index of the earliest container that hasn't been pruned
+	earliestIndex uint64
+	retention     RetentionPolicy
 	// When [baseDB] is committed, writes to [baseDB]
 	vDB    *versiondb.Database
 	baseDB database.Database
@@ -69,20 +127,38 @@ type index struct {
 	indexToContainer database.Database
 	// Container ID --> Index
 	containerToIndex database.Database
-	log              logging.Logger
+	// Timestamp + Index --> nil
+	// A container is indexed at its timestamp, or at the timestamp of the
+	// container before it if that is later, which can happen if the clock was
+	// moved back. So timestamps never decrease as indices increase, and
+	// iterating over [timeToIndex] visits containers in order of acceptance.
+	timeToIndex database.Database
+	// Timestamp that the last accepted container is indexed at in
+	// [timeToIndex]
+	lastTimestamp int64
+	log           logging.Logger
+	// Closed, and replaced, when a container is accepted
+	accepted chan struct{}
+	closed   bool
+	// Closed to stop pruning
+	stopPruning chan struct{}
+	pruningDone sync.WaitGroup
 }
 
 // Returns a new, thread-safe Index.
+// If [retention] prunes containers, the index prunes them until it's closed.
 // Closes [baseDB] on close.
 func newIndex(
 	baseDB database.Database,
 	log logging.Logger,
 	codec codec.Manager,
 	clock mockable.Clock,
+	retention RetentionPolicy,
 ) (Index, error) {
 	vDB := versiondb.New(baseDB)
 	indexToContainer := prefixdb.New(indexToContainerPrefix, vDB)
 	containerToIndex := prefixdb.New(containerToIDPrefix, vDB)
+	timeToIndex := prefixdb.New(timeToIndexPrefix, vDB)
 
 	i := &index{
 		clock:            clock,
@@ -91,34 +167,268 @@ func newIndex(
 		vDB:              vDB,
 		indexToContainer: indexToContainer,
 		containerToIndex: containerToIndex,
+		timeToIndex:      timeToIndex,
+		retention:        retention,
 		log:              log,
+		accepted:         make(chan struct{}),
+		stopPruning:      make(chan struct{}),
 	}
 
 	// Get next accepted index from db
 	nextAcceptedIndex, err := database.GetUInt64(i.vDB, nextAcceptedIndexKey)
-	if err == database.ErrNotFound {
+	switch err {
+	case nil:
+		i.nextAcceptedIndex = nextAcceptedIndex
+		if err := i.indexTimestamps(); err != nil {
+			return nil, err
+		}
+	case database.ErrNotFound:
 		// Couldn't find it in the database. Must not have accepted any containers in previous runs.
-		i.log.Info("created new index",
-			zap.Uint64("nextAcceptedIndex", i.nextAcceptedIndex),
-		)
-		return i, nil
-	}
-	if err != nil {
+	default:
 		return nil, fmt.Errorf("couldn't get next accepted index from database: %w", err)
 	}
-	i.nextAcceptedIndex = nextAcceptedIndex
+
+	earliestIndex, err := database.GetUInt64(i.vDB, earliestIndexKey)
+	switch err {
+	case nil:
+		i.earliestIndex = earliestIndex
+	case database.ErrNotFound:
+		// No containers have been pruned.
+	default:
+		return nil, fmt.Errorf("couldn't get earliest index from database: %w", err)
+	}
+
 	i.log.Info("created new index",
 		zap.Uint64("nextAcceptedIndex", i.nextAcceptedIndex),
+		zap.Uint64("earliestIndex", i.earliestIndex),
 	)
+	if retention.prunes() {
+		i.pruningDone.Add(1)
+		go i.pruneLoop()
+	}
 	return i, nil
 }
 
+// Adds the containers that were accepted before timestamps were indexed to
+// [i.timeToIndex] and sets [i.lastTimestamp].
+func (i *index) indexTimestamps() error {
+	numTimeIndexed, err := database.GetUInt64(i.vDB, numTimeIndexedKey)
+	if err == database.ErrNotFound {
+		numTimeIndexed = 0
+	} else if err != nil {
+		return fmt.Errorf("couldn't get number of time indexed containers: %w", err)
+	}
+
+	if numTimeIndexed > 0 {
+		lastTimestamp, err := database.GetUInt64(i.vDB, lastTimestampKey)
+		if err != nil {
+			return fmt.Errorf("couldn't get last indexed timestamp: %w", err)
+		}
+		i.lastTimestamp = int64(lastTimestamp)
+	}
+	if numTimeIndexed == i.nextAcceptedIndex {
+		return nil
+	}
+
+	i.log.Info("indexing container timestamps",
+		zap.Uint64("numTimeIndexed", numTimeIndexed),
+		zap.Uint64("nextAcceptedIndex", i.nextAcceptedIndex),
+	)
+	for index := numTimeIndexed; index < i.nextAcceptedIndex; index++ {
+		container, err := i.getContainerByIndex(index)
+		if err != nil {
+			return err
+		}
+		if err := i.putTimestamp(index, container.Timestamp); err != nil {
+			return err
+		}
+		if err := database.PutUInt64(i.vDB, numTimeIndexedKey, index+1); err != nil {
+			return err
+		}
+		// Don't hold the whole migration in memory.
+		if index%MaxFetchedByRange == 0 {
+			if err := i.vDB.Commit(); err != nil {
+				return err
+			}
+		}
+	}
+	return i.vDB.Commit()
+}
+
+// Persists index --> [container]
+// Assumes [i.lock] is held
+func (i *index) putContainer(index uint64, container Container) error {
+	bytes, err := i.codec.Marshal(codecVersion, container)
+	if err != nil {
+		return fmt.Errorf("couldn't serialize container %s: %w", container.ID, err)
+	}
+	if err := i.indexToContainer.Put(database.PackUInt64(index), bytes); err != nil {
+		return fmt.Errorf("couldn't put accepted container %s into index: %w", container.ID, err)
+	}
+	return nil
+}
+
+// Persists [index] in [i.timeToIndex] at [timestamp], or at [i.lastTimestamp]
+// if it's later, and sets [i.lastTimestamp].
+// Assumes [i.lock] is held
+func (i *index) putTimestamp(index uint64, timestamp int64) error {
+	timestamp = safemath.Max(timestamp, i.lastTimestamp)
+	if err := i.timeToIndex.Put(timeKey(timestamp, index), nil); err != nil {
+		return fmt.Errorf("couldn't index timestamp of index %d: %w", index, err)
+	}
+	if err := database.PutUInt64(i.vDB, lastTimestampKey, uint64(timestamp)); err != nil {
+		return fmt.Errorf("couldn't put last indexed timestamp: %w", err)
+	}
+	i.lastTimestamp = timestamp
+	return nil
+}
+
+// Returns the key of [index] in [i.timeToIndex]
+func timeKey(timestamp int64, index uint64) []byte {
+	key := make([]byte, 2*wrappers.LongLen)
+	binary.BigEndian.PutUint64(key, uint64(timestamp))
+	binary.BigEndian.PutUint64(key[wrappers.LongLen:], index)
+	return key
+}
+
+// Prunes containers every [pruneFrequency] until [i.stopPruning] is closed.
+func (i *index) pruneLoop() {
+	defer i.pruningDone.Done()
+
+	ticker := time.NewTicker(pruneFrequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-i.stopPruning:
+			return
+		}
+
+		// Prune in batches so that accepting containers isn't blocked for
+		// long while catching up.
+		for {
+			numPruned, err := i.prune()
+			if err != nil {
+				i.log.Error("failed to prune index",
+					zap.Error(err),
+				)
+				break
+			}
+			if numPruned < MaxFetchedByRange {
+				break
+			}
+			select {
+			case <-i.stopPruning:
+				return
+			default:
+			}
+		}
+	}
+}
+
+// Deletes up to [MaxFetchedByRange] of the earliest containers that are
+// outside of [i.retention].
+// Returns the number of containers deleted.
+func (i *index) prune() (_ int, err error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.closed {
+		return 0, nil
+	}
+
+	// The deletions are only committed along with the new earliest index. If
+	// they were left in [i.vDB], the next call to Accept would commit them
+	// without it.
+	defer func() {
+		if err != nil {
+			i.vDB.Abort()
+		}
+	}()
+
+	// Containers before [countCutoff] are beyond [MaxContainers].
+	var countCutoff uint64
+	if i.retention.MaxContainers != 0 && i.nextAcceptedIndex > i.retention.MaxContainers {
+		countCutoff = i.nextAcceptedIndex - i.retention.MaxContainers
+	}
+	// Containers accepted before [timeCutoff] are older than [MaxAge].
+	var timeCutoff int64
+	if i.retention.MaxAge != 0 {
+		timeCutoff = i.clock.Time().Add(-i.retention.MaxAge).UnixNano()
+	}
+
+	// The earliest key in [i.timeToIndex] is that of the earliest container,
+	// because timestamps never decrease as indices increase.
+	it := i.timeToIndex.NewIterator()
+	defer it.Release()
+
+	numPruned := 0
+	earliestIndex := i.earliestIndex
+	// The last accepted container is never pruned.
+	for numPruned < MaxFetchedByRange && earliestIndex+1 < i.nextAcceptedIndex && it.Next() {
+		timeKey := slices.Clone(it.Key())
+		if index := binary.BigEndian.Uint64(timeKey[wrappers.LongLen:]); index != earliestIndex {
+			return 0, fmt.Errorf("%w: expected index %d but got %d", errUnexpectedTimeIndex, earliestIndex, index)
+		}
+
+		container, err := i.getContainerByIndex(earliestIndex)
+		if err != nil {
+			return 0, err
+		}
+		if earliestIndex >= countCutoff && container.Timestamp >= timeCutoff {
+			break
+		}
+
+		if err := i.indexToContainer.Delete(database.PackUInt64(earliestIndex)); err != nil {
+			return 0, fmt.Errorf("couldn't delete container at index %d: %w", earliestIndex, err)
+		}
+		if err := i.containerToIndex.Delete(container.ID[:]); err != nil {
+			return 0, fmt.Errorf("couldn't delete index of container %s: %w", container.ID, err)
+		}
+		if err := i.timeToIndex.Delete(timeKey); err != nil {
+			return 0, fmt.Errorf("couldn't delete timestamp of index %d: %w", earliestIndex, err)
+		}
+		earliestIndex++
+		numPruned++
+	}
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+	if numPruned == 0 {
+		return 0, nil
+	}
+
+	if err := database.PutUInt64(i.vDB, earliestIndexKey, earliestIndex); err != nil {
+		return 0, fmt.Errorf("couldn't put earliest index: %w", err)
+	}
+	if err := i.vDB.Commit(); err != nil {
+		return 0, err
+	}
+	i.log.Debug("pruned index",
+		zap.Uint64("earliestIndex", earliestIndex),
+		zap.Int("numPruned", numPruned),
+	)
+	i.earliestIndex = earliestIndex
+	return numPruned, nil
+}
+
 // Close this index
 func (i *index) Close() error {
+	i.lock.Lock()
+	if !i.closed {
+		i.closed = true
+		close(i.accepted)
+		close(i.stopPruning)
+	}
+	i.lock.Unlock()
+	i.pruningDone.Wait()
+
 	errs := wrappers.Errs{}
 	errs.Add(
 		i.indexToContainer.Close(),
 		i.containerToIndex.Close(),
+		i.timeToIndex.Close(),
 		i.vDB.Close(),
 		i.baseDB.Close(),
 	)
@@ -151,18 +461,22 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 		zap.Uint64("nextAcceptedIndex", i.nextAcceptedIndex),
 		zap.Stringer("containerID", containerID),
 	)
+	timestamp := i.clock.Time().UnixNano()
+
 	// Persist index --> Container
 	nextAcceptedIndexBytes := database.PackUInt64(i.nextAcceptedIndex)
-	bytes, err := i.codec.Marshal(codecVersion, Container{
+	err = i.putContainer(i.nextAcceptedIndex, Container{
 		ID:        containerID,
 		Bytes:     containerBytes,
-		Timestamp: i.clock.Time().UnixNano(),
+		Timestamp: timestamp,
 	})
 	if err != nil {
-		return fmt.Errorf("couldn't serialize container %s: %w", containerID, err)
+		return err
 	}
-	if err := i.indexToContainer.Put(nextAcceptedIndexBytes, bytes); err != nil {
-		return fmt.Errorf("couldn't put accepted container %s into index: %w", containerID, err)
+
+	// Persist timestamp + index
+	if err := i.putTimestamp(i.nextAcceptedIndex, timestamp); err != nil {
+		return err
 	}
 
 	// Persist container ID --> index
@@ -175,9 +489,19 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 	if err := database.PutUInt64(i.vDB, nextAcceptedIndexKey, i.nextAcceptedIndex); err != nil {
 		return fmt.Errorf("couldn't put accepted container %s into index: %w", containerID, err)
 	}
+	if err := database.PutUInt64(i.vDB, numTimeIndexedKey, i.nextAcceptedIndex); err != nil {
+		return fmt.Errorf("couldn't put accepted container %s into index: %w", containerID, err)
+	}
 
 	// Atomically commit [i.vDB], [i.indexToContainer], [i.containerToIndex] to [i.baseDB]
-	return i.vDB.Commit()
+	if err := i.vDB.Commit(); err != nil {
+		return err
+	}
+
+	// Wake up anyone waiting for this container
+	close(i.accepted)
+	i.accepted = make(chan struct{})
+	return nil
 }
 
 // Returns the ID of the [index]th accepted container and the container itself.
@@ -197,6 +521,9 @@ func (i *index) getContainerByIndex(index uint64) (Container, error) {
 	if !ok || index > lastAcceptedIndex {
 		return Container{}, fmt.Errorf("no container at index %d", index)
 	}
+	if index < i.earliestIndex {
+		return Container{}, fmt.Errorf("%w: index %d is before the earliest index %d", errPruned, index, i.earliestIndex)
+	}
 	indexBytes := database.PackUInt64(index)
 	return i.getContainerByIndexBytes(indexBytes)
 }
@@ -220,7 +547,7 @@ func (i *index) getContainerByIndexBytes(indexBytes []byte) (Container, error) {
 
 // GetContainerRange returns the IDs of containers at indices
 // [startIndex], [startIndex+1], ..., [startIndex+numToFetch-1].
-// [startIndex] should be <= i.lastAcceptedIndex().
+// [startIndex] should be in [i.earliestIndex, i.lastAcceptedIndex()].
 // [numToFetch] should be in [0, MaxFetchedByRange]
 func (i *index) GetContainerRange(startIndex, numToFetch uint64) ([]Container, error) {
 	// Check arguments for validity
@@ -238,10 +565,12 @@ func (i *index) GetContainerRange(startIndex, numToFetch uint64) ([]Container, e
 		return nil, errNoneAccepted
 	} else if startIndex > lastAcceptedIndex {
 		return nil, fmt.Errorf("start index (%d) > last accepted index (%d)", startIndex, lastAcceptedIndex)
+	} else if startIndex < i.earliestIndex {
+		return nil, fmt.Errorf("%w: start index (%d) < earliest index (%d)", errPruned, startIndex, i.earliestIndex)
 	}
 
 	// Calculate the last index we will fetch
-	lastIndex := math.Min(startIndex+numToFetch-1, lastAcceptedIndex)
+	lastIndex := safemath.Min(startIndex+numToFetch-1, lastAcceptedIndex)
 	// [lastIndex] is always >= [startIndex] so this is safe.
 	// [numToFetch] is limited to [MaxFetchedByRange] so [containers] is bounded in size.
 	containers := make([]Container, int(lastIndex)-int(startIndex)+1)
@@ -291,6 +620,123 @@ func (i *index) GetLastAccepted() (Container, error) {
 	return i.getContainerByIndex(lastAcceptedIndex)
 }
 
+func (i *index) GetContainerByTimestamp(timestamp time.Time) (Container, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	ts := timestamp.UnixNano()
+	if ts < 0 {
+		return Container{}, errNoContainerBeforeTime
+	}
+
+	// The first container indexed after [timestamp] follows the container to
+	// return.
+	nextIndex, ok, err := i.firstIndexAtOrAfter(timeKey(ts, math.MaxUint64))
+	if err != nil {
+		return Container{}, err
+	}
+
+	var index uint64
+	if ok {
+		if nextIndex == 0 {
+			return Container{}, errNoContainerBeforeTime
+		}
+		index = nextIndex - 1
+	} else {
+		// Every container was accepted at or before [timestamp].
+		lastAcceptedIndex, ok := i.lastAcceptedIndex()
+		if !ok {
+			return Container{}, errNoContainerBeforeTime
+		}
+		index = lastAcceptedIndex
+	}
+	return i.getContainerByIndex(index)
+}
+
+func (i *index) GetContainerRangeByTime(startTime, endTime time.Time, startIndex, numToFetch uint64) ([]Container, error) {
+	// Check arguments for validity
+	if numToFetch == 0 {
+		return nil, errNumToFetchZero
+	} else if numToFetch > MaxFetchedByRange {
+		return nil, fmt.Errorf("requested %d but maximum page size is %d", numToFetch, MaxFetchedByRange)
+	} else if endTime.Before(startTime) {
+		return nil, errEndBeforeStart
+	}
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	// Timestamps never decrease as indices increase in [i.timeToIndex], so
+	// the containers in [startTime, endTime] have consecutive indices.
+	end := endTime.UnixNano()
+	if end < 0 {
+		return nil, nil
+	}
+	start := safemath.Max(startTime.UnixNano(), 0)
+	firstIndex, ok, err := i.firstIndexAtOrAfter(database.PackUInt64(uint64(start)))
+	if err != nil || !ok {
+		return nil, err
+	}
+	firstIndex = safemath.Max(firstIndex, startIndex)
+
+	// No container can be indexed after the largest possible index at [end].
+	endIndex, ok, err := i.firstIndexAtOrAfter(timeKey(end, math.MaxUint64))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		endIndex = i.nextAcceptedIndex
+	}
+	if firstIndex >= endIndex {
+		return nil, nil
+	}
+
+	numToFetch = safemath.Min(numToFetch, endIndex-firstIndex)
+	containers := make([]Container, 0, numToFetch)
+	for index := firstIndex; index < firstIndex+numToFetch; index++ {
+		container, err := i.getContainerByIndex(index)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get container at index %d: %w", index, err)
+		}
+		containers = append(containers, container)
+	}
+	return containers, nil
+}
+
+// Returns the index of the first container in [i.timeToIndex] whose key is at
+// or after [start], or false if there isn't one.
+// Assumes [i.lock] is held.
+func (i *index) firstIndexAtOrAfter(start []byte) (uint64, bool, error) {
+	it := i.timeToIndex.NewIteratorWithStart(start)
+	defer it.Release()
+
+	if !it.Next() {
+		return 0, false, it.Error()
+	}
+	return binary.BigEndian.Uint64(it.Key()[wrappers.LongLen:]), true, nil
+}
+
+func (i *index) GetLastAcceptedIndex() (uint64, bool) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.lastAcceptedIndex()
+}
+
+func (i *index) GetEarliestIndex() (uint64, bool) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.earliestIndex, i.nextAcceptedIndex != 0
+}
+
+func (i *index) NotifyAccepted() <-chan struct{} {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.accepted
+}
+
 // Assumes i.lock is held
 // Returns:
 // 1) The index of the most recently accepted transaction,
@@ -5,11 +5,13 @@ package indexer
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/dioneprotocol/dionego/codec"
 	"github.com/dioneprotocol/dionego/codec/linearcodec"
+	"github.com/dioneprotocol/dionego/database"
 	"github.com/dioneprotocol/dionego/database/memdb"
 	"github.com/dioneprotocol/dionego/database/versiondb"
 	"github.com/dioneprotocol/dionego/ids"
@@ -31,7 +33,7 @@ func TestIndex(t *testing.T) {
 	db := versiondb.New(baseDB)
 	ctx := snow.DefaultConsensusContextTest()
 
-	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, RetentionPolicy{})
 	require.NoError(err)
 	idx := indexIntf.(*index)
 
@@ -85,7 +87,7 @@ func TestIndex(t *testing.T) {
 	require.NoError(db.Commit())
 	require.NoError(idx.Close())
 	db = versiondb.New(baseDB)
-	indexIntf, err = newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	indexIntf, err = newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, RetentionPolicy{})
 	require.NoError(err)
 	idx = indexIntf.(*index)
 
@@ -120,7 +122,7 @@ func TestIndexGetContainerByRangeMaxPageSize(t *testing.T) {
 	require.NoError(err)
 	db := memdb.New()
 	ctx := snow.DefaultConsensusContextTest()
-	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, RetentionPolicy{})
 	require.NoError(err)
 	idx := indexIntf.(*index)
 
@@ -162,7 +164,7 @@ func TestDontIndexSameContainerTwice(t *testing.T) {
 	require.NoError(err)
 	db := memdb.New()
 	ctx := snow.DefaultConsensusContextTest()
-	idx, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	idx, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, RetentionPolicy{})
 	require.NoError(err)
 
 	// Accept the same container twice
@@ -175,3 +177,281 @@ func TestDontIndexSameContainerTwice(t *testing.T) {
 	require.NoError(err)
 	require.EqualValues(gotContainer.Bytes, []byte{1, 2, 3}, "should not have accepted same container twice")
 }
+
+func TestIndexGetContainerByTime(t *testing.T) {
+	// Setup
+	require := require.New(t)
+	codec := codec.NewDefaultManager()
+	require.NoError(codec.RegisterCodec(codecVersion, linearcodec.NewDefault()))
+	baseDB := memdb.New()
+	ctx := snow.DefaultConsensusContextTest()
+	indexIntf, err := newIndex(baseDB, logging.NoLog{}, codec, mockable.Clock{}, RetentionPolicy{})
+	require.NoError(err)
+	idx := indexIntf.(*index)
+
+	_, err = idx.GetContainerByTimestamp(time.Unix(10, 0))
+	require.ErrorIs(err, errNoContainerBeforeTime)
+
+	// The clock is moved back before the fourth container is accepted, so it
+	// is indexed at the timestamp of the third.
+	acceptTimes := []int64{10, 20, 20, 15, 30}
+	containerIDs := make([]ids.ID, len(acceptTimes))
+	for i, acceptTime := range acceptTimes {
+		idx.clock.Set(time.Unix(acceptTime, 0))
+		containerIDs[i] = ids.GenerateTestID()
+		require.NoError(idx.Accept(ctx, containerIDs[i], utils.RandomBytes(32)))
+	}
+
+	checkContainers := func(idx Index) {
+		// The timestamps of the containers aren't changed.
+		for i, acceptTime := range acceptTimes {
+			container, err := idx.GetContainerByIndex(uint64(i))
+			require.NoError(err)
+			require.Equal(time.Unix(acceptTime, 0).UnixNano(), container.Timestamp)
+		}
+
+		byTimestampTests := []struct {
+			timestamp   int64
+			expectedErr error
+			expectedID  ids.ID
+		}{
+			{timestamp: 5, expectedErr: errNoContainerBeforeTime},
+			{timestamp: 10, expectedID: containerIDs[0]},
+			{timestamp: 19, expectedID: containerIDs[0]},
+			{timestamp: 20, expectedID: containerIDs[3]},
+			{timestamp: 25, expectedID: containerIDs[3]},
+			{timestamp: 100, expectedID: containerIDs[4]},
+		}
+		for _, test := range byTimestampTests {
+			container, err := idx.GetContainerByTimestamp(time.Unix(test.timestamp, 0))
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedID, container.ID)
+		}
+
+		byTimeRangeTests := []struct {
+			start, end  int64
+			startIndex  uint64
+			numToFetch  uint64
+			expectedIDs []ids.ID
+		}{
+			{start: 0, end: 5, numToFetch: 10, expectedIDs: nil},
+			{start: 10, end: 20, numToFetch: 10, expectedIDs: containerIDs[:4]},
+			{start: 15, end: 25, numToFetch: 2, expectedIDs: containerIDs[1:3]},
+			{start: 15, end: 25, startIndex: 3, numToFetch: 2, expectedIDs: containerIDs[3:4]},
+			{start: 10, end: 20, startIndex: 4, numToFetch: 10, expectedIDs: nil},
+			{start: 21, end: 100, numToFetch: 10, expectedIDs: containerIDs[4:]},
+		}
+		for _, test := range byTimeRangeTests {
+			containers, err := idx.GetContainerRangeByTime(time.Unix(test.start, 0), time.Unix(test.end, 0), test.startIndex, test.numToFetch)
+			require.NoError(err)
+			var gotIDs []ids.ID
+			for _, container := range containers {
+				gotIDs = append(gotIDs, container.ID)
+			}
+			require.Equal(test.expectedIDs, gotIDs)
+		}
+	}
+	checkContainers(idx)
+
+	_, err = idx.GetContainerRangeByTime(time.Unix(20, 0), time.Unix(10, 0), 0, 1)
+	require.ErrorIs(err, errEndBeforeStart)
+	_, err = idx.GetContainerRangeByTime(time.Unix(10, 0), time.Unix(20, 0), 0, 0)
+	require.ErrorIs(err, errNumToFetchZero)
+
+	// Simulate an index created before timestamps were indexed.
+	it := idx.timeToIndex.NewIterator()
+	for it.Next() {
+		require.NoError(idx.timeToIndex.Delete(it.Key()))
+	}
+	it.Release()
+	require.NoError(idx.vDB.Delete(numTimeIndexedKey))
+	require.NoError(idx.vDB.Delete(lastTimestampKey))
+	require.NoError(idx.vDB.Commit())
+
+	// The timestamps are indexed when the index is created.
+	indexIntf, err = newIndex(baseDB, logging.NoLog{}, codec, mockable.Clock{}, RetentionPolicy{})
+	require.NoError(err)
+	checkContainers(indexIntf)
+
+	// The last indexed timestamp is restored when the index is created.
+	indexIntf, err = newIndex(baseDB, logging.NoLog{}, codec, mockable.Clock{}, RetentionPolicy{})
+	require.NoError(err)
+	require.Equal(time.Unix(30, 0).UnixNano(), indexIntf.(*index).lastTimestamp)
+}
+
+func TestIndexGetContainerRangeByTimePagination(t *testing.T) {
+	// Setup
+	require := require.New(t)
+	codec := codec.NewDefaultManager()
+	require.NoError(codec.RegisterCodec(codecVersion, linearcodec.NewDefault()))
+	ctx := snow.DefaultConsensusContextTest()
+	indexIntf, err := newIndex(memdb.New(), logging.NoLog{}, codec, mockable.Clock{}, RetentionPolicy{})
+	require.NoError(err)
+	idx := indexIntf.(*index)
+
+	// More containers share a timestamp than fit in a page.
+	idx.clock.Set(time.Unix(10, 0))
+	containerIDs := make([]ids.ID, 5)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+		require.NoError(idx.Accept(ctx, containerIDs[i], utils.RandomBytes(32)))
+	}
+
+	var (
+		gotIDs     []ids.ID
+		startIndex uint64
+	)
+	for {
+		containers, err := idx.GetContainerRangeByTime(time.Unix(10, 0), time.Unix(10, 0), startIndex, 2)
+		require.NoError(err)
+		if len(containers) == 0 {
+			break
+		}
+		for _, container := range containers {
+			gotIDs = append(gotIDs, container.ID)
+		}
+		lastIndex, err := idx.GetIndex(containers[len(containers)-1].ID)
+		require.NoError(err)
+		startIndex = lastIndex + 1
+	}
+	require.Equal(containerIDs, gotIDs)
+}
+
+func TestIndexRetention(t *testing.T) {
+	// Setup
+	require := require.New(t)
+	codec := codec.NewDefaultManager()
+	require.NoError(codec.RegisterCodec(codecVersion, linearcodec.NewDefault()))
+	baseDB := memdb.New()
+	ctx := snow.DefaultConsensusContextTest()
+	retention := RetentionPolicy{
+		MaxContainers: 4,
+		MaxAge:        100 * time.Second,
+	}
+	indexIntf, err := newIndex(baseDB, logging.NoLog{}, codec, mockable.Clock{}, retention)
+	require.NoError(err)
+	idx := indexIntf.(*index)
+
+	_, ok := idx.GetEarliestIndex()
+	require.False(ok)
+
+	// Accept a container every 10 seconds
+	containerIDs := make([]ids.ID, 6)
+	for i := range containerIDs {
+		idx.clock.Set(time.Unix(int64(10*i), 0))
+		containerIDs[i] = ids.GenerateTestID()
+		require.NoError(idx.Accept(ctx, containerIDs[i], utils.RandomBytes(32)))
+	}
+
+	checkRetained := func(idx Index, expectedEarliestIndex uint64) {
+		earliestIndex, ok := idx.GetEarliestIndex()
+		require.True(ok)
+		require.Equal(expectedEarliestIndex, earliestIndex)
+
+		for i, containerID := range containerIDs {
+			index, err := idx.GetIndex(containerID)
+			_, containerErr := idx.GetContainerByIndex(uint64(i))
+			if uint64(i) < expectedEarliestIndex {
+				require.ErrorIs(err, database.ErrNotFound)
+				require.ErrorIs(containerErr, errPruned)
+				continue
+			}
+			require.NoError(err)
+			require.EqualValues(i, index)
+			require.NoError(containerErr)
+		}
+
+		if expectedEarliestIndex > 0 {
+			_, err := idx.GetContainerRange(expectedEarliestIndex-1, 1)
+			require.ErrorIs(err, errPruned)
+		}
+		containers, err := idx.GetContainerRange(expectedEarliestIndex, MaxFetchedByRange)
+		require.NoError(err)
+		require.Len(containers, len(containerIDs)-int(expectedEarliestIndex))
+
+		containers, err = idx.GetContainerRangeByTime(time.Unix(0, 0), time.Unix(100, 0), 0, MaxFetchedByRange)
+		require.NoError(err)
+		require.Len(containers, len(containerIDs)-int(expectedEarliestIndex))
+		for i, container := range containers {
+			require.Equal(containerIDs[int(expectedEarliestIndex)+i], container.ID)
+		}
+	}
+
+	// Only the last 4 containers are kept.
+	numPruned, err := idx.prune()
+	require.NoError(err)
+	require.Equal(2, numPruned)
+	checkRetained(idx, 2)
+
+	// Containers accepted more than 100 seconds ago are pruned.
+	idx.clock.Set(time.Unix(145, 0))
+	numPruned, err = idx.prune()
+	require.NoError(err)
+	require.Equal(3, numPruned)
+	checkRetained(idx, 5)
+
+	// The last accepted container is never pruned.
+	idx.clock.Set(time.Unix(1000, 0))
+	numPruned, err = idx.prune()
+	require.NoError(err)
+	require.Zero(numPruned)
+	checkRetained(idx, 5)
+
+	// The earliest index is persisted across restarts.
+	indexIntf, err = newIndex(baseDB, logging.NoLog{}, codec, mockable.Clock{}, RetentionPolicy{})
+	require.NoError(err)
+	checkRetained(indexIntf, 5)
+
+	// Stop pruning
+	require.NoError(idx.Close())
+}
+
+func TestIndexPruneFailureAbortsDeletes(t *testing.T) {
+	// Setup
+	require := require.New(t)
+	codec := codec.NewDefaultManager()
+	require.NoError(codec.RegisterCodec(codecVersion, linearcodec.NewDefault()))
+	baseDB := memdb.New()
+	ctx := snow.DefaultConsensusContextTest()
+	retention := RetentionPolicy{
+		MaxContainers: 1,
+	}
+	indexIntf, err := newIndex(baseDB, logging.NoLog{}, codec, mockable.Clock{}, retention)
+	require.NoError(err)
+	idx := indexIntf.(*index)
+	defer func() {
+		require.NoError(idx.Close())
+	}()
+
+	containerIDs := make([]ids.ID, 4)
+	for i := range containerIDs {
+		idx.clock.Set(time.Unix(int64(10*i), 0))
+		containerIDs[i] = ids.GenerateTestID()
+		require.NoError(idx.Accept(ctx, containerIDs[i], utils.RandomBytes(32)))
+	}
+
+	// Remove the timestamp of the third container, so that pruning fails after
+	// deleting the first two containers.
+	require.NoError(idx.timeToIndex.Delete(timeKey(time.Unix(20, 0).UnixNano(), 2)))
+	require.NoError(idx.vDB.Commit())
+
+	_, err = idx.prune()
+	require.ErrorIs(err, errUnexpectedTimeIndex)
+
+	// Accepting a container must not commit the aborted deletions.
+	require.NoError(idx.Accept(ctx, ids.GenerateTestID(), utils.RandomBytes(32)))
+
+	earliestIndex, ok := idx.GetEarliestIndex()
+	require.True(ok)
+	require.Zero(earliestIndex)
+	for i, containerID := range containerIDs {
+		index, err := idx.GetIndex(containerID)
+		require.NoError(err)
+		require.EqualValues(i, index)
+		_, err = idx.GetContainerByIndex(uint64(i))
+		require.NoError(err)
+	}
+	containers, err := idx.GetContainerRange(0, MaxFetchedByRange)
+	require.NoError(err)
+	require.Len(containers, len(containerIDs)+1)
+}
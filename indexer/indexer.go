@@ -9,8 +9,6 @@ import (
 	"math"
 	"sync"
 
-	"github.com/gorilla/rpc/v2"
-
 	"go.uber.org/zap"
 
 	"github.com/dioneprotocol/dionego/api/server"
@@ -61,6 +59,7 @@ type Config struct {
 	Log                    logging.Logger
 	IndexingEnabled        bool
 	AllowIncompleteIndex   bool
+	Retention              RetentionPolicy
 	DecisionAcceptorGroup  snow.AcceptorGroup
 	ConsensusAcceptorGroup snow.AcceptorGroup
 	APIServer              server.PathAdder
@@ -85,6 +84,7 @@ func NewIndexer(config Config) (Indexer, error) {
 		db:                     config.DB,
 		allowIncompleteIndex:   config.AllowIncompleteIndex,
 		indexingEnabled:        config.IndexingEnabled,
+		retention:              config.Retention,
 		decisionAcceptorGroup:  config.DecisionAcceptorGroup,
 		consensusAcceptorGroup: config.ConsensusAcceptorGroup,
 		txIndices:              map[ids.ID]Index{},
@@ -132,6 +132,9 @@ type indexer struct {
 	// If false, don't create index for a chain when RegisterChain is called
 	indexingEnabled bool
 
+	// Bounds the containers kept by each index
+	retention RetentionPolicy
+
 	// Chain ID --> index of blocks of that chain (if applicable)
 	blockIndices map[ids.ID]Index
 	// Chain ID --> index of vertices of that chain (if applicable)
@@ -332,7 +335,7 @@ func (i *indexer) registerChainHelper(
 	copy(prefix, chainID[:])
 	prefix[hashing.HashLen] = prefixEnd
 	indexDB := prefixdb.New(prefix, i.db)
-	index, err := newIndex(indexDB, i.log, i.codec, i.clock)
+	index, err := newIndex(indexDB, i.log, i.codec, i.clock, i.retention)
 	if err != nil {
 		_ = indexDB.Close()
 		return nil, err
@@ -345,7 +348,7 @@ func (i *indexer) registerChainHelper(
 	}
 
 	// Create an API endpoint for this index
-	apiServer := rpc.NewServer()
+	apiServer := json.NewServer()
 	codec := json.NewCodec()
 	apiServer.RegisterCodec(codec, "application/json")
 	apiServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -358,6 +361,19 @@ func (i *indexer) registerChainHelper(
 		_ = index.Close()
 		return nil, err
 	}
+
+	// Create a websocket endpoint to stream this index
+	streamHandler := &common.HTTPHandler{
+		LockOptions: common.NoLock,
+		Handler: &streamServer{
+			log:   i.log,
+			index: index,
+		},
+	}
+	if err := i.pathAdder.AddRoute(streamHandler, &sync.RWMutex{}, "index/"+name, "/"+endpoint+streamEndpoint); err != nil {
+		_ = index.Close()
+		return nil, err
+	}
 	return index, nil
 }
 
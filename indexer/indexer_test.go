@@ -170,9 +170,10 @@ func TestIndexer(t *testing.T) {
 	require.NoError(err)
 	require.True(previouslyIndexed)
 	server := config.APIServer.(*apiServerMock)
-	require.EqualValues(1, server.timesCalled)
+	require.EqualValues(2, server.timesCalled) // block index and its stream
 	require.EqualValues("index/chain1", server.bases[0])
 	require.EqualValues("/block", server.endpoints[0])
+	require.EqualValues("/block/stream", server.endpoints[1])
 	require.Len(idxr.blockIndices, 1)
 	require.Len(idxr.txIndices, 0)
 	require.Len(idxr.vtxIndices, 0)
@@ -267,10 +268,12 @@ func TestIndexer(t *testing.T) {
 	idxr.RegisterChain("chain2", chain2Ctx, dagVM)
 	require.NoError(err)
 	server = config.APIServer.(*apiServerMock)
-	require.EqualValues(3, server.timesCalled) // block index, vtx index, tx index
+	require.EqualValues(6, server.timesCalled) // block, vtx and tx indices and their streams
 	require.Contains(server.bases, "index/chain2")
 	require.Contains(server.endpoints, "/vtx")
+	require.Contains(server.endpoints, "/vtx/stream")
 	require.Contains(server.endpoints, "/tx")
+	require.Contains(server.endpoints, "/tx/stream")
 	require.Len(idxr.blockIndices, 1)
 	require.Len(idxr.txIndices, 1)
 	require.Len(idxr.vtxIndices, 1)
@@ -125,6 +125,22 @@ func (s *service) GetIndex(_ *http.Request, args *GetIndexArgs, reply *GetIndexR
 	return err
 }
 
+type GetEarliestIndexResponse struct {
+	Index json.Uint64 `json:"index"`
+}
+
+// GetEarliestIndex returns the index of the earliest container that hasn't
+// been pruned. Containers before it are no longer kept by this node.
+// Returns an error if no containers have been accepted.
+func (s *service) GetEarliestIndex(_ *http.Request, _ *struct{}, reply *GetEarliestIndexResponse) error {
+	index, ok := s.Index.GetEarliestIndex()
+	if !ok {
+		return errNoneAccepted
+	}
+	reply.Index = json.Uint64(index)
+	return nil
+}
+
 type IsAcceptedArgs struct {
 	ID ids.ID `json:"id"`
 }
@@ -163,3 +179,57 @@ func (s *service) GetContainerByID(_ *http.Request, args *GetContainerByIDArgs,
 	*reply, err = newFormattedContainer(container, index, args.Encoding)
 	return err
 }
+
+type GetContainerByTimestampArgs struct {
+	Timestamp time.Time           `json:"timestamp"`
+	Encoding  formatting.Encoding `json:"encoding"`
+}
+
+// GetContainerByTimestamp returns the last container accepted at or before
+// [Timestamp].
+func (s *service) GetContainerByTimestamp(_ *http.Request, args *GetContainerByTimestampArgs, reply *FormattedContainer) error {
+	container, err := s.Index.GetContainerByTimestamp(args.Timestamp)
+	if err != nil {
+		return err
+	}
+	index, err := s.Index.GetIndex(container.ID)
+	if err != nil {
+		return fmt.Errorf("couldn't get index: %w", err)
+	}
+	*reply, err = newFormattedContainer(container, index, args.Encoding)
+	return err
+}
+
+type GetContainerRangeByTimeArgs struct {
+	StartTime  time.Time           `json:"startTime"`
+	EndTime    time.Time           `json:"endTime"`
+	StartIndex json.Uint64         `json:"startIndex"`
+	NumToFetch json.Uint64         `json:"numToFetch"`
+	Encoding   formatting.Encoding `json:"encoding"`
+}
+
+// GetContainerRangeByTime returns the first [NumToFetch] containers accepted
+// in [StartTime, EndTime] with an index of at least [StartIndex], in order of
+// acceptance.
+// To get the next page, call again with the same arguments and [StartIndex]
+// set to the index after that of the last container returned.
+// If [NumToFetch] > [MaxFetchedByRange], returns an error.
+func (s *service) GetContainerRangeByTime(_ *http.Request, args *GetContainerRangeByTimeArgs, reply *GetContainerRangeResponse) error {
+	containers, err := s.Index.GetContainerRangeByTime(args.StartTime, args.EndTime, uint64(args.StartIndex), uint64(args.NumToFetch))
+	if err != nil {
+		return err
+	}
+
+	reply.Containers = make([]FormattedContainer, len(containers))
+	for i, container := range containers {
+		index, err := s.Index.GetIndex(container.ID)
+		if err != nil {
+			return fmt.Errorf("couldn't get index: %w", err)
+		}
+		reply.Containers[i], err = newFormattedContainer(container, index, args.Encoding)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"go.uber.org/zap"
+
+	"github.com/dioneprotocol/dionego/utils/formatting"
+	"github.com/dioneprotocol/dionego/utils/logging"
+	"github.com/dioneprotocol/dionego/utils/math"
+	"github.com/dioneprotocol/dionego/utils/units"
+)
+
+const (
+	streamEndpoint = "/stream"
+
+	// Query parameters of a stream request
+	startIndexParam = "startIndex"
+	encodingParam   = "encoding"
+
+	// Max number of containers read from the index at a time by a stream.
+	// A stream doesn't read more containers until the previous ones have been
+	// written to the connection, so a slow subscriber only holds this many
+	// containers in memory.
+	streamBatchSize = 64
+
+	// Size of the ws read and write buffers
+	streamBufferSize = units.KiB
+
+	// Time allowed to write a message to the subscriber. Subscribers that
+	// don't keep up are disconnected, and can resume from the index after
+	// the last container they received.
+	streamWriteWait = 10 * time.Second
+
+	// Time allowed to read the next pong message from the subscriber.
+	streamPongWait = 60 * time.Second
+
+	// Send pings to the subscriber with this period. Must be less than
+	// [streamPongWait].
+	streamPingPeriod = (streamPongWait * 9) / 10
+
+	// Maximum message size allowed from the subscriber. Subscribers aren't
+	// expected to send anything but control messages.
+	streamMaxReadSize = 512
+)
+
+var (
+	errInvalidStartIndex = errors.New("invalid start index")
+
+	streamUpgrader = websocket.Upgrader{
+		ReadBufferSize:  streamBufferSize,
+		WriteBufferSize: streamBufferSize,
+		CheckOrigin: func(*http.Request) bool {
+			return true
+		},
+	}
+)
+
+// streamServer streams the containers of an index over websocket connections.
+//
+// A subscriber connects with the optional query parameters:
+//   - [startIndex]: the index of the first container to send. Defaults to 0.
+//   - [encoding]: the encoding of the container bytes. Defaults to hex.
+//
+// The server sends each container, as a FormattedContainer, in order of
+// acceptance starting at [startIndex], which must not have been pruned. Once
+// the subscriber has caught up, containers are sent as they are accepted. A
+// subscriber that disconnects can resume by reconnecting with [startIndex] set
+// to the index after the last container it received.
+type streamServer struct {
+	log   logging.Logger
+	index Index
+}
+
+func (s *streamServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	startIndex, encoding, err := parseStreamArgs(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if earliestIndex, ok := s.index.GetEarliestIndex(); ok && startIndex < earliestIndex {
+		err := fmt.Errorf("%w: start index (%d) < earliest index (%d)", errPruned, startIndex, earliestIndex)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := streamUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.Debug("failed to upgrade",
+			zap.Error(err),
+		)
+		return
+	}
+
+	st := &stream{
+		log:       s.log,
+		index:     s.index,
+		conn:      conn,
+		nextIndex: startIndex,
+		encoding:  encoding,
+		closed:    make(chan struct{}),
+	}
+	go st.readPump()
+	go st.writePump()
+}
+
+func parseStreamArgs(query url.Values) (uint64, formatting.Encoding, error) {
+	var (
+		startIndex uint64
+		encoding   = formatting.Hex
+		err        error
+	)
+	if startIndexStr := query.Get(startIndexParam); startIndexStr != "" {
+		startIndex, err = strconv.ParseUint(startIndexStr, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w %q: %s", errInvalidStartIndex, startIndexStr, err)
+		}
+	}
+	if encodingStr := query.Get(encodingParam); encodingStr != "" {
+		// Encodings are unmarshalled from quoted strings.
+		if err := encoding.UnmarshalJSON([]byte(strconv.Quote(encodingStr))); err != nil {
+			return 0, 0, err
+		}
+	}
+	return startIndex, encoding, nil
+}
+
+// stream sends the containers of [index] to a single subscriber.
+type stream struct {
+	log   logging.Logger
+	index Index
+	conn  *websocket.Conn
+	// Index of the next container to send
+	nextIndex uint64
+	encoding  formatting.Encoding
+	// Closed when the connection stops being read from
+	closed chan struct{}
+}
+
+// readPump reads from the connection so that control messages, including
+// pongs and close messages, are handled.
+func (s *stream) readPump() {
+	defer func() {
+		close(s.closed)
+		// close is called by both the writePump and the readPump so one of
+		// them will always error
+		_ = s.conn.Close()
+	}()
+
+	s.conn.SetReadLimit(streamMaxReadSize)
+	// SetReadDeadline returns an error if the connection is corrupted
+	if err := s.conn.SetReadDeadline(time.Now().Add(streamPongWait)); err != nil {
+		return
+	}
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(streamPongWait))
+	})
+
+	for {
+		if _, _, err := s.conn.NextReader(); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				s.log.Debug("unexpected close in index stream",
+					zap.Error(err),
+				)
+			}
+			return
+		}
+	}
+}
+
+// writePump sends containers to the subscriber until the connection is
+// closed.
+func (s *stream) writePump() {
+	ticker := time.NewTicker(streamPingPeriod)
+	defer func() {
+		ticker.Stop()
+		// close is called by both the writePump and the readPump so one of
+		// them will always error
+		_ = s.conn.Close()
+	}()
+
+	for {
+		// Get the notification before reading the index so that a container
+		// accepted after the read isn't missed.
+		accepted := s.index.NotifyAccepted()
+		numSent, err := s.sendNextContainers()
+		if err != nil {
+			s.log.Debug("closing index stream",
+				zap.Uint64("nextIndex", s.nextIndex),
+				zap.Error(err),
+			)
+			return
+		}
+
+		if numSent > 0 {
+			// Don't wait for new containers, but keep the connection alive
+			// while catching up.
+			select {
+			case <-ticker.C:
+				if err := s.ping(); err != nil {
+					return
+				}
+			case <-s.closed:
+				return
+			default:
+			}
+			continue
+		}
+
+		select {
+		case <-accepted:
+			// The channel isn't replaced once the index is closed.
+			if s.index.NotifyAccepted() == accepted {
+				s.log.Debug("closing index stream",
+					zap.String("reason", "index closed"),
+				)
+				return
+			}
+		case <-ticker.C:
+			if err := s.ping(); err != nil {
+				return
+			}
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+// Sends up to [streamBatchSize] containers, starting at [s.nextIndex].
+// Returns the number of containers sent.
+func (s *stream) sendNextContainers() (int, error) {
+	lastAcceptedIndex, ok := s.index.GetLastAcceptedIndex()
+	if !ok || s.nextIndex > lastAcceptedIndex {
+		return 0, nil
+	}
+
+	numToFetch := math.Min(lastAcceptedIndex-s.nextIndex+1, streamBatchSize)
+	containers, err := s.index.GetContainerRange(s.nextIndex, numToFetch)
+	if err != nil {
+		return 0, err
+	}
+	for _, container := range containers {
+		fc, err := newFormattedContainer(container, s.nextIndex, s.encoding)
+		if err != nil {
+			return 0, err
+		}
+		if err := s.conn.SetWriteDeadline(time.Now().Add(streamWriteWait)); err != nil {
+			return 0, err
+		}
+		if err := s.conn.WriteJSON(fc); err != nil {
+			return 0, err
+		}
+		s.nextIndex++
+	}
+	return len(containers), nil
+}
+
+func (s *stream) ping() error {
+	if err := s.conn.SetWriteDeadline(time.Now().Add(streamWriteWait)); err != nil {
+		return err
+	}
+	return s.conn.WriteMessage(websocket.PingMessage, nil)
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/codec"
+	"github.com/dioneprotocol/dionego/codec/linearcodec"
+	"github.com/dioneprotocol/dionego/database/memdb"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/snow"
+	"github.com/dioneprotocol/dionego/utils"
+	"github.com/dioneprotocol/dionego/utils/formatting"
+	"github.com/dioneprotocol/dionego/utils/logging"
+	"github.com/dioneprotocol/dionego/utils/timer/mockable"
+)
+
+func TestStream(t *testing.T) {
+	require := require.New(t)
+
+	codec := codec.NewDefaultManager()
+	require.NoError(codec.RegisterCodec(codecVersion, linearcodec.NewDefault()))
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, codec, mockable.Clock{}, RetentionPolicy{})
+	require.NoError(err)
+
+	ctx := snow.DefaultConsensusContextTest()
+	accept := func() ids.ID {
+		containerID := ids.GenerateTestID()
+		require.NoError(idx.Accept(ctx, containerID, utils.RandomBytes(32)))
+		return containerID
+	}
+	// Enough containers that catching up takes more than one batch
+	containerIDs := make([]ids.ID, 0, streamBatchSize+2)
+	for i := 0; i < streamBatchSize+1; i++ {
+		containerIDs = append(containerIDs, accept())
+	}
+
+	server := httptest.NewServer(&streamServer{
+		log:   logging.NoLog{},
+		index: idx,
+	})
+	defer server.Close()
+	// The stream server handles any path in this test.
+	uri := server.URL + "/ext/index/X/tx"
+
+	// Replay from an index, then receive new containers as they're accepted.
+	sub, err := Subscribe(context.Background(), uri, 1)
+	require.NoError(err)
+	for i := uint64(1); i < uint64(len(containerIDs)); i++ {
+		container, index, err := sub.Next()
+		require.NoError(err)
+		require.Equal(i, index)
+		require.Equal(containerIDs[i], container.ID)
+	}
+
+	containerIDs = append(containerIDs, accept())
+	container, index, err := sub.Next()
+	require.NoError(err)
+	require.EqualValues(len(containerIDs)-1, index)
+	require.Equal(containerIDs[index], container.ID)
+	require.NoError(sub.Close())
+
+	// Resume from the index after the last container received.
+	containerIDs = append(containerIDs, accept())
+	sub, err = Subscribe(context.Background(), uri, index+1)
+	require.NoError(err)
+	container, index, err = sub.Next()
+	require.NoError(err)
+	require.EqualValues(len(containerIDs)-1, index)
+	require.Equal(containerIDs[index], container.ID)
+
+	// Closing the index ends the stream.
+	require.NoError(idx.Close())
+	_, _, err = sub.Next()
+	require.Error(err)
+	require.NoError(sub.Close())
+}
+
+func TestParseStreamArgs(t *testing.T) {
+	require := require.New(t)
+
+	startIndex, encoding, err := parseStreamArgs(url.Values{})
+	require.NoError(err)
+	require.Zero(startIndex)
+	require.Equal(formatting.Hex, encoding)
+
+	startIndex, encoding, err = parseStreamArgs(url.Values{
+		startIndexParam: []string{"5"},
+		encodingParam:   []string{"json"},
+	})
+	require.NoError(err)
+	require.EqualValues(5, startIndex)
+	require.Equal(formatting.JSON, encoding)
+
+	_, _, err = parseStreamArgs(url.Values{
+		startIndexParam: []string{"-1"},
+	})
+	require.ErrorIs(err, errInvalidStartIndex)
+
+	_, _, err = parseStreamArgs(url.Values{
+		encodingParam: []string{"notAnEncoding"},
+	})
+	require.Error(err)
+}
@@ -30,26 +30,42 @@ type context struct {
 	log       logging.Logger
 	networkID uint32
 	path      string
+	// If false, events aren't written to IPC sockets
+	socketsEnabled bool
 }
 
 // ChainIPCs maintains IPCs for a set of chains
 type ChainIPCs struct {
 	context
-	chains                 map[ids.ID]*EventSockets
+	chains map[ids.ID]*EventSockets
+	// Streams the events of published chains over gRPC. Nil if events aren't
+	// streamed.
+	streams                *EventStreams
 	consensusAcceptorGroup snow.AcceptorGroup
 	decisionAcceptorGroup  snow.AcceptorGroup
 }
 
 // NewChainIPCs creates a new *ChainIPCs that writes consensus and decision
-// events to IPC sockets
-func NewChainIPCs(log logging.Logger, path string, networkID uint32, consensusAcceptorGroup, decisionAcceptorGroup snow.AcceptorGroup, defaultChainIDs []ids.ID) (*ChainIPCs, error) {
+// events to IPC sockets, if [socketsEnabled], and to [streams], if non-nil
+func NewChainIPCs(
+	log logging.Logger,
+	path string,
+	networkID uint32,
+	socketsEnabled bool,
+	streams *EventStreams,
+	consensusAcceptorGroup,
+	decisionAcceptorGroup snow.AcceptorGroup,
+	defaultChainIDs []ids.ID,
+) (*ChainIPCs, error) {
 	cipcs := &ChainIPCs{
 		context: context{
-			log:       log,
-			networkID: networkID,
-			path:      path,
+			log:            log,
+			networkID:      networkID,
+			path:           path,
+			socketsEnabled: socketsEnabled,
 		},
 		chains:                 make(map[ids.ID]*EventSockets),
+		streams:                streams,
 		consensusAcceptorGroup: consensusAcceptorGroup,
 		decisionAcceptorGroup:  decisionAcceptorGroup,
 	}
@@ -78,6 +94,16 @@ func (cipcs *ChainIPCs) Publish(chainID ids.ID) (*EventSockets, error) {
 		return nil, err
 	}
 
+	if cipcs.streams != nil {
+		if err := cipcs.streams.publish(chainID, cipcs.consensusAcceptorGroup, cipcs.decisionAcceptorGroup); err != nil {
+			cipcs.log.Error("can't create ipc streams",
+				zap.Error(err),
+			)
+			_ = es.stop()
+			return nil, err
+		}
+	}
+
 	cipcs.chains[chainID] = es
 	cipcs.log.Info("created IPC sockets",
 		zap.Stringer("blockchainID", chainID),
@@ -95,7 +121,13 @@ func (cipcs *ChainIPCs) Unpublish(chainID ids.ID) (bool, error) {
 		return false, nil
 	}
 	delete(cipcs.chains, chainID)
-	return true, chainIPCs.stop()
+
+	errs := wrappers.Errs{}
+	errs.Add(chainIPCs.stop())
+	if cipcs.streams != nil {
+		errs.Add(cipcs.streams.unpublish(chainID))
+	}
+	return true, errs.Err
 }
 
 // GetPublishedBlockchains returns the chains that are currently being published
@@ -110,6 +142,9 @@ func (cipcs *ChainIPCs) Shutdown() error {
 	for _, ch := range cipcs.chains {
 		errs.Add(ch.stop())
 	}
+	if cipcs.streams != nil {
+		errs.Add(cipcs.streams.shutdown())
+	}
 	return errs.Err
 }
 
@@ -23,8 +23,13 @@ type EventSockets struct {
 	decisionsSocket *eventSocket
 }
 
-// newEventSockets creates a *ChainIPCs with both consensus and decisions IPCs
+// newEventSockets creates a *ChainIPCs with both consensus and decisions IPCs.
+// If sockets aren't enabled in [ctx], the returned *EventSockets has neither.
 func newEventSockets(ctx context, chainID ids.ID, consensusAcceptorGroup, decisionAcceptorGroup snow.AcceptorGroup) (*EventSockets, error) {
+	if !ctx.socketsEnabled {
+		return &EventSockets{}, nil
+	}
+
 	consensusIPC, err := newEventIPCSocket(ctx, chainID, ipcConsensusIdentifier, consensusAcceptorGroup)
 	if err != nil {
 		return nil, err
@@ -32,6 +37,7 @@ func newEventSockets(ctx context, chainID ids.ID, consensusAcceptorGroup, decisi
 
 	decisionsIPC, err := newEventIPCSocket(ctx, chainID, ipcDecisionsIdentifier, decisionAcceptorGroup)
 	if err != nil {
+		_ = consensusIPC.stop()
 		return nil, err
 	}
 
@@ -73,13 +79,21 @@ func (ipcs *EventSockets) stop() error {
 	return errs.Err
 }
 
-// ConsensusURL returns the URL of socket receiving consensus events
+// ConsensusURL returns the URL of socket receiving consensus events, or the
+// empty string if there is no such socket
 func (ipcs *EventSockets) ConsensusURL() string {
+	if ipcs.consensusSocket == nil {
+		return ""
+	}
 	return ipcs.consensusSocket.URL()
 }
 
-// DecisionsURL returns the URL of socket receiving decisions events
+// DecisionsURL returns the URL of socket receiving decisions events, or the
+// empty string if there is no such socket
 func (ipcs *EventSockets) DecisionsURL() string {
+	if ipcs.decisionsSocket == nil {
+		return ""
+	}
 	return ipcs.decisionsSocket.URL()
 }
 
@@ -0,0 +1,519 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	stdcontext "context"
+
+	"go.uber.org/zap"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dioneprotocol/dionego/chains"
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/database/prefixdb"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/snow"
+	"github.com/dioneprotocol/dionego/snow/engine/common"
+	"github.com/dioneprotocol/dionego/snow/engine/dione/vertex"
+	"github.com/dioneprotocol/dionego/snow/engine/snowman/block"
+	"github.com/dioneprotocol/dionego/utils/hashing"
+	"github.com/dioneprotocol/dionego/utils/logging"
+	"github.com/dioneprotocol/dionego/utils/wrappers"
+
+	ipcspb "github.com/dioneprotocol/dionego/proto/pb/ipcs"
+)
+
+const (
+	// DefaultStreamBufferSize can be used as a reasonable default value for
+	// the number of events of each type, per chain, that can be replayed
+	DefaultStreamBufferSize = 1024
+
+	ipcStreamIdentifierPrefix = ipcIdentifierPrefix + "-stream"
+
+	// Max number of events read from a log at a time by a subscription.
+	// A subscription doesn't read more events until the previous ones have
+	// been sent, so a slow subscriber only holds this many events in memory.
+	streamBatchSize = 64
+)
+
+var (
+	nextSequenceKey        = []byte{0x00}
+	sequenceToEventPrefix  = byte(0x01)
+	heightToSequencePrefix = byte(0x02)
+
+	errPruned    = errors.New("event was pruned")
+	errLogClosed = errors.New("event log closed")
+
+	_ ipcspb.EventsServer = (*EventStreams)(nil)
+	_ chains.Registrant   = (*EventStreams)(nil)
+	_ snow.Acceptor       = (*eventLog)(nil)
+)
+
+// EventStreams streams the consensus and decisions events of published chains
+// to gRPC subscribers.
+//
+// The last [bufferSize] events of each type are persisted for each chain, so
+// that a subscriber can replay them. A subscriber that disconnects can resume
+// by subscribing from the sequence number after that of the last event it
+// received, or from the height after that of the last block it processed.
+//
+// The heights of the accepted containers are parsed using the VMs of the
+// chains, which are registered with RegisterChain.
+type EventStreams struct {
+	ipcspb.UnimplementedEventsServer
+
+	log        logging.Logger
+	db         database.Database
+	bufferSize uint64
+
+	lock sync.RWMutex
+	// Chain ID --> Event type --> Event log
+	chains map[ids.ID]map[ipcspb.EventType]*eventLog
+
+	// Held separately from [lock], which is held while the logs of a chain
+	// are stopped, because the logs get the VMs while accepting events.
+	vmsLock sync.RWMutex
+	// Chain ID --> VM of the chain
+	vms map[ids.ID]common.VM
+}
+
+// NewEventStreams returns a new *EventStreams that persists events in [db].
+// [bufferSize] must be positive.
+// Chains are streamed once published with ChainIPCs.
+func NewEventStreams(log logging.Logger, db database.Database, bufferSize uint64) *EventStreams {
+	return &EventStreams{
+		log:        log,
+		db:         db,
+		bufferSize: bufferSize,
+		chains:     make(map[ids.ID]map[ipcspb.EventType]*eventLog),
+		vms:        make(map[ids.ID]common.VM),
+	}
+}
+
+// RegisterChain records the VM of the chain, which is used to get the heights
+// of the containers accepted on the chain.
+// [vm] should be a vertex.DAGVM or block.ChainVM.
+func (s *EventStreams) RegisterChain(_ string, ctx *snow.ConsensusContext, vm common.VM) {
+	s.vmsLock.Lock()
+	defer s.vmsLock.Unlock()
+
+	s.vms[ctx.ChainID] = vm
+}
+
+// Starts logging the events of [chainID]. Does nothing if [chainID] is
+// already published.
+func (s *EventStreams) publish(chainID ids.ID, consensusAcceptorGroup, decisionAcceptorGroup snow.AcceptorGroup) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.chains[chainID]; ok {
+		return nil
+	}
+
+	consensusLog, err := s.newEventLog(chainID, ipcspb.EventType_EVENT_TYPE_CONSENSUS, ipcConsensusIdentifier, consensusAcceptorGroup)
+	if err != nil {
+		return err
+	}
+	decisionsLog, err := s.newEventLog(chainID, ipcspb.EventType_EVENT_TYPE_DECISIONS, ipcDecisionsIdentifier, decisionAcceptorGroup)
+	if err != nil {
+		_ = consensusLog.stop()
+		return err
+	}
+
+	s.chains[chainID] = map[ipcspb.EventType]*eventLog{
+		ipcspb.EventType_EVENT_TYPE_CONSENSUS: consensusLog,
+		ipcspb.EventType_EVENT_TYPE_DECISIONS: decisionsLog,
+	}
+	return nil
+}
+
+// Stops logging the events of [chainID] and ends its subscriptions.
+// The logged events are kept, so subscribers can replay them if [chainID] is
+// published again.
+func (s *EventStreams) unpublish(chainID ids.ID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	logs, ok := s.chains[chainID]
+	if !ok {
+		return nil
+	}
+	delete(s.chains, chainID)
+
+	errs := wrappers.Errs{}
+	for _, log := range logs {
+		errs.Add(log.stop())
+	}
+	return errs.Err
+}
+
+// Subscribe streams the events of [req.EventType] accepted on [req.ChainId],
+// starting at [req.StartHeight] if it's set, or at [req.StartSequence]
+// otherwise.
+func (s *EventStreams) Subscribe(req *ipcspb.SubscribeRequest, stream ipcspb.Events_SubscribeServer) error {
+	chainID, err := ids.ToID(req.ChainId)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid chain ID: %s", err)
+	}
+	if req.EventType != ipcspb.EventType_EVENT_TYPE_CONSENSUS && req.EventType != ipcspb.EventType_EVENT_TYPE_DECISIONS {
+		return status.Errorf(codes.InvalidArgument, "invalid event type: %s", req.EventType)
+	}
+
+	s.lock.RLock()
+	log, ok := s.chains[chainID][req.EventType]
+	s.lock.RUnlock()
+	if !ok {
+		return status.Errorf(codes.NotFound, "chain %s isn't published", chainID)
+	}
+
+	sequence := req.StartSequence
+	if req.StartHeight != nil {
+		sequence, err = log.sequenceAtHeight(*req.StartHeight)
+		if err != nil {
+			return logError(err)
+		}
+	}
+	for {
+		events, accepted, err := log.read(sequence, streamBatchSize)
+		if err != nil {
+			return logError(err)
+		}
+
+		for _, event := range events {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+		sequence += uint64(len(events))
+		if len(events) > 0 {
+			continue
+		}
+
+		select {
+		case <-accepted:
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// Returns the gRPC status of [err], which was returned by an event log.
+func logError(err error) error {
+	switch {
+	case errors.Is(err, errPruned):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, errLogClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// Ends all subscriptions.
+func (s *EventStreams) shutdown() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	errs := wrappers.Errs{}
+	for chainID, logs := range s.chains {
+		for _, log := range logs {
+			errs.Add(log.stop())
+		}
+		delete(s.chains, chainID)
+	}
+	return errs.Err
+}
+
+// Assumes [s.lock] is held
+func (s *EventStreams) newEventLog(
+	chainID ids.ID,
+	eventType ipcspb.EventType,
+	name string,
+	acceptorGroup snow.AcceptorGroup,
+) (*eventLog, error) {
+	prefix := make([]byte, hashing.HashLen+wrappers.ByteLen)
+	copy(prefix, chainID[:])
+	prefix[hashing.HashLen] = byte(eventType)
+
+	log := &eventLog{
+		chainID:   chainID,
+		eventType: eventType,
+		db:        prefixdb.New(prefix, s.db),
+		maxSize:   s.bufferSize,
+		heightFn: func(container []byte) (uint64, bool, error) {
+			return s.containerHeight(chainID, eventType, container)
+		},
+		accepted: make(chan struct{}),
+	}
+	nextSequence, err := database.GetUInt64(log.db, nextSequenceKey)
+	switch err {
+	case nil:
+		log.nextSequence = nextSequence
+	case database.ErrNotFound:
+		// No events have been logged for this chain.
+	default:
+		return nil, fmt.Errorf("couldn't get next sequence number of %s events of %s: %w", eventType, chainID, err)
+	}
+
+	acceptorName := ipcStreamIdentifierPrefix + "-" + name
+	log.unregisterFn = func() error {
+		return acceptorGroup.DeregisterAcceptor(chainID, acceptorName)
+	}
+	if err := acceptorGroup.RegisterAcceptor(chainID, acceptorName, log, false); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("created IPC event stream",
+		zap.Stringer("blockchainID", chainID),
+		zap.Stringer("eventType", eventType),
+		zap.Uint64("nextSequence", log.nextSequence),
+	)
+	return log, nil
+}
+
+// Returns the height of [container], which was accepted on [chainID] as an
+// event of [eventType], and true. Transactions, and the containers of chains
+// whose VM isn't registered, don't have a height, in which case false is
+// returned.
+func (s *EventStreams) containerHeight(chainID ids.ID, eventType ipcspb.EventType, container []byte) (uint64, bool, error) {
+	s.vmsLock.RLock()
+	vm := s.vms[chainID]
+	s.vmsLock.RUnlock()
+
+	switch vm := vm.(type) {
+	case block.ChainVM:
+		blk, err := vm.ParseBlock(stdcontext.TODO(), container)
+		if err != nil {
+			return 0, false, fmt.Errorf("couldn't parse block: %w", err)
+		}
+		return blk.Height(), true, nil
+	case vertex.DAGVM:
+		if eventType != ipcspb.EventType_EVENT_TYPE_CONSENSUS {
+			// The decisions of DAGs are transactions
+			return 0, false, nil
+		}
+		vtx, err := vertex.Parse(container)
+		if err != nil {
+			return 0, false, fmt.Errorf("couldn't parse vertex: %w", err)
+		}
+		return vtx.Height(), true, nil
+	default:
+		return 0, false, nil
+	}
+}
+
+// eventLog persists the last [maxSize] events of a type accepted on a chain.
+// The first logged event at each height is indexed, so that a subscriber can
+// start from a height.
+type eventLog struct {
+	chainID      ids.ID
+	eventType    ipcspb.EventType
+	db           database.Database
+	maxSize      uint64
+	unregisterFn func() error
+	// Returns the height of an accepted container, and false if it doesn't
+	// have one
+	heightFn func(container []byte) (uint64, bool, error)
+
+	lock sync.RWMutex
+	// Sequence number of the next accepted event
+	nextSequence uint64
+	// Closed, and replaced, when an event is accepted
+	accepted chan struct{}
+	closed   bool
+}
+
+// Accept logs the container as the event at the next sequence number
+func (l *eventLog) Accept(_ *snow.ConsensusContext, containerID ids.ID, container []byte) error {
+	height, hasHeight, err := l.heightFn(container)
+	if err != nil {
+		return fmt.Errorf("couldn't get height of %s event %s of %s: %w", l.eventType, containerID, l.chainID, err)
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.closed {
+		return nil
+	}
+
+	value := make([]byte, wrappers.LongLen+hashing.HashLen+len(container))
+	copy(value, database.PackUInt64(height))
+	copy(value[wrappers.LongLen:], containerID[:])
+	copy(value[wrappers.LongLen+hashing.HashLen:], container)
+
+	batch := l.db.NewBatch()
+	if err := batch.Put(sequenceKey(l.nextSequence), value); err != nil {
+		return err
+	}
+	if hasHeight {
+		// Only the first event at a height is indexed
+		indexed, err := l.db.Has(heightKey(height))
+		if err != nil {
+			return err
+		}
+		if !indexed {
+			if err := batch.Put(heightKey(height), database.PackUInt64(l.nextSequence)); err != nil {
+				return err
+			}
+		}
+	}
+	if l.nextSequence >= l.maxSize {
+		if err := l.prune(batch, l.nextSequence-l.maxSize); err != nil {
+			return err
+		}
+	}
+	if err := database.PutUInt64(batch, nextSequenceKey, l.nextSequence+1); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("couldn't log %s event %s of %s: %w", l.eventType, containerID, l.chainID, err)
+	}
+	l.nextSequence++
+
+	// Wake up anyone waiting for this event
+	close(l.accepted)
+	l.accepted = make(chan struct{})
+	return nil
+}
+
+// Deletes the event at [sequence], and its height index entry, in [batch].
+//
+// Assumes [l.lock] is held
+func (l *eventLog) prune(batch database.Batch, sequence uint64) error {
+	key := sequenceKey(sequence)
+	value, err := l.db.Get(key)
+	if err != nil {
+		return fmt.Errorf("couldn't get event at sequence number %d: %w", sequence, err)
+	}
+	if len(value) < wrappers.LongLen {
+		return fmt.Errorf("malformed event at sequence number %d", sequence)
+	}
+	indexKey := heightKey(binary.BigEndian.Uint64(value))
+	indexedSequence, err := database.GetUInt64(l.db, indexKey)
+	switch {
+	case err == nil && indexedSequence == sequence:
+		if err := batch.Delete(indexKey); err != nil {
+			return err
+		}
+	case err != nil && err != database.ErrNotFound:
+		return err
+	}
+	return batch.Delete(key)
+}
+
+// Returns the sequence number of the first event with a height of at least
+// [height]. If no logged event is at or above [height], the sequence number of
+// the next accepted event is returned. Returns [errPruned] if events below the
+// earliest logged height have been pruned and [height] is among them.
+func (l *eventLog) sequenceAtHeight(height uint64) (uint64, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if l.closed {
+		return 0, errLogClosed
+	}
+
+	prefix := []byte{heightToSequencePrefix}
+	if l.nextSequence > l.maxSize {
+		it := l.db.NewIteratorWithPrefix(prefix)
+		defer it.Release()
+
+		if it.Next() {
+			earliestHeight := binary.BigEndian.Uint64(it.Key()[wrappers.ByteLen:])
+			if height < earliestHeight {
+				return 0, fmt.Errorf("%w: start height (%d) < earliest height (%d)", errPruned, height, earliestHeight)
+			}
+		}
+		if err := it.Error(); err != nil {
+			return 0, err
+		}
+	}
+
+	it := l.db.NewIteratorWithStartAndPrefix(heightKey(height), prefix)
+	defer it.Release()
+
+	if !it.Next() {
+		if err := it.Error(); err != nil {
+			return 0, err
+		}
+		return l.nextSequence, nil
+	}
+	return database.ParseUInt64(it.Value())
+}
+
+// Returns up to [maxEvents] events starting at [startSequence], and a channel
+// that is closed when the next event is accepted.
+func (l *eventLog) read(startSequence, maxEvents uint64) ([]*ipcspb.Event, <-chan struct{}, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if l.closed {
+		return nil, nil, errLogClosed
+	}
+	var earliestSequence uint64
+	if l.nextSequence > l.maxSize {
+		earliestSequence = l.nextSequence - l.maxSize
+	}
+	if startSequence < earliestSequence {
+		return nil, nil, fmt.Errorf("%w: start sequence number (%d) < earliest sequence number (%d)", errPruned, startSequence, earliestSequence)
+	}
+
+	var events []*ipcspb.Event
+	for sequence := startSequence; sequence < l.nextSequence && uint64(len(events)) < maxEvents; sequence++ {
+		value, err := l.db.Get(sequenceKey(sequence))
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't get event at sequence number %d: %w", sequence, err)
+		}
+		if len(value) < wrappers.LongLen+hashing.HashLen {
+			return nil, nil, fmt.Errorf("malformed event at sequence number %d", sequence)
+		}
+		events = append(events, &ipcspb.Event{
+			ChainId:     l.chainID[:],
+			EventType:   l.eventType,
+			Sequence:    sequence,
+			ContainerId: value[wrappers.LongLen : wrappers.LongLen+hashing.HashLen],
+			Container:   value[wrappers.LongLen+hashing.HashLen:],
+			Height:      binary.BigEndian.Uint64(value),
+		})
+	}
+	return events, l.accepted, nil
+}
+
+// Unregisters the log and ends its subscriptions
+func (l *eventLog) stop() error {
+	// [l.lock] isn't held while unregistering because the acceptor group may
+	// be calling Accept.
+	err := l.unregisterFn()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if !l.closed {
+		l.closed = true
+		close(l.accepted)
+	}
+	return err
+}
+
+func sequenceKey(sequence uint64) []byte {
+	key := make([]byte, wrappers.ByteLen+wrappers.LongLen)
+	key[0] = sequenceToEventPrefix
+	copy(key[wrappers.ByteLen:], database.PackUInt64(sequence))
+	return key
+}
+
+func heightKey(height uint64) []byte {
+	key := make([]byte, wrappers.ByteLen+wrappers.LongLen)
+	key[0] = heightToSequencePrefix
+	copy(key[wrappers.ByteLen:], database.PackUInt64(height))
+	return key
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"encoding/binary"
+	"testing"
+
+	stdcontext "context"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/database/memdb"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/snow"
+	"github.com/dioneprotocol/dionego/snow/consensus/snowman"
+	"github.com/dioneprotocol/dionego/snow/engine/snowman/block"
+	"github.com/dioneprotocol/dionego/utils/logging"
+	"github.com/dioneprotocol/dionego/vms/rpcchainvm/grpcutils"
+
+	ipcspb "github.com/dioneprotocol/dionego/proto/pb/ipcs"
+)
+
+func TestEventStreams(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	chainID := ctx.ChainID
+	consensusAcceptorGroup := snow.NewAcceptorGroup(logging.NoLog{})
+	decisionAcceptorGroup := snow.NewAcceptorGroup(logging.NoLog{})
+
+	streams := NewEventStreams(logging.NoLog{}, memdb.New(), 4)
+	cipcs, err := NewChainIPCs(
+		logging.NoLog{},
+		DefaultBaseURL,
+		0,
+		false,
+		streams,
+		consensusAcceptorGroup,
+		decisionAcceptorGroup,
+		[]ids.ID{chainID},
+	)
+	require.NoError(err)
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+	server := grpcutils.NewServer()
+	defer server.Stop()
+	ipcspb.RegisterEventsServer(server, streams)
+	go grpcutils.Serve(listener, server)
+
+	conn, err := grpcutils.Dial(listener.Addr().String())
+	require.NoError(err)
+	defer conn.Close()
+	client := ipcspb.NewEventsClient(conn)
+
+	// The containers are blocks whose bytes are their height. The first block
+	// is at height [firstHeight].
+	const firstHeight = 100
+	streams.RegisterChain("", ctx, &block.TestVM{
+		ParseBlockF: func(_ stdcontext.Context, b []byte) (snowman.Block, error) {
+			return &snowman.TestBlock{HeightV: binary.BigEndian.Uint64(b)}, nil
+		},
+	})
+
+	var containerIDs []ids.ID
+	accept := func() {
+		containerID := ids.GenerateTestID()
+		container := database.PackUInt64(firstHeight + uint64(len(containerIDs)))
+		require.NoError(consensusAcceptorGroup.Accept(ctx, containerID, container))
+		containerIDs = append(containerIDs, containerID)
+	}
+	subscribe := func(startSequence uint64) ipcspb.Events_SubscribeClient {
+		stream, err := client.Subscribe(stdcontext.Background(), &ipcspb.SubscribeRequest{
+			ChainId:       chainID[:],
+			EventType:     ipcspb.EventType_EVENT_TYPE_CONSENSUS,
+			StartSequence: startSequence,
+		})
+		require.NoError(err)
+		return stream
+	}
+	subscribeHeight := func(startHeight uint64) ipcspb.Events_SubscribeClient {
+		stream, err := client.Subscribe(stdcontext.Background(), &ipcspb.SubscribeRequest{
+			ChainId:     chainID[:],
+			EventType:   ipcspb.EventType_EVENT_TYPE_CONSENSUS,
+			StartHeight: &startHeight,
+		})
+		require.NoError(err)
+		return stream
+	}
+	checkNext := func(stream ipcspb.Events_SubscribeClient, expectedSequence uint64) {
+		event, err := stream.Recv()
+		require.NoError(err)
+		require.Equal(expectedSequence, event.Sequence)
+		require.Equal(firstHeight+expectedSequence, event.Height)
+		require.Equal(containerIDs[expectedSequence][:], event.ContainerId)
+		require.Equal(chainID[:], event.ChainId)
+		require.Equal(ipcspb.EventType_EVENT_TYPE_CONSENSUS, event.EventType)
+	}
+
+	for i := 0; i < 3; i++ {
+		accept()
+	}
+
+	// Replay the accepted events, then receive events as they're accepted.
+	stream1 := subscribe(0)
+	stream2 := subscribe(1)
+	for sequence := uint64(0); sequence < 3; sequence++ {
+		checkNext(stream1, sequence)
+	}
+	for sequence := uint64(1); sequence < 3; sequence++ {
+		checkNext(stream2, sequence)
+	}
+	for i := 0; i < 4; i++ {
+		accept()
+	}
+	for sequence := uint64(3); sequence < 7; sequence++ {
+		checkNext(stream1, sequence)
+		checkNext(stream2, sequence)
+	}
+
+	// Only the last 4 events can be replayed.
+	_, err = subscribe(2).Recv()
+	require.Equal(codes.OutOfRange, status.Code(err))
+	checkNext(subscribe(3), 3)
+
+	// Subscribers can start from a height, unless it was pruned.
+	_, err = subscribeHeight(firstHeight + 2).Recv()
+	require.Equal(codes.OutOfRange, status.Code(err))
+	checkNext(subscribeHeight(firstHeight+3), 3)
+	checkNext(subscribeHeight(firstHeight+5), 5)
+
+	// Chains that aren't published can't be subscribed to.
+	unpublishedChainID := ids.GenerateTestID()
+	stream, err := client.Subscribe(stdcontext.Background(), &ipcspb.SubscribeRequest{
+		ChainId:   unpublishedChainID[:],
+		EventType: ipcspb.EventType_EVENT_TYPE_CONSENSUS,
+	})
+	require.NoError(err)
+	_, err = stream.Recv()
+	require.Equal(codes.NotFound, status.Code(err))
+
+	// Unpublishing the chain ends its subscriptions.
+	published, err := cipcs.Unpublish(chainID)
+	require.NoError(err)
+	require.True(published)
+	_, err = stream1.Recv()
+	require.Equal(codes.Unavailable, status.Code(err))
+
+	// Events are replayed after the chain is published again.
+	_, err = cipcs.Publish(chainID)
+	require.NoError(err)
+	futureStream := subscribeHeight(firstHeight + 7)
+	accept()
+	checkNext(futureStream, 7)
+	stream = subscribe(4)
+	for sequence := uint64(4); sequence < 8; sequence++ {
+		checkNext(stream, sequence)
+	}
+	require.NoError(cipcs.Shutdown())
+}
@@ -10,12 +10,21 @@ import (
 
 	"github.com/spf13/pflag"
 
+	"github.com/dioneprotocol/dionego/app/dbcmd"
 	"github.com/dioneprotocol/dionego/app/runner"
 	"github.com/dioneprotocol/dionego/config"
 	"github.com/dioneprotocol/dionego/version"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == dbcmd.Name {
+		if err := dbcmd.Run(os.Args[2:], os.Stdout); err != nil {
+			fmt.Printf("%s command failed: %s\n", dbcmd.Name, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	fs := config.BuildFlagSet()
 	v, err := config.BuildViper(fs, os.Args[1:])
 
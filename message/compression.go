@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"github.com/dioneprotocol/dionego/proto/pb/p2p"
+	"github.com/dioneprotocol/dionego/utils/compression"
+	"github.com/dioneprotocol/dionego/utils/set"
+)
+
+var (
+	// Compression type --> Value of a version message field that advertises it
+	compressionToProto = map[compression.Type]p2p.Compression{
+		compression.TypeGzip: p2p.Compression_COMPRESSION_GZIP,
+		compression.TypeZstd: p2p.Compression_COMPRESSION_ZSTD,
+	}
+	protoToCompression = map[p2p.Compression]compression.Type{
+		p2p.Compression_COMPRESSION_GZIP: compression.TypeGzip,
+		p2p.Compression_COMPRESSION_ZSTD: compression.TypeZstd,
+	}
+
+	// Compression types that this node can decompress, as advertised in its
+	// version messages
+	supportedCompression = func() []p2p.Compression {
+		supported := make([]p2p.Compression, len(compression.Types))
+		for i, compressionType := range compression.Types {
+			supported[i] = compressionToProto[compressionType]
+		}
+		return supported
+	}()
+)
+
+// SupportedCompression returns the compression types that the sender of
+// [msg] can decompress. Senders that don't advertise any compression types
+// predate compression negotiation, and can only decompress gzip.
+func SupportedCompression(msg *p2p.Version) set.Set[compression.Type] {
+	supported := set.Set[compression.Type]{}
+	for _, protoType := range msg.SupportedCompression {
+		// Types this node doesn't know about are ignored
+		if compressionType, ok := protoToCompression[protoType]; ok {
+			supported.Add(compressionType)
+		}
+	}
+	if len(msg.SupportedCompression) == 0 {
+		supported.Add(compression.TypeGzip)
+	}
+	return supported
+}
+
+// NegotiateCompression returns the compression type to compress messages sent
+// to a peer that can decompress [supported] with. If the peer can decompress
+// [preferred], it's used. Otherwise, the most preferred type in
+// compression.Types that the peer can decompress is used. If [preferred] is
+// compression.TypeNone, messages aren't compressed.
+func NegotiateCompression(preferred compression.Type, supported set.Set[compression.Type]) compression.Type {
+	if preferred == compression.TypeNone || supported.Contains(preferred) {
+		return preferred
+	}
+	for _, compressionType := range compression.Types {
+		if supported.Contains(compressionType) {
+			return compressionType
+		}
+	}
+	return compression.TypeNone
+}
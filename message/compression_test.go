@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/proto/pb/p2p"
+	"github.com/dioneprotocol/dionego/utils/compression"
+	"github.com/dioneprotocol/dionego/utils/set"
+)
+
+func TestSupportedCompression(t *testing.T) {
+	tests := []struct {
+		name      string
+		msg       *p2p.Version
+		supported set.Set[compression.Type]
+	}{
+		{
+			name:      "nothing advertised",
+			msg:       &p2p.Version{},
+			supported: set.Set[compression.Type]{compression.TypeGzip: struct{}{}},
+		},
+		{
+			name: "zstd advertised",
+			msg: &p2p.Version{
+				SupportedCompression: []p2p.Compression{p2p.Compression_COMPRESSION_ZSTD},
+			},
+			supported: set.Set[compression.Type]{compression.TypeZstd: struct{}{}},
+		},
+		{
+			name: "unknown type advertised",
+			msg: &p2p.Version{
+				SupportedCompression: []p2p.Compression{
+					p2p.Compression_COMPRESSION_GZIP,
+					p2p.Compression(100),
+				},
+			},
+			supported: set.Set[compression.Type]{compression.TypeGzip: struct{}{}},
+		},
+		{
+			name: "all advertised",
+			msg: &p2p.Version{
+				SupportedCompression: supportedCompression,
+			},
+			supported: set.Set[compression.Type]{
+				compression.TypeGzip: struct{}{},
+				compression.TypeZstd: struct{}{},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.supported, SupportedCompression(test.msg))
+		})
+	}
+}
+
+func TestNegotiateCompression(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred compression.Type
+		supported set.Set[compression.Type]
+		expected  compression.Type
+	}{
+		{
+			name:      "preferred supported",
+			preferred: compression.TypeGzip,
+			supported: set.Set[compression.Type]{
+				compression.TypeGzip: struct{}{},
+				compression.TypeZstd: struct{}{},
+			},
+			expected: compression.TypeGzip,
+		},
+		{
+			name:      "preferred not supported",
+			preferred: compression.TypeZstd,
+			supported: set.Set[compression.Type]{compression.TypeGzip: struct{}{}},
+			expected:  compression.TypeGzip,
+		},
+		{
+			name:      "compression disabled",
+			preferred: compression.TypeNone,
+			supported: set.Set[compression.Type]{compression.TypeZstd: struct{}{}},
+			expected:  compression.TypeNone,
+		},
+		{
+			name:      "nothing supported",
+			preferred: compression.TypeZstd,
+			supported: set.Set[compression.Type]{},
+			expected:  compression.TypeNone,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, NegotiateCompression(test.preferred, test.supported))
+		})
+	}
+}
@@ -4,7 +4,9 @@
 package message
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -24,6 +26,8 @@ import (
 var (
 	_ InboundMessage  = (*inboundMessage)(nil)
 	_ OutboundMessage = (*outboundMessage)(nil)
+
+	errUnknownCompressionType = errors.New("unknown compression type")
 )
 
 // InboundMessage represents a set of fields for an inbound message
@@ -93,6 +97,13 @@ type OutboundMessage interface {
 	// BytesSavedCompression returns the number of bytes that this message saved
 	// due to being compressed
 	BytesSavedCompression() int
+	// Compression returns the compression type of the bytes returned by Bytes
+	Compression() compression.Type
+	// BytesCompressedWith returns the bytes of this message compressed with
+	// [compressionType], to be sent to peers that can't decompress the bytes
+	// returned by Bytes. If this message isn't compressed, returns the bytes
+	// returned by Bytes.
+	BytesCompressedWith(compressionType compression.Type) ([]byte, error)
 }
 
 type outboundMessage struct {
@@ -100,6 +111,15 @@ type outboundMessage struct {
 	op                    Op
 	bytes                 []byte
 	bytesSavedCompression int
+	compressionType       compression.Type
+
+	// Used to compress this message with other compression types
+	builder *msgBuilder
+	msg     *p2p.Message
+
+	lock sync.Mutex
+	// Compression type --> Bytes of this message compressed with the type
+	compressedBytes map[compression.Type][]byte
 }
 
 func (m *outboundMessage) BypassThrottling() bool {
@@ -118,36 +138,119 @@ func (m *outboundMessage) BytesSavedCompression() int {
 	return m.bytesSavedCompression
 }
 
-// TODO: add other compression algorithms with extended interface
+func (m *outboundMessage) Compression() compression.Type {
+	return m.compressionType
+}
+
+func (m *outboundMessage) BytesCompressedWith(compressionType compression.Type) ([]byte, error) {
+	if compressionType == m.compressionType || m.compressionType == compression.TypeNone {
+		return m.bytes, nil
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if b, ok := m.compressedBytes[compressionType]; ok {
+		return b, nil
+	}
+	b, _, compressTook, err := m.builder.marshal(m.msg, compressionType)
+	if err != nil {
+		return nil, err
+	}
+	if compressionType != compression.TypeNone {
+		m.builder.compressTimeMetrics[m.op].Observe(float64(compressTook))
+	}
+	if m.compressedBytes == nil {
+		m.compressedBytes = make(map[compression.Type][]byte)
+	}
+	m.compressedBytes[compressionType] = b
+	return b, nil
+}
+
 type msgBuilder struct {
-	gzipCompressor compression.Compressor
+	// Compression type --> Compressor of the type
+	compressors map[compression.Type]compression.Compressor
 
 	compressTimeMetrics   map[Op]metric.Averager
 	decompressTimeMetrics map[Op]metric.Averager
+	// Compression type --> Metrics of the type
+	compressionMetrics map[compression.Type]*compressionMetrics
 
 	maxMessageTimeout time.Duration
 }
 
+// compressionMetrics compare the compression types by the time they take and
+// the ratio of compressed bytes to uncompressed bytes
+type compressionMetrics struct {
+	compressTime      metric.Averager
+	decompressTime    metric.Averager
+	uncompressedBytes prometheus.Counter
+	compressedBytes   prometheus.Counter
+}
+
+func newCompressionMetrics(
+	namespace string,
+	compressionType compression.Type,
+	metrics prometheus.Registerer,
+	errs *wrappers.Errs,
+) *compressionMetrics {
+	m := &compressionMetrics{
+		compressTime: metric.NewAveragerWithErrs(
+			namespace,
+			fmt.Sprintf("%s_compress_time", compressionType),
+			fmt.Sprintf("time (in ns) to compress messages with %s", compressionType),
+			metrics,
+			errs,
+		),
+		decompressTime: metric.NewAveragerWithErrs(
+			namespace,
+			fmt.Sprintf("%s_decompress_time", compressionType),
+			fmt.Sprintf("time (in ns) to decompress messages with %s", compressionType),
+			metrics,
+			errs,
+		),
+		uncompressedBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_uncompressed_bytes", compressionType),
+			Help:      fmt.Sprintf("number of bytes of messages before being compressed, or after being decompressed, with %s", compressionType),
+		}),
+		compressedBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_compressed_bytes", compressionType),
+			Help:      fmt.Sprintf("number of bytes of messages after being compressed, or before being decompressed, with %s", compressionType),
+		}),
+	}
+	errs.Add(
+		metrics.Register(m.uncompressedBytes),
+		metrics.Register(m.compressedBytes),
+	)
+	return m
+}
+
 func newMsgBuilder(
 	namespace string,
 	metrics prometheus.Registerer,
 	maxMessageTimeout time.Duration,
 ) (*msgBuilder, error) {
-	cpr, err := compression.NewGzipCompressor(constants.DefaultMaxMessageSize)
-	if err != nil {
-		return nil, err
-	}
-
 	mb := &msgBuilder{
-		gzipCompressor: cpr,
+		compressors: make(map[compression.Type]compression.Compressor, len(compression.Types)),
 
 		compressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
 		decompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
+		compressionMetrics:    make(map[compression.Type]*compressionMetrics, len(compression.Types)),
 
 		maxMessageTimeout: maxMessageTimeout,
 	}
 
 	errs := wrappers.Errs{}
+	for _, compressionType := range compression.Types {
+		compressor, err := compression.NewCompressor(compressionType, constants.DefaultMaxMessageSize)
+		if err != nil {
+			return nil, err
+		}
+		mb.compressors[compressionType] = compressor
+		mb.compressionMetrics[compressionType] = newCompressionMetrics(namespace, compressionType, metrics, &errs)
+	}
 	for _, op := range ExternalOps {
 		mb.compressTimeMetrics[op] = metric.NewAveragerWithErrs(
 			namespace,
@@ -169,17 +272,22 @@ func newMsgBuilder(
 
 func (mb *msgBuilder) marshal(
 	uncompressedMsg *p2p.Message,
-	gzipCompress bool,
+	compressionType compression.Type,
 ) ([]byte, int, time.Duration, error) {
 	uncompressedMsgBytes, err := proto.Marshal(uncompressedMsg)
 	if err != nil {
 		return nil, 0, 0, err
 	}
 
-	if !gzipCompress {
+	if compressionType == compression.TypeNone {
 		return uncompressedMsgBytes, 0, 0, nil
 	}
 
+	compressor, ok := mb.compressors[compressionType]
+	if !ok {
+		return nil, 0, 0, fmt.Errorf("%w: %s", errUnknownCompressionType, compressionType)
+	}
+
 	// If compression is enabled, we marshal twice:
 	// 1. the original message
 	// 2. the message with compressed bytes
@@ -187,15 +295,21 @@ func (mb *msgBuilder) marshal(
 	// This recursive packing allows us to avoid an extra compression on/off
 	// field in the message.
 	startTime := time.Now()
-	compressedBytes, err := mb.gzipCompressor.Compress(uncompressedMsgBytes)
+	compressedBytes, err := compressor.Compress(uncompressedMsgBytes)
 	if err != nil {
 		return nil, 0, 0, err
 	}
 
-	compressedMsg := p2p.Message{
-		Message: &p2p.Message_CompressedGzip{
+	var compressedMsg p2p.Message
+	switch compressionType {
+	case compression.TypeGzip:
+		compressedMsg.Message = &p2p.Message_CompressedGzip{
 			CompressedGzip: compressedBytes,
-		},
+		}
+	case compression.TypeZstd:
+		compressedMsg.Message = &p2p.Message_CompressedZstd{
+			CompressedZstd: compressedBytes,
+		}
 	}
 	compressedMsgBytes, err := proto.Marshal(&compressedMsg)
 	if err != nil {
@@ -203,6 +317,11 @@ func (mb *msgBuilder) marshal(
 	}
 	compressTook := time.Since(startTime)
 
+	metrics := mb.compressionMetrics[compressionType]
+	metrics.compressTime.Observe(float64(compressTook))
+	metrics.uncompressedBytes.Add(float64(len(uncompressedMsgBytes)))
+	metrics.compressedBytes.Add(float64(len(compressedMsgBytes)))
+
 	bytesSaved := len(uncompressedMsgBytes) - len(compressedMsgBytes)
 	return compressedMsgBytes, bytesSaved, compressTook, nil
 }
@@ -213,14 +332,25 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, bool, int, time.Duratio
 		return nil, false, 0, 0, err
 	}
 
-	compressed := m.GetCompressedGzip()
+	var (
+		compressionType compression.Type
+		compressed      []byte
+	)
+	switch msg := m.GetMessage().(type) {
+	case *p2p.Message_CompressedGzip:
+		compressionType = compression.TypeGzip
+		compressed = msg.CompressedGzip
+	case *p2p.Message_CompressedZstd:
+		compressionType = compression.TypeZstd
+		compressed = msg.CompressedZstd
+	}
 	if len(compressed) == 0 {
 		// The message wasn't compressed
 		return m, false, 0, 0, nil
 	}
 
 	startTime := time.Now()
-	decompressed, err := mb.gzipCompressor.Decompress(compressed)
+	decompressed, err := mb.compressors[compressionType].Decompress(compressed)
 	if err != nil {
 		return nil, true, 0, 0, err
 	}
@@ -230,12 +360,17 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, bool, int, time.Duratio
 	}
 	decompressTook := time.Since(startTime)
 
+	metrics := mb.compressionMetrics[compressionType]
+	metrics.decompressTime.Observe(float64(decompressTook))
+	metrics.uncompressedBytes.Add(float64(len(decompressed)))
+	metrics.compressedBytes.Add(float64(len(b)))
+
 	bytesSavedCompression := len(decompressed) - len(compressed)
 	return m, true, bytesSavedCompression, decompressTook, nil
 }
 
-func (mb *msgBuilder) createOutbound(m *p2p.Message, gzipCompress bool, bypassThrottling bool) (*outboundMessage, error) {
-	b, saved, compressTook, err := mb.marshal(m, gzipCompress)
+func (mb *msgBuilder) createOutbound(m *p2p.Message, compressionType compression.Type, bypassThrottling bool) (*outboundMessage, error) {
+	b, saved, compressTook, err := mb.marshal(m, compressionType)
 	if err != nil {
 		return nil, err
 	}
@@ -245,7 +380,7 @@ func (mb *msgBuilder) createOutbound(m *p2p.Message, gzipCompress bool, bypassTh
 		return nil, err
 	}
 
-	if gzipCompress {
+	if compressionType != compression.TypeNone {
 		mb.compressTimeMetrics[op].Observe(float64(compressTook))
 	}
 
@@ -254,6 +389,9 @@ func (mb *msgBuilder) createOutbound(m *p2p.Message, gzipCompress bool, bypassTh
 		op:                    op,
 		bytes:                 b,
 		bytesSavedCompression: saved,
+		compressionType:       compressionType,
+		builder:               mb,
+		msg:                   m,
 	}, nil
 }
 
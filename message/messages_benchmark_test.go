@@ -17,6 +17,7 @@ import (
 
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/proto/pb/p2p"
+	"github.com/dioneprotocol/dionego/utils/compression"
 )
 
 var (
@@ -70,7 +71,7 @@ func BenchmarkMarshalVersion(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		if useBuilder {
-			_, err = codec.createOutbound(&msg, false, false)
+			_, err = codec.createOutbound(&msg, compression.TypeNone, false)
 		} else {
 			_, err = proto.Marshal(&msg)
 		}
@@ -18,6 +18,7 @@ import (
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/proto/pb/p2p"
 	"github.com/dioneprotocol/dionego/staking"
+	"github.com/dioneprotocol/dionego/utils/compression"
 )
 
 func TestMessage(t *testing.T) {
@@ -51,7 +52,7 @@ func TestMessage(t *testing.T) {
 		desc             string
 		op               Op
 		msg              *p2p.Message
-		gzipCompress     bool
+		compressionType  compression.Type
 		bypassThrottling bool
 		bytesSaved       bool // if true, outbound message saved bytes must be non-zero
 	}{
@@ -63,7 +64,7 @@ func TestMessage(t *testing.T) {
 					Ping: &p2p.Ping{},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -77,7 +78,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -97,7 +98,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -118,7 +119,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -140,7 +141,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -162,7 +163,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -181,7 +182,24 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
+			bypassThrottling: false,
+			bytesSaved:       false,
+		},
+		{
+			desc: "get_peer_list message with no compression",
+			op:   GetPeerListOp,
+			msg: &p2p.Message{
+				Message: &p2p.Message_GetPeerList_{
+					GetPeerList_: &p2p.GetPeerList{
+						KnownPeers: &p2p.BloomFilter{
+							Filter: []byte{1, 0},
+							Salt:   testID[:],
+						},
+					},
+				},
+			},
+			compressionType:  compression.TypeNone,
 			bypassThrottling: false,
 			bytesSaved:       false,
 		},
@@ -197,7 +215,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -213,7 +231,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -229,7 +247,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -246,7 +264,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -263,7 +281,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -279,7 +297,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -295,7 +313,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -312,7 +330,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -329,7 +347,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -347,7 +365,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -364,7 +382,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -382,7 +400,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -399,7 +417,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -416,7 +434,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -434,7 +452,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -451,7 +469,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -468,7 +486,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -486,7 +504,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -504,7 +522,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -522,7 +540,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -539,7 +557,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -556,7 +574,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -573,7 +591,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -589,7 +607,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -605,7 +623,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -620,7 +638,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -635,7 +653,22 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
+			bypassThrottling: true,
+			bytesSaved:       true,
+		},
+		{
+			desc: "app_gossip message with zstd compression",
+			op:   AppGossipOp,
+			msg: &p2p.Message{
+				Message: &p2p.Message_AppGossip{
+					AppGossip: &p2p.AppGossip{
+						ChainId:  testID[:],
+						AppBytes: compressibleContainers[0],
+					},
+				},
+			},
+			compressionType:  compression.TypeZstd,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -643,7 +676,7 @@ func TestMessage(t *testing.T) {
 
 	for _, tv := range tests {
 		require.True(t.Run(tv.desc, func(t2 *testing.T) {
-			encodedMsg, err := mb.createOutbound(tv.msg, tv.gzipCompress, tv.bypassThrottling)
+			encodedMsg, err := mb.createOutbound(tv.msg, tv.compressionType, tv.bypassThrottling)
 			require.NoError(err)
 
 			require.Equal(tv.bypassThrottling, encodedMsg.BypassThrottling())
@@ -659,6 +692,63 @@ func TestMessage(t *testing.T) {
 	}
 }
 
+func TestBytesCompressedWith(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+
+	mb, err := newMsgBuilder(
+		"test",
+		prometheus.NewRegistry(),
+		5*time.Second,
+	)
+	require.NoError(err)
+
+	testID := ids.GenerateTestID()
+	msg := &p2p.Message{
+		Message: &p2p.Message_AppGossip{
+			AppGossip: &p2p.AppGossip{
+				ChainId:  testID[:],
+				AppBytes: bytes.Repeat([]byte{0}, 100),
+			},
+		},
+	}
+
+	encodedMsg, err := mb.createOutbound(msg, compression.TypeZstd, false)
+	require.NoError(err)
+	require.Equal(compression.TypeZstd, encodedMsg.Compression())
+
+	zstdBytes, err := encodedMsg.BytesCompressedWith(compression.TypeZstd)
+	require.NoError(err)
+	require.Equal(encodedMsg.Bytes(), zstdBytes)
+
+	for _, compressionType := range []compression.Type{
+		compression.TypeGzip,
+		compression.TypeNone,
+	} {
+		msgBytes, err := encodedMsg.BytesCompressedWith(compressionType)
+		require.NoError(err)
+		require.NotEqual(zstdBytes, msgBytes)
+
+		// The bytes are only compressed once per compression type
+		cachedBytes, err := encodedMsg.BytesCompressedWith(compressionType)
+		require.NoError(err)
+		require.Equal(msgBytes, cachedBytes)
+
+		parsedMsg, err := mb.parseInbound(msgBytes, ids.EmptyNodeID, func() {})
+		require.NoError(err)
+		require.Equal(AppGossipOp, parsedMsg.Op())
+		require.Equal(msg.GetAppGossip().AppBytes, parsedMsg.Message().(*p2p.AppGossip).AppBytes)
+	}
+
+	// Messages that aren't compressed are sent uncompressed to every peer
+	encodedMsg, err = mb.createOutbound(msg, compression.TypeNone, false)
+	require.NoError(err)
+	msgBytes, err := encodedMsg.BytesCompressedWith(compression.TypeZstd)
+	require.NoError(err)
+	require.Equal(encodedMsg.Bytes(), msgBytes)
+}
+
 func TestEmptyInboundMessage(t *testing.T) {
 	t.Parallel()
 
@@ -10,6 +10,7 @@ package message
 import (
 	reflect "reflect"
 
+	compression "github.com/dioneprotocol/dionego/utils/compression"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -64,6 +65,21 @@ func (mr *MockOutboundMessageMockRecorder) Bytes() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bytes", reflect.TypeOf((*MockOutboundMessage)(nil).Bytes))
 }
 
+// BytesCompressedWith mocks base method.
+func (m *MockOutboundMessage) BytesCompressedWith(arg0 compression.Type) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BytesCompressedWith", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BytesCompressedWith indicates an expected call of BytesCompressedWith.
+func (mr *MockOutboundMessageMockRecorder) BytesCompressedWith(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BytesCompressedWith", reflect.TypeOf((*MockOutboundMessage)(nil).BytesCompressedWith), arg0)
+}
+
 // BytesSavedCompression mocks base method.
 func (m *MockOutboundMessage) BytesSavedCompression() int {
 	m.ctrl.T.Helper()
@@ -78,6 +94,20 @@ func (mr *MockOutboundMessageMockRecorder) BytesSavedCompression() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BytesSavedCompression", reflect.TypeOf((*MockOutboundMessage)(nil).BytesSavedCompression))
 }
 
+// Compression mocks base method.
+func (m *MockOutboundMessage) Compression() compression.Type {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compression")
+	ret0, _ := ret[0].(compression.Type)
+	return ret0
+}
+
+// Compression indicates an expected call of Compression.
+func (mr *MockOutboundMessageMockRecorder) Compression() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compression", reflect.TypeOf((*MockOutboundMessage)(nil).Compression))
+}
+
 // Op mocks base method.
 func (m *MockOutboundMessage) Op() Op {
 	m.ctrl.T.Helper()
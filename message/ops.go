@@ -24,6 +24,7 @@ const (
 	VersionOp
 	PeerListOp
 	PeerListAckOp
+	GetPeerListOp
 	// State sync:
 	GetStateSummaryFrontierOp
 	GetStateSummaryFrontierFailedOp
@@ -74,6 +75,7 @@ var (
 		VersionOp,
 		PeerListOp,
 		PeerListAckOp,
+		GetPeerListOp,
 	}
 
 	// List of all consensus request message types
@@ -215,6 +217,8 @@ func (op Op) String() string {
 		return "peerlist"
 	case PeerListAckOp:
 		return "peerlist_ack"
+	case GetPeerListOp:
+		return "get_peer_list"
 	// State sync
 	case GetStateSummaryFrontierOp:
 		return "get_state_summary_frontier"
@@ -309,6 +313,8 @@ func Unwrap(m *p2p.Message) (interface{}, error) {
 		return msg.PeerList, nil
 	case *p2p.Message_PeerListAck:
 		return msg.PeerListAck, nil
+	case *p2p.Message_GetPeerList_:
+		return msg.GetPeerList_, nil
 	// State sync:
 	case *p2p.Message_GetStateSummaryFrontier:
 		return msg.GetStateSummaryFrontier, nil
@@ -366,6 +372,8 @@ func ToOp(m *p2p.Message) (Op, error) {
 		return PeerListOp, nil
 	case *p2p.Message_PeerListAck:
 		return PeerListAckOp, nil
+	case *p2p.Message_GetPeerList_:
+		return GetPeerListOp, nil
 	case *p2p.Message_GetStateSummaryFrontier:
 		return GetStateSummaryFrontierOp, nil
 	case *p2p.Message_StateSummaryFrontier_:
@@ -0,0 +1,340 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package banlist
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/database/prefixdb"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/utils/logging"
+	"github.com/dioneprotocol/dionego/utils/timer/mockable"
+)
+
+var (
+	_ List = (*banList)(nil)
+
+	nodePrefix   = []byte{0x00}
+	subnetPrefix = []byte{0x01}
+
+	errNotBanned = errors.New("not banned")
+)
+
+// Ban prevents this node from connecting to a peer, or to any peer in a range
+// of IPs
+type Ban struct {
+	// Exactly one of NodeID and Subnet is set
+	NodeID *ids.NodeID
+	Subnet *net.IPNet
+
+	Reason string
+	// Expiry is the time the ban is lifted. Zero if the ban never expires.
+	Expiry time.Time
+}
+
+// List tracks the peers that this node refuses to connect to. Bans are
+// persisted as soon as they are added or removed.
+type List interface {
+	// BanNode bans [nodeID] for [duration]. If [duration] is 0, the ban never
+	// expires. Replaces any existing ban of [nodeID].
+	BanNode(nodeID ids.NodeID, reason string, duration time.Duration) error
+
+	// UnbanNode lifts the ban of [nodeID]
+	UnbanNode(nodeID ids.NodeID) error
+
+	// BanSubnet bans all the IPs in [subnet] for [duration]. If [duration] is
+	// 0, the ban never expires. Replaces any existing ban of [subnet].
+	BanSubnet(subnet *net.IPNet, reason string, duration time.Duration) error
+
+	// UnbanSubnet lifts the ban of [subnet]
+	UnbanSubnet(subnet *net.IPNet) error
+
+	// IsNodeBanned returns true if [nodeID] is currently banned
+	IsNodeBanned(nodeID ids.NodeID) bool
+
+	// IsIPBanned returns true if [ip] is in a currently banned subnet
+	IsIPBanned(ip net.IP) bool
+
+	// Bans returns all the bans that haven't expired
+	Bans() []Ban
+}
+
+type banList struct {
+	log      logging.Logger
+	nodeDB   database.Database
+	subnetDB database.Database
+	clock    mockable.Clock
+
+	lock sync.RWMutex
+	// Node ID --> Ban of the node
+	nodes map[ids.NodeID]*Ban
+	// Subnet in CIDR notation --> Ban of the subnet
+	subnets map[string]*Ban
+}
+
+// New returns a List that stores its bans in [db]. The bans already in [db]
+// are loaded into memory, and the expired ones are removed.
+func New(log logging.Logger, db database.Database) (List, error) {
+	b := &banList{
+		log:      log,
+		nodeDB:   prefixdb.New(nodePrefix, db),
+		subnetDB: prefixdb.New(subnetPrefix, db),
+		nodes:    make(map[ids.NodeID]*Ban),
+		subnets:  make(map[string]*Ban),
+	}
+	if err := b.loadNodes(); err != nil {
+		return nil, err
+	}
+	if err := b.loadSubnets(); err != nil {
+		return nil, err
+	}
+	return b, b.removeExpired()
+}
+
+func (b *banList) BanNode(nodeID ids.NodeID, reason string, duration time.Duration) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	ban := b.newBan(reason, duration)
+	ban.NodeID = &nodeID
+	if err := putBan(b.nodeDB, nodeID[:], ban); err != nil {
+		return err
+	}
+	b.nodes[nodeID] = ban
+
+	b.log.Info("banned node",
+		zap.Stringer("nodeID", nodeID),
+		zap.String("reason", reason),
+		zap.Duration("duration", duration),
+	)
+	return b.removeExpired()
+}
+
+func (b *banList) UnbanNode(nodeID ids.NodeID) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.nodes[nodeID]; !ok {
+		return errNotBanned
+	}
+	if err := b.nodeDB.Delete(nodeID[:]); err != nil {
+		return err
+	}
+	delete(b.nodes, nodeID)
+
+	b.log.Info("unbanned node",
+		zap.Stringer("nodeID", nodeID),
+	)
+	return nil
+}
+
+func (b *banList) BanSubnet(subnet *net.IPNet, reason string, duration time.Duration) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	subnet = normalize(subnet)
+	ban := b.newBan(reason, duration)
+	ban.Subnet = subnet
+	key := subnet.String()
+	if err := putBan(b.subnetDB, []byte(key), ban); err != nil {
+		return err
+	}
+	b.subnets[key] = ban
+
+	b.log.Info("banned subnet",
+		zap.String("subnet", key),
+		zap.String("reason", reason),
+		zap.Duration("duration", duration),
+	)
+	return b.removeExpired()
+}
+
+func (b *banList) UnbanSubnet(subnet *net.IPNet) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	key := normalize(subnet).String()
+	if _, ok := b.subnets[key]; !ok {
+		return errNotBanned
+	}
+	if err := b.subnetDB.Delete([]byte(key)); err != nil {
+		return err
+	}
+	delete(b.subnets, key)
+
+	b.log.Info("unbanned subnet",
+		zap.String("subnet", key),
+	)
+	return nil
+}
+
+func (b *banList) IsNodeBanned(nodeID ids.NodeID) bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	ban, ok := b.nodes[nodeID]
+	return ok && !b.expired(ban)
+}
+
+func (b *banList) IsIPBanned(ip net.IP) bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	for _, ban := range b.subnets {
+		if ban.Subnet.Contains(ip) && !b.expired(ban) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *banList) Bans() []Ban {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	bans := make([]Ban, 0, len(b.nodes)+len(b.subnets))
+	for _, ban := range b.nodes {
+		if !b.expired(ban) {
+			bans = append(bans, *ban)
+		}
+	}
+	for _, ban := range b.subnets {
+		if !b.expired(ban) {
+			bans = append(bans, *ban)
+		}
+	}
+	return bans
+}
+
+func (b *banList) newBan(reason string, duration time.Duration) *Ban {
+	ban := &Ban{
+		Reason: reason,
+	}
+	if duration > 0 {
+		ban.Expiry = b.clock.Time().Add(duration)
+	}
+	return ban
+}
+
+func (b *banList) expired(ban *Ban) bool {
+	return !ban.Expiry.IsZero() && !b.clock.Time().Before(ban.Expiry)
+}
+
+// removeExpired deletes the bans that have expired.
+// Assumes [b.lock] is held.
+func (b *banList) removeExpired() error {
+	for nodeID, ban := range b.nodes {
+		if !b.expired(ban) {
+			continue
+		}
+		if err := b.nodeDB.Delete(nodeID[:]); err != nil {
+			return err
+		}
+		delete(b.nodes, nodeID)
+	}
+	for key, ban := range b.subnets {
+		if !b.expired(ban) {
+			continue
+		}
+		if err := b.subnetDB.Delete([]byte(key)); err != nil {
+			return err
+		}
+		delete(b.subnets, key)
+	}
+	return nil
+}
+
+func (b *banList) loadNodes() error {
+	it := b.nodeDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		nodeID, err := ids.ToNodeID(it.Key())
+		if err != nil {
+			return err
+		}
+		ban, err := unmarshalBan(it.Value())
+		if err != nil {
+			return err
+		}
+		ban.NodeID = &nodeID
+		b.nodes[nodeID] = ban
+	}
+	return it.Error()
+}
+
+func (b *banList) loadSubnets() error {
+	it := b.subnetDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		_, subnet, err := net.ParseCIDR(string(it.Key()))
+		if err != nil {
+			return err
+		}
+		ban, err := unmarshalBan(it.Value())
+		if err != nil {
+			return err
+		}
+		ban.Subnet = subnet
+		b.subnets[subnet.String()] = ban
+	}
+	return it.Error()
+}
+
+// normalize masks the IP of [subnet], so that every subnet is stored under
+// the same key regardless of which of its IPs it was specified with.
+func normalize(subnet *net.IPNet) *net.IPNet {
+	return &net.IPNet{
+		IP:   subnet.IP.Mask(subnet.Mask),
+		Mask: subnet.Mask,
+	}
+}
+
+func putBan(db database.KeyValueWriter, key []byte, ban *Ban) error {
+	bytes, err := marshalBan(ban)
+	if err != nil {
+		return err
+	}
+	return db.Put(key, bytes)
+}
+
+// diskBan is the format a Ban is stored in. The banned node or subnet is the
+// key the ban is stored under.
+type diskBan struct {
+	Reason string `serialize:"true"`
+	// Unix time the ban expires at. Zero if the ban never expires.
+	Expiry int64 `serialize:"true"`
+}
+
+func marshalBan(ban *Ban) ([]byte, error) {
+	d := diskBan{
+		Reason: ban.Reason,
+	}
+	if !ban.Expiry.IsZero() {
+		d.Expiry = ban.Expiry.Unix()
+	}
+	return c.Marshal(codecVersion, &d)
+}
+
+func unmarshalBan(b []byte) (*Ban, error) {
+	d := diskBan{}
+	if _, err := c.Unmarshal(b, &d); err != nil {
+		return nil, err
+	}
+
+	ban := &Ban{
+		Reason: d.Reason,
+	}
+	if d.Expiry != 0 {
+		ban.Expiry = time.Unix(d.Expiry, 0)
+	}
+	return ban, nil
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package banlist
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/database/memdb"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/utils/logging"
+)
+
+func TestBanNode(t *testing.T) {
+	require := require.New(t)
+
+	bans, err := New(logging.NoLog{}, memdb.New())
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	require.False(bans.IsNodeBanned(nodeID))
+	require.ErrorIs(bans.UnbanNode(nodeID), errNotBanned)
+
+	require.NoError(bans.BanNode(nodeID, "test", 0))
+	require.True(bans.IsNodeBanned(nodeID))
+	require.False(bans.IsNodeBanned(ids.GenerateTestNodeID()))
+
+	require.NoError(bans.UnbanNode(nodeID))
+	require.False(bans.IsNodeBanned(nodeID))
+	require.Empty(bans.Bans())
+}
+
+func TestBanSubnet(t *testing.T) {
+	require := require.New(t)
+
+	bans, err := New(logging.NoLog{}, memdb.New())
+	require.NoError(err)
+
+	// The subnet is identified by its masked IP
+	ip, subnet, err := net.ParseCIDR("10.1.2.3/16")
+	require.NoError(err)
+	require.NoError(bans.BanSubnet(&net.IPNet{IP: ip, Mask: subnet.Mask}, "test", 0))
+
+	require.True(bans.IsIPBanned(net.ParseIP("10.1.255.255")))
+	require.True(bans.IsIPBanned(net.IPv4(10, 1, 0, 1).To16()))
+	require.False(bans.IsIPBanned(net.ParseIP("10.2.0.1")))
+	require.False(bans.IsIPBanned(net.IPv6loopback))
+
+	require.NoError(bans.UnbanSubnet(subnet))
+	require.False(bans.IsIPBanned(net.ParseIP("10.1.255.255")))
+	require.ErrorIs(bans.UnbanSubnet(subnet), errNotBanned)
+}
+
+func TestBanExpiry(t *testing.T) {
+	require := require.New(t)
+
+	bans, err := New(logging.NoLog{}, memdb.New())
+	require.NoError(err)
+
+	now := time.Unix(1000, 0)
+	bans.(*banList).clock.Set(now)
+
+	expiring := ids.GenerateTestNodeID()
+	permanent := ids.GenerateTestNodeID()
+	require.NoError(bans.BanNode(expiring, "test", time.Minute))
+	require.NoError(bans.BanNode(permanent, "test", 0))
+	require.Len(bans.Bans(), 2)
+
+	bans.(*banList).clock.Set(now.Add(time.Minute))
+	require.False(bans.IsNodeBanned(expiring))
+	require.True(bans.IsNodeBanned(permanent))
+
+	activeBans := bans.Bans()
+	require.Len(activeBans, 1)
+	require.Equal(permanent, *activeBans[0].NodeID)
+	require.True(activeBans[0].Expiry.IsZero())
+}
+
+func TestBanPersistence(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	bans, err := New(logging.NoLog{}, db)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	_, subnet, err := net.ParseCIDR("2001:db8::/32")
+	require.NoError(err)
+	require.NoError(bans.BanNode(nodeID, "node reason", time.Hour))
+	require.NoError(bans.BanSubnet(subnet, "subnet reason", 0))
+
+	reloaded, err := New(logging.NoLog{}, db)
+	require.NoError(err)
+	require.True(reloaded.IsNodeBanned(nodeID))
+	require.True(reloaded.IsIPBanned(net.ParseIP("2001:db8::1")))
+
+	reloadedBans := reloaded.Bans()
+	require.Len(reloadedBans, 2)
+	for _, ban := range reloadedBans {
+		if ban.NodeID != nil {
+			require.Equal(nodeID, *ban.NodeID)
+			require.Equal("node reason", ban.Reason)
+			require.False(ban.Expiry.IsZero())
+		} else {
+			require.Equal(subnet.String(), ban.Subnet.String())
+			require.Equal("subnet reason", ban.Reason)
+			require.True(ban.Expiry.IsZero())
+		}
+	}
+
+	// Unbanning is persisted as well
+	require.NoError(reloaded.UnbanNode(nodeID))
+	reloaded, err = New(logging.NoLog{}, db)
+	require.NoError(err)
+	require.False(reloaded.IsNodeBanned(nodeID))
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package banlist
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dioneprotocol/dionego/cache"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/utils/timer/mockable"
+	"github.com/dioneprotocol/dionego/utils/window"
+)
+
+// Max number of nodes whose violations are tracked. The violations of the node
+// that least recently committed one are forgotten first.
+const maxTrackedNodes = 10_000
+
+// ViolationTracker counts the protocol violations each node committed within a
+// sliding window, so that only nodes that commit violations frequently are
+// banned.
+type ViolationTracker struct {
+	clock         mockable.Clock
+	maxViolations int
+	windowSize    time.Duration
+
+	lock sync.Mutex
+	// Node ID --> Violations committed by the node within [windowSize]
+	nodes cache.LRU[ids.NodeID, window.Window[struct{}]]
+}
+
+// NewViolationTracker returns a tracker that reports nodes that commit
+// [maxViolations] violations within [windowSize]. If [maxViolations] is 0,
+// nodes are never reported.
+func NewViolationTracker(maxViolations int, windowSize time.Duration) *ViolationTracker {
+	return &ViolationTracker{
+		maxViolations: maxViolations,
+		windowSize:    windowSize,
+		nodes:         cache.LRU[ids.NodeID, window.Window[struct{}]]{Size: maxTrackedNodes},
+	}
+}
+
+// Violation records a protocol violation committed by [nodeID]. Returns true
+// if [nodeID] committed [maxViolations] violations within the window, in which
+// case its violations are forgotten.
+func (t *ViolationTracker) Violation(nodeID ids.NodeID) bool {
+	if t.maxViolations == 0 {
+		return false
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	violations, ok := t.nodes.Get(nodeID)
+	if !ok {
+		violations = window.New[struct{}](window.Config{
+			Clock:   &t.clock,
+			MaxSize: t.maxViolations,
+			TTL:     t.windowSize,
+		})
+		t.nodes.Put(nodeID, violations)
+	}
+	violations.Add(struct{}{})
+	if violations.Length() < t.maxViolations {
+		return false
+	}
+	t.nodes.Evict(nodeID)
+	return true
+}
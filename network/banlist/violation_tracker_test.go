@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package banlist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/ids"
+)
+
+func TestViolationTracker(t *testing.T) {
+	require := require.New(t)
+
+	tracker := NewViolationTracker(3, time.Minute)
+	now := time.Unix(1000, 0)
+	tracker.clock.Set(now)
+
+	nodeID := ids.GenerateTestNodeID()
+	require.False(tracker.Violation(nodeID))
+	require.False(tracker.Violation(nodeID))
+
+	// Violations older than the window are forgotten
+	tracker.clock.Set(now.Add(time.Minute + time.Second))
+	require.False(tracker.Violation(nodeID))
+	require.False(tracker.Violation(nodeID))
+
+	// Each node's violations are counted separately
+	require.False(tracker.Violation(ids.GenerateTestNodeID()))
+
+	require.True(tracker.Violation(nodeID))
+
+	// Reported nodes start over
+	require.False(tracker.Violation(nodeID))
+}
+
+func TestViolationTrackerDisabled(t *testing.T) {
+	require := require.New(t)
+
+	tracker := NewViolationTracker(0, time.Minute)
+	nodeID := ids.GenerateTestNodeID()
+	for i := 0; i < 10; i++ {
+		require.False(tracker.Violation(nodeID))
+	}
+}
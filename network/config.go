@@ -9,12 +9,14 @@ import (
 	"time"
 
 	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/network/banlist"
 	"github.com/dioneprotocol/dionego/network/dialer"
-	"github.com/dioneprotocol/dionego/network/peer"
+	"github.com/dioneprotocol/dionego/network/peerdb"
 	"github.com/dioneprotocol/dionego/network/throttling"
 	"github.com/dioneprotocol/dionego/snow/networking/tracker"
 	"github.com/dioneprotocol/dionego/snow/uptime"
 	"github.com/dioneprotocol/dionego/snow/validators"
+	"github.com/dioneprotocol/dionego/utils/compression"
 	"github.com/dioneprotocol/dionego/utils/ips"
 	"github.com/dioneprotocol/dionego/utils/set"
 )
@@ -72,6 +74,10 @@ type PeerListGossipConfig struct {
 	// PeerListGossipFreq is the frequency that this node will attempt to gossip
 	// signed IPs to its peers.
 	PeerListGossipFreq time.Duration `json:"peerListGossipFreq"`
+
+	// PeerListBloomResetFreq is how frequently this node recalculates the
+	// bloom filter of IPs it sends to its peers to request unknown IPs.
+	PeerListBloomResetFreq time.Duration `json:"peerListBloomResetFreq"`
 }
 
 type TimeoutConfig struct {
@@ -125,9 +131,11 @@ type Config struct {
 	PingFrequency      time.Duration     `json:"pingFrequency"`
 	AllowPrivateIPs    bool              `json:"allowPrivateIPs"`
 
-	// CompressionEnabled will compress available outbound messages when set to
-	// true.
-	CompressionEnabled bool `json:"compressionEnabled"`
+	// CompressionType is the preferred compression type of available outbound
+	// messages. Messages are compressed with it when sent to peers that can
+	// decompress it, and with a type negotiated during the handshake
+	// otherwise. If compression.TypeNone, messages aren't compressed.
+	CompressionType compression.Type `json:"compressionType"`
 
 	// TLSKey is this node's TLS key that is used to sign IPs.
 	TLSKey crypto.Signer `json:"-"`
@@ -179,6 +187,31 @@ type Config struct {
 	// we rate-limit them.
 	DiskTargeter tracker.Targeter `json:"-"`
 
-	// Tracks which validators have been sent to which peers
-	GossipTracker peer.GossipTracker `json:"-"`
+	// PeerDB persists the signed IPs and reputations of peers across restarts
+	PeerDB peerdb.DB `json:"-"`
+
+	// PeerDBMaxRecords is the maximum number of peers whose records are kept
+	// in [PeerDB].
+	PeerDBMaxRecords int `json:"peerDBMaxRecords"`
+
+	// PeerDBCommitFrequency is how often [PeerDB] is written to disk.
+	PeerDBCommitFrequency time.Duration `json:"peerDBCommitFrequency"`
+
+	// BanList is the set of nodes and IP ranges that this node refuses to
+	// connect to
+	BanList banlist.List `json:"-"`
+
+	// MaxProtocolViolations is the number of unparsable messages or invalid
+	// signed IPs a peer can send within [ProtocolViolationWindow] before it is
+	// banned. Primary Network validators are never banned. If 0, peers are
+	// never banned for sending invalid messages.
+	MaxProtocolViolations uint64 `json:"maxProtocolViolations"`
+
+	// ProtocolViolationWindow is the duration over which the invalid messages
+	// sent by a peer are counted.
+	ProtocolViolationWindow time.Duration `json:"protocolViolationWindow"`
+
+	// ProtocolViolationBanDuration is how long a peer is banned for after
+	// sending [MaxProtocolViolations] invalid messages.
+	ProtocolViolationBanDuration time.Duration `json:"protocolViolationBanDuration"`
 }
@@ -30,6 +30,8 @@ type metrics struct {
 	inboundConnRateLimited          prometheus.Counter
 	inboundConnAllowed              prometheus.Counter
 	numUselessPeerListBytes         prometheus.Counter
+	peerListBytesSaved              prometheus.Counter
+	knownPeersResets                prometheus.Counter
 	nodeUptimeWeightedAverage       prometheus.Gauge
 	nodeUptimeRewardingStake        prometheus.Gauge
 	nodeSubnetUptimeWeightedAverage *prometheus.GaugeVec
@@ -106,6 +108,16 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 			Name:      "num_useless_peerlist_bytes",
 			Help:      "Amount of useless bytes (i.e. information about nodes we already knew/don't want to connect to) received in PeerList messages",
 		}),
+		peerListBytesSaved: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "peerlist_bytes_saved",
+			Help:      "Amount of bytes of IPs not sent in PeerList messages because the requesting peer already knew about them",
+		}),
+		knownPeersResets: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "known_peers_bloom_resets",
+			Help:      "Times the bloom filter of known peers sent to other nodes was reset",
+		}),
 		inboundConnRateLimited: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: namespace,
 			Name:      "inbound_conn_throttler_rate_limited",
@@ -161,6 +173,8 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 		registerer.Register(m.acceptFailed),
 		registerer.Register(m.inboundConnAllowed),
 		registerer.Register(m.numUselessPeerListBytes),
+		registerer.Register(m.peerListBytesSaved),
+		registerer.Register(m.knownPeersResets),
 		registerer.Register(m.inboundConnRateLimited),
 		registerer.Register(m.nodeUptimeWeightedAverage),
 		registerer.Register(m.nodeUptimeRewardingStake),
@@ -5,6 +5,8 @@ package network
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"net"
@@ -21,19 +23,22 @@ import (
 
 	"go.uber.org/zap"
 
-	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	"github.com/dioneprotocol/dionego/api/health"
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/message"
+	"github.com/dioneprotocol/dionego/network/banlist"
 	"github.com/dioneprotocol/dionego/network/dialer"
 	"github.com/dioneprotocol/dionego/network/peer"
+	"github.com/dioneprotocol/dionego/network/peerdb"
 	"github.com/dioneprotocol/dionego/network/throttling"
-	"github.com/dioneprotocol/dionego/proto/pb/p2p"
 	"github.com/dioneprotocol/dionego/snow/networking/router"
 	"github.com/dioneprotocol/dionego/snow/networking/sender"
+	"github.com/dioneprotocol/dionego/snow/networking/tracker"
 	"github.com/dioneprotocol/dionego/snow/validators"
 	"github.com/dioneprotocol/dionego/subnets"
+	"github.com/dioneprotocol/dionego/utils/bloom"
 	"github.com/dioneprotocol/dionego/utils/constants"
 	"github.com/dioneprotocol/dionego/utils/ips"
 	"github.com/dioneprotocol/dionego/utils/logging"
@@ -49,6 +54,15 @@ const (
 	TimeSinceLastMsgReceivedKey = "timeSinceLastMsgReceived"
 	TimeSinceLastMsgSentKey     = "timeSinceLastMsgSent"
 	SendFailRateKey             = "sendFailRate"
+
+	// minKnownPeersCount is the minimum number of IPs that the known peers
+	// bloom filter is sized for.
+	minKnownPeersCount = 128
+	// knownPeersFalsePositiveProbability is the target false positive
+	// probability of the known peers bloom filter. A false positive causes a
+	// peer to not gossip an IP that this node doesn't know about until the
+	// filter is reset.
+	knownPeersFalsePositiveProbability = .01
 )
 
 var (
@@ -92,6 +106,12 @@ type Network interface {
 	// connect to this ID.
 	ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort)
 
+	// RegisterChain accounts inbound messages destined for [chainID] to the
+	// inbound message throttling allocation of [subnetID]. Returns the
+	// ResourceTracker that the processing of messages destined for [chainID]
+	// must be reported to, so that it is accounted to [subnetID].
+	RegisterChain(chainID ids.ID, subnetID ids.ID) tracker.ResourceTracker
+
 	// PeerInfo returns information about peers. If [nodeIDs] is empty, returns
 	// info about all peers that have finished the handshake. Otherwise, returns
 	// info about the peers in [nodeIDs] that have finished the handshake.
@@ -145,9 +165,16 @@ type network struct {
 
 	sendFailRateCalculator math.Averager
 
-	// Tracks which peers know about which peers
-	gossipTracker peer.GossipTracker
-	peersLock     sync.RWMutex
+	peersLock sync.RWMutex
+	// knownPeers is a bloom filter of the IPs in [peerIPs]. It is sent to
+	// peers so that they only gossip the IPs that this node doesn't already
+	// know about. Entries are salted with [knownPeersSalt], which is replaced
+	// every time the filter is reset so that false positives aren't persistent.
+	knownPeers     *bloom.WriteFilter
+	knownPeersSalt []byte
+	// knownPeersMaxCount is the number of entries that [knownPeers] was sized
+	// for. Once it is exceeded, the filter is reset.
+	knownPeersMaxCount int
 	// peerIPs contains the most up to date set of signed IPs for nodes we are
 	// currently connected or attempting to connect to.
 	// Note: The txID provided inside of a claimed IP is not verified and should
@@ -256,6 +283,7 @@ func NewNetwork(
 		WriteBufferSize: config.PeerWriteBufferSize,
 		Metrics:         peerMetrics,
 		MessageCreator:  msgCreator,
+		CompressionType: config.CompressionType,
 
 		Log:                  log,
 		InboundMsgThrottler:  inboundMsgThrottler,
@@ -271,6 +299,15 @@ func NewNetwork(
 		ResourceTracker:      config.ResourceTracker,
 		UptimeCalculator:     config.UptimeCalculator,
 		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey),
+		PeerDB:               config.PeerDB,
+
+		BanList: config.BanList,
+		ProtocolViolations: banlist.NewViolationTracker(
+			int(config.MaxProtocolViolations),
+			config.ProtocolViolationWindow,
+		),
+		ProtocolViolationBanDuration: config.ProtocolViolationBanDuration,
+		Validators:                   primaryNetworkValidators,
 	}
 
 	onCloseCtx, cancel := context.WithCancel(context.Background())
@@ -297,11 +334,13 @@ func NewNetwork(
 
 		peerIPs:         make(map[ids.NodeID]*ips.ClaimedIPPort),
 		trackedIPs:      make(map[ids.NodeID]*trackedIP),
-		gossipTracker:   config.GossipTracker,
 		connectingPeers: peer.NewSet(),
 		connectedPeers:  peer.NewSet(),
 		router:          router,
 	}
+	if err := n.resetKnownPeers(); err != nil {
+		return nil, fmt.Errorf("initializing known peers failed with: %w", err)
+	}
 	n.peerConfig.Network = n
 	return n, nil
 }
@@ -323,7 +362,7 @@ func (n *network) Gossip(
 	numPeersToSend int,
 	allower subnets.Allower,
 ) set.Set[ids.NodeID] {
-	peers := n.samplePeers(subnetID, numValidatorsToSend, numNonValidatorsToSend, numPeersToSend, allower)
+	peers := n.samplePeers(subnetID, numValidatorsToSend, numNonValidatorsToSend, numPeersToSend, allower, false /*=prioritizeReputation*/)
 	return n.send(msg, peers)
 }
 
@@ -413,23 +452,9 @@ func (n *network) Connected(nodeID ids.NodeID) {
 		Timestamp: peerIP.Timestamp,
 		Signature: peerIP.Signature,
 	}
-	prevIP, ok := n.peerIPs[nodeID]
-	if !ok {
-		// If the IP wasn't previously tracked, then we never could have
-		// gossiped it. This means we don't need to reset the validator's
-		// tracked set.
-		n.peerIPs[nodeID] = newIP
-	} else if prevIP.Timestamp < newIP.Timestamp {
+	if prevIP, ok := n.peerIPs[nodeID]; !ok || prevIP.Timestamp < newIP.Timestamp {
 		// The previous IP was stale, so we should gossip the newer IP.
-		n.peerIPs[nodeID] = newIP
-
-		if !prevIP.IPPort.Equal(newIP.IPPort) {
-			// This IP is actually different, so we should gossip it.
-			n.peerConfig.Log.Debug("resetting gossip due to ip change",
-				zap.Stringer("nodeID", nodeID),
-			)
-			_ = n.gossipTracker.ResetValidator(nodeID)
-		}
+		n.setPeerIP(nodeID, newIP)
 	}
 
 	if tracked, ok := n.trackedIPs[nodeID]; ok {
@@ -438,8 +463,10 @@ func (n *network) Connected(nodeID ids.NodeID) {
 	}
 	n.connectingPeers.Remove(nodeID)
 	n.connectedPeers.Add(peer)
+	latestIP := n.peerIPs[nodeID]
 	n.peersLock.Unlock()
 
+	n.config.PeerDB.Connected(nodeID, latestIP)
 	n.metrics.markConnected(peer)
 
 	peerVersion := peer.Version()
@@ -459,7 +486,7 @@ func (n *network) AllowConnection(nodeID ids.NodeID) bool {
 		n.WantsConnection(nodeID)
 }
 
-func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort) ([]*p2p.PeerAck, error) {
+func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort) error {
 	// Perform all signature verification and hashing before grabbing the peer
 	// lock.
 	// Note: Avoiding signature verification when the IP isn't needed is a
@@ -475,15 +502,9 @@ func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort)
 			zap.Stringer("nodeID", peerID),
 			zap.Error(err),
 		)
-		return nil, err
+		return err
 	}
 
-	// Information for them to update about us
-	ipLen := len(claimedIPPorts)
-	newestTimestamp := make(map[ids.ID]uint64, ipLen)
-	// Information for us to update about them
-	txIDsWithUpToDateIP := make([]ids.ID, 0, ipLen)
-
 	// Atomically modify peer data
 	n.peersLock.Lock()
 	defer n.peersLock.Unlock()
@@ -501,31 +522,14 @@ func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort)
 		prevIP, previouslyTracked, shouldUpdateOurIP, shouldDial := n.peerIPStatus(nodeID, ip)
 		tracked, isTracked := n.trackedIPs[nodeID]
 
-		// Evaluate if the gossiped IP is useful to us or to the peer that
-		// shared it with us.
+		// Evaluate if the gossiped IP is useful to us.
 		switch {
-		case previouslyTracked && prevIP.Timestamp > ip.Timestamp:
-			// Our previous IP was more up to date. We should tell the peer
-			// not to gossip their IP to us. We should still gossip our IP to
-			// them.
-			newestTimestamp[ip.TxID] = prevIP.Timestamp
-
-			n.metrics.numUselessPeerListBytes.Add(float64(ip.BytesLen()))
-		case previouslyTracked && prevIP.Timestamp == ip.Timestamp:
-			// Our previous IP was equally fresh. We should tell the peer
-			// not to gossip this IP to us. We should not gossip our IP to them.
-			newestTimestamp[ip.TxID] = prevIP.Timestamp
-			txIDsWithUpToDateIP = append(txIDsWithUpToDateIP, ip.TxID)
-
+		case previouslyTracked && prevIP.Timestamp >= ip.Timestamp:
+			// Our previous IP was at least as up to date.
 			n.metrics.numUselessPeerListBytes.Add(float64(ip.BytesLen()))
 		case verifiedIP && shouldUpdateOurIP:
-			// This IP is more up to date. We should tell the peer not to gossip
-			// this IP to us. We should not gossip our IP to them.
-			newestTimestamp[ip.TxID] = ip.Timestamp
-			txIDsWithUpToDateIP = append(txIDsWithUpToDateIP, ip.TxID)
-
 			// In the future, we should gossip this IP rather than the old IP.
-			n.peerIPs[nodeID] = ip
+			n.setPeerIP(nodeID, ip)
 
 			// If the new IP is equal to the old IP, there is no reason to
 			// refresh the references to it. This can happen when a node
@@ -534,12 +538,6 @@ func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort)
 				continue
 			}
 
-			// We should gossip this new IP to all our peers.
-			n.peerConfig.Log.Debug("resetting gossip due to ip change",
-				zap.Stringer("nodeID", nodeID),
-			)
-			_ = n.gossipTracker.ResetValidator(nodeID)
-
 			// We should update any existing outbound connection attempts.
 			if isTracked {
 				// Stop tracking the old IP and start tracking the new one.
@@ -551,14 +549,8 @@ func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort)
 			// Invariant: [isTracked] is false here.
 
 			// This is the first we've heard of this IP and we want to connect
-			// to it. We should tell the peer not to gossip this IP to us again.
-			newestTimestamp[ip.TxID] = ip.Timestamp
-			// We should not gossip this IP back to them.
-			txIDsWithUpToDateIP = append(txIDsWithUpToDateIP, ip.TxID)
-
-			// We don't need to reset gossip about this validator because
-			// we've never gossiped it before.
-			n.peerIPs[nodeID] = ip
+			// to it.
+			n.setPeerIP(nodeID, ip)
 
 			tracked := newTrackedIP(ip.IPPort)
 			n.trackedIPs[nodeID] = tracked
@@ -569,65 +561,14 @@ func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort)
 		}
 	}
 
-	txIDsToAck := maps.Keys(newestTimestamp)
-	txIDsToAck, ok := n.gossipTracker.AddKnown(peerID, txIDsWithUpToDateIP, txIDsToAck)
-	if !ok {
-		n.peerConfig.Log.Error("failed to update known peers",
-			zap.Stringer("nodeID", peerID),
-		)
-		return nil, nil
-	}
-
-	peerAcks := make([]*p2p.PeerAck, len(txIDsToAck))
-	for i, txID := range txIDsToAck {
-		txID := txID
-		peerAcks[i] = &p2p.PeerAck{
-			TxId: txID[:],
-			// By responding with the highest timestamp, not just the timestamp
-			// the peer provided us, we may be able to avoid some unnecessary
-			// gossip in the case that the peer is about to update this
-			// validator's IP.
-			Timestamp: newestTimestamp[txID],
-		}
-	}
-	return peerAcks, nil
+	return nil
 }
 
-func (n *network) MarkTracked(peerID ids.NodeID, ips []*p2p.PeerAck) error {
-	txIDs := make([]ids.ID, 0, len(ips))
-
+func (n *network) KnownPeers() ([]byte, []byte) {
 	n.peersLock.RLock()
 	defer n.peersLock.RUnlock()
 
-	for _, ip := range ips {
-		txID, err := ids.ToID(ip.TxId)
-		if err != nil {
-			return err
-		}
-
-		// If [txID]'s corresponding nodeID isn't known, then they must no
-		// longer be a validator. Therefore we wouldn't gossip their IP anyways.
-		nodeID, ok := n.gossipTracker.GetNodeID(txID)
-		if !ok {
-			continue
-		}
-
-		// If the peer returns a lower timestamp than I currently have, then I
-		// have updated the IP since I sent the PeerList message this is in
-		// response to. That means that I should re-gossip this node's IP to the
-		// peer.
-		myIP, previouslyTracked := n.peerIPs[nodeID]
-		if previouslyTracked && myIP.Timestamp <= ip.Timestamp {
-			txIDs = append(txIDs, txID)
-		}
-	}
-
-	if _, ok := n.gossipTracker.AddKnown(peerID, txIDs, nil); !ok {
-		n.peerConfig.Log.Error("failed to update known peers",
-			zap.Stringer("nodeID", peerID),
-		)
-	}
-	return nil
+	return n.knownPeers.Marshal(), n.knownPeersSalt
 }
 
 // Disconnected is called after the peer's handling has been shutdown.
@@ -636,13 +577,6 @@ func (n *network) MarkTracked(peerID ids.NodeID, ips []*p2p.PeerAck) error {
 // call. Note that this is from the perspective of a single peer object, because
 // a peer with the same ID can reconnect to this network instance.
 func (n *network) Disconnected(nodeID ids.NodeID) {
-	if !n.gossipTracker.StopTrackingPeer(nodeID) {
-		n.peerConfig.Log.Error(
-			"stopped non-existent peer tracker",
-			zap.Stringer("nodeID", nodeID),
-		)
-	}
-
 	n.peersLock.RLock()
 	_, connecting := n.connectingPeers.GetByID(nodeID)
 	peer, connected := n.connectedPeers.GetByID(nodeID)
@@ -656,48 +590,51 @@ func (n *network) Disconnected(nodeID ids.NodeID) {
 	}
 }
 
-func (n *network) Peers(peerID ids.NodeID) ([]ips.ClaimedIPPort, error) {
-	// Only select validators that we haven't already sent to this peer
-	unknownValidators, ok := n.gossipTracker.GetUnknown(peerID)
+func (n *network) Peers(
+	peerID ids.NodeID,
+	knownPeers *bloom.ReadFilter,
+	salt []byte,
+) ([]ips.ClaimedIPPort, error) {
+	primaryValidators, ok := n.config.Validators.Get(constants.PrimaryNetworkID)
 	if !ok {
-		n.peerConfig.Log.Debug(
-			"unable to find peer to gossip to",
-			zap.Stringer("nodeID", peerID),
-		)
-		return nil, nil
+		return nil, errMissingPrimaryValidators
 	}
 
-	// We select a random sample of validators to gossip to avoid starving out a
-	// validator from being gossiped for an extended period of time.
-	s := sampler.NewUniform()
-	if err := s.Initialize(uint64(len(unknownValidators))); err != nil {
-		return nil, err
-	}
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
 
-	// Calculate the unknown information we need to send to this peer.
-	validatorIPs := make([]ips.ClaimedIPPort, 0, int(n.config.PeerListNumValidatorIPs))
-	for i := 0; i < len(unknownValidators) && len(validatorIPs) < int(n.config.PeerListNumValidatorIPs); i++ {
-		drawn, err := s.Next()
-		if err != nil {
-			return nil, err
+	// Only select connected validators that [peerID] doesn't already know
+	// about
+	var (
+		numPeers          = n.connectedPeers.Len()
+		unknownValidators = make([]ips.ClaimedIPPort, 0, numPeers)
+		bytesSaved        int
+	)
+	for i := 0; i < numPeers; i++ {
+		p, _ := n.connectedPeers.GetByIndex(i)
+		nodeID := p.ID()
+		if nodeID == peerID {
+			continue
 		}
 
-		validator := unknownValidators[drawn]
-		n.peersLock.RLock()
-		_, isConnected := n.connectedPeers.GetByID(validator.NodeID)
-		peerIP := n.peerIPs[validator.NodeID]
-		n.peersLock.RUnlock()
-		if !isConnected {
-			n.peerConfig.Log.Verbo(
-				"unable to find validator in connected peers",
-				zap.Stringer("nodeID", validator.NodeID),
-			)
+		validator, ok := primaryValidators.Get(nodeID)
+		if !ok {
+			continue
+		}
+
+		peerIP, ok := n.peerIPs[nodeID]
+		if !ok {
+			continue
+		}
+
+		if knownPeers.Contains(bloom.Hash(gossipID(nodeID, peerIP), salt)) {
+			bytesSaved += peerIP.BytesLen()
 			continue
 		}
 
 		// Note: peerIP isn't used directly here because the TxID may be
 		//       incorrect.
-		validatorIPs = append(validatorIPs,
+		unknownValidators = append(unknownValidators,
 			ips.ClaimedIPPort{
 				Cert:      peerIP.Cert,
 				IPPort:    peerIP.IPPort,
@@ -707,7 +644,28 @@ func (n *network) Peers(peerID ids.NodeID) ([]ips.ClaimedIPPort, error) {
 			},
 		)
 	}
+	n.metrics.peerListBytesSaved.Add(float64(bytesSaved))
 
+	numValidatorIPs := int(n.config.PeerListNumValidatorIPs)
+	if len(unknownValidators) <= numValidatorIPs {
+		return unknownValidators, nil
+	}
+
+	// We select a random sample of validators to gossip to avoid starving out a
+	// validator from being gossiped for an extended period of time.
+	s := sampler.NewUniform()
+	if err := s.Initialize(uint64(len(unknownValidators))); err != nil {
+		return nil, err
+	}
+	indices, err := s.Sample(numValidatorIPs)
+	if err != nil {
+		return nil, err
+	}
+
+	validatorIPs := make([]ips.ClaimedIPPort, len(indices))
+	for i, index := range indices {
+		validatorIPs[i] = unknownValidators[index]
+	}
 	return validatorIPs, nil
 }
 
@@ -757,6 +715,15 @@ func (n *network) Dispatch() error {
 				return
 			}
 
+			if n.config.BanList.IsIPBanned(ip.IP) {
+				n.peerConfig.Log.Debug("failed to upgrade connection",
+					zap.String("reason", "ip is banned"),
+					zap.Stringer("peerIP", ip),
+				)
+				_ = conn.Close()
+				return
+			}
+
 			if !n.inboundConnUpgradeThrottler.ShouldUpgrade(ip) {
 				n.peerConfig.Log.Debug("failed to upgrade connection",
 					zap.String("reason", "rate-limiting"),
@@ -829,6 +796,11 @@ func (n *network) ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort) {
 	}
 }
 
+func (n *network) RegisterChain(chainID ids.ID, subnetID ids.ID) tracker.ResourceTracker {
+	n.peerConfig.InboundMsgThrottler.AddChain(chainID, subnetID)
+	return n.peerConfig.InboundMsgThrottler.ChainResourceTracker(chainID, n.config.ResourceTracker)
+}
+
 // getPeers returns a slice of connected peers from a set of [nodeIDs].
 //
 // - [nodeIDs] the IDs of the peers that should be returned if they are
@@ -870,12 +842,16 @@ func (n *network) getPeers(
 	return peers
 }
 
+// samplePeers returns a random sample of connected peers tracking [subnetID].
+// If [prioritizeReputation] is true, peers are sampled with probability
+// proportional to their reputation scores.
 func (n *network) samplePeers(
 	subnetID ids.ID,
 	numValidatorsToSample,
 	numNonValidatorsToSample int,
 	numPeersToSample int,
 	allower subnets.Allower,
+	prioritizeReputation bool,
 ) []peer.Peer {
 	subnetValidators, ok := n.config.Validators.Get(subnetID)
 	if !ok {
@@ -892,36 +868,42 @@ func (n *network) samplePeers(
 	n.peersLock.RLock()
 	defer n.peersLock.RUnlock()
 
-	return n.connectedPeers.Sample(
-		numValidatorsToSample+numNonValidatorsToSample+numPeersToSample,
-		func(p peer.Peer) bool {
-			// Only return peers that are tracking [subnetID]
-			trackedSubnets := p.TrackedSubnets()
-			if subnetID != constants.PrimaryNetworkID && !trackedSubnets.Contains(subnetID) {
-				return false
-			}
+	numToSample := numValidatorsToSample + numNonValidatorsToSample + numPeersToSample
+	precondition := func(p peer.Peer) bool {
+		// Only return peers that are tracking [subnetID]
+		trackedSubnets := p.TrackedSubnets()
+		if subnetID != constants.PrimaryNetworkID && !trackedSubnets.Contains(subnetID) {
+			return false
+		}
 
-			peerID := p.ID()
-			isValidator := subnetValidators.Contains(peerID)
-			// check if the peer is allowed to connect to the subnet
-			if !allower.IsAllowed(peerID, isValidator) {
-				return false
-			}
+		peerID := p.ID()
+		isValidator := subnetValidators.Contains(peerID)
+		// check if the peer is allowed to connect to the subnet
+		if !allower.IsAllowed(peerID, isValidator) {
+			return false
+		}
 
-			if numPeersToSample > 0 {
-				numPeersToSample--
-				return true
-			}
+		if numPeersToSample > 0 {
+			numPeersToSample--
+			return true
+		}
 
-			if isValidator {
-				numValidatorsToSample--
-				return numValidatorsToSample >= 0
-			}
+		if isValidator {
+			numValidatorsToSample--
+			return numValidatorsToSample >= 0
+		}
 
-			numNonValidatorsToSample--
-			return numNonValidatorsToSample >= 0
-		},
-	)
+		numNonValidatorsToSample--
+		return numNonValidatorsToSample >= 0
+	}
+	if prioritizeReputation {
+		return n.connectedPeers.SampleWeighted(numToSample, n.reputation, precondition)
+	}
+	return n.connectedPeers.Sample(numToSample, precondition)
+}
+
+func (n *network) reputation(p peer.Peer) float64 {
+	return n.config.PeerDB.Score(p.ID())
 }
 
 // send the message to the provided peers.
@@ -954,6 +936,7 @@ func (n *network) disconnectedFromConnecting(nodeID ids.NodeID) {
 	defer n.peersLock.Unlock()
 
 	n.connectingPeers.Remove(nodeID)
+	n.config.PeerDB.ConnectionFailed(nodeID)
 
 	// The peer that is disconnecting from us didn't finish the handshake
 	tracked, ok := n.trackedIPs[nodeID]
@@ -1115,8 +1098,21 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 				n.config.MaxReconnectDelay,
 			)
 
+			// Bans may expire, so the peer is retried later rather than no
+			// longer tracked.
+			if n.config.BanList.IsNodeBanned(nodeID) || n.config.BanList.IsIPBanned(ip.ip.IP) {
+				n.peerConfig.Log.Verbo(
+					"skipping attempt to dial peer",
+					zap.String("reason", "peer is banned"),
+					zap.Stringer("nodeID", nodeID),
+					zap.Duration("delay", ip.delay),
+				)
+				continue
+			}
+
 			conn, err := n.dialer.Dial(ctx, ip.ip)
 			if err != nil {
+				n.config.PeerDB.ConnectionFailed(nodeID)
 				n.peerConfig.Log.Verbo(
 					"failed to reach peer, attempting again",
 					zap.Stringer("peerIP", ip.ip.IP),
@@ -1132,6 +1128,7 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 
 			err = n.upgrade(conn, n.clientUpgrader)
 			if err != nil {
+				n.config.PeerDB.ConnectionFailed(nodeID)
 				n.peerConfig.Log.Verbo(
 					"failed to upgrade, attempting again",
 					zap.Stringer("peerIP", ip.ip.IP),
@@ -1188,6 +1185,16 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		return nil
 	}
 
+	if n.config.BanList.IsNodeBanned(nodeID) {
+		_ = tlsConn.Close()
+		n.peerConfig.Log.Verbo(
+			"dropping connection",
+			zap.String("reason", "peer is banned"),
+			zap.Stringer("nodeID", nodeID),
+		)
+		return nil
+	}
+
 	if !n.AllowConnection(nodeID) {
 		_ = tlsConn.Close()
 		n.peerConfig.Log.Verbo(
@@ -1238,13 +1245,6 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		zap.Stringer("nodeID", nodeID),
 	)
 
-	if !n.gossipTracker.StartTrackingPeer(nodeID) {
-		n.peerConfig.Log.Error(
-			"started duplicate peer tracker",
-			zap.Stringer("nodeID", nodeID),
-		)
-	}
-
 	// peer.Start requires there is only ever one peer instance running with the
 	// same [peerConfig.InboundMsgThrottler]. This is guaranteed by the above
 	// de-duplications for [connectingPeers] and [connectedPeers].
@@ -1291,6 +1291,8 @@ func (n *network) StartClose() {
 		n.closing = true
 		n.onCloseCtxCancel()
 
+		n.commitPeerDB()
+
 		for nodeID, tracked := range n.trackedIPs {
 			tracked.stopTracking()
 			delete(n.peerIPs, nodeID)
@@ -1367,17 +1369,37 @@ func (n *network) NodeUptime(subnetID ids.ID) (UptimeResult, error) {
 func (n *network) runTimers() {
 	gossipPeerlists := time.NewTicker(n.config.PeerListGossipFreq)
 	updateUptimes := time.NewTicker(n.config.UptimeMetricFreq)
+	commitPeerDB := time.NewTicker(n.config.PeerDBCommitFrequency)
+	resetKnownPeers := time.NewTicker(n.config.PeerListBloomResetFreq)
 	defer func() {
 		gossipPeerlists.Stop()
 		updateUptimes.Stop()
+		commitPeerDB.Stop()
+		resetKnownPeers.Stop()
 	}()
 
+	n.dialKnownPeers()
+
 	for {
 		select {
 		case <-n.onCloseCtx.Done():
 			return
 		case <-gossipPeerlists.C:
 			n.gossipPeerLists()
+		case <-resetKnownPeers.C:
+			n.peersLock.Lock()
+			err := n.resetKnownPeers()
+			n.peersLock.Unlock()
+			if err != nil {
+				n.peerConfig.Log.Error("failed to reset known peers",
+					zap.Error(err),
+				)
+			}
+		case <-commitPeerDB.C:
+			n.commitPeerDB()
+			// Peers in the peer database may have become validators since they
+			// were last dialed.
+			n.dialKnownPeers()
 		case <-updateUptimes.C:
 			primaryUptime, err := n.NodeUptime(constants.PrimaryNetworkID)
 			if err != nil {
@@ -1412,9 +1434,110 @@ func (n *network) gossipPeerLists() {
 		int(n.config.PeerListNonValidatorGossipSize),
 		int(n.config.PeerListPeersGossipSize),
 		subnets.NoOpAllower,
+		true, // =prioritizeReputation
 	)
 
 	for _, p := range peers {
-		p.StartSendPeerList()
+		p.StartGossipPeerList()
+	}
+}
+
+// dialKnownPeers starts connecting to the peers in the peer database that this
+// node wants to connect to, but isn't connected or connecting to. Peers with
+// higher reputation scores are dialed sooner.
+func (n *network) dialKnownPeers() {
+	records := n.config.PeerDB.Records()
+	slices.SortFunc(records, func(a, b peerdb.Record) bool {
+		return a.Score() > b.Score()
+	})
+
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	for _, record := range records {
+		nodeID := record.NodeID
+		if n.closing || record.IP == nil || !n.wantsConnection(nodeID) {
+			continue
+		}
+		if _, ok := n.trackedIPs[nodeID]; ok {
+			continue
+		}
+		if _, ok := n.connectingPeers.GetByID(nodeID); ok {
+			continue
+		}
+		if _, ok := n.connectedPeers.GetByID(nodeID); ok {
+			continue
+		}
+
+		n.setPeerIP(nodeID, record.IP)
+		tracked := newTrackedIP(record.IP.IPPort)
+		tracked.delay = time.Duration((1 - record.Score()) * float64(n.config.InitialReconnectDelay))
+		n.trackedIPs[nodeID] = tracked
+		n.dial(n.onCloseCtx, nodeID, tracked)
+	}
+}
+
+// setPeerIP sets the most up to date IP of [nodeID] and adds it to the known
+// peers.
+// Assumes [n.peersLock] is held.
+func (n *network) setPeerIP(nodeID ids.NodeID, ip *ips.ClaimedIPPort) {
+	n.peerIPs[nodeID] = ip
+	n.knownPeers.Add(bloom.Hash(gossipID(nodeID, ip), n.knownPeersSalt))
+	if n.knownPeers.Count() <= n.knownPeersMaxCount {
+		return
+	}
+
+	if err := n.resetKnownPeers(); err != nil {
+		n.peerConfig.Log.Error("failed to reset known peers",
+			zap.Error(err),
+		)
+	}
+}
+
+// resetKnownPeers replaces the known peers bloom filter with a new one that
+// is sized for the current number of IPs and uses a new salt.
+// Assumes [n.peersLock] is held.
+func (n *network) resetKnownPeers() error {
+	maxCount := 2 * len(n.peerIPs)
+	if maxCount < minKnownPeersCount {
+		maxCount = minKnownPeersCount
+	}
+	numHashes, numEntries := bloom.OptimalParameters(maxCount, knownPeersFalsePositiveProbability)
+	knownPeers, err := bloom.NewWriteFilter(numHashes, numEntries)
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, peer.MaxBloomSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+
+	for nodeID, ip := range n.peerIPs {
+		knownPeers.Add(bloom.Hash(gossipID(nodeID, ip), salt))
+	}
+
+	n.knownPeers = knownPeers
+	n.knownPeersSalt = salt
+	n.knownPeersMaxCount = maxCount
+	n.metrics.knownPeersResets.Inc()
+	return nil
+}
+
+// gossipID identifies a signed IP of [nodeID] in the known peers bloom
+// filter. A newer IP of the same node has a different ID, so it is gossiped
+// even if a peer knows about the older IP.
+func gossipID(nodeID ids.NodeID, ip *ips.ClaimedIPPort) []byte {
+	id := make([]byte, len(nodeID)+wrappers.LongLen)
+	copy(id, nodeID[:])
+	binary.BigEndian.PutUint64(id[len(nodeID):], ip.Timestamp)
+	return id
+}
+
+func (n *network) commitPeerDB() {
+	if err := n.config.PeerDB.Commit(); err != nil {
+		n.peerConfig.Log.Warn("failed to commit the peer database",
+			zap.Error(err),
+		)
 	}
 }
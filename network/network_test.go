@@ -6,6 +6,8 @@ package network
 import (
 	"context"
 	"crypto"
+	"crypto/x509"
+	"io"
 	"net"
 	"sync"
 	"testing"
@@ -15,10 +17,12 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dioneprotocol/dionego/database/memdb"
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/message"
+	"github.com/dioneprotocol/dionego/network/banlist"
 	"github.com/dioneprotocol/dionego/network/dialer"
-	"github.com/dioneprotocol/dionego/network/peer"
+	"github.com/dioneprotocol/dionego/network/peerdb"
 	"github.com/dioneprotocol/dionego/network/throttling"
 	"github.com/dioneprotocol/dionego/proto/pb/p2p"
 	"github.com/dioneprotocol/dionego/snow/networking/router"
@@ -26,6 +30,8 @@ import (
 	"github.com/dioneprotocol/dionego/snow/uptime"
 	"github.com/dioneprotocol/dionego/snow/validators"
 	"github.com/dioneprotocol/dionego/subnets"
+	"github.com/dioneprotocol/dionego/utils/bloom"
+	"github.com/dioneprotocol/dionego/utils/compression"
 	"github.com/dioneprotocol/dionego/utils/constants"
 	"github.com/dioneprotocol/dionego/utils/ips"
 	"github.com/dioneprotocol/dionego/utils/logging"
@@ -51,6 +57,7 @@ var (
 		PeerListNonValidatorGossipSize: 100,
 		PeerListPeersGossipSize:        100,
 		PeerListGossipFreq:             time.Second,
+		PeerListBloomResetFreq:         time.Second,
 	}
 	defaultTimeoutConfig = TimeoutConfig{
 		PingPongTimeout:      30 * time.Second,
@@ -82,6 +89,9 @@ var (
 			DiskThrottlerConfig: throttling.SystemThrottlerConfig{
 				MaxRecheckDelay: 50 * time.Millisecond,
 			},
+			SubnetThrottlerConfig: throttling.SubnetThrottlerConfig{
+				UnreservedShare: .1,
+			},
 		},
 		OutboundMsgThrottlerConfig: throttling.MsgByteThrottlerConfig{
 			VdrAllocSize:        1 * units.GiB,
@@ -110,7 +120,7 @@ var (
 		PingFrequency:      constants.DefaultPingFrequency,
 		AllowPrivateIPs:    true,
 
-		CompressionEnabled: true,
+		CompressionType: compression.TypeGzip,
 
 		UptimeCalculator:  uptime.NewManager(uptime.NewTestState()),
 		UptimeMetricFreq:  30 * time.Second,
@@ -122,6 +132,13 @@ var (
 		ResourceTracker:              newDefaultResourceTracker(),
 		CPUTargeter:                  nil, // Set in init
 		DiskTargeter:                 nil, // Set in init
+
+		PeerDBMaxRecords:      constants.DefaultNetworkPeerDBMaxRecords,
+		PeerDBCommitFrequency: constants.DefaultNetworkPeerDBCommitFrequency,
+
+		MaxProtocolViolations:        constants.DefaultNetworkMaxProtocolViolations,
+		ProtocolViolationWindow:      constants.DefaultNetworkProtocolViolationWindow,
+		ProtocolViolationBanDuration: constants.DefaultNetworkProtocolViolationBanDuration,
 	}
 )
 
@@ -172,6 +189,14 @@ func newTestNetwork(t *testing.T, count int) (*testDialer, []*testListener, []id
 		config.MyIPPort = ip
 		config.TLSKey = tlsCert.PrivateKey.(crypto.Signer)
 
+		peerDB, err := peerdb.New(logging.NoLog{}, memdb.New(), config.PeerDBMaxRecords)
+		require.NoError(t, err)
+		config.PeerDB = peerDB
+
+		banList, err := banlist.New(logging.NoLog{}, memdb.New())
+		require.NoError(t, err)
+		config.BanList = banList
+
 		listeners[i] = listener
 		nodeIDs[i] = nodeID
 		configs[i] = &config
@@ -185,7 +210,7 @@ func newMessageCreator(t *testing.T) message.Creator {
 	mc, err := message.NewCreator(
 		prometheus.NewRegistry(),
 		"",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -209,22 +234,13 @@ func newFullyConnectedTestNetwork(t *testing.T, handlers []router.InboundHandler
 	for i, config := range configs {
 		msgCreator := newMessageCreator(t)
 		registry := prometheus.NewRegistry()
-
-		g, err := peer.NewGossipTracker(registry, "foobar")
-		require.NoError(err)
-
 		log := logging.NoLog{}
-		gossipTrackerCallback := peer.GossipTrackerCallback{
-			Log:           log,
-			GossipTracker: g,
-		}
 
 		beacons := validators.NewSet()
-		err = beacons.Add(nodeIDs[0], nil, ids.GenerateTestID(), 1)
+		err := beacons.Add(nodeIDs[0], nil, ids.GenerateTestID(), 1)
 		require.NoError(err)
 
 		primaryVdrs := validators.NewSet()
-		primaryVdrs.RegisterCallbackListener(&gossipTrackerCallback)
 		for _, nodeID := range nodeIDs {
 			err := primaryVdrs.Add(nodeID, nil, ids.GenerateTestID(), 1)
 			require.NoError(err)
@@ -235,7 +251,6 @@ func newFullyConnectedTestNetwork(t *testing.T, handlers []router.InboundHandler
 
 		config := config
 
-		config.GossipTracker = g
 		config.Beacons = beacons
 		config.Validators = vdrs
 
@@ -409,7 +424,7 @@ func TestTrackVerifiesSignatures(t *testing.T) {
 	err := validators.Add(network.config.Validators, constants.PrimaryNetworkID, nodeID, nil, ids.Empty, 1)
 	require.NoError(err)
 
-	_, err = network.Track(ids.EmptyNodeID, []*ips.ClaimedIPPort{{
+	err = network.Track(ids.EmptyNodeID, []*ips.ClaimedIPPort{{
 		Cert: tlsCert.Leaf,
 		IPPort: ips.IPPort{
 			IP:   net.IPv4(123, 132, 123, 123),
@@ -430,3 +445,129 @@ func TestTrackVerifiesSignatures(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestDialKnownPeers(t *testing.T) {
+	require := require.New(t)
+
+	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil})
+
+	network := networks[0].(*network)
+	validatorID, validatorCert, _ := getTLS(t, 1)
+	err := validators.Add(network.config.Validators, constants.PrimaryNetworkID, validatorID, nil, ids.Empty, 1)
+	require.NoError(err)
+	nonValidatorID, nonValidatorCert, _ := getTLS(t, 2)
+
+	for nodeID, cert := range map[ids.NodeID]*x509.Certificate{
+		validatorID:    validatorCert.Leaf,
+		nonValidatorID: nonValidatorCert.Leaf,
+	} {
+		network.config.PeerDB.Connected(nodeID, &ips.ClaimedIPPort{
+			Cert: cert,
+			IPPort: ips.IPPort{
+				IP:   net.IPv4(123, 132, 123, 123),
+				Port: 10000,
+			},
+			Timestamp: 1000,
+		})
+	}
+
+	network.dialKnownPeers()
+
+	// Only the known peers that this node wants to connect to are dialed
+	network.peersLock.RLock()
+	require.Len(network.trackedIPs, 1)
+	require.Contains(network.trackedIPs, validatorID)
+	require.Contains(network.peerIPs, validatorID)
+	network.peersLock.RUnlock()
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestKnownPeers(t *testing.T) {
+	require := require.New(t)
+
+	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil})
+
+	network := networks[0].(*network)
+	newIP := func(timestamp uint64) (ids.NodeID, *ips.ClaimedIPPort) {
+		return ids.GenerateTestNodeID(), &ips.ClaimedIPPort{
+			IPPort: ips.IPPort{
+				IP:   net.IPv4(123, 132, 123, 123),
+				Port: 10000,
+			},
+			Timestamp: timestamp,
+		}
+	}
+
+	nodeID, ip := newIP(1000)
+	network.peersLock.Lock()
+	network.setPeerIP(nodeID, ip)
+	network.peersLock.Unlock()
+
+	filterBytes, salt := network.KnownPeers()
+	filter, err := bloom.Parse(filterBytes)
+	require.NoError(err)
+	require.True(filter.Contains(bloom.Hash(gossipID(nodeID, ip), salt)))
+
+	// Exceeding the number of IPs the filter was sized for resets the filter
+	// with a new salt, which still contains all the known IPs.
+	network.peersLock.Lock()
+	for i := 0; i < minKnownPeersCount; i++ {
+		network.setPeerIP(newIP(uint64(i)))
+	}
+	network.peersLock.Unlock()
+
+	filterBytes, newSalt := network.KnownPeers()
+	require.NotEqual(salt, newSalt)
+	filter, err = bloom.Parse(filterBytes)
+	require.NoError(err)
+
+	network.peersLock.RLock()
+	for nodeID, ip := range network.peerIPs {
+		require.True(filter.Contains(bloom.Hash(gossipID(nodeID, ip), newSalt)))
+	}
+	network.peersLock.RUnlock()
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+type testUpgrader struct {
+	nodeID ids.NodeID
+}
+
+func (u testUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *x509.Certificate, error) {
+	return u.nodeID, conn, nil, nil
+}
+
+func TestUpgradeBannedPeer(t *testing.T) {
+	require := require.New(t)
+
+	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil})
+
+	network := networks[0].(*network)
+	bannedID := ids.GenerateTestNodeID()
+	require.NoError(network.config.BanList.BanNode(bannedID, "test", 0))
+
+	conn, remoteConn := net.Pipe()
+	require.NoError(network.upgrade(conn, testUpgrader{nodeID: bannedID}))
+
+	// The connection to the banned peer is dropped
+	_, err := remoteConn.Read(make([]byte, 1))
+	require.ErrorIs(err, io.EOF)
+
+	network.peersLock.RLock()
+	_, connecting := network.connectingPeers.GetByID(bannedID)
+	network.peersLock.RUnlock()
+	require.False(connecting)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
@@ -8,11 +8,14 @@ import (
 
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/message"
+	"github.com/dioneprotocol/dionego/network/banlist"
+	"github.com/dioneprotocol/dionego/network/peerdb"
 	"github.com/dioneprotocol/dionego/network/throttling"
 	"github.com/dioneprotocol/dionego/snow/networking/router"
 	"github.com/dioneprotocol/dionego/snow/networking/tracker"
 	"github.com/dioneprotocol/dionego/snow/uptime"
 	"github.com/dioneprotocol/dionego/snow/validators"
+	"github.com/dioneprotocol/dionego/utils/compression"
 	"github.com/dioneprotocol/dionego/utils/logging"
 	"github.com/dioneprotocol/dionego/utils/set"
 	"github.com/dioneprotocol/dionego/utils/timer/mockable"
@@ -27,6 +30,10 @@ type Config struct {
 	Clock           mockable.Clock
 	Metrics         *Metrics
 	MessageCreator  message.Creator
+	// CompressionType is the preferred compression type of messages sent to
+	// this peer. Messages are compressed with the type negotiated with this
+	// peer during the handshake.
+	CompressionType compression.Type
 
 	Log                  logging.Logger
 	InboundMsgThrottler  throttling.InboundMsgThrottler
@@ -52,4 +59,16 @@ type Config struct {
 
 	// Signs my IP so I can send my signed IP address in the Version message
 	IPSigner *IPSigner
+
+	// Records the latency and protocol violations of peers
+	PeerDB peerdb.DB
+
+	// Peers that are banned are disconnected from
+	BanList banlist.List
+	// Counts the unparsable messages and invalid signed IPs sent by peers. A
+	// peer that sends too many of them is banned for
+	// [ProtocolViolationBanDuration], unless it's in [Validators].
+	ProtocolViolations           *banlist.ViolationTracker
+	ProtocolViolationBanDuration time.Duration
+	Validators                   validators.Set
 }
@@ -110,8 +110,8 @@ func NewMetrics(
 	return m, errs.Err
 }
 
-// Sent updates the metrics for having sent [msg].
-func (m *Metrics) Sent(msg message.OutboundMessage) {
+// Sent updates the metrics for having sent [msg] as [numBytes] bytes.
+func (m *Metrics) Sent(msg message.OutboundMessage, numBytes int) {
 	op := msg.Op()
 	msgMetrics := m.MessageMetrics[op]
 	if msgMetrics == nil {
@@ -122,7 +122,7 @@ func (m *Metrics) Sent(msg message.OutboundMessage) {
 		return
 	}
 	msgMetrics.NumSent.Inc()
-	msgMetrics.SentBytes.Add(float64(len(msg.Bytes())))
+	msgMetrics.SentBytes.Add(float64(numBytes))
 	// assume that if [saved] == 0, [msg] wasn't compressed
 	if saved := msg.BytesSavedCompression(); saved != 0 {
 		msgMetrics.SavedSentBytes.Observe(float64(saved))
@@ -5,7 +5,7 @@ package peer
 
 import (
 	"github.com/dioneprotocol/dionego/ids"
-	"github.com/dioneprotocol/dionego/proto/pb/p2p"
+	"github.com/dioneprotocol/dionego/utils/bloom"
 	"github.com/dioneprotocol/dionego/utils/ips"
 )
 
@@ -22,12 +22,7 @@ type Network interface {
 	// Track allows the peer to notify the network of a potential new peer to
 	// connect to, given the [ips] of the peers it sent us during the peer
 	// handshake.
-	//
-	// Returns which IPs should not be gossipped to this node again.
-	Track(peerID ids.NodeID, ips []*ips.ClaimedIPPort) ([]*p2p.PeerAck, error)
-
-	// MarkTracked stops sending gossip about [ips] to [peerID].
-	MarkTracked(peerID ids.NodeID, ips []*p2p.PeerAck) error
+	Track(peerID ids.NodeID, ips []*ips.ClaimedIPPort) error
 
 	// Disconnected is called when the peer finishes shutting down. It is not
 	// guaranteed that [Connected] was called for the provided peer. However, it
@@ -35,6 +30,11 @@ type Network interface {
 	// for a given [Peer] object.
 	Disconnected(peerID ids.NodeID)
 
-	// Peers returns peers that [peerID] might not know about.
-	Peers(peerID ids.NodeID) ([]ips.ClaimedIPPort, error)
+	// KnownPeers returns the bloom filter of the peers this node already knows
+	// about, along with the salt its entries were hashed with.
+	KnownPeers() (filter []byte, salt []byte)
+
+	// Peers returns peers that [peerID] might not know about. Peers that are
+	// contained in [knownPeers], with entries hashed with [salt], are skipped.
+	Peers(peerID ids.NodeID, knownPeers *bloom.ReadFilter, salt []byte) ([]ips.ClaimedIPPort, error)
 }
@@ -19,8 +19,11 @@ import (
 
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/message"
+	"github.com/dioneprotocol/dionego/network/throttling"
 	"github.com/dioneprotocol/dionego/proto/pb/p2p"
 	"github.com/dioneprotocol/dionego/utils"
+	"github.com/dioneprotocol/dionego/utils/bloom"
+	"github.com/dioneprotocol/dionego/utils/compression"
 	"github.com/dioneprotocol/dionego/utils/constants"
 	"github.com/dioneprotocol/dionego/utils/ips"
 	"github.com/dioneprotocol/dionego/utils/json"
@@ -29,6 +32,10 @@ import (
 	"github.com/dioneprotocol/dionego/version"
 )
 
+// MaxBloomSaltLen is the maximum length of the salt of a known peers bloom
+// filter
+const MaxBloomSaltLen = 32
+
 var (
 	errClosed = errors.New("closed")
 
@@ -86,10 +93,11 @@ type Peer interface {
 	// guaranteed not to be delivered to the peer.
 	Send(ctx context.Context, msg message.OutboundMessage) bool
 
-	// StartSendPeerList attempts to send a PeerList message to this peer on
-	// this peer's gossip routine. It is not guaranteed that a PeerList will be
-	// sent.
-	StartSendPeerList()
+	// StartGossipPeerList attempts to gossip peers with this peer on this
+	// peer's gossip routine. Peers that support GetPeerList are sent a
+	// GetPeerList message, older peers are sent a PeerList message. It is not
+	// guaranteed that a message will be sent.
+	StartGossipPeerList()
 
 	// StartClose will begin shutting down the peer. It will not block.
 	StartClose()
@@ -128,6 +136,10 @@ type peer struct {
 	// trackedSubnets is the subset of subnetIDs the peer sent us in the Version
 	// message that we are also tracking.
 	trackedSubnets set.Set[ids.ID]
+	// compressionType is the compression type negotiated with the peer using
+	// the compression types it advertised in the Version message. Messages
+	// are sent uncompressed until the Version message is received.
+	compressionType utils.Atomic[compression.Type]
 
 	observedUptimesLock sync.RWMutex
 	// [observedUptimesLock] must be held while accessing [observedUptime]
@@ -139,6 +151,11 @@ type peer struct {
 	// Only modified on the connection's reader routine.
 	gotVersion utils.Atomic[bool]
 
+	// True if this peer sent its known peers in its Version message, which
+	// means that it supports GetPeerList.
+	// Only modified on the connection's reader routine.
+	supportsGetPeerList utils.Atomic[bool]
+
 	// True if the peer:
 	// * Has sent us a Version message
 	// * Has sent us a PeerList message
@@ -164,9 +181,15 @@ type peer struct {
 	// Must only be accessed atomically
 	lastSent, lastReceived int64
 
-	// peerListChan signals that we should attempt to send a PeerList to this
-	// peer
-	peerListChan chan struct{}
+	// Unix time, in nanoseconds, that the outstanding Ping message was sent.
+	// Zero if there isn't an outstanding Ping message. Used to measure the
+	// latency of the peer.
+	// Must only be accessed atomically
+	pingSent int64
+
+	// gossipPeerListChan signals that we should attempt to gossip peers with
+	// this peer
+	gossipPeerListChan chan struct{}
 }
 
 // Start a new peer instance.
@@ -193,8 +216,9 @@ func Start(
 		onClosingCtxCancel: onClosingCtxCancel,
 		onClosed:           make(chan struct{}),
 		observedUptimes:    make(map[ids.ID]uint32),
-		peerListChan:       make(chan struct{}, 1),
+		gossipPeerListChan: make(chan struct{}, 1),
 	}
+	p.compressionType.Set(compression.TypeNone)
 
 	go p.readMessages()
 	go p.writeMessages()
@@ -271,6 +295,7 @@ func (p *peer) Info() Info {
 		LastReceived:          time.Unix(atomic.LoadInt64(&p.lastReceived), 0),
 		ObservedUptime:        json.Uint32(primaryUptime),
 		ObservedSubnetUptimes: uptimes,
+		ReputationScore:       json.Float64(p.PeerDB.Score(p.id)),
 		TrackedSubnets:        trackedSubnets,
 	}
 }
@@ -299,9 +324,9 @@ func (p *peer) Send(ctx context.Context, msg message.OutboundMessage) bool {
 	return p.messageQueue.Push(ctx, msg)
 }
 
-func (p *peer) StartSendPeerList() {
+func (p *peer) StartGossipPeerList() {
 	select {
-	case p.peerListChan <- struct{}{}:
+	case p.gossipPeerListChan <- struct{}{}:
 	default:
 	}
 }
@@ -451,8 +476,16 @@ func (p *peer) readMessages() {
 			zap.Binary("messageBytes", msgBytes),
 		)
 
-		// Parse the message
-		msg, err := p.MessageCreator.Parse(msgBytes, p.id, onFinishedHandling)
+		// Parse the message. [releaseChain] is set below once the message has
+		// acquired space in the allocation of the subnet it is destined for.
+		var releaseChain throttling.ReleaseFunc
+		onFinishedHandlingChain := func() {
+			if releaseChain != nil {
+				releaseChain()
+			}
+			onFinishedHandling()
+		}
+		msg, err := p.MessageCreator.Parse(msgBytes, p.id, onFinishedHandlingChain)
 		if err != nil {
 			p.Log.Verbo("failed to parse message",
 				zap.Stringer("nodeID", p.id),
@@ -461,6 +494,7 @@ func (p *peer) readMessages() {
 			)
 
 			p.Metrics.FailedToParse.Inc()
+			p.bannableProtocolViolation()
 
 			// Couldn't parse the message. Read the next one.
 			onFinishedHandling()
@@ -468,6 +502,31 @@ func (p *peer) readMessages() {
 			continue
 		}
 
+		// Drop the message if the subnet of the chain it is destined for has
+		// exhausted its allocation, so that the chains of one subnet can't
+		// starve the chains of other subnets. The message is dropped rather
+		// than waited on so that messages from this peer to other subnets
+		// aren't delayed. Messages destined for the Primary Network are never
+		// dropped, and nothing is dropped unless a subnet reserves a share.
+		if chainID, err := message.GetChainID(msg.Message()); err == nil {
+			var ok bool
+			releaseChain, ok = p.InboundMsgThrottler.AcquireChain(
+				uint64(msgLen),
+				p.id,
+				chainID,
+			)
+			if !ok {
+				p.Log.Verbo("dropping message due to subnet throttling",
+					zap.Stringer("nodeID", p.id),
+					zap.Stringer("chainID", chainID),
+					zap.Stringer("messageOp", msg.Op()),
+				)
+				msg.OnFinishedHandling()
+				p.ResourceTracker.StopProcessing(p.id, p.Clock.Time())
+				continue
+			}
+		}
+
 		now := p.Clock.Time().Unix()
 		atomic.StoreInt64(&p.Config.LastReceived, now)
 		atomic.StoreInt64(&p.lastReceived, now)
@@ -497,6 +556,7 @@ func (p *peer) writeMessages() {
 		return
 	}
 
+	knownPeersFilter, knownPeersSalt := p.Network.KnownPeers()
 	msg, err := p.MessageCreator.Version(
 		p.NetworkID,
 		p.Clock.Unix(),
@@ -505,6 +565,8 @@ func (p *peer) writeMessages() {
 		mySignedIP.Timestamp,
 		mySignedIP.Signature,
 		p.MySubnets.List(),
+		knownPeersFilter,
+		knownPeersSalt,
 	)
 	if err != nil {
 		p.Log.Error("failed to create message",
@@ -544,7 +606,15 @@ func (p *peer) writeMessages() {
 }
 
 func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
-	msgBytes := msg.Bytes()
+	msgBytes, err := p.messageBytes(msg)
+	if err != nil {
+		p.Log.Verbo("error compressing message",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Error(err),
+		)
+		return
+	}
 	p.Log.Verbo("sending message",
 		zap.Stringer("nodeID", p.id),
 		zap.Binary("messageBytes", msgBytes),
@@ -581,7 +651,17 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	now := p.Clock.Time().Unix()
 	atomic.StoreInt64(&p.Config.LastSent, now)
 	atomic.StoreInt64(&p.lastSent, now)
-	p.Metrics.Sent(msg)
+	p.Metrics.Sent(msg, len(msgBytes))
+}
+
+// messageBytes returns the bytes of [msg] compressed with the compression type
+// negotiated with the peer
+func (p *peer) messageBytes(msg message.OutboundMessage) ([]byte, error) {
+	compressionType := p.compressionType.Get()
+	if compressionType == msg.Compression() {
+		return msg.Bytes(), nil
+	}
+	return msg.BytesCompressedWith(compressionType)
 }
 
 func (p *peer) sendNetworkMessages() {
@@ -595,41 +675,17 @@ func (p *peer) sendNetworkMessages() {
 
 	for {
 		select {
-		case <-p.peerListChan:
-			peerIPs, err := p.Config.Network.Peers(p.id)
-			if err != nil {
-				p.Log.Error("failed to get peers to gossip",
+		case <-p.gossipPeerListChan:
+			p.gossipPeerList()
+		case <-sendPingsTicker.C:
+			if p.isBanned() {
+				p.Log.Debug("disconnecting from peer",
+					zap.String("reason", "peer is banned"),
 					zap.Stringer("nodeID", p.id),
-					zap.Error(err),
 				)
 				return
 			}
 
-			if len(peerIPs) == 0 {
-				p.Log.Debug(
-					"skipping peer gossip as there are no unknown peers",
-					zap.Stringer("nodeID", p.id),
-				)
-				continue
-			}
-
-			// Bypass throttling is disabled here to follow the non-handshake
-			// message sending pattern.
-			msg, err := p.Config.MessageCreator.PeerList(peerIPs, false /*=bypassThrottling*/)
-			if err != nil {
-				p.Log.Error("failed to create peer list message",
-					zap.Stringer("nodeID", p.id),
-					zap.Error(err),
-				)
-				continue
-			}
-
-			if !p.Send(p.onClosingCtx, msg) {
-				p.Log.Debug("failed to send peer list",
-					zap.Stringer("nodeID", p.id),
-				)
-			}
-		case <-sendPingsTicker.C:
 			if !p.Network.AllowConnection(p.id) {
 				p.Log.Debug("disconnecting from peer",
 					zap.String("reason", "connection is no longer desired"),
@@ -659,6 +715,9 @@ func (p *peer) sendNetworkMessages() {
 				return
 			}
 
+			// If a Pong wasn't received for the previous Ping, the latency is
+			// measured from this Ping.
+			atomic.StoreInt64(&p.pingSent, p.Clock.Time().UnixNano())
 			p.Send(p.onClosingCtx, pingMessage)
 		case <-p.onClosingCtx.Done():
 			return
@@ -684,8 +743,17 @@ func (p *peer) handle(msg message.InboundMessage) {
 		p.handlePeerList(m)
 		msg.OnFinishedHandling()
 		return
+	case *p2p.GetPeerList:
+		p.handleGetPeerList(m)
+		msg.OnFinishedHandling()
+		return
 	case *p2p.PeerListAck:
-		p.handlePeerListAck(m)
+		// PeerListAck is no longer sent, but may still be received from
+		// nodes that haven't upgraded.
+		p.Log.Debug("dropping deprecated message",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", message.PeerListAckOp),
+		)
 		msg.OnFinishedHandling()
 		return
 	}
@@ -750,11 +818,17 @@ func (p *peer) handlePing(*p2p.Ping) {
 }
 
 func (p *peer) handlePong(msg *p2p.Pong) {
+	if pingSent := atomic.SwapInt64(&p.pingSent, 0); pingSent != 0 {
+		latency := p.Clock.Time().Sub(time.Unix(0, pingSent))
+		p.PeerDB.ObserveLatency(p.id, latency)
+	}
+
 	if msg.Uptime > 100 {
 		p.Log.Debug("dropping pong message with invalid uptime",
 			zap.Stringer("nodeID", p.id),
 			zap.Uint32("uptime", msg.Uptime),
 		)
+		p.PeerDB.ProtocolViolation(p.id)
 		p.StartClose()
 		return
 	}
@@ -767,6 +841,7 @@ func (p *peer) handlePong(msg *p2p.Pong) {
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
 			)
+			p.PeerDB.ProtocolViolation(p.id)
 			p.StartClose()
 			return
 		}
@@ -778,6 +853,7 @@ func (p *peer) handlePong(msg *p2p.Pong) {
 				zap.Stringer("subnetID", subnetID),
 				zap.Uint32("uptime", uptime),
 			)
+			p.PeerDB.ProtocolViolation(p.id)
 			p.StartClose()
 			return
 		}
@@ -837,6 +913,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.Stringer("nodeID", p.id),
 			zap.Error(err),
 		)
+		p.PeerDB.ProtocolViolation(p.id)
 		p.StartClose()
 		return
 	}
@@ -886,6 +963,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
 			)
+			p.PeerDB.ProtocolViolation(p.id)
 			p.StartClose()
 			return
 		}
@@ -895,6 +973,14 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 		}
 	}
 
+	compressionType := message.NegotiateCompression(p.CompressionType, message.SupportedCompression(msg))
+	if compressionType == compression.TypeNone && p.CompressionType != compression.TypeNone {
+		p.Log.Debug("peer doesn't support any compression type",
+			zap.Stringer("nodeID", p.id),
+		)
+	}
+	p.compressionType.Set(compressionType)
+
 	// "net.IP" type in Golang is 16-byte
 	if ipLen := len(msg.IpAddr); ipLen != net.IPv6len {
 		p.Log.Debug("message with invalid field",
@@ -903,6 +989,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.String("field", "IP"),
 			zap.Int("ipLen", ipLen),
 		)
+		p.PeerDB.ProtocolViolation(p.id)
 		p.StartClose()
 		return
 	}
@@ -922,13 +1009,20 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.Stringer("nodeID", p.id),
 			zap.Error(err),
 		)
+		p.bannableProtocolViolation()
 		p.StartClose()
 		return
 	}
 
+	knownPeers, salt, ok := p.parseVersionKnownPeers(msg.KnownPeers)
+	if !ok {
+		return
+	}
+
+	p.supportsGetPeerList.Set(msg.KnownPeers != nil)
 	p.gotVersion.Set(true)
 
-	peerIPs, err := p.Network.Peers(p.id)
+	peerIPs, err := p.Network.Peers(p.id, knownPeers, salt)
 	if err != nil {
 		p.Log.Error("failed to get peers to gossip for handshake",
 			zap.Stringer("nodeID", p.id),
@@ -978,6 +1072,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "Cert"),
 				zap.Error(err),
 			)
+			p.PeerDB.ProtocolViolation(p.id)
 			p.StartClose()
 			return
 		}
@@ -990,6 +1085,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "IP"),
 				zap.Int("ipLen", ipLen),
 			)
+			p.PeerDB.ProtocolViolation(p.id)
 			p.StartClose()
 			return
 		}
@@ -1005,6 +1101,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 					zap.String("field", "txID"),
 					zap.Error(err),
 				)
+				p.PeerDB.ProtocolViolation(p.id)
 				p.StartClose()
 				return
 			}
@@ -1022,52 +1119,173 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 		}
 	}
 
-	trackedPeers, err := p.Network.Track(p.id, discoveredIPs)
-	if err != nil {
+	if err := p.Network.Track(p.id, discoveredIPs); err != nil {
 		p.Log.Debug("message with invalid field",
 			zap.Stringer("nodeID", p.id),
 			zap.Stringer("messageOp", message.PeerListOp),
 			zap.String("field", "claimedIP"),
 			zap.Error(err),
 		)
+		p.bannableProtocolViolation()
 		p.StartClose()
+	}
+}
+
+func (p *peer) handleGetPeerList(msg *p2p.GetPeerList) {
+	if !p.finishedHandshake.Get() {
+		p.Log.Verbo("dropping get peer list message",
+			zap.String("reason", "handshake isn't finished"),
+			zap.Stringer("nodeID", p.id),
+		)
+		return
+	}
+
+	knownPeers, salt, ok := p.parseKnownPeers(message.GetPeerListOp, msg.KnownPeers)
+	if !ok {
 		return
 	}
-	if len(trackedPeers) == 0 {
-		p.Log.Debug("skipping peerlist ack as there were no tracked peers",
+	p.sendPeerList(knownPeers, salt)
+}
+
+// sendPeerList sends the peers to gossip that aren't in [knownPeers] to this
+// peer.
+func (p *peer) sendPeerList(knownPeers *bloom.ReadFilter, salt []byte) {
+	peerIPs, err := p.Network.Peers(p.id, knownPeers, salt)
+	if err != nil {
+		p.Log.Error("failed to get peers to gossip",
 			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
 		)
 		return
 	}
 
-	peerListAckMsg, err := p.Config.MessageCreator.PeerListAck(trackedPeers)
+	if len(peerIPs) == 0 {
+		p.Log.Debug("skipping peer gossip as there are no unknown peers",
+			zap.Stringer("nodeID", p.id),
+		)
+		return
+	}
+
+	// Bypass throttling is disabled here to follow the non-handshake message
+	// sending pattern.
+	peerListMsg, err := p.Config.MessageCreator.PeerList(peerIPs, false /*=bypassThrottling*/)
 	if err != nil {
-		p.Log.Error("failed to create message",
+		p.Log.Error("failed to create peer list message",
 			zap.Stringer("nodeID", p.id),
-			zap.Stringer("messageOp", message.PeerListAckOp),
 			zap.Error(err),
 		)
 		return
 	}
 
-	if !p.Send(p.onClosingCtx, peerListAckMsg) {
-		p.Log.Debug("failed to send peer list ack",
+	if !p.Send(p.onClosingCtx, peerListMsg) {
+		p.Log.Debug("failed to send peer list",
 			zap.Stringer("nodeID", p.id),
 		)
 	}
 }
 
-func (p *peer) handlePeerListAck(msg *p2p.PeerListAck) {
-	err := p.Network.MarkTracked(p.id, msg.PeerAcks)
+// gossipPeerList sends a GetPeerList message to this peer, or, if this peer
+// doesn't support GetPeerList, a PeerList message.
+func (p *peer) gossipPeerList() {
+	if !p.supportsGetPeerList.Get() {
+		// Peers that don't support GetPeerList never request peers, so they
+		// are sent the peers to gossip directly.
+		p.sendPeerList(bloom.EmptyFilter, nil)
+		return
+	}
+
+	knownPeersFilter, knownPeersSalt := p.Config.Network.KnownPeers()
+	msg, err := p.Config.MessageCreator.GetPeerList(knownPeersFilter, knownPeersSalt)
+	if err != nil {
+		p.Log.Error("failed to create get peer list message",
+			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if !p.Send(p.onClosingCtx, msg) {
+		p.Log.Debug("failed to send get peer list",
+			zap.Stringer("nodeID", p.id),
+		)
+	}
+}
+
+// parseVersionKnownPeers parses the known peers sent in the Version message.
+// Peers that don't support GetPeerList don't send their known peers, so they
+// are assumed to not know about any peers.
+func (p *peer) parseVersionKnownPeers(msg *p2p.BloomFilter) (*bloom.ReadFilter, []byte, bool) {
+	if msg == nil {
+		return bloom.EmptyFilter, nil, true
+	}
+	return p.parseKnownPeers(message.VersionOp, msg)
+}
+
+// parseKnownPeers parses the bloom filter of the peers this peer knows about.
+// If the filter is invalid, the peer is disconnected and false is returned.
+func (p *peer) parseKnownPeers(op message.Op, msg *p2p.BloomFilter) (*bloom.ReadFilter, []byte, bool) {
+	filter, err := bloom.Parse(msg.GetFilter())
 	if err != nil {
 		p.Log.Debug("message with invalid field",
 			zap.Stringer("nodeID", p.id),
-			zap.Stringer("messageOp", message.PeerListAckOp),
-			zap.String("field", "txID"),
+			zap.Stringer("messageOp", op),
+			zap.String("field", "KnownPeers.Filter"),
 			zap.Error(err),
 		)
+		p.PeerDB.ProtocolViolation(p.id)
 		p.StartClose()
+		return nil, nil, false
+	}
+
+	salt := msg.GetSalt()
+	if saltLen := len(salt); saltLen > MaxBloomSaltLen {
+		p.Log.Debug("message with invalid field",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", op),
+			zap.String("field", "KnownPeers.Salt"),
+			zap.Int("saltLen", saltLen),
+		)
+		p.PeerDB.ProtocolViolation(p.id)
+		p.StartClose()
+		return nil, nil, false
+	}
+	return filter, salt, true
+}
+
+// bannableProtocolViolation records that the peer sent a message that an
+// honest peer never sends: an unparsable message or an invalid signed IP. If
+// the peer sent too many of them recently, it is banned and disconnected.
+// Validators are never banned automatically, so that a bug in their messages
+// can't cause this node to disconnect from a large part of the network.
+func (p *peer) bannableProtocolViolation() {
+	p.PeerDB.ProtocolViolation(p.id)
+	if !p.ProtocolViolations.Violation(p.id) {
+		return
+	}
+	if p.Validators.Contains(p.id) {
+		p.Log.Debug("not banning peer",
+			zap.String("reason", "peer is a validator"),
+			zap.Stringer("nodeID", p.id),
+		)
+		return
+	}
+
+	if err := p.BanList.BanNode(p.id, "too many protocol violations", p.ProtocolViolationBanDuration); err != nil {
+		p.Log.Error("failed to ban peer",
+			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
+		)
+	}
+	p.StartClose()
+}
+
+// isBanned returns true if either the nodeID or the IP of the peer is banned
+func (p *peer) isBanned() bool {
+	if p.BanList.IsNodeBanned(p.id) {
+		return true
 	}
+	ip, err := ips.ToIPPort(p.conn.RemoteAddr().String())
+	return err == nil && p.BanList.IsIPBanned(ip.IP)
 }
 
 func (p *peer) nextTimeout() time.Time {
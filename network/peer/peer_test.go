@@ -15,14 +15,19 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dioneprotocol/dionego/database/memdb"
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/message"
+	"github.com/dioneprotocol/dionego/network/banlist"
+	"github.com/dioneprotocol/dionego/network/peerdb"
 	"github.com/dioneprotocol/dionego/network/throttling"
 	"github.com/dioneprotocol/dionego/proto/pb/p2p"
 	"github.com/dioneprotocol/dionego/snow/networking/router"
 	"github.com/dioneprotocol/dionego/snow/networking/tracker"
 	"github.com/dioneprotocol/dionego/snow/validators"
 	"github.com/dioneprotocol/dionego/staking"
+	"github.com/dioneprotocol/dionego/utils/bloom"
+	"github.com/dioneprotocol/dionego/utils/compression"
 	"github.com/dioneprotocol/dionego/utils/constants"
 	"github.com/dioneprotocol/dionego/utils/ips"
 	"github.com/dioneprotocol/dionego/utils/logging"
@@ -51,7 +56,7 @@ func newMessageCreator(t *testing.T) message.Creator {
 	mc, err := message.NewCreator(
 		prometheus.NewRegistry(),
 		"",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -91,9 +96,20 @@ func makeRawTestPeers(t *testing.T) (*rawTestPeer, *rawTestPeer) {
 	)
 	require.NoError(err)
 
+	peerDB, err := peerdb.New(
+		logging.NoLog{},
+		memdb.New(),
+		constants.DefaultNetworkPeerDBMaxRecords,
+	)
+	require.NoError(err)
+
+	banList, err := banlist.New(logging.NoLog{}, memdb.New())
+	require.NoError(err)
+
 	sharedConfig := Config{
 		Metrics:              metrics,
 		MessageCreator:       mc,
+		CompressionType:      compression.TypeGzip,
 		Log:                  logging.NoLog{},
 		InboundMsgThrottler:  throttling.NewNoInboundThrottler(),
 		VersionCompatibility: version.GetCompatibility(constants.LocalID),
@@ -104,6 +120,10 @@ func makeRawTestPeers(t *testing.T) (*rawTestPeer, *rawTestPeer) {
 		PongTimeout:          constants.DefaultPingPongTimeout,
 		MaxClockDifference:   time.Minute,
 		ResourceTracker:      resourceTracker,
+		PeerDB:               peerDB,
+		BanList:              banList,
+		ProtocolViolations:   banlist.NewViolationTracker(0, 0),
+		Validators:           validators.NewSet(),
 	}
 	peerConfig0 := sharedConfig
 	peerConfig1 := sharedConfig
@@ -272,3 +292,222 @@ func TestSend(t *testing.T) {
 	err = peer1.AwaitClosed(context.Background())
 	require.NoError(err)
 }
+
+func TestProtocolViolationBan(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+	rawPeer0.config.ProtocolViolations = banlist.NewViolationTracker(2, time.Hour)
+	rawPeer0.config.ProtocolViolationBanDuration = time.Hour
+
+	peer0 := Start(
+		rawPeer0.config,
+		rawPeer0.conn,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+	banList := rawPeer0.config.BanList
+
+	peer0.(*peer).bannableProtocolViolation()
+	require.False(banList.IsNodeBanned(rawPeer1.nodeID))
+
+	// The peer is banned and disconnected once it reaches the maximum number
+	// of protocol violations within the window
+	peer0.(*peer).bannableProtocolViolation()
+	require.True(banList.IsNodeBanned(rawPeer1.nodeID))
+	require.NoError(peer0.AwaitClosed(context.Background()))
+}
+
+func TestProtocolViolationBanSkipsValidators(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+	rawPeer0.config.ProtocolViolations = banlist.NewViolationTracker(1, time.Hour)
+	rawPeer0.config.ProtocolViolationBanDuration = time.Hour
+	rawPeer0.config.Validators = validators.NewSet()
+	require.NoError(rawPeer0.config.Validators.Add(rawPeer1.nodeID, nil, ids.Empty, 1))
+
+	peer0 := Start(
+		rawPeer0.config,
+		rawPeer0.conn,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+
+	// Validators are never banned for protocol violations
+	peer0.(*peer).bannableProtocolViolation()
+	require.False(rawPeer0.config.BanList.IsNodeBanned(rawPeer1.nodeID))
+
+	peer0.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+}
+
+// knownPeersTestNetwork records the known peers that it was asked to filter
+// the peers to gossip with.
+type knownPeersTestNetwork struct {
+	testNetwork
+
+	peers      []ips.ClaimedIPPort
+	knownPeers *bloom.ReadFilter
+	salt       []byte
+}
+
+func (n *knownPeersTestNetwork) Peers(_ ids.NodeID, knownPeers *bloom.ReadFilter, salt []byte) ([]ips.ClaimedIPPort, error) {
+	n.knownPeers = knownPeers
+	n.salt = salt
+	return n.peers, nil
+}
+
+// makeHandshakedTestPeer returns a peer that finished the handshake, without
+// starting its goroutines, so that its message handlers can be called directly.
+func makeHandshakedTestPeer(t *testing.T, network Network) (*peer, MessageQueue) {
+	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+	rawPeer0.config.Network = network
+
+	messageQueue := NewThrottledMessageQueue(
+		rawPeer0.config.Metrics,
+		rawPeer1.nodeID,
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+	)
+	onClosingCtx, onClosingCtxCancel := context.WithCancel(context.Background())
+	p := &peer{
+		Config:             rawPeer0.config,
+		conn:               rawPeer0.conn,
+		cert:               rawPeer1.cert,
+		id:                 rawPeer1.nodeID,
+		messageQueue:       messageQueue,
+		onClosingCtx:       onClosingCtx,
+		onClosingCtxCancel: onClosingCtxCancel,
+	}
+	p.finishedHandshake.Set(true)
+	return p, messageQueue
+}
+
+func TestHandleGetPeerList(t *testing.T) {
+	require := require.New(t)
+
+	network := &knownPeersTestNetwork{}
+	p, messageQueue := makeHandshakedTestPeer(t, network)
+	network.peers = []ips.ClaimedIPPort{{
+		Cert:   p.cert,
+		IPPort: ips.IPPort{IP: net.IPv6loopback, Port: 9651},
+	}}
+
+	knownPeers, err := bloom.NewWriteFilter(1, 8)
+	require.NoError(err)
+	salt := []byte{1, 2, 3}
+	p.handleGetPeerList(&p2p.GetPeerList{
+		KnownPeers: &p2p.BloomFilter{
+			Filter: knownPeers.Marshal(),
+			Salt:   salt,
+		},
+	})
+
+	// The peers that aren't known are replied with a PeerList
+	require.Equal(knownPeers.Marshal(), network.knownPeers.Marshal())
+	require.Equal(salt, network.salt)
+	msg, ok := messageQueue.PopNow()
+	require.True(ok)
+	require.Equal(message.PeerListOp, msg.Op())
+	require.NoError(p.onClosingCtx.Err())
+}
+
+func TestHandleGetPeerListInvalidKnownPeers(t *testing.T) {
+	tests := []struct {
+		name       string
+		knownPeers *p2p.BloomFilter
+	}{
+		{
+			name:       "missing filter",
+			knownPeers: nil,
+		},
+		{
+			name: "invalid filter",
+			knownPeers: &p2p.BloomFilter{
+				Filter: []byte{bloom.MaxHashes + 1, 0},
+			},
+		},
+		{
+			name: "oversized salt",
+			knownPeers: &p2p.BloomFilter{
+				Filter: bloom.EmptyFilter.Marshal(),
+				Salt:   make([]byte, MaxBloomSaltLen+1),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			network := &knownPeersTestNetwork{}
+			p, messageQueue := makeHandshakedTestPeer(t, network)
+
+			p.handleGetPeerList(&p2p.GetPeerList{
+				KnownPeers: test.knownPeers,
+			})
+
+			// The peer is disconnected without being sent any peers
+			require.Nil(network.knownPeers)
+			_, ok := messageQueue.PopNow()
+			require.False(ok)
+			require.ErrorIs(p.onClosingCtx.Err(), context.Canceled)
+		})
+	}
+}
+
+func TestParseVersionKnownPeers(t *testing.T) {
+	require := require.New(t)
+
+	p, _ := makeHandshakedTestPeer(t, TestNetwork)
+
+	// Peers that don't send their known peers don't know about any peers
+	knownPeers, salt, ok := p.parseVersionKnownPeers(nil)
+	require.True(ok)
+	require.Equal(bloom.EmptyFilter, knownPeers)
+	require.Nil(salt)
+	require.NoError(p.onClosingCtx.Err())
+
+	_, _, ok = p.parseVersionKnownPeers(&p2p.BloomFilter{
+		Filter: bloom.EmptyFilter.Marshal(),
+		Salt:   make([]byte, MaxBloomSaltLen+1),
+	})
+	require.False(ok)
+	require.ErrorIs(p.onClosingCtx.Err(), context.Canceled)
+}
+
+func TestGossipPeerList(t *testing.T) {
+	require := require.New(t)
+
+	network := &knownPeersTestNetwork{}
+	p, messageQueue := makeHandshakedTestPeer(t, network)
+	network.peers = []ips.ClaimedIPPort{{
+		Cert:   p.cert,
+		IPPort: ips.IPPort{IP: net.IPv6loopback, Port: 9651},
+	}}
+
+	// Peers that didn't send their known peers are pushed peers
+	p.gossipPeerList()
+	msg, ok := messageQueue.PopNow()
+	require.True(ok)
+	require.Equal(message.PeerListOp, msg.Op())
+
+	// Peers that sent their known peers are asked which peers they are missing
+	p.supportsGetPeerList.Set(true)
+	p.gossipPeerList()
+	msg, ok = messageQueue.PopNow()
+	require.True(ok)
+	require.Equal(message.GetPeerListOp, msg.Op())
+}
@@ -4,6 +4,11 @@
 package peer
 
 import (
+	"math"
+	"math/rand"
+
+	"golang.org/x/exp/slices"
+
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/utils/sampler"
 )
@@ -43,6 +48,11 @@ type Set interface {
 	// [precondition] to return true will be returned in the slice.
 	Sample(n int, precondition func(Peer) bool) []Peer
 
+	// SampleWeighted is the same as Sample, except that peers are sampled
+	// with probability proportional to [weight]. Peers with a weight <= 0 are
+	// never sampled.
+	SampleWeighted(n int, weight func(Peer) float64, precondition func(Peer) bool) []Peer
+
 	// Returns information about all the peers.
 	AllInfo() []Info
 
@@ -140,6 +150,47 @@ func (s *peerSet) Sample(n int, precondition func(Peer) bool) []Peer {
 	return peers
 }
 
+func (s *peerSet) SampleWeighted(n int, weight func(Peer) float64, precondition func(Peer) bool) []Peer {
+	if n <= 0 {
+		return nil
+	}
+
+	// Peers are ordered by u^(1/weight), where u is uniform in [0, 1), which
+	// orders them as if they were sampled without replacement with
+	// probability proportional to their weights. Randomization is only used to
+	// spread load across peers, so cryptographically secure random number
+	// generation isn't required.
+	type weightedPeer struct {
+		peer Peer
+		key  float64
+	}
+	weightedPeers := make([]weightedPeer, 0, len(s.peersSlice))
+	for _, peer := range s.peersSlice {
+		w := weight(peer)
+		if w <= 0 {
+			continue
+		}
+		weightedPeers = append(weightedPeers, weightedPeer{
+			peer: peer,
+			key:  math.Pow(rand.Float64(), 1/w), // #nosec G404
+		})
+	}
+	slices.SortFunc(weightedPeers, func(a, b weightedPeer) bool {
+		return a.key > b.key
+	})
+
+	peers := make([]Peer, 0, n)
+	for _, weightedPeer := range weightedPeers {
+		if len(peers) >= n {
+			break
+		}
+		if precondition(weightedPeer.peer) {
+			peers = append(peers, weightedPeer.peer)
+		}
+	}
+	return peers
+}
+
 func (s *peerSet) AllInfo() []Info {
 	peerInfo := make([]Info, len(s.peersSlice))
 	for i, peer := range s.peersSlice {
@@ -139,3 +139,53 @@ func TestSetSample(t *testing.T) {
 	peers = set.Sample(1, NoPrecondition)
 	require.Len(peers, 1)
 }
+
+func TestSetSampleWeighted(t *testing.T) {
+	require := require.New(t)
+
+	set := NewSet()
+
+	peer1 := &peer{
+		id: ids.NodeID{0x01},
+	}
+	peer2 := &peer{
+		id: ids.NodeID{0x02},
+	}
+	peer3 := &peer{
+		id: ids.NodeID{0x03},
+	}
+	weights := map[ids.NodeID]float64{
+		peer1.id: 1,
+		peer2.id: 1,
+		peer3.id: 0,
+	}
+	weight := func(p Peer) float64 {
+		return weights[p.ID()]
+	}
+
+	// Case: Empty
+	peers := set.SampleWeighted(1, weight, NoPrecondition)
+	require.Empty(peers)
+
+	set.Add(peer1)
+	set.Add(peer2)
+	set.Add(peer3)
+
+	peers = set.SampleWeighted(0, weight, NoPrecondition)
+	require.Empty(peers)
+
+	// Peers without weight are never sampled
+	peers = set.SampleWeighted(3, weight, NoPrecondition)
+	require.ElementsMatch([]Peer{peer1, peer2}, peers)
+
+	// Only peers that pass the precondition are sampled
+	peers = set.SampleWeighted(3, weight, func(p Peer) bool {
+		return p.ID() != peer1.id
+	})
+	require.Equal([]Peer{peer2}, peers)
+
+	// Peers with much higher weights are sampled first
+	weights[peer1.id] = 1e-9
+	peers = set.SampleWeighted(1, weight, NoPrecondition)
+	require.Equal([]Peer{peer2}, peers)
+}
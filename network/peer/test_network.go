@@ -5,7 +5,7 @@ package peer
 
 import (
 	"github.com/dioneprotocol/dionego/ids"
-	"github.com/dioneprotocol/dionego/proto/pb/p2p"
+	"github.com/dioneprotocol/dionego/utils/bloom"
 	"github.com/dioneprotocol/dionego/utils/ips"
 )
 
@@ -19,16 +19,16 @@ func (testNetwork) AllowConnection(ids.NodeID) bool {
 	return true
 }
 
-func (testNetwork) Track(ids.NodeID, []*ips.ClaimedIPPort) ([]*p2p.PeerAck, error) {
-	return nil, nil
-}
-
-func (testNetwork) MarkTracked(ids.NodeID, []*p2p.PeerAck) error {
+func (testNetwork) Track(ids.NodeID, []*ips.ClaimedIPPort) error {
 	return nil
 }
 
 func (testNetwork) Disconnected(ids.NodeID) {}
 
-func (testNetwork) Peers(ids.NodeID) ([]ips.ClaimedIPPort, error) {
+func (testNetwork) KnownPeers() ([]byte, []byte) {
+	return bloom.EmptyFilter.Marshal(), nil
+}
+
+func (testNetwork) Peers(ids.NodeID, *bloom.ReadFilter, []byte) ([]ips.ClaimedIPPort, error) {
 	return nil, nil
 }
@@ -11,13 +11,17 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/dioneprotocol/dionego/database/memdb"
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/message"
+	"github.com/dioneprotocol/dionego/network/banlist"
+	"github.com/dioneprotocol/dionego/network/peerdb"
 	"github.com/dioneprotocol/dionego/network/throttling"
 	"github.com/dioneprotocol/dionego/snow/networking/router"
 	"github.com/dioneprotocol/dionego/snow/networking/tracker"
 	"github.com/dioneprotocol/dionego/snow/validators"
 	"github.com/dioneprotocol/dionego/staking"
+	"github.com/dioneprotocol/dionego/utils/compression"
 	"github.com/dioneprotocol/dionego/utils/constants"
 	"github.com/dioneprotocol/dionego/utils/ips"
 	"github.com/dioneprotocol/dionego/utils/logging"
@@ -71,7 +75,7 @@ func StartTestPeer(
 	mc, err := message.NewCreator(
 		prometheus.NewRegistry(),
 		"",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	if err != nil {
@@ -97,6 +101,20 @@ func StartTestPeer(
 		return nil, err
 	}
 
+	peerDB, err := peerdb.New(
+		logging.NoLog{},
+		memdb.New(),
+		constants.DefaultNetworkPeerDBMaxRecords,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	banList, err := banlist.New(logging.NoLog{}, memdb.New())
+	if err != nil {
+		return nil, err
+	}
+
 	signerIP := ips.NewDynamicIPPort(net.IPv6zero, 0)
 	tls := tlsCert.PrivateKey.(crypto.Signer)
 
@@ -104,6 +122,7 @@ func StartTestPeer(
 		&Config{
 			Metrics:              metrics,
 			MessageCreator:       mc,
+			CompressionType:      compression.TypeGzip,
 			Log:                  logging.NoLog{},
 			InboundMsgThrottler:  throttling.NewNoInboundThrottler(),
 			Network:              TestNetwork,
@@ -117,6 +136,10 @@ func StartTestPeer(
 			MaxClockDifference:   time.Minute,
 			ResourceTracker:      resourceTracker,
 			IPSigner:             NewIPSigner(signerIP, tls),
+			PeerDB:               peerDB,
+			BanList:              banList,
+			ProtocolViolations:   banlist.NewViolationTracker(0, 0),
+			Validators:           validators.NewSet(),
 		},
 		conn,
 		cert,
@@ -0,0 +1,22 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peerdb
+
+import (
+	"github.com/dioneprotocol/dionego/codec"
+	"github.com/dioneprotocol/dionego/codec/linearcodec"
+)
+
+const codecVersion = 0
+
+// c is used to marshal and unmarshal the records stored on disk
+var c codec.Manager
+
+func init() {
+	lc := linearcodec.NewDefault()
+	c = codec.NewDefaultManager()
+	if err := c.RegisterCodec(codecVersion, lc); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peerdb
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/utils/ips"
+	"github.com/dioneprotocol/dionego/utils/logging"
+	"github.com/dioneprotocol/dionego/utils/set"
+	"github.com/dioneprotocol/dionego/utils/timer/mockable"
+)
+
+var _ DB = (*peerDB)(nil)
+
+// DB persists what is known about peers across restarts, and scores the
+// reputation of peers based on it.
+//
+// Changes are kept in memory until [Commit] is called.
+type DB interface {
+	// Get returns the record of [nodeID], if there is one
+	Get(nodeID ids.NodeID) (Record, bool)
+
+	// Records returns the records of all the known peers
+	Records() []Record
+
+	// Score returns the reputation score of [nodeID]. Peers without a record
+	// are given the score of a new record.
+	Score(nodeID ids.NodeID) float64
+
+	// Connected records that a handshake with [nodeID] finished, and that
+	// [ip] is the most recent signed IP of the peer.
+	Connected(nodeID ids.NodeID, ip *ips.ClaimedIPPort)
+
+	// ConnectionFailed records that an attempt to connect to [nodeID] failed
+	// before the handshake finished.
+	ConnectionFailed(nodeID ids.NodeID)
+
+	// ObserveLatency records that a round trip to [nodeID] took [latency]
+	ObserveLatency(nodeID ids.NodeID, latency time.Duration)
+
+	// ProtocolViolation records that [nodeID] sent an invalid message
+	ProtocolViolation(nodeID ids.NodeID)
+
+	// Commit writes the records modified since the last call to Commit to
+	// disk. If there are more than the maximum number of records, the peers
+	// that were seen least recently are removed.
+	Commit() error
+}
+
+type peerDB struct {
+	log        logging.Logger
+	db         database.Database
+	clock      mockable.Clock
+	maxRecords int
+
+	lock sync.Mutex
+	// Node ID --> Record of the node
+	records map[ids.NodeID]*Record
+	// Records that have changed since the last commit
+	modified set.Set[ids.NodeID]
+	// Records that have been removed since the last commit
+	removed set.Set[ids.NodeID]
+}
+
+// New returns a DB that stores at most [maxRecords] records in [db]. The
+// records already in [db] are loaded into memory.
+func New(log logging.Logger, db database.Database, maxRecords int) (DB, error) {
+	p := &peerDB{
+		log:        log,
+		db:         db,
+		maxRecords: maxRecords,
+		records:    make(map[ids.NodeID]*Record),
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		nodeID, err := ids.ToNodeID(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		r, err := unmarshalRecord(nodeID, it.Value())
+		if err != nil {
+			// The record will be removed on the next commit
+			log.Warn("dropping invalid peer record",
+				zap.Stringer("nodeID", nodeID),
+				zap.Error(err),
+			)
+			p.removed.Add(nodeID)
+			continue
+		}
+		p.records[nodeID] = r
+	}
+	return p, it.Error()
+}
+
+func (p *peerDB) Get(nodeID ids.NodeID) (Record, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	r, ok := p.records[nodeID]
+	if !ok {
+		return Record{}, false
+	}
+	return *r, true
+}
+
+func (p *peerDB) Records() []Record {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	records := make([]Record, 0, len(p.records))
+	for _, r := range p.records {
+		records = append(records, *r)
+	}
+	return records
+}
+
+func (p *peerDB) Score(nodeID ids.NodeID) float64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	r, ok := p.records[nodeID]
+	if !ok {
+		r = &Record{}
+	}
+	return r.Score()
+}
+
+func (p *peerDB) Connected(nodeID ids.NodeID, ip *ips.ClaimedIPPort) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	r := p.getOrCreate(nodeID)
+	r.IP = ip
+	r.LastSeen = p.clock.Time()
+	r.connectionSucceeded()
+}
+
+func (p *peerDB) ConnectionFailed(nodeID ids.NodeID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.getOrCreate(nodeID).connectionFailed()
+}
+
+func (p *peerDB) ObserveLatency(nodeID ids.NodeID, latency time.Duration) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.getOrCreate(nodeID).observeLatency(latency)
+}
+
+func (p *peerDB) ProtocolViolation(nodeID ids.NodeID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.getOrCreate(nodeID).ProtocolViolations++
+}
+
+func (p *peerDB) Commit() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.evict()
+
+	batch := p.db.NewBatch()
+	for nodeID := range p.removed {
+		if err := batch.Delete(nodeID[:]); err != nil {
+			return err
+		}
+	}
+	for nodeID := range p.modified {
+		b, err := marshalRecord(p.records[nodeID])
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(nodeID[:], b); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	p.modified.Clear()
+	p.removed.Clear()
+	return nil
+}
+
+// getOrCreate returns the record of [nodeID], which is marked as modified.
+// Assumes [p.lock] is held.
+func (p *peerDB) getOrCreate(nodeID ids.NodeID) *Record {
+	p.modified.Add(nodeID)
+	p.removed.Remove(nodeID)
+
+	r, ok := p.records[nodeID]
+	if !ok {
+		r = &Record{NodeID: nodeID}
+		p.records[nodeID] = r
+	}
+	return r
+}
+
+// evict removes the records of the peers that were seen least recently until
+// there are at most [p.maxRecords] records.
+// Assumes [p.lock] is held.
+func (p *peerDB) evict() {
+	numToEvict := len(p.records) - p.maxRecords
+	if numToEvict <= 0 {
+		return
+	}
+
+	records := make([]*Record, 0, len(p.records))
+	for _, r := range p.records {
+		records = append(records, r)
+	}
+	slices.SortFunc(records, func(a, b *Record) bool {
+		return a.LastSeen.Before(b.LastSeen)
+	})
+	for _, r := range records[:numToEvict] {
+		delete(p.records, r.NodeID)
+		p.modified.Remove(r.NodeID)
+		p.removed.Add(r.NodeID)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peerdb
+
+import (
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/database/memdb"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/staking"
+	"github.com/dioneprotocol/dionego/utils/ips"
+	"github.com/dioneprotocol/dionego/utils/logging"
+)
+
+func newTestIP(t *testing.T) *ips.ClaimedIPPort {
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(tlsCert.Certificate[0])
+	require.NoError(t, err)
+	return &ips.ClaimedIPPort{
+		Cert: cert,
+		IPPort: ips.IPPort{
+			IP:   net.IPv6loopback,
+			Port: 9651,
+		},
+		Timestamp: 1,
+		Signature: []byte{1, 2, 3},
+	}
+}
+
+func TestPeerDBPersistence(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	peers, err := New(logging.NoLog{}, db, 10)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	ip := newTestIP(t)
+	now := time.Unix(1000, 0)
+	peers.(*peerDB).clock.Set(now)
+
+	peers.Connected(nodeID, ip)
+	peers.ConnectionFailed(nodeID)
+	peers.ObserveLatency(nodeID, 50*time.Millisecond)
+	peers.ProtocolViolation(nodeID)
+
+	// Nothing is written until the changes are committed
+	reloaded, err := New(logging.NoLog{}, db, 10)
+	require.NoError(err)
+	require.Empty(reloaded.Records())
+
+	require.NoError(peers.Commit())
+
+	reloaded, err = New(logging.NoLog{}, db, 10)
+	require.NoError(err)
+	record, ok := reloaded.Get(nodeID)
+	require.True(ok)
+	require.Equal(nodeID, record.NodeID)
+	require.Equal(ip.Cert.Raw, record.IP.Cert.Raw)
+	require.Equal(ip.IPPort, record.IP.IPPort)
+	require.Equal(ip.Timestamp, record.IP.Timestamp)
+	require.Equal(ip.Signature, record.IP.Signature)
+	require.Equal(now, record.LastSeen)
+	require.Equal(uint64(1), record.ConnectionSuccesses)
+	require.Equal(uint64(1), record.ConnectionFailures)
+	require.Equal(50*time.Millisecond, record.Latency)
+	require.Equal(uint64(1), record.ProtocolViolations)
+	require.Equal(peers.Score(nodeID), reloaded.Score(nodeID))
+}
+
+func TestPeerDBInvalidRecord(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(db.Put(nodeID[:], []byte{1, 2, 3}))
+
+	peers, err := New(logging.NoLog{}, db, 10)
+	require.NoError(err)
+	_, ok := peers.Get(nodeID)
+	require.False(ok)
+
+	// The invalid record is removed on commit
+	require.NoError(peers.Commit())
+	has, err := db.Has(nodeID[:])
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestPeerDBEviction(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	peers, err := New(logging.NoLog{}, db, 2)
+	require.NoError(err)
+
+	nodeIDs := []ids.NodeID{
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+	}
+	ip := newTestIP(t)
+	for i, nodeID := range nodeIDs {
+		peers.(*peerDB).clock.Set(time.Unix(int64(i), 0))
+		peers.Connected(nodeID, ip)
+	}
+	require.NoError(peers.Commit())
+
+	// The peer that was seen least recently is evicted
+	_, ok := peers.Get(nodeIDs[0])
+	require.False(ok)
+
+	reloaded, err := New(logging.NoLog{}, db, 2)
+	require.NoError(err)
+	require.Len(reloaded.Records(), 2)
+	_, ok = reloaded.Get(nodeIDs[0])
+	require.False(ok)
+	_, ok = reloaded.Get(nodeIDs[1])
+	require.True(ok)
+	_, ok = reloaded.Get(nodeIDs[2])
+	require.True(ok)
+}
+
+func TestRecordScore(t *testing.T) {
+	require := require.New(t)
+
+	unknown := &Record{}
+	require.Equal(.25, unknown.Score())
+
+	reliable := &Record{
+		ConnectionSuccesses: 10,
+		Latency:             referenceLatency,
+	}
+	unreliable := &Record{
+		ConnectionFailures: 10,
+		Latency:            referenceLatency,
+	}
+	require.Greater(reliable.Score(), unknown.Score())
+	require.Less(unreliable.Score(), unknown.Score())
+
+	fast := &Record{Latency: referenceLatency / 2}
+	slow := &Record{Latency: 2 * referenceLatency}
+	require.Greater(fast.Score(), unknown.Score())
+	require.Less(slow.Score(), unknown.Score())
+
+	misbehaving := &Record{ProtocolViolations: 1}
+	require.Equal(unknown.Score()/2, misbehaving.Score())
+}
+
+func TestRecordHistory(t *testing.T) {
+	require := require.New(t)
+
+	r := &Record{}
+	for i := 0; i < maxConnectionAttempts-1; i++ {
+		r.connectionFailed()
+	}
+	r.connectionSucceeded()
+	require.Equal(uint64(maxConnectionAttempts/2-1), r.ConnectionFailures)
+	require.Equal(uint64(0), r.ConnectionSuccesses)
+
+	r.observeLatency(100 * time.Millisecond)
+	require.Equal(100*time.Millisecond, r.Latency)
+	r.observeLatency(900 * time.Millisecond)
+	require.Equal(200*time.Millisecond, r.Latency)
+}
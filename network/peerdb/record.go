@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peerdb
+
+import (
+	"crypto/x509"
+	"time"
+
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/utils/ips"
+)
+
+const (
+	// Weight of a new latency measurement in the latency estimate of a peer
+	latencyAlpha = .125
+	// Latency at which the responsiveness of a peer is halved
+	referenceLatency = 200 * time.Millisecond
+	// Once the connection attempts recorded for a peer reach this number, they
+	// are halved so that recent attempts outweigh older ones.
+	maxConnectionAttempts = 1024
+)
+
+// Record is what is known about a peer
+type Record struct {
+	NodeID ids.NodeID
+	// IP is the most recent signed IP of the peer. Nil if this node has never
+	// finished a handshake with the peer.
+	IP *ips.ClaimedIPPort
+	// LastSeen is the last time this node finished a handshake with the peer
+	LastSeen time.Time
+
+	ConnectionSuccesses uint64
+	ConnectionFailures  uint64
+	// Latency is the estimated round trip time to the peer. Zero if it has
+	// never been measured.
+	Latency time.Duration
+	// ProtocolViolations is the number of invalid messages sent by the peer
+	ProtocolViolations uint64
+}
+
+// Score returns the reputation of the peer, in (0, 1]. Peers with higher
+// scores are more reliable, more responsive and better behaved.
+//
+// The score is the product of:
+//   - The portion of connection attempts that succeeded, assuming one prior
+//     success and one prior failure.
+//   - [referenceLatency] / ([referenceLatency] + [Latency]), where an
+//     unmeasured latency is assumed to be [referenceLatency].
+//   - 1 / (1 + [ProtocolViolations]).
+func (r *Record) Score() float64 {
+	reliability := float64(r.ConnectionSuccesses+1) / float64(r.ConnectionSuccesses+r.ConnectionFailures+2)
+
+	latency := r.Latency
+	if latency == 0 {
+		latency = referenceLatency
+	}
+	responsiveness := float64(referenceLatency) / float64(referenceLatency+latency)
+
+	conduct := 1 / float64(1+r.ProtocolViolations)
+	return reliability * responsiveness * conduct
+}
+
+func (r *Record) connectionSucceeded() {
+	r.ConnectionSuccesses++
+	r.maybeHalveConnectionAttempts()
+}
+
+func (r *Record) connectionFailed() {
+	r.ConnectionFailures++
+	r.maybeHalveConnectionAttempts()
+}
+
+func (r *Record) maybeHalveConnectionAttempts() {
+	if r.ConnectionSuccesses+r.ConnectionFailures < maxConnectionAttempts {
+		return
+	}
+	r.ConnectionSuccesses /= 2
+	r.ConnectionFailures /= 2
+}
+
+func (r *Record) observeLatency(latency time.Duration) {
+	if r.Latency == 0 {
+		r.Latency = latency
+		return
+	}
+	r.Latency = time.Duration((1-latencyAlpha)*float64(r.Latency) + latencyAlpha*float64(latency))
+}
+
+// diskRecord is the format a Record is stored in
+type diskRecord struct {
+	// Empty if the IP of the peer isn't known
+	Cert      []byte `serialize:"true"`
+	IP        []byte `serialize:"true"`
+	Port      uint16 `serialize:"true"`
+	Timestamp uint64 `serialize:"true"`
+	Signature []byte `serialize:"true"`
+
+	LastSeen            int64  `serialize:"true"`
+	ConnectionSuccesses uint64 `serialize:"true"`
+	ConnectionFailures  uint64 `serialize:"true"`
+	Latency             int64  `serialize:"true"`
+	ProtocolViolations  uint64 `serialize:"true"`
+}
+
+func marshalRecord(r *Record) ([]byte, error) {
+	dr := diskRecord{
+		LastSeen:            r.LastSeen.Unix(),
+		ConnectionSuccesses: r.ConnectionSuccesses,
+		ConnectionFailures:  r.ConnectionFailures,
+		Latency:             int64(r.Latency),
+		ProtocolViolations:  r.ProtocolViolations,
+	}
+	if r.IP != nil {
+		dr.Cert = r.IP.Cert.Raw
+		dr.IP = r.IP.IPPort.IP
+		dr.Port = r.IP.IPPort.Port
+		dr.Timestamp = r.IP.Timestamp
+		dr.Signature = r.IP.Signature
+	}
+	return c.Marshal(codecVersion, &dr)
+}
+
+func unmarshalRecord(nodeID ids.NodeID, b []byte) (*Record, error) {
+	dr := diskRecord{}
+	if _, err := c.Unmarshal(b, &dr); err != nil {
+		return nil, err
+	}
+
+	r := &Record{
+		NodeID:              nodeID,
+		LastSeen:            time.Unix(dr.LastSeen, 0),
+		ConnectionSuccesses: dr.ConnectionSuccesses,
+		ConnectionFailures:  dr.ConnectionFailures,
+		Latency:             time.Duration(dr.Latency),
+		ProtocolViolations:  dr.ProtocolViolations,
+	}
+	if len(dr.Cert) == 0 {
+		return r, nil
+	}
+
+	cert, err := x509.ParseCertificate(dr.Cert)
+	if err != nil {
+		return nil, err
+	}
+	r.IP = &ips.ClaimedIPPort{
+		Cert: cert,
+		IPPort: ips.IPPort{
+			IP:   dr.IP,
+			Port: dr.Port,
+		},
+		Timestamp: dr.Timestamp,
+		Signature: dr.Signature,
+	}
+	return r, nil
+}
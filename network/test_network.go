@@ -13,10 +13,13 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/dioneprotocol/dionego/database/memdb"
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/message"
+	"github.com/dioneprotocol/dionego/network/banlist"
 	"github.com/dioneprotocol/dionego/network/dialer"
 	"github.com/dioneprotocol/dionego/network/peer"
+	"github.com/dioneprotocol/dionego/network/peerdb"
 	"github.com/dioneprotocol/dionego/network/throttling"
 	"github.com/dioneprotocol/dionego/snow/networking/router"
 	"github.com/dioneprotocol/dionego/snow/networking/tracker"
@@ -81,7 +84,7 @@ func NewTestNetwork(
 	msgCreator, err := message.NewCreator(
 		metrics,
 		"",
-		constants.DefaultNetworkCompressionEnabled,
+		constants.DefaultNetworkCompressionType,
 		constants.DefaultNetworkMaximumInboundTimeout,
 	)
 	if err != nil {
@@ -116,6 +119,10 @@ func NewTestNetwork(
 				},
 
 				MaxProcessingMsgsPerNode: constants.DefaultInboundThrottlerMaxProcessingMsgsPerNode,
+
+				SubnetThrottlerConfig: throttling.SubnetThrottlerConfig{
+					UnreservedShare: constants.DefaultInboundThrottlerUnreservedSubnetsShare,
+				},
 			},
 			OutboundMsgThrottlerConfig: throttling.MsgByteThrottlerConfig{
 				VdrAllocSize:        constants.DefaultOutboundThrottlerVdrAllocSize,
@@ -155,6 +162,7 @@ func NewTestNetwork(
 			PeerListNonValidatorGossipSize: constants.DefaultNetworkPeerListNonValidatorGossipSize,
 			PeerListPeersGossipSize:        constants.DefaultNetworkPeerListPeersGossipSize,
 			PeerListGossipFreq:             constants.DefaultNetworkPeerListGossipFreq,
+			PeerListBloomResetFreq:         constants.DefaultNetworkPeerListBloomResetFreq,
 		},
 
 		DelayConfig: DelayConfig{
@@ -163,7 +171,7 @@ func NewTestNetwork(
 		},
 
 		MaxClockDifference:           constants.DefaultNetworkMaxClockDifference,
-		CompressionEnabled:           constants.DefaultNetworkCompressionEnabled,
+		CompressionType:              constants.DefaultNetworkCompressionType,
 		PingFrequency:                constants.DefaultPingFrequency,
 		AllowPrivateIPs:              constants.DefaultNetworkAllowPrivateIPs,
 		UptimeMetricFreq:             constants.DefaultUptimeMetricFreq,
@@ -172,6 +180,13 @@ func NewTestNetwork(
 		RequireValidatorToConnect: constants.DefaultNetworkRequireValidatorToConnect,
 		PeerReadBufferSize:        constants.DefaultNetworkPeerReadBufferSize,
 		PeerWriteBufferSize:       constants.DefaultNetworkPeerWriteBufferSize,
+
+		PeerDBMaxRecords:      constants.DefaultNetworkPeerDBMaxRecords,
+		PeerDBCommitFrequency: constants.DefaultNetworkPeerDBCommitFrequency,
+
+		MaxProtocolViolations:        constants.DefaultNetworkMaxProtocolViolations,
+		ProtocolViolationWindow:      constants.DefaultNetworkProtocolViolationWindow,
+		ProtocolViolationBanDuration: constants.DefaultNetworkProtocolViolationBanDuration,
 	}
 
 	networkConfig.NetworkID = networkID
@@ -226,7 +241,13 @@ func NewTestNetwork(
 
 	networkConfig.MyIPPort = ips.NewDynamicIPPort(net.IPv4zero, 0)
 
-	networkConfig.GossipTracker, err = peer.NewGossipTracker(metrics, "")
+	// TestNetwork doesn't persist anything, so peers and bans are only
+	// recorded in memory.
+	networkConfig.PeerDB, err = peerdb.New(log, memdb.New(), networkConfig.PeerDBMaxRecords)
+	if err != nil {
+		return nil, err
+	}
+	networkConfig.BanList, err = banlist.New(log, memdb.New())
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,424 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"golang.org/x/time/rate"
+
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/snow/networking/tracker"
+	"github.com/dioneprotocol/dionego/utils/constants"
+	"github.com/dioneprotocol/dionego/utils/math"
+	"github.com/dioneprotocol/dionego/utils/math/meter"
+	"github.com/dioneprotocol/dionego/utils/timer/mockable"
+	"github.com/dioneprotocol/dionego/utils/wrappers"
+)
+
+const (
+	subnetLabel = "subnetID"
+	chainLabel  = "chainID"
+	reasonLabel = "reason"
+
+	// unreservedLabel is the subnet label of the allocation shared by the
+	// subnets without a reserved share and by unknown chains.
+	unreservedLabel = "unreserved"
+	// unknownLabel is the subnet and chain label of messages destined for
+	// chains that weren't registered with AddChain. Peers choose the chain IDs
+	// of the messages they send, so they must not be used as labels directly.
+	unknownLabel = "unknown"
+
+	// Reasons a message is dropped
+	bytesReason     = "bytes"
+	bandwidthReason = "bandwidth"
+	cpuReason       = "cpu"
+)
+
+var (
+	_ tracker.ResourceTracker = (*chainResourceTracker)(nil)
+
+	errInvalidReservedShare   = errors.New("reserved share must be in (0, 1)")
+	errInvalidUnreservedShare = errors.New("unreserved share must be in (0, 1)")
+	errReservedSharesTooLarge = errors.New("reserved shares must sum to less than 1")
+)
+
+// See inbound_msg_throttler.go
+
+type SubnetThrottlerConfig struct {
+	// Subnet ID --> Share of the inbound message byte allocation, bandwidth
+	// and CPU reserved for messages destined for the chains of the subnet.
+	ReservedShares map[ids.ID]float64 `json:"reservedShares"`
+	// Share of the inbound message byte allocation, bandwidth and CPU shared
+	// by the subnets without a reserved share and by unknown chains. The
+	// reserved shares and this share must leave some of the allocation to the
+	// Primary Network, whose messages are never dropped by this throttler.
+	UnreservedShare float64 `json:"unreservedShare"`
+	// CPU usage targeted for handling inbound messages, which is partitioned
+	// between subnets like the byte allocation. If 0, CPU usage isn't
+	// partitioned between subnets.
+	CPUTarget float64 `json:"cpuTarget"`
+	// Halflife of the CPU usage of each subnet
+	CPUHalflife time.Duration `json:"cpuHalflife"`
+}
+
+// newInboundMsgSubnetThrottler returns a throttler that caps the share of
+// [allocSize] bytes, of the bandwidth of each node described by
+// [bandwidthConfig], and of [config.CPUTarget] used by subnets. Each subnet in
+// [config.ReservedShares] is capped at its share, and the subnets without a
+// reserved share and unknown chains share [config.UnreservedShare]. Messages
+// destined for the Primary Network are never dropped.
+//
+// If no subnet has a reserved share, the throttler doesn't drop any messages.
+func newInboundMsgSubnetThrottler(
+	namespace string,
+	registerer prometheus.Registerer,
+	allocSize uint64,
+	bandwidthConfig BandwidthThrottlerConfig,
+	config SubnetThrottlerConfig,
+	cpuTracker tracker.Tracker,
+) (*inboundMsgSubnetThrottler, error) {
+	if config.UnreservedShare <= 0 || config.UnreservedShare >= 1 {
+		return nil, fmt.Errorf("%w: %f", errInvalidUnreservedShare, config.UnreservedShare)
+	}
+
+	t := &inboundMsgSubnetThrottler{
+		SubnetThrottlerConfig: config,
+		bandwidthConfig:       bandwidthConfig,
+		cpuTracker:            cpuTracker,
+		processing:            meter.ContinuousFactory{}.New(config.CPUHalflife),
+		allocations:           make(map[ids.ID]*subnetAllocation, len(config.ReservedShares)),
+		chainToSubnet:         make(map[ids.ID]ids.ID),
+	}
+	if err := t.metrics.initialize(namespace, registerer); err != nil {
+		return nil, err
+	}
+
+	totalShare := config.UnreservedShare
+	for subnetID, share := range config.ReservedShares {
+		if subnetID == constants.PrimaryNetworkID {
+			continue
+		}
+		if share <= 0 || share >= 1 {
+			return nil, fmt.Errorf("%w: subnet %s has share %f", errInvalidReservedShare, subnetID, share)
+		}
+		totalShare += share
+		t.allocations[subnetID] = t.newAllocation(subnetID.String(), share, allocSize)
+	}
+	if totalShare >= 1 {
+		return nil, fmt.Errorf("%w: %f", errReservedSharesTooLarge, totalShare)
+	}
+	t.enabled = len(t.allocations) > 0
+	t.unreserved = t.newAllocation(unreservedLabel, config.UnreservedShare, allocSize)
+	return t, nil
+}
+
+// Bytes, bandwidth and CPU reserved for the messages destined for the chains of
+// a subnet
+type subnetAllocation struct {
+	maxBytes    uint64
+	remaining   uint64
+	remainingGa prometheus.Gauge
+	// Rate at which the bandwidth of each node replenishes, and the max
+	// bandwidth that can accumulate for each node
+	refillRate   rate.Limit
+	maxBurstSize int
+	// Node ID --> Bandwidth of the node reserved for the subnet
+	limiters map[ids.NodeID]*rate.Limiter
+	// Targeted CPU usage of the messages destined for the subnet
+	cpuTarget float64
+	// Tracks the time spent processing messages destined for the subnet
+	processing meter.Meter
+}
+
+// inboundMsgSubnetThrottler accounts for the bytes, bandwidth and CPU used by
+// the messages destined for the chains of each subnet, so that the chains of
+// one subnet can't starve the chains of other subnets.
+//
+// Messages are dropped, rather than waited on, when their subnet has exhausted
+// its allocation. Messages are acquired on the goroutine reading messages from
+// their sender, so waiting would delay the sender's messages to other subnets.
+//
+// Messages destined for the Primary Network are never dropped, because its
+// engines aren't notified of dropped responses. The Primary Network remains
+// subject to the other inbound message throttlers, which wait rather than
+// drop.
+type inboundMsgSubnetThrottler struct {
+	SubnetThrottlerConfig
+	bandwidthConfig BandwidthThrottlerConfig
+	cpuTracker      tracker.Tracker
+	clock           mockable.Clock
+	lock            sync.Mutex
+	metrics         inboundMsgSubnetThrottlerMetrics
+	// True iff a subnet has a reserved share. If false, the subnets without a
+	// reserved share aren't throttled either, so that nodes that don't reserve
+	// shares behave as if this throttler didn't exist.
+	enabled bool
+	// Tracks the time spent processing messages destined for any subnet,
+	// including the Primary Network
+	processing meter.Meter
+	// Subnet ID --> Allocation of the subnet. Contains every subnet with a
+	// reserved share.
+	allocations map[ids.ID]*subnetAllocation
+	// Allocation of the subnets without a reserved share and of unknown
+	// chains
+	unreserved *subnetAllocation
+	// Chain ID --> ID of the subnet that validates the chain
+	chainToSubnet map[ids.ID]ids.ID
+}
+
+func (t *inboundMsgSubnetThrottler) newAllocation(label string, share float64, allocSize uint64) *subnetAllocation {
+	size := uint64(share * float64(allocSize))
+	remainingGa := t.metrics.remainingBytes.WithLabelValues(label)
+	remainingGa.Set(float64(size))
+	return &subnetAllocation{
+		maxBytes:     size,
+		remaining:    size,
+		remainingGa:  remainingGa,
+		refillRate:   rate.Limit(share * float64(t.bandwidthConfig.RefillRate)),
+		maxBurstSize: math.Max(int(share*float64(t.bandwidthConfig.MaxBurstSize)), 1),
+		limiters:     make(map[ids.NodeID]*rate.Limiter),
+		cpuTarget:    share * t.CPUTarget,
+		processing:   meter.ContinuousFactory{}.New(t.CPUHalflife),
+	}
+}
+
+// AddChain accounts messages destined for [chainID] to [subnetID].
+func (t *inboundMsgSubnetThrottler) AddChain(chainID ids.ID, subnetID ids.ID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.chainToSubnet[chainID] = subnetID
+}
+
+// AddNode reserves bandwidth of [nodeID] for each subnet.
+func (t *inboundMsgSubnetThrottler) AddNode(nodeID ids.NodeID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if !t.enabled || t.bandwidthConfig.RefillRate == 0 {
+		return
+	}
+	t.unreserved.addNode(nodeID)
+	for _, allocation := range t.allocations {
+		allocation.addNode(nodeID)
+	}
+}
+
+// RemoveNode removes the bandwidth of [nodeID] reserved for each subnet.
+func (t *inboundMsgSubnetThrottler) RemoveNode(nodeID ids.NodeID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.unreserved.limiters, nodeID)
+	for _, allocation := range t.allocations {
+		delete(allocation.limiters, nodeID)
+	}
+}
+
+func (a *subnetAllocation) addNode(nodeID ids.NodeID) {
+	a.limiters[nodeID] = rate.NewLimiter(a.refillRate, a.maxBurstSize)
+}
+
+// Returns whether the subnet of [chainID] has the bytes, bandwidth and CPU
+// remaining for a message of size [msgSize] from [nodeID]. If so, the bytes of
+// the message are taken from the allocation of the subnet until the returned
+// ReleaseFunc is called (!). Always returns true if [chainID] is validated by
+// the Primary Network, or if the throttler isn't enabled.
+func (t *inboundMsgSubnetThrottler) Acquire(msgSize uint64, nodeID ids.NodeID, chainID ids.ID) (ReleaseFunc, bool) {
+	if !t.enabled {
+		return noopRelease, true
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	allocation, labels := t.getAllocation(chainID)
+	if allocation == nil {
+		t.metrics.acquiredBytes.With(labels).Add(float64(msgSize))
+		return noopRelease, true
+	}
+	now := t.clock.Time()
+
+	if t.CPUTarget > 0 && t.cpuUsage(allocation, now) > allocation.cpuTarget {
+		t.drop(labels, cpuReason)
+		return nil, false
+	}
+
+	// A message larger than the allocation would never be handled, so it is
+	// only required to acquire all of it.
+	bytesNeeded := math.Min(msgSize, allocation.maxBytes)
+	if bytesNeeded > allocation.remaining {
+		t.drop(labels, bytesReason)
+		return nil, false
+	}
+
+	if limiter, ok := allocation.limiters[nodeID]; ok {
+		tokensNeeded := math.Min(int(msgSize), allocation.maxBurstSize)
+		if !limiter.AllowN(now, tokensNeeded) {
+			t.drop(labels, bandwidthReason)
+			return nil, false
+		}
+	}
+
+	allocation.remaining -= bytesNeeded
+	allocation.remainingGa.Set(float64(allocation.remaining))
+	t.metrics.acquiredBytes.With(labels).Add(float64(msgSize))
+	return func() {
+		t.release(allocation, bytesNeeded)
+	}, true
+}
+
+// Returns the allocation that messages destined for [chainID] are accounted
+// to, and the labels of the messages. Returns a nil allocation if [chainID] is
+// validated by the Primary Network.
+// Assumes [t.lock] is held.
+func (t *inboundMsgSubnetThrottler) getAllocation(chainID ids.ID) (*subnetAllocation, prometheus.Labels) {
+	subnetID, ok := t.chainToSubnet[chainID]
+	if !ok {
+		return t.unreserved, prometheus.Labels{
+			subnetLabel: unknownLabel,
+			chainLabel:  unknownLabel,
+		}
+	}
+
+	labels := prometheus.Labels{
+		subnetLabel: subnetID.String(),
+		chainLabel:  chainID.String(),
+	}
+	if subnetID == constants.PrimaryNetworkID {
+		return nil, labels
+	}
+	allocation, ok := t.allocations[subnetID]
+	if !ok {
+		return t.unreserved, labels
+	}
+	return allocation, labels
+}
+
+// Returns the share of the CPU usage of the node caused by handling messages
+// destined for the subnets of [allocation].
+// Assumes [t.lock] is held.
+func (t *inboundMsgSubnetThrottler) cpuUsage(allocation *subnetAllocation, now time.Time) float64 {
+	totalProcessing := t.processing.Read(now)
+	if totalProcessing == 0 {
+		return 0
+	}
+	return t.cpuTracker.TotalUsage() * allocation.processing.Read(now) / totalProcessing
+}
+
+// Assumes [t.lock] is held.
+func (t *inboundMsgSubnetThrottler) drop(labels prometheus.Labels, reason string) {
+	t.metrics.dropped.With(prometheus.Labels{
+		subnetLabel: labels[subnetLabel],
+		chainLabel:  labels[chainLabel],
+		reasonLabel: reason,
+	}).Inc()
+}
+
+// Must correspond to a previous successful call of Acquire
+func (t *inboundMsgSubnetThrottler) release(allocation *subnetAllocation, bytes uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	allocation.remaining += bytes
+	allocation.remainingGa.Set(float64(allocation.remaining))
+}
+
+// ChainResourceTracker returns a ResourceTracker that reports to
+// [resourceTracker], and accounts the processing reported to it to the subnet
+// of [chainID]. Returns [resourceTracker] if the throttler isn't enabled.
+func (t *inboundMsgSubnetThrottler) ChainResourceTracker(chainID ids.ID, resourceTracker tracker.ResourceTracker) tracker.ResourceTracker {
+	if !t.enabled {
+		return resourceTracker
+	}
+	return &chainResourceTracker{
+		ResourceTracker: resourceTracker,
+		throttler:       t,
+		chainID:         chainID,
+	}
+}
+
+func (t *inboundMsgSubnetThrottler) startProcessing(chainID ids.ID, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if allocation, _ := t.getAllocation(chainID); allocation != nil {
+		allocation.processing.Inc(now, 1)
+	}
+	t.processing.Inc(now, 1)
+}
+
+func (t *inboundMsgSubnetThrottler) stopProcessing(chainID ids.ID, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if allocation, _ := t.getAllocation(chainID); allocation != nil {
+		allocation.processing.Dec(now, 1)
+	}
+	t.processing.Dec(now, 1)
+}
+
+// chainResourceTracker accounts the processing of a chain's messages to the
+// subnet of the chain, in addition to the node that sent them.
+type chainResourceTracker struct {
+	tracker.ResourceTracker
+	throttler *inboundMsgSubnetThrottler
+	chainID   ids.ID
+}
+
+func (t *chainResourceTracker) StartProcessing(nodeID ids.NodeID, now time.Time) {
+	t.ResourceTracker.StartProcessing(nodeID, now)
+	t.throttler.startProcessing(t.chainID, now)
+}
+
+func (t *chainResourceTracker) StopProcessing(nodeID ids.NodeID, now time.Time) {
+	t.ResourceTracker.StopProcessing(nodeID, now)
+	t.throttler.stopProcessing(t.chainID, now)
+}
+
+type inboundMsgSubnetThrottlerMetrics struct {
+	remainingBytes *prometheus.GaugeVec
+	acquiredBytes  *prometheus.CounterVec
+	dropped        *prometheus.CounterVec
+}
+
+func (m *inboundMsgSubnetThrottlerMetrics) initialize(namespace string, reg prometheus.Registerer) error {
+	m.remainingBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "subnet_throttler_inbound_remaining_bytes",
+			Help:      "Bytes remaining in the byte allocation of a subnet",
+		},
+		[]string{subnetLabel},
+	)
+	m.acquiredBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "subnet_throttler_inbound_acquired_bytes",
+			Help:      "Bytes of inbound messages accepted by the subnet throttler",
+		},
+		[]string{subnetLabel, chainLabel},
+	)
+	m.dropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "subnet_throttler_inbound_dropped",
+			Help:      "Number of inbound messages dropped because their subnet exhausted its bytes, bandwidth or CPU",
+		},
+		[]string{subnetLabel, chainLabel, reasonLabel},
+	)
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.remainingBytes),
+		reg.Register(m.acquiredBytes),
+		reg.Register(m.dropped),
+	)
+	return errs.Err
+}
@@ -0,0 +1,403 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/snow/networking/tracker"
+	"github.com/dioneprotocol/dionego/utils/constants"
+	"github.com/dioneprotocol/dionego/utils/math/meter"
+	"github.com/dioneprotocol/dionego/utils/resource"
+)
+
+func newTestSubnetThrottler(
+	t *testing.T,
+	bandwidthConfig BandwidthThrottlerConfig,
+	config SubnetThrottlerConfig,
+	cpuTracker tracker.Tracker,
+) *inboundMsgSubnetThrottler {
+	throttler, err := newInboundMsgSubnetThrottler(
+		"",
+		prometheus.NewRegistry(),
+		1024,
+		bandwidthConfig,
+		config,
+		cpuTracker,
+	)
+	require.NoError(t, err)
+	throttler.clock.Set(time.Now())
+	return throttler
+}
+
+func TestInboundMsgSubnetThrottlerInvalidShares(t *testing.T) {
+	tests := []struct {
+		name            string
+		shares          map[ids.ID]float64
+		unreservedShare float64
+		expectedErr     error
+	}{
+		{
+			name: "zero share",
+			shares: map[ids.ID]float64{
+				ids.GenerateTestID(): 0,
+			},
+			unreservedShare: .25,
+			expectedErr:     errInvalidReservedShare,
+		},
+		{
+			name: "whole allocation",
+			shares: map[ids.ID]float64{
+				ids.GenerateTestID(): 1,
+			},
+			unreservedShare: .25,
+			expectedErr:     errInvalidReservedShare,
+		},
+		{
+			name:            "zero unreserved share",
+			unreservedShare: 0,
+			expectedErr:     errInvalidUnreservedShare,
+		},
+		{
+			name:            "whole allocation unreserved",
+			unreservedShare: 1,
+			expectedErr:     errInvalidUnreservedShare,
+		},
+		{
+			name: "shares sum to whole allocation",
+			shares: map[ids.ID]float64{
+				ids.GenerateTestID(): .25,
+				ids.GenerateTestID(): .5,
+			},
+			unreservedShare: .25,
+			expectedErr:     errReservedSharesTooLarge,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newInboundMsgSubnetThrottler(
+				"",
+				prometheus.NewRegistry(),
+				1024,
+				BandwidthThrottlerConfig{},
+				SubnetThrottlerConfig{
+					ReservedShares:  test.shares,
+					UnreservedShare: test.unreservedShare,
+				},
+				nil,
+			)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestInboundMsgSubnetThrottlerReservedShare(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	throttler := newTestSubnetThrottler(
+		t,
+		BandwidthThrottlerConfig{},
+		SubnetThrottlerConfig{
+			ReservedShares: map[ids.ID]float64{
+				subnetID: .25,
+			},
+			UnreservedShare: .25,
+		},
+		nil,
+	)
+	subnet := throttler.allocations[subnetID]
+	require.EqualValues(256, subnet.remaining)
+	require.EqualValues(256, throttler.unreserved.remaining)
+
+	nodeID := ids.GenerateTestNodeID()
+	primaryChainID := ids.GenerateTestID()
+	subnetChainID := ids.GenerateTestID()
+	throttler.AddChain(primaryChainID, constants.PrimaryNetworkID)
+	throttler.AddChain(subnetChainID, subnetID)
+
+	subnetRelease, ok := throttler.Acquire(256, nodeID, subnetChainID)
+	require.True(ok)
+	require.Zero(subnet.remaining)
+
+	// The subnet exhausted its allocation, so its messages should be dropped
+	// rather than waited on
+	_, ok = throttler.Acquire(1, nodeID, subnetChainID)
+	require.False(ok)
+
+	// Messages destined for the Primary Network should never be dropped
+	for i := 0; i < 2; i++ {
+		primaryRelease, ok := throttler.Acquire(1024, nodeID, primaryChainID)
+		require.True(ok)
+		defer primaryRelease()
+	}
+	_, ok = throttler.Acquire(1, nodeID, subnetChainID)
+	require.False(ok)
+
+	subnetRelease()
+	require.EqualValues(256, subnet.remaining)
+	subnetRelease, ok = throttler.Acquire(1, nodeID, subnetChainID)
+	require.True(ok)
+	subnetRelease()
+	require.EqualValues(256, subnet.remaining)
+}
+
+func TestInboundMsgSubnetThrottlerUnreservedChains(t *testing.T) {
+	require := require.New(t)
+
+	throttler := newTestSubnetThrottler(
+		t,
+		BandwidthThrottlerConfig{},
+		SubnetThrottlerConfig{
+			ReservedShares: map[ids.ID]float64{
+				ids.GenerateTestID(): .25,
+			},
+			UnreservedShare: .25,
+		},
+		nil,
+	)
+
+	nodeID := ids.GenerateTestNodeID()
+	unreservedSubnetID := ids.GenerateTestID()
+	unreservedChainID := ids.GenerateTestID()
+	unknownChainID := ids.GenerateTestID()
+	throttler.AddChain(unreservedChainID, unreservedSubnetID)
+
+	// Chains of subnets without a reserved share are labeled with their own
+	// IDs, but share an allocation with chains that were never added
+	allocation, labels := throttler.getAllocation(unreservedChainID)
+	require.Equal(throttler.unreserved, allocation)
+	require.Equal(prometheus.Labels{
+		subnetLabel: unreservedSubnetID.String(),
+		chainLabel:  unreservedChainID.String(),
+	}, labels)
+
+	allocation, labels = throttler.getAllocation(unknownChainID)
+	require.Equal(throttler.unreserved, allocation)
+	require.Equal(prometheus.Labels{
+		subnetLabel: unknownLabel,
+		chainLabel:  unknownLabel,
+	}, labels)
+
+	unreservedRelease, ok := throttler.Acquire(100, nodeID, unreservedChainID)
+	require.True(ok)
+	unknownRelease, ok := throttler.Acquire(100, nodeID, unknownChainID)
+	require.True(ok)
+	require.EqualValues(56, throttler.unreserved.remaining)
+	_, ok = throttler.Acquire(100, nodeID, unknownChainID)
+	require.False(ok)
+
+	// The unreserved allocation being exhausted shouldn't affect the Primary
+	// Network
+	primaryChainID := ids.GenerateTestID()
+	throttler.AddChain(primaryChainID, constants.PrimaryNetworkID)
+	primaryRelease, ok := throttler.Acquire(100, nodeID, primaryChainID)
+	require.True(ok)
+	primaryRelease()
+
+	// Messages larger than the allocation only need to acquire all of it
+	unreservedRelease()
+	unknownRelease()
+	largeRelease, ok := throttler.Acquire(2048, nodeID, unknownChainID)
+	require.True(ok)
+	require.Zero(throttler.unreserved.remaining)
+	largeRelease()
+	require.EqualValues(256, throttler.unreserved.remaining)
+}
+
+func TestInboundMsgSubnetThrottlerBandwidth(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	throttler := newTestSubnetThrottler(
+		t,
+		BandwidthThrottlerConfig{
+			RefillRate:   1024,
+			MaxBurstSize: 1024,
+		},
+		SubnetThrottlerConfig{
+			ReservedShares: map[ids.ID]float64{
+				subnetID: .25,
+			},
+			UnreservedShare: .25,
+		},
+		nil,
+	)
+
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID1)
+	throttler.AddNode(nodeID2)
+
+	primaryChainID := ids.GenerateTestID()
+	subnetChainID := ids.GenerateTestID()
+	throttler.AddChain(primaryChainID, constants.PrimaryNetworkID)
+	throttler.AddChain(subnetChainID, subnetID)
+
+	// [nodeID1] uses all of its bandwidth reserved for the subnet
+	release, ok := throttler.Acquire(256, nodeID1, subnetChainID)
+	require.True(ok)
+	release()
+	_, ok = throttler.Acquire(1, nodeID1, subnetChainID)
+	require.False(ok)
+
+	// The bandwidth of [nodeID1] reserved for other subnets, and the bandwidth
+	// of other nodes, should be unaffected
+	release, ok = throttler.Acquire(512, nodeID1, primaryChainID)
+	require.True(ok)
+	release()
+	release, ok = throttler.Acquire(256, nodeID2, subnetChainID)
+	require.True(ok)
+	release()
+
+	// Bandwidth should be replenished over time
+	throttler.clock.Set(throttler.clock.Time().Add(time.Second))
+	release, ok = throttler.Acquire(256, nodeID1, subnetChainID)
+	require.True(ok)
+	release()
+
+	// Removed nodes aren't rate-limited
+	throttler.RemoveNode(nodeID1)
+	for i := 0; i < 2; i++ {
+		release, ok = throttler.Acquire(256, nodeID1, subnetChainID)
+		require.True(ok)
+		release()
+	}
+}
+
+func TestInboundMsgSubnetThrottlerCPU(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	require := require.New(t)
+
+	cpuTracker := tracker.NewMockTracker(ctrl)
+	cpuTracker.EXPECT().TotalUsage().Return(1.0).AnyTimes()
+
+	subnetID := ids.GenerateTestID()
+	throttler := newTestSubnetThrottler(
+		t,
+		BandwidthThrottlerConfig{},
+		SubnetThrottlerConfig{
+			ReservedShares: map[ids.ID]float64{
+				subnetID: .25,
+			},
+			UnreservedShare: .25,
+			CPUTarget:       1,
+			CPUHalflife:     time.Second,
+		},
+		cpuTracker,
+	)
+
+	user := resource.NewMockUser(ctrl)
+	user.EXPECT().CPUUsage().Return(1.0).AnyTimes()
+	user.EXPECT().DiskUsage().Return(0.0, 0.0).AnyTimes()
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		user,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	primaryChainID := ids.GenerateTestID()
+	subnetChainID := ids.GenerateTestID()
+	throttler.AddChain(primaryChainID, constants.PrimaryNetworkID)
+	throttler.AddChain(subnetChainID, subnetID)
+	subnetTracker := throttler.ChainResourceTracker(subnetChainID, resourceTracker)
+
+	// Nothing has been processed yet
+	release, ok := throttler.Acquire(1, nodeID, subnetChainID)
+	require.True(ok)
+	release()
+
+	// The subnet is the only one processing messages, so it is responsible for
+	// all of the CPU usage, which exceeds its share of the target
+	start := throttler.clock.Time()
+	subnetTracker.StartProcessing(nodeID, start)
+	throttler.clock.Set(start.Add(time.Second))
+
+	_, ok = throttler.Acquire(1, nodeID, subnetChainID)
+	require.False(ok)
+
+	// The processing should have been reported to [resourceTracker] too
+	require.Positive(resourceTracker.CPUTracker().Usage(nodeID, throttler.clock.Time()))
+
+	// The subnet exceeding its share shouldn't affect the Primary Network
+	release, ok = throttler.Acquire(1, nodeID, primaryChainID)
+	require.True(ok)
+	release()
+
+	// Once the CPU usage is caused by the Primary Network, the subnet's
+	// messages should be handled again
+	primaryTracker := throttler.ChainResourceTracker(primaryChainID, resourceTracker)
+	subnetTracker.StopProcessing(nodeID, throttler.clock.Time())
+	primaryTracker.StartProcessing(nodeID, throttler.clock.Time())
+	throttler.clock.Set(throttler.clock.Time().Add(time.Minute))
+	release, ok = throttler.Acquire(1, nodeID, subnetChainID)
+	require.True(ok)
+	release()
+}
+
+func TestInboundMsgSubnetThrottlerNoReservedShares(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	require := require.New(t)
+
+	cpuTracker := tracker.NewMockTracker(ctrl)
+	cpuTracker.EXPECT().TotalUsage().Return(1.0).AnyTimes()
+
+	// The default config doesn't reserve any shares
+	throttler := newTestSubnetThrottler(
+		t,
+		BandwidthThrottlerConfig{
+			RefillRate:   constants.DefaultInboundThrottlerBandwidthRefillRate,
+			MaxBurstSize: constants.DefaultInboundThrottlerBandwidthMaxBurstSize,
+		},
+		SubnetThrottlerConfig{
+			UnreservedShare: constants.DefaultInboundThrottlerUnreservedSubnetsShare,
+			CPUTarget:       1,
+			CPUHalflife:     time.Second,
+		},
+		cpuTracker,
+	)
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID)
+
+	chainIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()}
+	throttler.AddChain(chainIDs[0], constants.PrimaryNetworkID)
+	throttler.AddChain(chainIDs[1], ids.GenerateTestID())
+
+	// Processing isn't accounted to subnets
+	require.Equal(resourceTracker, throttler.ChainResourceTracker(chainIDs[1], resourceTracker))
+
+	// No message should be dropped, regardless of the chain it is destined
+	// for, its size and the number of messages being handled
+	for _, chainID := range chainIDs {
+		for i := 0; i < 10; i++ {
+			release, ok := throttler.Acquire(2048, nodeID, chainID)
+			require.True(ok)
+			defer release()
+		}
+	}
+}
@@ -29,6 +29,25 @@ type InboundMsgThrottler interface {
 	//            given nodeID. Callers must enforce this invariant.
 	Acquire(ctx context.Context, msgSize uint64, nodeID ids.NodeID) ReleaseFunc
 
+	// Returns whether the subnet that validates [chainID] has the bytes,
+	// bandwidth and CPU remaining to handle a message of size [msgSize] from
+	// [nodeID]. Never blocks, so that a subnet that exhausted its allocation
+	// can't delay messages destined for other subnets. Messages destined for
+	// chains that weren't added with AddChain are accounted to the allocation
+	// shared by the subnets without a reserved share.
+	// It's safe for multiple goroutines to concurrently call AcquireChain.
+	// If true is returned, the returned release function needs to be called
+	// so that any allocated resources will be released.
+	AcquireChain(msgSize uint64, nodeID ids.NodeID, chainID ids.ID) (ReleaseFunc, bool)
+
+	// Returns a ResourceTracker that reports to [resourceTracker], and
+	// accounts the processing reported to it to the subnet of [chainID].
+	ChainResourceTracker(chainID ids.ID, resourceTracker tracker.ResourceTracker) tracker.ResourceTracker
+
+	// Account messages destined for [chainID] to [subnetID].
+	// It's safe for multiple goroutines to concurrently call AddChain.
+	AddChain(chainID ids.ID, subnetID ids.ID)
+
 	// Add a new node to this throttler.
 	// Must be called before Acquire(..., [nodeID]) is called.
 	// RemoveNode([nodeID]) must have been called since the last time
@@ -49,6 +68,7 @@ type InboundMsgThrottlerConfig struct {
 	CPUThrottlerConfig       SystemThrottlerConfig `json:"cpuThrottlerConfig"`
 	DiskThrottlerConfig      SystemThrottlerConfig `json:"diskThrottlerConfig"`
 	MaxProcessingMsgsPerNode uint64                `json:"maxProcessingMsgsPerNode"`
+	SubnetThrottlerConfig    `json:"subnetThrottlerConfig"`
 }
 
 // Returns a new, sybil-safe inbound message throttler.
@@ -109,7 +129,19 @@ func NewInboundMsgThrottler(
 	if err != nil {
 		return nil, err
 	}
+	subnetThrottler, err := newInboundMsgSubnetThrottler(
+		namespace,
+		registerer,
+		throttlerConfig.VdrAllocSize+throttlerConfig.AtLargeAllocSize,
+		throttlerConfig.BandwidthThrottlerConfig,
+		throttlerConfig.SubnetThrottlerConfig,
+		resourceTracker.CPUTracker(),
+	)
+	if err != nil {
+		return nil, err
+	}
 	return &inboundMsgThrottler{
+		subnetThrottler:    subnetThrottler,
 		byteThrottler:      byteThrottler,
 		bufferThrottler:    bufferThrottler,
 		bandwidthThrottler: bandwidthThrottler,
@@ -139,6 +171,9 @@ type inboundMsgThrottler struct {
 	// Rate-limits based on size of all messages from a given
 	// node that we're currently processing.
 	byteThrottler *inboundMsgByteThrottler
+	// Rate-limits based on size of all messages destined for the chains of a
+	// given subnet that we're currently processing.
+	subnetThrottler *inboundMsgSubnetThrottler
 	// Rate-limits based on CPU usage caused by a given node.
 	cpuThrottler SystemThrottler
 	// Rate-limits based on disk usage caused by a given node.
@@ -167,12 +202,29 @@ func (t *inboundMsgThrottler) Acquire(ctx context.Context, msgSize uint64, nodeI
 	}
 }
 
+// Returns whether the subnet that validates [chainID] can handle a message of
+// size [msgSize] from [nodeID]. Unlike the resources considered by Acquire,
+// this can only be checked once the message has been read and parsed.
+func (t *inboundMsgThrottler) AcquireChain(msgSize uint64, nodeID ids.NodeID, chainID ids.ID) (ReleaseFunc, bool) {
+	return t.subnetThrottler.Acquire(msgSize, nodeID, chainID)
+}
+
+func (t *inboundMsgThrottler) ChainResourceTracker(chainID ids.ID, resourceTracker tracker.ResourceTracker) tracker.ResourceTracker {
+	return t.subnetThrottler.ChainResourceTracker(chainID, resourceTracker)
+}
+
+func (t *inboundMsgThrottler) AddChain(chainID ids.ID, subnetID ids.ID) {
+	t.subnetThrottler.AddChain(chainID, subnetID)
+}
+
 // See BandwidthThrottler.
 func (t *inboundMsgThrottler) AddNode(nodeID ids.NodeID) {
 	t.bandwidthThrottler.AddNode(nodeID)
+	t.subnetThrottler.AddNode(nodeID)
 }
 
 // See BandwidthThrottler.
 func (t *inboundMsgThrottler) RemoveNode(nodeID ids.NodeID) {
 	t.bandwidthThrottler.RemoveNode(nodeID)
+	t.subnetThrottler.RemoveNode(nodeID)
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/snow/networking/tracker"
 )
 
 var _ InboundMsgThrottler = (*noInboundMsgThrottler)(nil)
@@ -23,6 +24,16 @@ func (*noInboundMsgThrottler) Acquire(context.Context, uint64, ids.NodeID) Relea
 	return noopRelease
 }
 
+func (*noInboundMsgThrottler) AcquireChain(uint64, ids.NodeID, ids.ID) (ReleaseFunc, bool) {
+	return noopRelease, true
+}
+
+func (*noInboundMsgThrottler) ChainResourceTracker(_ ids.ID, resourceTracker tracker.ResourceTracker) tracker.ResourceTracker {
+	return resourceTracker
+}
+
+func (*noInboundMsgThrottler) AddChain(ids.ID, ids.ID) {}
+
 func (*noInboundMsgThrottler) AddNode(ids.NodeID) {}
 
 func (*noInboundMsgThrottler) RemoveNode(ids.NodeID) {}
@@ -7,7 +7,9 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/dioneprotocol/dionego/api/server"
 	"github.com/dioneprotocol/dionego/chains"
+	"github.com/dioneprotocol/dionego/database/encdb"
 	"github.com/dioneprotocol/dionego/genesis"
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/nat"
@@ -31,6 +33,20 @@ type IPCConfig struct {
 	IPCAPIEnabled      bool     `json:"ipcAPIEnabled"`
 	IPCPath            string   `json:"ipcPath"`
 	IPCDefaultChainIDs []string `json:"ipcDefaultChainIDs"`
+	IPCSocketsEnabled  bool     `json:"ipcSocketsEnabled"`
+	IPCGRPCEnabled     bool     `json:"ipcGRPCEnabled"`
+	IPCGRPCAddress     string   `json:"ipcGRPCAddress"`
+	IPCGRPCBufferSize  uint64   `json:"ipcGRPCBufferSize"`
+}
+
+type ExporterConfig struct {
+	ExportEnabled        bool          `json:"exportEnabled"`
+	ExportDir            string        `json:"exportDir"`
+	ExportMaxSegmentSize uint64        `json:"exportMaxSegmentSize"`
+	ExportKafkaAddress   string        `json:"exportKafkaAddress"`
+	ExportKafkaTopic     string        `json:"exportKafkaTopic"`
+	ExportKafkaPartition int32         `json:"exportKafkaPartition"`
+	ExportKafkaTimeout   time.Duration `json:"exportKafkaTimeout"`
 }
 
 type APIAuthConfig struct {
@@ -39,8 +55,10 @@ type APIAuthConfig struct {
 }
 
 type APIIndexerConfig struct {
-	IndexAPIEnabled      bool `json:"indexAPIEnabled"`
-	IndexAllowIncomplete bool `json:"indexAllowIncomplete"`
+	IndexAPIEnabled      bool          `json:"indexAPIEnabled"`
+	IndexAllowIncomplete bool          `json:"indexAllowIncomplete"`
+	IndexMaxContainers   uint64        `json:"indexMaxContainers"`
+	IndexMaxAge          time.Duration `json:"indexMaxAge"`
 }
 
 type HTTPConfig struct {
@@ -53,6 +71,9 @@ type HTTPConfig struct {
 	HTTPSCert    []byte `json:"-"`
 
 	APIAllowedOrigins []string `json:"apiAllowedOrigins"`
+	APIMaxBatchSize   int      `json:"apiMaxBatchSize"`
+
+	APIRateLimitConfig server.RateLimitConfig `json:"apiRateLimitConfig"`
 
 	ShutdownTimeout time.Duration `json:"shutdownTimeout"`
 	ShutdownWait    time.Duration `json:"shutdownWait"`
@@ -131,6 +152,10 @@ type DatabaseConfig struct {
 
 	// Path to config file
 	Config []byte `json:"-"`
+
+	// Keyring to encrypt the database with. If nil, the database isn't
+	// encrypted.
+	Keyring *encdb.Keyring `json:"-"`
 }
 
 // Config contains all of the configurations of an Dione node.
@@ -214,6 +239,8 @@ type Config struct {
 
 	TraceConfig trace.Config `json:"traceConfig"`
 
+	ExporterConfig ExporterConfig `json:"exporterConfig"`
+
 	// See comment on [MinPercentConnectedStakeHealthy] in platformvm.Config
 	// TODO: consider moving to subnet config
 	MinPercentConnectedStakeHealthy map[ids.ID]float64 `json:"minPercentConnectedStakeHealthy"`
@@ -21,6 +21,8 @@ import (
 
 	"go.uber.org/zap"
 
+	"google.golang.org/grpc"
+
 	coreth "github.com/dioneprotocol/coreth/plugin/evm"
 
 	"github.com/dioneprotocol/dionego/api/admin"
@@ -36,15 +38,19 @@ import (
 	"github.com/dioneprotocol/dionego/database/leveldb"
 	"github.com/dioneprotocol/dionego/database/manager"
 	"github.com/dioneprotocol/dionego/database/memdb"
+	"github.com/dioneprotocol/dionego/database/pebble"
 	"github.com/dioneprotocol/dionego/database/prefixdb"
+	"github.com/dioneprotocol/dionego/exporter"
 	"github.com/dioneprotocol/dionego/genesis"
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/indexer"
 	"github.com/dioneprotocol/dionego/ipcs"
 	"github.com/dioneprotocol/dionego/message"
 	"github.com/dioneprotocol/dionego/network"
+	"github.com/dioneprotocol/dionego/network/banlist"
 	"github.com/dioneprotocol/dionego/network/dialer"
 	"github.com/dioneprotocol/dionego/network/peer"
+	"github.com/dioneprotocol/dionego/network/peerdb"
 	"github.com/dioneprotocol/dionego/network/throttling"
 	"github.com/dioneprotocol/dionego/snow"
 	"github.com/dioneprotocol/dionego/snow/engine/common"
@@ -77,15 +83,20 @@ import (
 	"github.com/dioneprotocol/dionego/vms/platformvm/signer"
 	"github.com/dioneprotocol/dionego/vms/propertyfx"
 	"github.com/dioneprotocol/dionego/vms/registry"
+	"github.com/dioneprotocol/dionego/vms/rpcchainvm/grpcutils"
 	"github.com/dioneprotocol/dionego/vms/rpcchainvm/runtime"
 	"github.com/dioneprotocol/dionego/vms/secp256k1fx"
 
 	ipcsapi "github.com/dioneprotocol/dionego/api/ipcs"
+	ipcspb "github.com/dioneprotocol/dionego/proto/pb/ipcs"
 )
 
 var (
 	genesisHashKey  = []byte("genesisID")
 	indexerDBPrefix = []byte{0x00}
+	ipcsDBPrefix    = []byte{0x01}
+	peerDBPrefix    = []byte{0x02}
+	banListPrefix   = []byte{0x03}
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
@@ -104,12 +115,19 @@ type Node struct {
 	DBManager manager.Manager
 	DB        database.Database
 
+	// The databases of [DBManager] as they are stored on disk. If database
+	// encryption is enabled, their values are encrypted.
+	storedDBManager manager.Manager
+
 	// Profiles the process. Nil if continuous profiling is disabled.
 	profiler profiler.ContinuousProfiler
 
 	// Indexes blocks, transactions and blocks
 	indexer indexer.Indexer
 
+	// Exports accepted containers. Nil if export is disabled.
+	exporter exporter.Exporter
+
 	// Handles calls to Keystore API
 	keystore keystore.Keystore
 
@@ -135,6 +153,9 @@ type Node struct {
 	ConsensusAcceptorGroup snow.AcceptorGroup
 
 	IPCs *ipcs.ChainIPCs
+	// Streams the events of the chains published by [IPCs]. Nil if IPC gRPC
+	// is disabled.
+	ipcGRPCServer *grpc.Server
 
 	// Net runs the networking stack
 	networkNamespace string
@@ -306,18 +327,6 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 		}
 	}
 
-	// initialize gossip tracker
-	gossipTracker, err := peer.NewGossipTracker(n.MetricsRegisterer, n.networkNamespace)
-	if err != nil {
-		return err
-	}
-
-	// keep gossip tracker synchronized with the validator set
-	primaryNetVdrs.RegisterCallbackListener(&peer.GossipTrackerCallback{
-		Log:           n.Log,
-		GossipTracker: gossipTracker,
-	})
-
 	// add node configs to network config
 	n.Config.NetworkConfig.Namespace = n.networkNamespace
 	n.Config.NetworkConfig.MyNodeID = n.ID
@@ -328,12 +337,45 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 	n.Config.NetworkConfig.TLSConfig = tlsConfig
 	n.Config.NetworkConfig.TLSKey = tlsKey
 	n.Config.NetworkConfig.TrackedSubnets = n.Config.TrackedSubnets
+
+	// Reserve inbound message bytes, bandwidth and CPU for the tracked subnets
+	// that request it
+	reservedShares := make(map[ids.ID]float64)
+	for subnetID := range n.Config.TrackedSubnets {
+		subnetConfig, ok := n.Config.SubnetConfigs[subnetID]
+		if ok && subnetConfig.InboundThrottlerReservedShare > 0 {
+			reservedShares[subnetID] = subnetConfig.InboundThrottlerReservedShare
+		}
+	}
+	subnetThrottlerConfig := &n.Config.NetworkConfig.ThrottlerConfig.InboundMsgThrottlerConfig.SubnetThrottlerConfig
+	subnetThrottlerConfig.ReservedShares = reservedShares
+	subnetThrottlerConfig.CPUTarget = n.Config.CPUTargeterConfig.VdrAlloc + n.Config.CPUTargeterConfig.MaxNonVdrUsage
+	subnetThrottlerConfig.CPUHalflife = n.Config.SystemTrackerProcessingHalflife
+
 	n.Config.NetworkConfig.UptimeCalculator = n.uptimeCalculator
 	n.Config.NetworkConfig.UptimeRequirement = n.Config.UptimeRequirement
 	n.Config.NetworkConfig.ResourceTracker = n.resourceTracker
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
-	n.Config.NetworkConfig.GossipTracker = gossipTracker
+
+	// Peers are remembered across restarts so that their IPs can be dialed
+	// before any peer lists are received
+	n.Config.NetworkConfig.PeerDB, err = peerdb.New(
+		n.Log,
+		prefixdb.New(peerDBPrefix, n.DB),
+		n.Config.NetworkConfig.PeerDBMaxRecords,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize peer database: %w", err)
+	}
+
+	n.Config.NetworkConfig.BanList, err = banlist.New(
+		n.Log,
+		prefixdb.New(banListPrefix, n.DB),
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize ban list: %w", err)
+	}
 
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
@@ -423,18 +465,29 @@ func (n *Node) initDatabase() error {
 		dbManager, err = manager.NewLevelDB(n.Config.DatabaseConfig.Path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
 	case memdb.Name:
 		dbManager = manager.NewMemDB(version.CurrentDatabase)
+	case pebble.Name:
+		dbManager, err = manager.NewPebbleDB(n.Config.DatabaseConfig.Path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
 	default:
 		err = fmt.Errorf(
-			"db-type was %q but should have been one of {%s, %s}",
+			"db-type was %q but should have been one of {%s, %s, %s}",
 			n.Config.DatabaseConfig.Name,
 			leveldb.Name,
 			memdb.Name,
+			pebble.Name,
 		)
 	}
 	if err != nil {
 		return err
 	}
 
+	n.storedDBManager = dbManager
+	if n.Config.DatabaseConfig.Keyring != nil {
+		dbManager, err = dbManager.NewEncryptedDBManager(n.Config.DatabaseConfig.Keyring, n.Log)
+		if err != nil {
+			return err
+		}
+	}
+
 	meterDBManager, err := dbManager.NewMeterDBManager("db", n.MetricsRegisterer)
 	if err != nil {
 		return err
@@ -505,8 +558,38 @@ func (n *Node) initIPCs() error {
 		chainIDs[i] = id
 	}
 
+	var streams *ipcs.EventStreams
+	if n.Config.IPCGRPCEnabled {
+		listener, err := net.Listen("tcp", n.Config.IPCGRPCAddress)
+		if err != nil {
+			return fmt.Errorf("couldn't listen for IPC gRPC on %s: %w", n.Config.IPCGRPCAddress, err)
+		}
+
+		streams = ipcs.NewEventStreams(n.Log, prefixdb.New(ipcsDBPrefix, n.DB), n.Config.IPCGRPCBufferSize)
+		n.ipcGRPCServer = grpcutils.NewServer()
+		ipcspb.RegisterEventsServer(n.ipcGRPCServer, streams)
+		go grpcutils.Serve(listener, n.ipcGRPCServer)
+
+		// Chain manager will notify the streams when a chain is created, so
+		// that the heights of its containers can be parsed
+		n.chainManager.AddRegistrant(streams)
+
+		n.Log.Info("serving IPC gRPC",
+			zap.Stringer("address", listener.Addr()),
+		)
+	}
+
 	var err error
-	n.IPCs, err = ipcs.NewChainIPCs(n.Log, n.Config.IPCPath, n.Config.NetworkID, n.ConsensusAcceptorGroup, n.DecisionAcceptorGroup, chainIDs)
+	n.IPCs, err = ipcs.NewChainIPCs(
+		n.Log,
+		n.Config.IPCPath,
+		n.Config.NetworkID,
+		n.Config.IPCSocketsEnabled,
+		streams,
+		n.ConsensusAcceptorGroup,
+		n.DecisionAcceptorGroup,
+		chainIDs,
+	)
 	return err
 }
 
@@ -528,6 +611,10 @@ func (n *Node) initIndexer() error {
 		ShutdownF: func() {
 			n.Shutdown(0) // TODO put exit code here
 		},
+		Retention: indexer.RetentionPolicy{
+			MaxContainers: n.Config.IndexMaxContainers,
+			MaxAge:        n.Config.IndexMaxAge,
+		},
 	})
 	if err != nil {
 		return fmt.Errorf("couldn't create index for txs: %w", err)
@@ -539,6 +626,53 @@ func (n *Node) initIndexer() error {
 	return nil
 }
 
+// Initialize [n.exporter], if export is enabled.
+// Should only be called after [n.DecisionAcceptorGroup],
+// [n.ConsensusAcceptorGroup], [n.Log], [n.health], [n.chainManager] are
+// initialized
+func (n *Node) initExporter() error {
+	if !n.Config.ExportEnabled {
+		n.Log.Info("skipping exporter initialization because it has been disabled")
+		return nil
+	}
+
+	var sink exporter.Sink
+	if n.Config.ExportKafkaAddress != "" {
+		var err error
+		sink, err = exporter.NewKafkaSink(exporter.KafkaConfig{
+			Address:   n.Config.ExportKafkaAddress,
+			Topic:     n.Config.ExportKafkaTopic,
+			Partition: n.Config.ExportKafkaPartition,
+			Timeout:   n.Config.ExportKafkaTimeout,
+		})
+		if err != nil {
+			return fmt.Errorf("couldn't create kafka sink: %w", err)
+		}
+	}
+
+	var err error
+	n.exporter, err = exporter.NewExporter(exporter.Config{
+		Dir:                    n.Config.ExportDir,
+		MaxSegmentSize:         n.Config.ExportMaxSegmentSize,
+		Sink:                   sink,
+		Log:                    n.Log,
+		Namespace:              "exporter",
+		Registerer:             n.MetricsRegisterer,
+		DecisionAcceptorGroup:  n.DecisionAcceptorGroup,
+		ConsensusAcceptorGroup: n.ConsensusAcceptorGroup,
+	})
+	if err != nil {
+		return err
+	}
+	if err := n.health.RegisterHealthCheck("exporter", n.exporter); err != nil {
+		return fmt.Errorf("couldn't register exporter health check: %w", err)
+	}
+
+	// Chain manager will notify exporter when a chain is created
+	n.chainManager.AddRegistrant(n.exporter)
+	return nil
+}
+
 // Initializes the Platform chain.
 // Its genesis data specifies the other chains that should be created.
 func (n *Node) initChains(genesisBytes []byte) error {
@@ -579,6 +713,8 @@ func (n *Node) initAPIServer() error {
 			n.tracer,
 			"api",
 			n.MetricsRegisterer,
+			n.Config.APIMaxBatchSize,
+			n.Config.APIRateLimitConfig,
 		)
 		return err
 	}
@@ -600,6 +736,8 @@ func (n *Node) initAPIServer() error {
 		n.tracer,
 		"api",
 		n.MetricsRegisterer,
+		n.Config.APIMaxBatchSize,
+		n.Config.APIRateLimitConfig,
 		a,
 	)
 	if err != nil {
@@ -726,7 +864,6 @@ func (n *Node) initChainManager(dioneAssetID ids.ID) error {
 		BootstrapAncestorsMaxContainersReceived: n.Config.BootstrapAncestorsMaxContainersReceived,
 		ApricotPhase4Time:                       version.GetApricotPhase4Time(n.Config.NetworkID),
 		ApricotPhase4MinPChainHeight:            version.GetApricotPhase4MinPChainHeight(n.Config.NetworkID),
-		ResourceTracker:                         n.resourceTracker,
 		StateSyncBeacons:                        n.Config.StateSyncIDs,
 		TracingEnabled:                          n.Config.TraceConfig.Enabled,
 		Tracer:                                  n.tracer,
@@ -919,6 +1056,8 @@ func (n *Node) initAdminAPI() error {
 			NodeConfig:   n.Config,
 			VMManager:    n.Config.VMManager,
 			VMRegistry:   n.VMRegistry,
+			DBManager:    n.storedDBManager,
+			BanList:      n.Config.NetworkConfig.BanList,
 		},
 	)
 	if err != nil {
@@ -1058,6 +1197,8 @@ func (n *Node) initHealthAPI() error {
 		&common.HTTPHandler{
 			LockOptions: common.NoLock,
 			Handler:     handler,
+			// POST requests are served by a JSON-RPC server
+			BatchRequests: true,
 		},
 		&sync.RWMutex{},
 		"health",
@@ -1275,7 +1416,7 @@ func (n *Node) Initialize(
 	n.msgCreator, err = message.NewCreator(
 		n.MetricsRegisterer,
 		n.networkNamespace,
-		n.Config.NetworkConfig.CompressionEnabled,
+		n.Config.NetworkConfig.CompressionType,
 		n.Config.NetworkConfig.MaximumInboundMessageTimeout,
 	)
 	if err != nil {
@@ -1330,6 +1471,9 @@ func (n *Node) Initialize(
 	if err := n.initIndexer(); err != nil {
 		return fmt.Errorf("couldn't initialize indexer: %w", err)
 	}
+	if err := n.initExporter(); err != nil {
+		return fmt.Errorf("couldn't initialize exporter: %w", err)
+	}
 
 	n.health.Start(context.TODO(), n.Config.HealthCheckFreq)
 	n.initProfiler()
@@ -1384,6 +1528,9 @@ func (n *Node) shutdown() {
 			)
 		}
 	}
+	if n.ipcGRPCServer != nil {
+		n.ipcGRPCServer.Stop()
+	}
 	if n.chainManager != nil {
 		n.chainManager.Shutdown()
 	}
@@ -1403,6 +1550,13 @@ func (n *Node) shutdown() {
 			zap.Error(err),
 		)
 	}
+	if n.exporter != nil {
+		if err := n.exporter.Close(); err != nil {
+			n.Log.Debug("error closing exporter",
+				zap.Error(err),
+			)
+		}
+	}
 
 	// Ensure all runtimes are shutdown
 	n.Log.Info("cleaning up plugin runtimes")
@@ -20,4 +20,8 @@ const (
 type HTTPHandler struct {
 	LockOptions LockOption
 	Handler     http.Handler
+	// BatchRequests is true if [Handler] serves JSON-RPC 2.0 calls, so that
+	// batch requests sent to it can be split into their calls. Handlers that
+	// are JSON-RPC servers are batched regardless of this option.
+	BatchRequests bool
 }
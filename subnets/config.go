@@ -13,7 +13,10 @@ import (
 	"github.com/dioneprotocol/dionego/utils/set"
 )
 
-var errAllowedNodesWhenNotValidatorOnly = errors.New("allowedNodes can only be set when ValidatorOnly is true")
+var (
+	errAllowedNodesWhenNotValidatorOnly = errors.New("allowedNodes can only be set when ValidatorOnly is true")
+	errInvalidInboundThrottlerShare     = errors.New("inboundThrottlerReservedShare must be in [0, 1)")
+)
 
 type GossipConfig struct {
 	AcceptedFrontierValidatorSize    uint `json:"gossipAcceptedFrontierValidatorSize" yaml:"gossipAcceptedFrontierValidatorSize"`
@@ -47,6 +50,14 @@ type Config struct {
 
 	// See comment on [MinPercentConnectedStakeHealthy] in platformvm.Config
 	MinPercentConnectedStakeHealthy float64 `json:"minPercentConnectedStakeHealthy" yaml:"minPercentConnectedStakeHealthy"`
+
+	// InboundThrottlerReservedShare is the share of the inbound message byte
+	// allocation, bandwidth and CPU reserved for messages destined for this
+	// Subnet's Chains. Messages exceeding the share are dropped. If 0, messages
+	// destined for this Subnet's Chains share the allocation of the Subnets
+	// without a reserved share, which is only enforced if some Subnet reserves
+	// a share.
+	InboundThrottlerReservedShare float64 `json:"inboundThrottlerReservedShare" yaml:"inboundThrottlerReservedShare"`
 }
 
 func (c *Config) Valid() error {
@@ -56,5 +67,8 @@ func (c *Config) Valid() error {
 	if !c.ValidatorOnly && c.AllowedNodes.Len() > 0 {
 		return errAllowedNodesWhenNotValidatorOnly
 	}
+	if c.InboundThrottlerReservedShare < 0 || c.InboundThrottlerReservedShare >= 1 {
+		return fmt.Errorf("%w: %f", errInvalidInboundThrottlerShare, c.InboundThrottlerReservedShare)
+	}
 	return nil
 }
@@ -56,6 +56,22 @@ func TestValid(t *testing.T) {
 			},
 			err: errAllowedNodesWhenNotValidatorOnly.Error(),
 		},
+		{
+			name: "negative inbound throttler reserved share",
+			s: Config{
+				ConsensusParameters:           validParameters,
+				InboundThrottlerReservedShare: -.1,
+			},
+			err: errInvalidInboundThrottlerShare.Error(),
+		},
+		{
+			name: "inbound throttler reserved share too large",
+			s: Config{
+				ConsensusParameters:           validParameters,
+				InboundThrottlerReservedShare: 1,
+			},
+			err: errInvalidInboundThrottlerShare.Error(),
+		},
 		{
 			name: "valid",
 			s: Config{
@@ -0,0 +1,121 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bloom
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+const (
+	bitsPerByte = 8
+
+	// MinHashes and MaxHashes bound the number of hash functions of a filter
+	MinHashes = 1
+	MaxHashes = 16
+	// MinEntries is the minimum number of bytes of entries of a filter
+	MinEntries = 1
+)
+
+var (
+	// EmptyFilter is a filter that doesn't contain anything
+	EmptyFilter = &ReadFilter{
+		numHashes: MinHashes,
+		entries:   make([]byte, MinEntries),
+	}
+
+	errTooFewHashes  = errors.New("too few hashes")
+	errTooManyHashes = errors.New("too many hashes")
+	errTooFewEntries = errors.New("too few entries")
+)
+
+// ReadFilter is an immutable bloom filter, typically received from another
+// node. Entries are identified by their hash, such as the result of [Hash].
+//
+// The marshalled format of a filter is the number of hash functions as a
+// single byte, followed by the entries.
+//
+// [Filter] can't be sent to other nodes: its hash functions are randomly keyed
+// per filter, and the marshalled format of the underlying library includes the
+// keys and a checksum, and allocates as many keys and bits as the sender
+// claims when parsed. ReadFilter and [WriteFilter] instead derive their hash
+// functions from the salted hash of the entries, and are bounded by the size
+// of the marshalled filter.
+type ReadFilter struct {
+	numHashes int
+	entries   []byte
+}
+
+// Parse parses a filter that was marshalled by [WriteFilter.Marshal]
+func Parse(bytes []byte) (*ReadFilter, error) {
+	if len(bytes) == 0 {
+		return nil, errTooFewHashes
+	}
+	numHashes := int(bytes[0])
+	entries := bytes[1:]
+	switch {
+	case numHashes < MinHashes:
+		return nil, fmt.Errorf("%w: %d < %d", errTooFewHashes, numHashes, MinHashes)
+	case numHashes > MaxHashes:
+		return nil, fmt.Errorf("%w: %d > %d", errTooManyHashes, numHashes, MaxHashes)
+	case len(entries) < MinEntries:
+		return nil, fmt.Errorf("%w: %d < %d", errTooFewEntries, len(entries), MinEntries)
+	}
+	return &ReadFilter{
+		numHashes: numHashes,
+		entries:   entries,
+	}, nil
+}
+
+// Contains returns true if [hash] may have been added to the filter. False
+// positives are possible, false negatives are not.
+func (f *ReadFilter) Contains(hash uint64) bool {
+	return contains(f.numHashes, f.entries, hash)
+}
+
+// Size returns the number of bytes of the marshalled filter
+func (f *ReadFilter) Size() int {
+	return 1 + len(f.entries)
+}
+
+// Marshal returns the bytes of the filter, which can be parsed with [Parse]
+func (f *ReadFilter) Marshal() []byte {
+	return marshal(f.numHashes, f.entries)
+}
+
+func marshal(numHashes int, entries []byte) []byte {
+	bytes := make([]byte, 1+len(entries))
+	bytes[0] = byte(numHashes)
+	copy(bytes[1:], entries)
+	return bytes
+}
+
+// contains and add derive the [numHashes] bits of [hash] with double hashing.
+func contains(numHashes int, entries []byte, hash uint64) bool {
+	var (
+		numBits = uint64(len(entries)) * bitsPerByte
+		h2      = bits.RotateLeft64(hash, 32)
+	)
+	for i := 0; i < numHashes; i++ {
+		index := hash % numBits
+		if entries[index/bitsPerByte]&(1<<(index%bitsPerByte)) == 0 {
+			return false
+		}
+		hash += h2
+	}
+	return true
+}
+
+func add(numHashes int, entries []byte, hash uint64) {
+	var (
+		numBits = uint64(len(entries)) * bitsPerByte
+		h2      = bits.RotateLeft64(hash, 32)
+	)
+	for i := 0; i < numHashes; i++ {
+		index := hash % numBits
+		entries[index/bitsPerByte] |= 1 << (index % bitsPerByte)
+		hash += h2
+	}
+}
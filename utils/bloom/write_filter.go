@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bloom
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+)
+
+var _ fmt.Stringer = (*WriteFilter)(nil)
+
+// WriteFilter is a bloom filter that can be added to and marshalled to be
+// sent to other nodes, which read it with [Parse].
+type WriteFilter struct {
+	lock      sync.RWMutex
+	numHashes int
+	entries   []byte
+	count     int
+}
+
+// NewWriteFilter returns an empty filter with [numHashes] hash functions and
+// [numEntries] bytes of entries.
+func NewWriteFilter(numHashes, numEntries int) (*WriteFilter, error) {
+	switch {
+	case numHashes < MinHashes:
+		return nil, fmt.Errorf("%w: %d < %d", errTooFewHashes, numHashes, MinHashes)
+	case numHashes > MaxHashes:
+		return nil, fmt.Errorf("%w: %d > %d", errTooManyHashes, numHashes, MaxHashes)
+	case numEntries < MinEntries:
+		return nil, fmt.Errorf("%w: %d < %d", errTooFewEntries, numEntries, MinEntries)
+	}
+	return &WriteFilter{
+		numHashes: numHashes,
+		entries:   make([]byte, numEntries),
+	}, nil
+}
+
+// Add adds [hash] to the filter
+func (f *WriteFilter) Add(hash uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	add(f.numHashes, f.entries, hash)
+	f.count++
+}
+
+// Contains returns true if [hash] may have been added to the filter
+func (f *WriteFilter) Contains(hash uint64) bool {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return contains(f.numHashes, f.entries, hash)
+}
+
+// Count returns the number of times Add was called
+func (f *WriteFilter) Count() int {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.count
+}
+
+// Marshal returns the bytes of the filter, which can be parsed with [Parse]
+func (f *WriteFilter) Marshal() []byte {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return marshal(f.numHashes, f.entries)
+}
+
+func (f *WriteFilter) String() string {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return fmt.Sprintf("BloomFilter(NumHashes = %d, NumEntries = %d, Count = %d)",
+		f.numHashes, len(f.entries), f.count)
+}
+
+// OptimalParameters returns the number of hash functions and the number of
+// bytes of entries that minimize the size of a filter holding [count] entries
+// with a false positive probability of at most [falsePositiveProbability].
+func OptimalParameters(count int, falsePositiveProbability float64) (int, int) {
+	if count < 1 {
+		count = 1
+	}
+	numBits := math.Ceil(-float64(count) * math.Log(falsePositiveProbability) / (math.Ln2 * math.Ln2))
+	numEntries := int(math.Ceil(numBits / bitsPerByte))
+	numHashes := int(math.Round(numBits / float64(count) * math.Ln2))
+	switch {
+	case numHashes < MinHashes:
+		numHashes = MinHashes
+	case numHashes > MaxHashes:
+		numHashes = MaxHashes
+	}
+	if numEntries < MinEntries {
+		numEntries = MinEntries
+	}
+	return numHashes, numEntries
+}
+
+// Hash returns the hash of [key] salted with [salt], to be added to or looked
+// up in a filter.
+func Hash(key, salt []byte) uint64 {
+	hash := sha256.New()
+	_, _ = hash.Write(key)
+	_, _ = hash.Write(salt)
+	return binary.BigEndian.Uint64(hash.Sum(nil))
+}
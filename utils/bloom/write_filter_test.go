@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bloom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFilter(t *testing.T) {
+	require := require.New(t)
+
+	numHashes, numEntries := OptimalParameters(100, .01)
+	f, err := NewWriteFilter(numHashes, numEntries)
+	require.NoError(err)
+
+	salt := []byte("salt")
+	for i := byte(0); i < 100; i++ {
+		f.Add(Hash([]byte{i}, salt))
+	}
+	require.Equal(100, f.Count())
+
+	parsed, err := Parse(f.Marshal())
+	require.NoError(err)
+	require.Equal(1+numEntries, parsed.Size())
+
+	for i := byte(0); i < 100; i++ {
+		hash := Hash([]byte{i}, salt)
+		require.True(f.Contains(hash))
+		require.True(parsed.Contains(hash))
+	}
+
+	falsePositives := 0
+	for i := byte(100); i < 200; i++ {
+		if parsed.Contains(Hash([]byte{i}, salt)) {
+			falsePositives++
+		}
+	}
+	require.Less(falsePositives, 10)
+}
+
+func TestEmptyFilter(t *testing.T) {
+	require := require.New(t)
+
+	for i := byte(0); i < 100; i++ {
+		require.False(EmptyFilter.Contains(Hash([]byte{i}, nil)))
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		bytes       []byte
+		expectedErr error
+	}{
+		{
+			name:        "empty",
+			bytes:       nil,
+			expectedErr: errTooFewHashes,
+		},
+		{
+			name:        "no hashes",
+			bytes:       []byte{0, 0},
+			expectedErr: errTooFewHashes,
+		},
+		{
+			name:        "too many hashes",
+			bytes:       []byte{MaxHashes + 1, 0},
+			expectedErr: errTooManyHashes,
+		},
+		{
+			name:        "no entries",
+			bytes:       []byte{1},
+			expectedErr: errTooFewEntries,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(test.bytes)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestOptimalParameters(t *testing.T) {
+	require := require.New(t)
+
+	numHashes, numEntries := OptimalParameters(0, .01)
+	require.GreaterOrEqual(numHashes, MinHashes)
+	require.GreaterOrEqual(numEntries, MinEntries)
+
+	numHashes, numEntries = OptimalParameters(1000, .01)
+	require.Equal(7, numHashes)
+	require.Equal(1199, numEntries)
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errUnknownCompressionType = errors.New("unknown compression type")
+
+// Type is a compression algorithm
+type Type byte
+
+const (
+	TypeNone Type = iota + 1
+	TypeGzip
+	TypeZstd
+)
+
+// Types are the compression algorithms that messages can be compressed with,
+// in order of preference
+var Types = []Type{TypeZstd, TypeGzip}
+
+func (t Type) String() string {
+	switch t {
+	case TypeNone:
+		return "none"
+	case TypeGzip:
+		return "gzip"
+	case TypeZstd:
+		return "zstd"
+	default:
+		return "unknown"
+	}
+}
+
+// TypeFromString returns the compression type named [s]
+func TypeFromString(s string) (Type, error) {
+	switch s {
+	case TypeNone.String():
+		return TypeNone, nil
+	case TypeGzip.String():
+		return TypeGzip, nil
+	case TypeZstd.String():
+		return TypeZstd, nil
+	default:
+		return TypeNone, fmt.Errorf("%w: %q", errUnknownCompressionType, s)
+	}
+}
+
+func (t Type) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("%q", t)), nil
+}
+
+// NewCompressor returns a Compressor of type [t] that compresses messages of
+// at most [maxSize] bytes
+func NewCompressor(t Type, maxSize int64) (Compressor, error) {
+	switch t {
+	case TypeNone:
+		return NewNoCompressor(), nil
+	case TypeGzip:
+		return NewGzipCompressor(maxSize)
+	case TypeZstd:
+		return NewZstdCompressor(maxSize)
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnknownCompressionType, t)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/utils"
+	"github.com/dioneprotocol/dionego/utils/units"
+)
+
+func TestZstdCompressDecompress(t *testing.T) {
+	require := require.New(t)
+
+	compressor, err := NewZstdCompressor(2 * units.MiB)
+	require.NoError(err)
+
+	for _, data := range [][]byte{
+		{},
+		utils.RandomBytes(4096),
+		bytes.Repeat([]byte{1, 2, 3, 4}, 1024),
+	} {
+		compressed, err := compressor.Compress(data)
+		require.NoError(err)
+
+		decompressed, err := compressor.Decompress(compressed)
+		require.NoError(err)
+		require.Equal(len(data), len(decompressed))
+		require.True(bytes.Equal(data, decompressed))
+	}
+
+	_, err = compressor.Decompress([]byte{1, 2, 3})
+	require.Error(err)
+}
+
+func TestZstdSizeLimiting(t *testing.T) {
+	require := require.New(t)
+
+	data := make([]byte, 3*units.MiB)
+	compressor, err := NewZstdCompressor(2 * units.MiB)
+	require.NoError(err)
+
+	_, err = compressor.Compress(data) // should be too large
+	require.Error(err)
+
+	compressor2, err := NewZstdCompressor(4 * units.MiB)
+	require.NoError(err)
+
+	dataCompressed, err := compressor2.Compress(data)
+	require.NoError(err)
+
+	_, err = compressor.Decompress(dataCompressed) // should be too large
+	require.Error(err)
+}
+
+func TestNewZstdCompressorWithInvalidLimit(t *testing.T) {
+	require := require.New(t)
+	_, err := NewZstdCompressor(math.MaxInt64)
+	require.ErrorIs(err, ErrInvalidMaxSizeZstdCompressor)
+}
+
+func TestTypeFromString(t *testing.T) {
+	require := require.New(t)
+
+	for _, compressionType := range []Type{TypeNone, TypeGzip, TypeZstd} {
+		parsedType, err := TypeFromString(compressionType.String())
+		require.NoError(err)
+		require.Equal(compressionType, parsedType)
+	}
+	_, err := TypeFromString("snappy")
+	require.ErrorIs(err, errUnknownCompressionType)
+}
+
+func FuzzZstdCompressor(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		require := require.New(t)
+
+		if len(data) > 2*units.MiB {
+			t.SkipNow()
+		}
+
+		compressor, err := NewZstdCompressor(2 * units.MiB)
+		require.NoError(err)
+
+		compressed, err := compressor.Compress(data)
+		require.NoError(err)
+
+		decompressed, err := compressor.Decompress(compressed)
+		require.NoError(err)
+
+		require.True(bytes.Equal(data, decompressed))
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/dioneprotocol/dionego/utils/compression"
 	"github.com/dioneprotocol/dionego/utils/units"
 )
 
@@ -36,6 +37,7 @@ const (
 	DefaultNetworkPeerListNonValidatorGossipSize = 0
 	DefaultNetworkPeerListPeersGossipSize        = 10
 	DefaultNetworkPeerListGossipFreq             = time.Minute
+	DefaultNetworkPeerListBloomResetFreq         = time.Minute
 
 	// Inbound Connection Throttling
 	DefaultInboundConnUpgradeThrottlerCooldown = 10 * time.Second
@@ -54,12 +56,18 @@ const (
 	DefaultNetworkTimeoutCoefficient    = 2
 	DefaultNetworkReadHandshakeTimeout  = 15 * time.Second
 
-	DefaultNetworkCompressionEnabled        = true
-	DefaultNetworkMaxClockDifference        = time.Minute
-	DefaultNetworkAllowPrivateIPs           = true
-	DefaultNetworkRequireValidatorToConnect = false
-	DefaultNetworkPeerReadBufferSize        = 8 * units.KiB
-	DefaultNetworkPeerWriteBufferSize       = 8 * units.KiB
+	DefaultNetworkCompressionEnabled           = true
+	DefaultNetworkCompressionType              = compression.TypeZstd
+	DefaultNetworkPeerDBMaxRecords             = 10_000
+	DefaultNetworkPeerDBCommitFrequency        = time.Minute
+	DefaultNetworkMaxProtocolViolations        = 10
+	DefaultNetworkProtocolViolationWindow      = time.Hour
+	DefaultNetworkProtocolViolationBanDuration = 24 * time.Hour
+	DefaultNetworkMaxClockDifference           = time.Minute
+	DefaultNetworkAllowPrivateIPs              = true
+	DefaultNetworkRequireValidatorToConnect    = false
+	DefaultNetworkPeerReadBufferSize           = 8 * units.KiB
+	DefaultNetworkPeerWriteBufferSize          = 8 * units.KiB
 
 	DefaultNetworkTCPProxyEnabled = false
 
@@ -97,6 +105,7 @@ const (
 	DefaultInboundThrottlerBandwidthMaxBurstSize    = DefaultMaxMessageSize
 	DefaultInboundThrottlerCPUMaxRecheckDelay       = 5 * time.Second
 	DefaultInboundThrottlerDiskMaxRecheckDelay      = 5 * time.Second
+	DefaultInboundThrottlerUnreservedSubnetsShare   = .1
 
 	// Outbound Throttling
 	DefaultOutboundThrottlerAtLargeAllocSize    = 32 * units.MiB
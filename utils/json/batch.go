@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/dioneprotocol/dionego/utils/units"
+)
+
+const (
+	// DefaultMaxBatchSize is a reasonable default value for the max number of
+	// calls in a JSON-RPC batch request
+	DefaultMaxBatchSize = 100
+
+	// Max size of a request body that is read to split a batch request into
+	// its calls
+	maxBatchBodySize = 16 * units.MiB
+)
+
+// NewBatchHandler returns a handler that serves JSON-RPC 2.0 batch requests
+// by passing each call of the batch to [handler] as its own request, and
+// writing the responses to the calls as an array.
+//
+// Each call is handled even if other calls of the batch fail, and reports its
+// own error. Calls that are notifications don't have a response. Batches with
+// more than [maxBatchSize] calls are rejected. If [maxBatchSize] is 0, all
+// batches are rejected.
+//
+// Requests that aren't batches are passed to [handler] unchanged.
+func NewBatchHandler(handler http.Handler, maxBatchSize int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Body == nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBatchBodySize+1))
+		if err != nil {
+			writeBatchError(w, http.StatusBadRequest, json2.E_PARSE, fmt.Sprintf("couldn't read request body: %s", err))
+			return
+		}
+		if len(body) > maxBatchBodySize {
+			writeBatchError(w, http.StatusRequestEntityTooLarge, json2.E_INVALID_REQ, fmt.Sprintf("request body exceeds %d bytes", maxBatchBodySize))
+			return
+		}
+		_ = r.Body.Close()
+
+		trimmedBody := bytes.TrimSpace(body)
+		if len(trimmedBody) == 0 || trimmedBody[0] != '[' {
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		var calls []json.RawMessage
+		if err := json.Unmarshal(trimmedBody, &calls); err != nil {
+			writeBatchError(w, http.StatusBadRequest, json2.E_PARSE, fmt.Sprintf("couldn't parse batch request: %s", err))
+			return
+		}
+		switch {
+		case len(calls) == 0:
+			writeBatchError(w, http.StatusBadRequest, json2.E_INVALID_REQ, "batch request is empty")
+			return
+		case len(calls) > maxBatchSize:
+			writeBatchError(w, http.StatusBadRequest, json2.E_INVALID_REQ, fmt.Sprintf("batch request has %d calls but at most %d are allowed", len(calls), maxBatchSize))
+			return
+		}
+
+		responses := make([]json.RawMessage, 0, len(calls))
+		for _, call := range calls {
+			if response := serveCall(handler, r, call); len(response) > 0 {
+				responses = append(responses, response)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		// Per the JSON-RPC 2.0 spec, nothing is returned if each call of the
+		// batch is a notification
+		if len(responses) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		// There isn't anything to do with the returned error, so it is dropped.
+		_ = json.NewEncoder(w).Encode(responses)
+	})
+}
+
+// Passes [call] of batch request [r] to [handler], and returns the response.
+// Returns nil if [call] is a notification.
+func serveCall(handler http.Handler, r *http.Request, call json.RawMessage) json.RawMessage {
+	var header struct {
+		ID *json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(call, &header); err != nil {
+		// Per the JSON-RPC 2.0 spec, calls that aren't objects are invalid
+		// requests rather than notifications
+		return newErrorResponse(nil, json2.E_INVALID_REQ, "call must be an object")
+	}
+
+	callRequest := r.Clone(r.Context())
+	callRequest.Body = io.NopCloser(bytes.NewReader(call))
+	callRequest.ContentLength = int64(len(call))
+
+	w := &responseRecorder{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+	handler.ServeHTTP(w, callRequest)
+
+	response := bytes.TrimSpace(w.body.Bytes())
+	switch {
+	case len(response) == 0 && header.ID == nil:
+		return nil
+	case len(response) > 0 && response[0] == '{' && json.Valid(response):
+		return response
+	}
+
+	// [handler] didn't respond with a JSON-RPC response, so report its
+	// response as the error of the call
+	message := strings.TrimSpace(string(response))
+	if message == "" {
+		message = http.StatusText(w.status)
+	}
+	return newErrorResponse(header.ID, json2.E_SERVER, message)
+}
+
+// Returns a JSON-RPC response to the call with [id] that failed with [code]
+// and [message]
+func newErrorResponse(id *json.RawMessage, code json2.ErrorCode, message string) json.RawMessage {
+	response, err := json.Marshal(&errorResponse{
+		Version: json2.Version,
+		Error: &json2.Error{
+			Code:    code,
+			Message: message,
+		},
+		ID: id,
+	})
+	if err != nil {
+		return nil
+	}
+	return response
+}
+
+// Write a JSON-RPC formatted error response for a batch that couldn't be
+// handled. Errors while writing are ignored.
+func writeBatchError(w http.ResponseWriter, status int, code json2.ErrorCode, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(newErrorResponse(nil, code, message))
+}
+
+type errorResponse struct {
+	Version string           `json:"jsonrpc"`
+	Error   *json2.Error     `json:"error"`
+	ID      *json.RawMessage `json:"id"`
+}
+
+// responseRecorder buffers the response to a call of a batch request
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
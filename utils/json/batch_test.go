@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/rpc/v2"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errOdd = errors.New("odd")
+
+type testService struct{}
+
+type DoubleArgs struct {
+	X int `json:"x"`
+}
+
+type DoubleReply struct {
+	Double int `json:"double"`
+}
+
+func (*testService) Double(_ *http.Request, args *DoubleArgs, reply *DoubleReply) error {
+	if args.X%2 != 0 {
+		return errOdd
+	}
+	reply.Double = 2 * args.X
+	return nil
+}
+
+type testResponse struct {
+	Result *DoubleReply `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	ID *int `json:"id"`
+}
+
+func TestBatchHandler(t *testing.T) {
+	require := require.New(t)
+
+	server := rpc.NewServer()
+	server.RegisterCodec(NewCodec(), "application/json")
+	require.NoError(server.RegisterService(&testService{}, "test"))
+	handler := NewBatchHandler(server, 3)
+
+	call := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// Requests that aren't batches are handled as usual
+	w := call(`{"jsonrpc":"2.0","id":1,"method":"test.double","params":{"x":2}}`)
+	require.Equal(http.StatusOK, w.Code)
+	response := testResponse{}
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(4, response.Result.Double)
+
+	// Each call of a batch has its own response or error, and notifications
+	// don't have a response
+	w = call(`[
+		{"jsonrpc":"2.0","id":1,"method":"test.double","params":{"x":2}},
+		{"jsonrpc":"2.0","id":2,"method":"test.double","params":{"x":3}},
+		{"jsonrpc":"2.0","method":"test.double","params":{"x":4}}
+	]`)
+	require.Equal(http.StatusOK, w.Code)
+	var responses []testResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &responses))
+	require.Len(responses, 2)
+	require.Equal(1, *responses[0].ID)
+	require.Equal(4, responses[0].Result.Double)
+	require.Nil(responses[0].Error)
+	require.Equal(2, *responses[1].ID)
+	require.Equal(errOdd.Error(), responses[1].Error.Message)
+
+	// Calls that aren't objects are reported as invalid requests
+	w = call(`[1, {"jsonrpc":"2.0","id":3,"method":"test.unknown"}]`)
+	require.Equal(http.StatusOK, w.Code)
+	responses = nil
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &responses))
+	require.Len(responses, 2)
+	require.Nil(responses[0].ID)
+	require.Equal(-32600, responses[0].Error.Code)
+	require.Equal(3, *responses[1].ID)
+	require.NotNil(responses[1].Error)
+
+	// Batches of only notifications don't have a response
+	w = call(`[{"jsonrpc":"2.0","method":"test.double","params":{"x":4}}]`)
+	require.Equal(http.StatusNoContent, w.Code)
+	require.Empty(w.Body.Bytes())
+
+	// Empty and oversized batches are rejected
+	for _, body := range []string{
+		`[]`,
+		`[{"id":1},{"id":2},{"id":3},{"id":4}]`,
+	} {
+		w = call(body)
+		require.Equal(http.StatusBadRequest, w.Code)
+		response := testResponse{}
+		require.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(-32600, response.Error.Code)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrBodyTooLarge is returned by RequestMethods if the body of the request is
+// too large to be read.
+var ErrBodyTooLarge = errors.New("request body is too large")
+
+// RequestMethods returns the JSON-RPC method called by each request in the body
+// of [r], which may be a single request or a batch of requests. A request whose
+// method can't be parsed, including a body that isn't JSON-RPC, is returned as
+// an empty method so that every request is accounted for. Returns no methods if
+// the body is empty. The body of [r] is replaced so that it can be read again
+// by the handler of [r].
+// Returns ErrBodyTooLarge if the body is larger than [maxBodySize] bytes.
+func RequestMethods(r *http.Request, maxBodySize int64) ([]string, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read request body: %w", err)
+	}
+	if int64(len(body)) > maxBodySize {
+		return nil, fmt.Errorf("%w: exceeds %d bytes", ErrBodyTooLarge, maxBodySize)
+	}
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil, nil
+	}
+	if body[0] != '[' {
+		return []string{requestMethod(body)}, nil
+	}
+
+	var reqs []json.RawMessage
+	if err := json.Unmarshal(body, &reqs); err != nil {
+		return []string{""}, nil
+	}
+	methods := make([]string, len(reqs))
+	for i, req := range reqs {
+		methods[i] = requestMethod(req)
+	}
+	return methods, nil
+}
+
+// Returns the method called by [req], or the empty string if it can't be
+// parsed.
+func requestMethod(req []byte) string {
+	parsedReq := struct {
+		Method string `json:"method"`
+	}{}
+	if err := json.Unmarshal(req, &parsedReq); err != nil {
+		return ""
+	}
+	return parsedReq.Method
+}
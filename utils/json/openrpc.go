@@ -0,0 +1,311 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// OpenRPCVersion is the version of the OpenRPC specification that
+	// generated documents conform to
+	OpenRPCVersion = "1.2.6"
+
+	schemaRefPrefix = "#/components/schemas/"
+	// Runes that can't be in the name of a schema
+	invalidSchemaNameRunes = "[]*, /"
+)
+
+var (
+	errorType          = reflect.TypeOf((*error)(nil)).Elem()
+	requestType        = reflect.TypeOf((*http.Request)(nil))
+	jsonMarshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType  = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	rawMessageType     = reflect.TypeOf(json.RawMessage(nil))
+	emptyInterfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+)
+
+// Document is an OpenRPC document that describes the JSON-RPC methods of a
+// server. See https://spec.open-rpc.org.
+type Document struct {
+	OpenRPC    string       `json:"openrpc"`
+	Info       DocumentInfo `json:"info"`
+	Methods    []Method     `json:"methods"`
+	Components Components   `json:"components"`
+}
+
+type DocumentInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Method describes a JSON-RPC method. Arguments are passed by name, so each
+// field of the method's args is a param.
+type Method struct {
+	Name           string              `json:"name"`
+	ParamStructure string              `json:"paramStructure"`
+	Params         []ContentDescriptor `json:"params"`
+	Result         ContentDescriptor   `json:"result"`
+}
+
+type ContentDescriptor struct {
+	Name   string  `json:"name"`
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	// Name --> Schema of the named type
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema is a JSON Schema
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// schemaGenerator generates the JSON Schemas of Go types. Named struct types
+// are described once in [schemas] and referenced by name.
+type schemaGenerator struct {
+	// Name --> Schema of the named type
+	schemas map[string]*Schema
+	// Type --> Name of the type in [schemas]
+	names map[reflect.Type]string
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// Returns the methods of [receiver] that can be called over JSON-RPC as
+// [serviceName].method. Methods are recognized the same way as
+// github.com/gorilla/rpc/v2 recognizes them.
+func (g *schemaGenerator) methods(serviceName string, receiver interface{}) []Method {
+	receiverType := reflect.TypeOf(receiver)
+	var methods []Method
+	for i := 0; i < receiverType.NumMethod(); i++ {
+		method := receiverType.Method(i)
+		methodType := method.Type
+		if method.PkgPath != "" ||
+			methodType.NumIn() != 4 ||
+			methodType.In(1) != requestType ||
+			methodType.In(2).Kind() != reflect.Ptr ||
+			methodType.In(3).Kind() != reflect.Ptr ||
+			methodType.NumOut() != 1 ||
+			methodType.Out(0) != errorType {
+			continue
+		}
+		argsType := methodType.In(2).Elem()
+		replyType := methodType.In(3).Elem()
+		methods = append(methods, Method{
+			Name:           serviceName + "." + lowercaseFirst(method.Name),
+			ParamStructure: "by-name",
+			Params:         g.params(argsType),
+			Result: ContentDescriptor{
+				Name:   "reply",
+				Schema: g.schema(replyType),
+			},
+		})
+	}
+	return methods
+}
+
+// Returns the params of a method with args of type [argsType]
+func (g *schemaGenerator) params(argsType reflect.Type) []ContentDescriptor {
+	params := []ContentDescriptor{}
+	if argsType.Kind() != reflect.Struct || implementsMarshaler(argsType) {
+		return params
+	}
+	forEachField(argsType, func(name string, fieldType reflect.Type) {
+		params = append(params, ContentDescriptor{
+			Name:   name,
+			Schema: g.schema(fieldType),
+		})
+	})
+	return params
+}
+
+// Returns the schema of [t], as it's marshalled by encoding/json
+func (g *schemaGenerator) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == rawMessageType || t == emptyInterfaceType {
+		return &Schema{}
+	}
+	if implementsMarshaler(t) {
+		return marshalerSchema(t)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t)
+	default:
+		// Other kinds, such as interfaces, could be marshalled as anything
+		return &Schema{}
+	}
+}
+
+// Returns the schema of struct type [t]. If [t] is named, its schema is added
+// to [g.schemas] and a reference to it is returned.
+func (g *schemaGenerator) structSchema(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return g.objectSchema(t)
+	}
+	if name, ok := g.names[t]; ok {
+		return &Schema{Ref: schemaRefPrefix + name}
+	}
+
+	name := schemaName(t)
+	// Types with the same name in different packages are told apart by
+	// suffixes
+	for i := 2; ; i++ {
+		if _, ok := g.schemas[name]; !ok {
+			break
+		}
+		name = schemaName(t) + "_" + strconv.Itoa(i)
+	}
+	g.names[t] = name
+	// Reserve the name before generating the schema of the struct, which may
+	// reference [t]
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.objectSchema(t)
+	return &Schema{Ref: schemaRefPrefix + name}
+}
+
+func (g *schemaGenerator) objectSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+	forEachField(t, func(name string, fieldType reflect.Type) {
+		schema.Properties[name] = g.schema(fieldType)
+	})
+	return schema
+}
+
+// Calls [f] with the JSON name and type of each field of struct type [t] that
+// is marshalled by encoding/json, including the promoted fields of embedded
+// structs.
+func forEachField(t reflect.Type, f func(name string, fieldType reflect.Type)) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			for fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct && !implementsMarshaler(fieldType) {
+				forEachField(fieldType, f)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		f(name, field.Type)
+	}
+}
+
+func implementsMarshaler(t reflect.Type) bool {
+	ptrType := reflect.PtrTo(t)
+	return t.Implements(jsonMarshalerType) || ptrType.Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || ptrType.Implements(textMarshalerType)
+}
+
+// Returns the schema of [t], which marshals itself. The JSON type is inferred
+// by marshalling the zero value of [t].
+func marshalerSchema(t reflect.Type) (schema *Schema) {
+	defer func() {
+		// The zero value of [t] may not be marshallable
+		if r := recover(); r != nil {
+			schema = &Schema{}
+		}
+	}()
+
+	if !t.Implements(jsonMarshalerType) && !reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		// Text marshallers are marshalled as strings
+		return &Schema{Type: "string"}
+	}
+	b, err := json.Marshal(reflect.New(t).Interface())
+	if err != nil || len(b) == 0 {
+		return &Schema{}
+	}
+	switch b[0] {
+	case '"':
+		return &Schema{Type: "string"}
+	case '{':
+		return &Schema{Type: "object"}
+	case '[':
+		return &Schema{Type: "array"}
+	case 't', 'f':
+		return &Schema{Type: "boolean"}
+	case 'n':
+		return &Schema{}
+	default:
+		return &Schema{Type: "number"}
+	}
+}
+
+// Returns the name of named type [t] in the components of a document, such as
+// "avm.GetTxArgs"
+func schemaName(t reflect.Type) string {
+	name := path.Base(t.PkgPath()) + "." + t.Name()
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(invalidSchemaNameRunes, r) {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// Returns [s] with its first rune lowercased, which is the inverse of the
+// method name conversion performed by the codec
+func lowercaseFirst(s string) string {
+	firstRune, runeLen := utf8.DecodeRuneInString(s)
+	if firstRune == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToLower(firstRune)) + s[runeLen:]
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/rpc/v2"
+
+	"github.com/dioneprotocol/dionego/version"
+)
+
+// DiscoverServiceName is the name of the service that serves the OpenRPC
+// document of a server, as rpc.discover
+const DiscoverServiceName = "rpc"
+
+// Server is a JSON-RPC server that describes its methods with an OpenRPC
+// document. The document is served in response to GET requests, and as the
+// result of the rpc.discover method.
+type Server struct {
+	*rpc.Server
+
+	lock sync.Mutex
+	// Names of the registered services, in the order they were registered
+	serviceNames []string
+	// Service name --> Receiver of the service
+	services map[string]interface{}
+	// OpenRPC document describing [services]. Generated when first requested.
+	document *Document
+}
+
+// NewServer returns a new JSON-RPC server
+func NewServer() *Server {
+	s := &Server{
+		Server:   rpc.NewServer(),
+		services: make(map[string]interface{}),
+	}
+	// This can't fail because the discover service is well formed
+	_ = s.Server.RegisterService(&discoverService{server: s}, DiscoverServiceName)
+	return s
+}
+
+// RegisterService registers the methods of [receiver] as [name].method and
+// describes them in the server's OpenRPC document.
+func (s *Server) RegisterService(receiver interface{}, name string) error {
+	if err := s.Server.RegisterService(receiver, name); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.serviceNames = append(s.serviceNames, name)
+	s.services[name] = receiver
+	s.document = nil
+	return nil
+}
+
+// Document returns the OpenRPC document describing the methods of the server
+func (s *Server) Document() *Document {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.document != nil {
+		return s.document
+	}
+
+	g := newSchemaGenerator()
+	methods := []Method{}
+	for _, name := range s.serviceNames {
+		methods = append(methods, g.methods(name, s.services[name])...)
+	}
+	s.document = &Document{
+		OpenRPC: OpenRPCVersion,
+		Info: DocumentInfo{
+			Title:   strings.Join(s.serviceNames, ", "),
+			Version: version.Current.String(),
+		},
+		Methods: methods,
+		Components: Components{
+			Schemas: g.schemas,
+		},
+	}
+	return s.document
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.Server.ServeHTTP(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	// There isn't anything to do with the returned error, so it is dropped.
+	_ = json.NewEncoder(w).Encode(s.Document())
+}
+
+// discoverService serves rpc.discover, as defined by the OpenRPC specification
+type discoverService struct {
+	server *Server
+}
+
+func (d *discoverService) Discover(_ *http.Request, _ *struct{}, reply *Document) error {
+	*reply = *d.server.Document()
+	return nil
+}
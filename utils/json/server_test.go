@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/ids"
+)
+
+type Embedded struct {
+	Limit Uint32 `json:"limit"`
+}
+
+type ListArgs struct {
+	Embedded
+	Prefix  string `json:"prefix,omitempty"`
+	Ignored string `json:"-"`
+	hidden  string
+}
+
+type Node struct {
+	ID       ids.ID            `json:"id"`
+	Started  time.Time         `json:"started"`
+	Weight   float64           `json:"weight"`
+	Tags     map[string]string `json:"tags"`
+	Bytes    []byte            `json:"bytes"`
+	Children []*Node           `json:"children"`
+}
+
+type ListReply struct {
+	Nodes []Node `json:"nodes"`
+	Valid bool   `json:"valid"`
+}
+
+type listService struct{}
+
+func (*listService) List(_ *http.Request, _ *ListArgs, reply *ListReply) error {
+	reply.Valid = true
+	return nil
+}
+
+// Not a JSON-RPC method because it has the wrong signature
+func (*listService) Helper() {}
+
+func TestServerDocument(t *testing.T) {
+	require := require.New(t)
+
+	server := NewServer()
+	server.RegisterCodec(NewCodec(), "application/json")
+	require.NoError(server.RegisterService(&listService{}, "list"))
+	require.NoError(server.RegisterService(&testService{}, "test"))
+
+	document := server.Document()
+	require.Equal(OpenRPCVersion, document.OpenRPC)
+	require.Equal("list, test", document.Info.Title)
+	require.Len(document.Methods, 2)
+
+	list := document.Methods[0]
+	require.Equal("list.list", list.Name)
+	require.Equal("by-name", list.ParamStructure)
+	require.Len(list.Params, 2)
+	require.Equal("limit", list.Params[0].Name)
+	require.Equal(&Schema{Type: "string"}, list.Params[0].Schema)
+	require.Equal("prefix", list.Params[1].Name)
+	require.Equal(&Schema{Type: "string"}, list.Params[1].Schema)
+	require.Equal(&Schema{Ref: "#/components/schemas/json.ListReply"}, list.Result.Schema)
+
+	require.Equal(&Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"nodes": {Type: "array", Items: &Schema{Ref: "#/components/schemas/json.Node"}},
+			"valid": {Type: "boolean"},
+		},
+	}, document.Components.Schemas["json.ListReply"])
+	require.Equal(&Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"id":       {Type: "string"},
+			"started":  {Type: "string"},
+			"weight":   {Type: "number"},
+			"tags":     {Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+			"bytes":    {Type: "string", Format: "byte"},
+			"children": {Type: "array", Items: &Schema{Ref: "#/components/schemas/json.Node"}},
+		},
+	}, document.Components.Schemas["json.Node"])
+
+	require.Equal("test.double", document.Methods[1].Name)
+	require.Equal(&Schema{Type: "integer"}, document.Methods[1].Params[0].Schema)
+
+	// The document is served in response to GET requests
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(http.StatusOK, w.Code)
+	servedDocument := &Document{}
+	require.NoError(json.Unmarshal(w.Body.Bytes(), servedDocument))
+	require.Equal(document.Methods, servedDocument.Methods)
+
+	// The document is the result of rpc.discover
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"rpc.discover"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	require.Equal(http.StatusOK, w.Code)
+	response := struct {
+		Result *Document `json:"result"`
+	}{}
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(document.Methods, response.Result.Methods)
+
+	// Registered methods are still callable
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"list.list","params":{}}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	require.Equal(http.StatusOK, w.Code)
+	require.Contains(w.Body.String(), `"valid":true`)
+}
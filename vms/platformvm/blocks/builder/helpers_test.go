@@ -21,6 +21,7 @@ import (
 	"github.com/dioneprotocol/dionego/database/prefixdb"
 	"github.com/dioneprotocol/dionego/database/versiondb"
 	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/pubsub"
 	"github.com/dioneprotocol/dionego/snow"
 	"github.com/dioneprotocol/dionego/snow/engine/common"
 	"github.com/dioneprotocol/dionego/snow/uptime"
@@ -41,6 +42,7 @@ import (
 	"github.com/dioneprotocol/dionego/vms/platformvm/api"
 	"github.com/dioneprotocol/dionego/vms/platformvm/config"
 	"github.com/dioneprotocol/dionego/vms/platformvm/fx"
+	"github.com/dioneprotocol/dionego/vms/platformvm/index"
 	"github.com/dioneprotocol/dionego/vms/platformvm/metrics"
 	"github.com/dioneprotocol/dionego/vms/platformvm/reward"
 	"github.com/dioneprotocol/dionego/vms/platformvm/state"
@@ -179,6 +181,8 @@ func newEnvironment(t *testing.T) *environment {
 		res.state,
 		&res.backend,
 		window,
+		index.NewNoIndexer(),
+		pubsub.New(logging.NoLog{}),
 	)
 
 	res.Builder = New(
@@ -9,10 +9,12 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/pubsub"
 	"github.com/dioneprotocol/dionego/snow/choices"
 	"github.com/dioneprotocol/dionego/utils"
 	"github.com/dioneprotocol/dionego/utils/window"
 	"github.com/dioneprotocol/dionego/vms/platformvm/blocks"
+	"github.com/dioneprotocol/dionego/vms/platformvm/index"
 	"github.com/dioneprotocol/dionego/vms/platformvm/metrics"
 	"github.com/dioneprotocol/dionego/vms/platformvm/state"
 )
@@ -27,6 +29,11 @@ type acceptor struct {
 	metrics          metrics.Metrics
 	recentlyAccepted window.Window[ids.ID]
 	bootstrapped     *utils.Atomic[bool]
+	// Indexed after each block is committed to [state].
+	addressTxsIndexer index.AddressTxsIndexer
+	// Notified of the transactions in each block after it's committed to
+	// [state].
+	pubsub *pubsub.Server
 }
 
 func (a *acceptor) BanffAbortBlock(b *blocks.BanffAbortBlock) error {
@@ -170,7 +177,8 @@ func (a *acceptor) ApricotAtomicBlock(b *blocks.ApricotAtomicBlock) error {
 			err,
 		)
 	}
-	return nil
+	a.publishTxs(b)
+	return a.indexAddressTxs()
 }
 
 func (a *acceptor) abortBlock(b blocks.Block) error {
@@ -188,7 +196,7 @@ func (a *acceptor) abortBlock(b blocks.Block) error {
 		}
 	}
 
-	return a.optionBlock(b, parentState.statelessBlock)
+	return a.optionBlock(b, parentState.statelessBlock, false /*committed*/)
 }
 
 func (a *acceptor) commitBlock(b blocks.Block) error {
@@ -206,10 +214,11 @@ func (a *acceptor) commitBlock(b blocks.Block) error {
 		}
 	}
 
-	return a.optionBlock(b, parentState.statelessBlock)
+	return a.optionBlock(b, parentState.statelessBlock, true /*committed*/)
 }
 
-func (a *acceptor) optionBlock(b, parent blocks.Block) error {
+// The transactions of [parent] are only published if they were [committed].
+func (a *acceptor) optionBlock(b, parent blocks.Block, committed bool) error {
 	blkID := b.ID()
 	parentID := parent.ID()
 
@@ -234,7 +243,13 @@ func (a *acceptor) optionBlock(b, parent blocks.Block) error {
 		return fmt.Errorf("couldn't find state of block %s", blkID)
 	}
 	blkState.onAcceptState.Apply(a.state)
-	return a.state.Commit()
+	if err := a.state.Commit(); err != nil {
+		return err
+	}
+	if committed {
+		a.publishTxs(parent)
+	}
+	return a.indexAddressTxs()
 }
 
 func (a *acceptor) proposalBlock(b blocks.Block) {
@@ -291,7 +306,8 @@ func (a *acceptor) standardBlock(b blocks.Block) error {
 	if onAcceptFunc := blkState.onAcceptFunc; onAcceptFunc != nil {
 		onAcceptFunc()
 	}
-	return nil
+	a.publishTxs(b)
+	return a.indexAddressTxs()
 }
 
 func (a *acceptor) commonAccept(b blocks.Block) error {
@@ -308,3 +324,31 @@ func (a *acceptor) commonAccept(b blocks.Block) error {
 	a.recentlyAccepted.Add(blkID)
 	return nil
 }
+
+// Publishes the transactions in [b], which was committed to [a.state], to
+// the subscribers of the addresses they involve.
+// Transactions aren't published while bootstrapping.
+func (a *acceptor) publishTxs(b blocks.Block) {
+	if !a.bootstrapped.Get() {
+		return
+	}
+	for _, tx := range b.Txs() {
+		filterer, err := index.NewPubSubFilterer(a.state, tx)
+		if err != nil {
+			a.ctx.Log.Warn("failed to publish tx",
+				zap.Stringer("txID", tx.ID()),
+				zap.Error(err),
+			)
+			continue
+		}
+		a.pubsub.Publish(filterer)
+	}
+}
+
+// Indexes the transactions in the blocks that were committed to [a.state].
+func (a *acceptor) indexAddressTxs() error {
+	if err := a.addressTxsIndexer.Accept(a.state); err != nil {
+		return fmt.Errorf("failed to index address transactions: %w", err)
+	}
+	return nil
+}
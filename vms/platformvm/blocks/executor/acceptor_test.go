@@ -22,6 +22,7 @@ import (
 	"github.com/dioneprotocol/dionego/utils/window"
 	"github.com/dioneprotocol/dionego/vms/components/verify"
 	"github.com/dioneprotocol/dionego/vms/platformvm/blocks"
+	"github.com/dioneprotocol/dionego/vms/platformvm/index"
 	"github.com/dioneprotocol/dionego/vms/platformvm/metrics"
 	"github.com/dioneprotocol/dionego/vms/platformvm/state"
 	"github.com/dioneprotocol/dionego/vms/platformvm/txs"
@@ -104,6 +105,8 @@ func TestAcceptorVisitAtomicBlock(t *testing.T) {
 			MaxSize: 1,
 			TTL:     time.Hour,
 		}),
+		bootstrapped:      &utils.Atomic[bool]{},
+		addressTxsIndexer: index.NewNoIndexer(),
 	}
 
 	blk, err := blocks.NewApricotAtomicBlock(
@@ -189,6 +192,8 @@ func TestAcceptorVisitStandardBlock(t *testing.T) {
 			MaxSize: 1,
 			TTL:     time.Hour,
 		}),
+		bootstrapped:      &utils.Atomic[bool]{},
+		addressTxsIndexer: index.NewNoIndexer(),
 	}
 
 	blk, err := blocks.NewBanffStandardBlock(
@@ -284,7 +289,8 @@ func TestAcceptorVisitCommitBlock(t *testing.T) {
 			MaxSize: 1,
 			TTL:     time.Hour,
 		}),
-		bootstrapped: &utils.Atomic[bool]{},
+		addressTxsIndexer: index.NewNoIndexer(),
+		bootstrapped:      &utils.Atomic[bool]{},
 	}
 
 	blk, err := blocks.NewApricotCommitBlock(parentID, 1 /*height*/)
@@ -374,9 +380,14 @@ func TestAcceptorVisitAbortBlock(t *testing.T) {
 			MaxSize: 1,
 			TTL:     time.Hour,
 		}),
-		bootstrapped: &utils.Atomic[bool]{},
+		addressTxsIndexer: index.NewNoIndexer(),
+		bootstrapped:      &utils.Atomic[bool]{},
 	}
 
+	// The transactions of the parent must not be published, because they
+	// were aborted.
+	acceptor.bootstrapped.Set(true)
+
 	blk, err := blocks.NewApricotAbortBlock(parentID, 1 /*height*/)
 	require.NoError(err)
 
@@ -433,6 +444,7 @@ func TestAcceptorVisitAbortBlock(t *testing.T) {
 		onAcceptState.EXPECT().Apply(s).Times(1),
 		s.EXPECT().Commit().Return(nil).Times(1),
 	)
+	parentStatelessBlk.EXPECT().Txs().Times(0)
 
 	err = acceptor.ApricotAbortBlock(blk)
 	require.NoError(err)
@@ -22,6 +22,7 @@ import (
 	"github.com/dioneprotocol/dionego/database/prefixdb"
 	"github.com/dioneprotocol/dionego/database/versiondb"
 	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/pubsub"
 	"github.com/dioneprotocol/dionego/snow"
 	"github.com/dioneprotocol/dionego/snow/engine/common"
 	"github.com/dioneprotocol/dionego/snow/uptime"
@@ -42,6 +43,7 @@ import (
 	"github.com/dioneprotocol/dionego/vms/platformvm/api"
 	"github.com/dioneprotocol/dionego/vms/platformvm/config"
 	"github.com/dioneprotocol/dionego/vms/platformvm/fx"
+	"github.com/dioneprotocol/dionego/vms/platformvm/index"
 	"github.com/dioneprotocol/dionego/vms/platformvm/metrics"
 	"github.com/dioneprotocol/dionego/vms/platformvm/reward"
 	"github.com/dioneprotocol/dionego/vms/platformvm/state"
@@ -214,6 +216,8 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller) *environment {
 			res.state,
 			res.backend,
 			window,
+			index.NewNoIndexer(),
+			pubsub.New(logging.NoLog{}),
 		)
 		addSubnet(res)
 	} else {
@@ -223,6 +227,8 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller) *environment {
 			res.mockedState,
 			res.backend,
 			window,
+			index.NewNoIndexer(),
+			pubsub.New(logging.NoLog{}),
 		)
 		// we do not add any subnet to state, since we can mock
 		// whatever we need
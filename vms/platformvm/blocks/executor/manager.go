@@ -5,9 +5,11 @@ package executor
 
 import (
 	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/pubsub"
 	"github.com/dioneprotocol/dionego/snow/consensus/snowman"
 	"github.com/dioneprotocol/dionego/utils/window"
 	"github.com/dioneprotocol/dionego/vms/platformvm/blocks"
+	"github.com/dioneprotocol/dionego/vms/platformvm/index"
 	"github.com/dioneprotocol/dionego/vms/platformvm/metrics"
 	"github.com/dioneprotocol/dionego/vms/platformvm/state"
 	"github.com/dioneprotocol/dionego/vms/platformvm/txs/executor"
@@ -32,6 +34,8 @@ func NewManager(
 	s state.State,
 	txExecutorBackend *executor.Backend,
 	recentlyAccepted window.Window[ids.ID],
+	addressTxsIndexer index.AddressTxsIndexer,
+	pubsub *pubsub.Server,
 ) Manager {
 	backend := &backend{
 		Mempool:      mempool,
@@ -48,10 +52,12 @@ func NewManager(
 			txExecutorBackend: txExecutorBackend,
 		},
 		acceptor: &acceptor{
-			backend:          backend,
-			metrics:          metrics,
-			recentlyAccepted: recentlyAccepted,
-			bootstrapped:     txExecutorBackend.Bootstrapped,
+			backend:           backend,
+			metrics:           metrics,
+			recentlyAccepted:  recentlyAccepted,
+			bootstrapped:      txExecutorBackend.Bootstrapped,
+			addressTxsIndexer: addressTxsIndexer,
+			pubsub:            pubsub,
 		},
 		rejector: &rejector{backend: backend},
 	}
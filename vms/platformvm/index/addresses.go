@@ -0,0 +1,183 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"fmt"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/utils/set"
+	"github.com/dioneprotocol/dionego/vms/components/dione"
+	"github.com/dioneprotocol/dionego/vms/platformvm/txs"
+)
+
+var _ txs.Visitor = (*addressVisitor)(nil)
+
+// Returns the addresses that [tx] involved.
+// See AddressTxsIndexer for the definition of involvement.
+func txAddresses(chain Chain, tx *txs.Tx) (set.Set[ids.ShortID], error) {
+	v := &addressVisitor{
+		chain: chain,
+		addrs: set.Set[ids.ShortID]{},
+	}
+	if err := tx.Unsigned.Visit(v); err != nil {
+		return nil, err
+	}
+	return v.addrs, nil
+}
+
+// addressVisitor collects the addresses involved in the transactions it
+// visits.
+type addressVisitor struct {
+	chain Chain
+	addrs set.Set[ids.ShortID]
+}
+
+func (v *addressVisitor) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	return v.stakerTx(&tx.BaseTx, tx)
+}
+
+func (v *addressVisitor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	return v.baseTx(&tx.BaseTx)
+}
+
+func (v *addressVisitor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	return v.stakerTx(&tx.BaseTx, tx)
+}
+
+func (v *addressVisitor) CreateChainTx(tx *txs.CreateChainTx) error {
+	return v.baseTx(&tx.BaseTx)
+}
+
+func (v *addressVisitor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	return v.baseTx(&tx.BaseTx)
+}
+
+// The imported inputs were produced on another chain, so their owners aren't
+// known to the P-Chain.
+func (v *addressVisitor) ImportTx(tx *txs.ImportTx) error {
+	return v.baseTx(&tx.BaseTx)
+}
+
+func (v *addressVisitor) ExportTx(tx *txs.ExportTx) error {
+	v.addOutputs(tx.ExportedOutputs)
+	return v.baseTx(&tx.BaseTx)
+}
+
+func (*addressVisitor) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	return nil
+}
+
+// A RewardValidatorTx returns the stake of the staker it removes and, if the
+// staker was rewarded, produces its reward UTXOs.
+func (v *addressVisitor) RewardValidatorTx(tx *txs.RewardValidatorTx) error {
+	stakerTx, _, err := v.chain.GetTx(tx.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get staker tx %s: %w", tx.TxID, err)
+	}
+	if staker, ok := stakerTx.Unsigned.(txs.PermissionlessStaker); ok {
+		v.addOutputs(staker.Stake())
+	}
+
+	rewardUTXOs, err := v.chain.GetRewardUTXOs(tx.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get reward UTXOs of %s: %w", tx.TxID, err)
+	}
+	for _, utxo := range rewardUTXOs {
+		addAddresses(v.addrs, outputAddresses(utxo.Out))
+	}
+	return nil
+}
+
+func (v *addressVisitor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	return v.baseTx(&tx.BaseTx)
+}
+
+func (v *addressVisitor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	return v.baseTx(&tx.BaseTx)
+}
+
+func (v *addressVisitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	return v.stakerTx(&tx.BaseTx, tx)
+}
+
+func (v *addressVisitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	return v.stakerTx(&tx.BaseTx, tx)
+}
+
+// Adds the owners of the stake and of the rewards of [staker].
+func (v *addressVisitor) stakerTx(tx *txs.BaseTx, staker txs.PermissionlessStaker) error {
+	v.addOutputs(staker.Stake())
+	switch staker := staker.(type) {
+	case txs.Validator:
+		addAddresses(v.addrs, outputAddresses(staker.ValidationRewardsOwner()))
+		addAddresses(v.addrs, outputAddresses(staker.DelegationRewardsOwner()))
+	case txs.Delegator:
+		addAddresses(v.addrs, outputAddresses(staker.RewardsOwner()))
+	}
+	return v.baseTx(tx)
+}
+
+// Adds the owners of the UTXOs that [tx] consumes and produces.
+func (v *addressVisitor) baseTx(tx *txs.BaseTx) error {
+	v.addOutputs(tx.Outs)
+	for _, in := range tx.Ins {
+		if err := v.addInput(&in.UTXOID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *addressVisitor) addOutputs(outs []*dione.TransferableOutput) {
+	for _, out := range outs {
+		addAddresses(v.addrs, outputAddresses(out.Out))
+	}
+}
+
+// Adds the owners of the UTXO [utxoID].
+//
+// The UTXO has already been consumed, so its owners are found from the
+// transaction that produced it. The UTXOs a transaction produces are indexed
+// in the order: its outputs, its stake outputs and then its reward UTXOs.
+func (v *addressVisitor) addInput(utxoID *dione.UTXOID) error {
+	producer, _, err := v.chain.GetTx(utxoID.TxID)
+	if err == database.ErrNotFound {
+		// The UTXO was created in genesis or imported from another chain,
+		// neither of which has a transaction on the P-Chain.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get tx %s: %w", utxoID.TxID, err)
+	}
+
+	outs := producer.Unsigned.Outputs()
+	index := int(utxoID.OutputIndex)
+	if index < len(outs) {
+		addAddresses(v.addrs, outputAddresses(outs[index].Out))
+		return nil
+	}
+	index -= len(outs)
+
+	if staker, ok := producer.Unsigned.(txs.PermissionlessStaker); ok {
+		stake := staker.Stake()
+		if index < len(stake) {
+			addAddresses(v.addrs, outputAddresses(stake[index].Out))
+			return nil
+		}
+	}
+
+	rewardUTXOs, err := v.chain.GetRewardUTXOs(utxoID.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get reward UTXOs of %s: %w", utxoID.TxID, err)
+	}
+	for _, utxo := range rewardUTXOs {
+		if utxo.OutputIndex == utxoID.OutputIndex {
+			addAddresses(v.addrs, outputAddresses(utxo.Out))
+			return nil
+		}
+	}
+	return nil
+}
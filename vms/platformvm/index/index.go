@@ -0,0 +1,346 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/database/prefixdb"
+	"github.com/dioneprotocol/dionego/database/versiondb"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/snow/choices"
+	"github.com/dioneprotocol/dionego/utils/logging"
+	"github.com/dioneprotocol/dionego/utils/set"
+	"github.com/dioneprotocol/dionego/utils/wrappers"
+	"github.com/dioneprotocol/dionego/vms/components/dione"
+	"github.com/dioneprotocol/dionego/vms/platformvm/blocks"
+	"github.com/dioneprotocol/dionego/vms/platformvm/status"
+	"github.com/dioneprotocol/dionego/vms/platformvm/txs"
+)
+
+// Log the backfill progress every this many blocks.
+const backfillLogFrequency = 10_000
+
+var (
+	addressTxsPrefix    = []byte("addressTxs")
+	pendingBlocksPrefix = []byte("pendingBlocks")
+	singletonPrefix     = []byte("singleton")
+
+	idxKey           = []byte("idx")
+	indexedHeightKey = []byte("indexedHeight")
+
+	_ AddressTxsIndexer = (*indexer)(nil)
+	_ AddressTxsIndexer = (*noIndexer)(nil)
+)
+
+// Chain is the accepted state of the P-Chain that is indexed.
+type Chain interface {
+	GetLastAccepted() ids.ID
+	GetStatelessBlock(blockID ids.ID) (blocks.Block, choices.Status, error)
+	GetTx(txID ids.ID) (*txs.Tx, status.Status, error)
+	GetRewardUTXOs(txID ids.ID) ([]*dione.UTXO, error)
+}
+
+// AddressTxsIndexer maintains which accepted P-Chain transactions involved
+// which addresses.
+// A transaction is said to involve an address if any of these is true:
+// 1) A UTXO that the transaction consumes was at least partially owned by the
+// address.
+// 2) A UTXO that the transaction produces, including stake and exported
+// outputs, is at least partially owned by the address.
+// 3) The address is one of the owners of rewards the transaction specifies.
+// 4) The transaction returned stake to, or rewarded, the address.
+type AddressTxsIndexer interface {
+	// Accept indexes the transactions in the blocks accepted in [chain] that
+	// haven't been indexed yet, in order of increasing height.
+	// The first time this is called on a chain that was accepted without
+	// indexing, all of its history is indexed.
+	// If the error is non-nil, the index may be behind [chain], and it will
+	// catch up the next time Accept is called.
+	Accept(chain Chain) error
+
+	// Read returns the IDs of transactions that involved [address].
+	// The returned transactions are in order of increasing acceptance time.
+	// The length of the returned slice <= [pageSize].
+	// [cursor] is the offset to start reading from.
+	Read(address ids.ShortID, cursor, pageSize uint64) ([]ids.ID, error)
+}
+
+type indexer struct {
+	log     logging.Logger
+	metrics metrics
+
+	// Commits all changes made by Accept atomically.
+	db *versiondb.Database
+	// [address] -> [idx] -> txID
+	addressTxsDB database.Database
+	// height -> blkID of blocks that were accepted but aren't indexed yet.
+	pendingBlocksDB database.Database
+	singletonDB     database.Database
+}
+
+// NewIndexer returns a new AddressTxsIndexer.
+func NewIndexer(
+	db database.Database,
+	log logging.Logger,
+	metricsNamespace string,
+	metricsRegisterer prometheus.Registerer,
+) (AddressTxsIndexer, error) {
+	vdb := versiondb.New(db)
+	i := &indexer{
+		log:             log,
+		db:              vdb,
+		addressTxsDB:    prefixdb.New(addressTxsPrefix, vdb),
+		pendingBlocksDB: prefixdb.New(pendingBlocksPrefix, vdb),
+		singletonDB:     prefixdb.New(singletonPrefix, vdb),
+	}
+	return i, i.metrics.initialize(metricsNamespace, metricsRegisterer)
+}
+
+// See AddressTxsIndexer.Accept
+//
+// The database structure is:
+// addressTxs
+// |  [address]
+// |  | "idx" => 2 		Running transaction index key, represents the next index
+// |  | "0"   => txID1
+// |  | "1"   => txID2
+// pendingBlocks
+// |  [height] => blkID
+// singleton
+// |  "indexedHeight" => height of the last indexed block
+func (i *indexer) Accept(chain Chain) error {
+	// Drop any changes that weren't committed because of an error.
+	defer i.db.Abort()
+
+	indexedHeight, err := database.GetUInt64(i.singletonDB, indexedHeightKey)
+	if err == database.ErrNotFound {
+		// The genesis block doesn't have any transactions to index.
+		indexedHeight = 0
+	} else if err != nil {
+		return err
+	}
+
+	// Blocks only reference their parents, so record the blocks to index by
+	// walking back from the last accepted block. These are persisted so that
+	// the backfill of a long chain can resume where it stopped.
+	blkID := chain.GetLastAccepted()
+	for {
+		blk, _, err := chain.GetStatelessBlock(blkID)
+		if err != nil {
+			return fmt.Errorf("couldn't get block %s: %w", blkID, err)
+		}
+		height := blk.Height()
+		if height <= indexedHeight {
+			break
+		}
+		heightBytes := database.PackUInt64(height)
+		if has, err := i.pendingBlocksDB.Has(heightBytes); err != nil {
+			return err
+		} else if has {
+			// This block, and therefore all of its ancestors, were recorded
+			// by a previous call.
+			break
+		}
+		if err := i.pendingBlocksDB.Put(heightBytes, blkID[:]); err != nil {
+			return err
+		}
+		blkID = blk.Parent()
+	}
+	if err := i.db.Commit(); err != nil {
+		return err
+	}
+
+	for numIndexed := 1; ; numIndexed++ {
+		height, blkID, ok, err := i.nextPendingBlock()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		blk, _, err := chain.GetStatelessBlock(blkID)
+		if err != nil {
+			return fmt.Errorf("couldn't get block %s: %w", blkID, err)
+		}
+
+		// Each block is indexed atomically, so a block is never indexed
+		// twice.
+		for _, tx := range blk.Txs() {
+			if err := i.acceptTx(chain, tx); err != nil {
+				return err
+			}
+		}
+		if err := i.pendingBlocksDB.Delete(database.PackUInt64(height)); err != nil {
+			return err
+		}
+		if err := database.PutUInt64(i.singletonDB, indexedHeightKey, height); err != nil {
+			return err
+		}
+		if err := i.db.Commit(); err != nil {
+			return err
+		}
+
+		if numIndexed%backfillLogFrequency == 0 {
+			i.log.Info("indexing address transactions",
+				zap.Uint64("height", height),
+			)
+		}
+	}
+}
+
+// Returns the lowest block that is waiting to be indexed, if there is one.
+func (i *indexer) nextPendingBlock() (uint64, ids.ID, bool, error) {
+	it := i.pendingBlocksDB.NewIterator()
+	defer it.Release()
+
+	if !it.Next() {
+		return 0, ids.Empty, false, it.Error()
+	}
+	height, err := database.ParseUInt64(it.Key())
+	if err != nil {
+		return 0, ids.Empty, false, err
+	}
+	blkID, err := ids.ToID(it.Value())
+	return height, blkID, err == nil, err
+}
+
+// Indexes [tx] under each address it involved.
+// Transactions that had no effect are ignored.
+func (i *indexer) acceptTx(chain Chain, tx *txs.Tx) error {
+	txID := tx.ID()
+	_, txStatus, err := chain.GetTx(txID)
+	if err != nil {
+		return fmt.Errorf("couldn't get status of tx %s: %w", txID, err)
+	}
+	// A RewardValidatorTx returns the stake whether or not it's committed.
+	_, isRewardTx := tx.Unsigned.(*txs.RewardValidatorTx)
+	if txStatus != status.Committed && !isRewardTx {
+		return nil
+	}
+
+	addrs, err := txAddresses(chain, tx)
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses of tx %s: %w", txID, err)
+	}
+	for addr := range addrs {
+		if err := i.put(addr, txID); err != nil {
+			return err
+		}
+	}
+	i.metrics.numTxsIndexed.Inc()
+	return nil
+}
+
+// Appends [txID] to the transactions of [addr].
+func (i *indexer) put(addr ids.ShortID, txID ids.ID) error {
+	addressDB := prefixdb.New(addr[:], i.addressTxsDB)
+
+	var idx uint64
+	idxBytes, err := addressDB.Get(idxKey)
+	switch err {
+	case nil:
+		// index is found, parse stored [idxBytes]
+		idx = binary.BigEndian.Uint64(idxBytes)
+	case database.ErrNotFound:
+		// idx not found; this must be the first entry.
+		idxBytes = make([]byte, wrappers.LongLen)
+	default:
+		// Unexpected error
+		return fmt.Errorf("unexpected error when indexing txID %s: %w", txID, err)
+	}
+
+	// write the [txID] at the index
+	i.log.Verbo("writing indexed tx to DB",
+		zap.Stringer("address", addr),
+		zap.Uint64("index", idx),
+		zap.Stringer("txID", txID),
+	)
+	if err := addressDB.Put(idxBytes, txID[:]); err != nil {
+		return fmt.Errorf("failed to write txID while indexing %s: %w", txID, err)
+	}
+
+	// increment and store the index for next use
+	idx++
+	binary.BigEndian.PutUint64(idxBytes, idx)
+
+	if err := addressDB.Put(idxKey, idxBytes); err != nil {
+		return fmt.Errorf("failed to write index txID while indexing %s: %w", txID, err)
+	}
+	return nil
+}
+
+// Read returns IDs of transactions that involved [address], starting at
+// [cursor], in order of transaction acceptance. e.g. if [cursor] == 1, does
+// not return the first transaction that involved [address]. (This is for
+// pagination.)
+// Returns at most [pageSize] elements.
+// See AddressTxsIndexer
+func (i *indexer) Read(address ids.ShortID, cursor, pageSize uint64) ([]ids.ID, error) {
+	addressDB := prefixdb.New(address[:], i.addressTxsDB)
+
+	// get cursor in bytes
+	cursorBytes := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(cursorBytes, cursor)
+
+	// start reading from the cursor bytes, numeric keys maintain the order (see put)
+	iter := addressDB.NewIteratorWithStart(cursorBytes)
+	defer iter.Release()
+
+	var txIDs []ids.ID
+	for uint64(len(txIDs)) < pageSize && iter.Next() {
+		if bytes.Equal(idxKey, iter.Key()) {
+			// This key has the next index to use, not a tx ID
+			continue
+		}
+
+		txID, err := ids.ToID(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs, iter.Error()
+}
+
+type noIndexer struct{}
+
+func NewNoIndexer() AddressTxsIndexer {
+	return &noIndexer{}
+}
+
+func (*noIndexer) Accept(Chain) error {
+	return nil
+}
+
+func (*noIndexer) Read(ids.ShortID, uint64, uint64) ([]ids.ID, error) {
+	return nil, nil
+}
+
+// Returns the addresses that own [out], if any.
+func outputAddresses(out interface{}) [][]byte {
+	addressable, ok := out.(dione.Addressable)
+	if !ok {
+		return nil
+	}
+	return addressable.Addresses()
+}
+
+// Adds the addresses in [addrs] to [s].
+func addAddresses(s set.Set[ids.ShortID], addrs [][]byte) {
+	for _, addrBytes := range addrs {
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			continue
+		}
+		s.Add(addr)
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/database/memdb"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/snow/choices"
+	"github.com/dioneprotocol/dionego/utils/logging"
+	"github.com/dioneprotocol/dionego/vms/components/dione"
+	"github.com/dioneprotocol/dionego/vms/platformvm/blocks"
+	"github.com/dioneprotocol/dionego/vms/platformvm/status"
+	"github.com/dioneprotocol/dionego/vms/platformvm/txs"
+	"github.com/dioneprotocol/dionego/vms/secp256k1fx"
+)
+
+var _ Chain = (*testChain)(nil)
+
+type testTx struct {
+	tx     *txs.Tx
+	status status.Status
+}
+
+type testChain struct {
+	lastAccepted ids.ID
+	blocks       map[ids.ID]blocks.Block
+	txs          map[ids.ID]testTx
+	rewardUTXOs  map[ids.ID][]*dione.UTXO
+}
+
+func newTestChain(t *testing.T) *testChain {
+	genesis, err := blocks.NewApricotCommitBlock(ids.Empty, 0)
+	require.NoError(t, err)
+	return &testChain{
+		lastAccepted: genesis.ID(),
+		blocks: map[ids.ID]blocks.Block{
+			genesis.ID(): genesis,
+		},
+		txs:         make(map[ids.ID]testTx),
+		rewardUTXOs: make(map[ids.ID][]*dione.UTXO),
+	}
+}
+
+// Accepts a block with [txs] on top of the last accepted block.
+func (c *testChain) accept(t *testing.T, txStatus status.Status, txs ...*txs.Tx) {
+	parent := c.blocks[c.lastAccepted]
+	blk, err := blocks.NewBanffStandardBlock(time.Time{}, parent.ID(), parent.Height()+1, txs)
+	require.NoError(t, err)
+
+	c.lastAccepted = blk.ID()
+	c.blocks[blk.ID()] = blk
+	for _, tx := range txs {
+		c.txs[tx.ID()] = testTx{
+			tx:     tx,
+			status: txStatus,
+		}
+	}
+}
+
+func (c *testChain) GetLastAccepted() ids.ID {
+	return c.lastAccepted
+}
+
+func (c *testChain) GetStatelessBlock(blkID ids.ID) (blocks.Block, choices.Status, error) {
+	blk, ok := c.blocks[blkID]
+	if !ok {
+		return nil, choices.Unknown, database.ErrNotFound
+	}
+	return blk, choices.Accepted, nil
+}
+
+func (c *testChain) GetTx(txID ids.ID) (*txs.Tx, status.Status, error) {
+	tx, ok := c.txs[txID]
+	if !ok {
+		return nil, status.Unknown, database.ErrNotFound
+	}
+	return tx.tx, tx.status, nil
+}
+
+func (c *testChain) GetRewardUTXOs(txID ids.ID) ([]*dione.UTXO, error) {
+	return c.rewardUTXOs[txID], nil
+}
+
+func newOutput(addr ids.ShortID) *dione.TransferableOutput {
+	return &dione.TransferableOutput{
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+}
+
+func newInput(txID ids.ID, outputIndex uint32) *dione.TransferableInput {
+	return &dione.TransferableInput{
+		UTXOID: dione.UTXOID{
+			TxID:        txID,
+			OutputIndex: outputIndex,
+		},
+		In: &secp256k1fx.TransferInput{
+			Amt: 1,
+		},
+	}
+}
+
+func newOwner(addr ids.ShortID) *secp256k1fx.OutputOwners {
+	return &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr},
+	}
+}
+
+func newBaseTx(ins []*dione.TransferableInput, outs []*dione.TransferableOutput) txs.BaseTx {
+	return txs.BaseTx{
+		BaseTx: dione.BaseTx{
+			Ins:  ins,
+			Outs: outs,
+		},
+	}
+}
+
+func newTx(t *testing.T, unsignedTx txs.UnsignedTx) *txs.Tx {
+	tx := &txs.Tx{Unsigned: unsignedTx}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return tx
+}
+
+func TestIndexer(t *testing.T) {
+	require := require.New(t)
+
+	addr1 := ids.GenerateTestShortID()
+	addr2 := ids.GenerateTestShortID()
+	addr3 := ids.GenerateTestShortID()
+	chain := newTestChain(t)
+
+	// The input was created in genesis, so its owner isn't known.
+	tx1 := newTx(t, &txs.CreateSubnetTx{
+		BaseTx: newBaseTx(
+			[]*dione.TransferableInput{newInput(ids.GenerateTestID(), 0)},
+			[]*dione.TransferableOutput{newOutput(addr1)},
+		),
+		Owner: newOwner(addr3),
+	})
+	chain.accept(t, status.Committed, tx1)
+
+	tx2 := newTx(t, &txs.CreateSubnetTx{
+		BaseTx: newBaseTx(
+			[]*dione.TransferableInput{newInput(tx1.ID(), 0)},
+			[]*dione.TransferableOutput{newOutput(addr2)},
+		),
+		Owner: newOwner(addr3),
+	})
+	chain.accept(t, status.Committed, tx2)
+
+	db := memdb.New()
+	indexer, err := NewIndexer(db, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	// The history is indexed the first time.
+	require.NoError(indexer.Accept(chain))
+	txIDs, err := indexer.Read(addr1, 0, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{tx1.ID(), tx2.ID()}, txIDs)
+	txIDs, err = indexer.Read(addr2, 0, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{tx2.ID()}, txIDs)
+	txIDs, err = indexer.Read(addr3, 0, 10)
+	require.NoError(err)
+	require.Empty(txIDs)
+
+	// Transactions that were dropped aren't indexed.
+	tx3 := newTx(t, &txs.CreateSubnetTx{
+		BaseTx: newBaseTx(
+			[]*dione.TransferableInput{newInput(tx2.ID(), 0)},
+			[]*dione.TransferableOutput{newOutput(addr1)},
+		),
+		Owner: newOwner(addr3),
+	})
+	chain.accept(t, status.Dropped, tx3)
+
+	tx4 := newTx(t, &txs.CreateSubnetTx{
+		BaseTx: newBaseTx(
+			[]*dione.TransferableInput{newInput(tx2.ID(), 0)},
+			[]*dione.TransferableOutput{newOutput(addr1)},
+		),
+		Owner: newOwner(addr1),
+	})
+	chain.accept(t, status.Committed, tx4)
+
+	// Accepting twice doesn't index a block twice.
+	require.NoError(indexer.Accept(chain))
+	require.NoError(indexer.Accept(chain))
+
+	// A new indexer continues where the previous one stopped.
+	indexer, err = NewIndexer(db, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(indexer.Accept(chain))
+
+	txIDs, err = indexer.Read(addr1, 0, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{tx1.ID(), tx2.ID(), tx4.ID()}, txIDs)
+	txIDs, err = indexer.Read(addr2, 0, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{tx2.ID(), tx4.ID()}, txIDs)
+
+	// Pagination
+	txIDs, err = indexer.Read(addr1, 1, 1)
+	require.NoError(err)
+	require.Equal([]ids.ID{tx2.ID()}, txIDs)
+	txIDs, err = indexer.Read(addr1, 3, 1)
+	require.NoError(err)
+	require.Empty(txIDs)
+}
+
+func TestIndexerStakers(t *testing.T) {
+	require := require.New(t)
+
+	feeAddr := ids.GenerateTestShortID()
+	stakeAddr := ids.GenerateTestShortID()
+	rewardAddr := ids.GenerateTestShortID()
+	spendAddr := ids.GenerateTestShortID()
+	chain := newTestChain(t)
+
+	addDelegatorTx := newTx(t, &txs.AddDelegatorTx{
+		BaseTx: newBaseTx(
+			nil,
+			[]*dione.TransferableOutput{newOutput(feeAddr)},
+		),
+		StakeOuts:              []*dione.TransferableOutput{newOutput(stakeAddr)},
+		DelegationRewardsOwner: newOwner(rewardAddr),
+	})
+	chain.accept(t, status.Committed, addDelegatorTx)
+
+	rewardTx := newTx(t, &txs.RewardValidatorTx{
+		TxID: addDelegatorTx.ID(),
+	})
+	chain.rewardUTXOs[addDelegatorTx.ID()] = []*dione.UTXO{{
+		UTXOID: dione.UTXOID{
+			TxID:        addDelegatorTx.ID(),
+			OutputIndex: 2,
+		},
+		Out: newOutput(rewardAddr).Out,
+	}}
+	// The stake is returned even if the reward isn't committed.
+	chain.accept(t, status.Aborted, rewardTx)
+
+	// Spend the returned stake and the reward.
+	spendTx := newTx(t, &txs.CreateSubnetTx{
+		BaseTx: newBaseTx(
+			[]*dione.TransferableInput{
+				newInput(addDelegatorTx.ID(), 1),
+				newInput(addDelegatorTx.ID(), 2),
+			},
+			[]*dione.TransferableOutput{newOutput(spendAddr)},
+		),
+		Owner: newOwner(spendAddr),
+	})
+	chain.accept(t, status.Committed, spendTx)
+
+	indexer, err := NewIndexer(memdb.New(), logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(indexer.Accept(chain))
+
+	tests := []struct {
+		addr          ids.ShortID
+		expectedTxIDs []ids.ID
+	}{
+		{
+			addr:          feeAddr,
+			expectedTxIDs: []ids.ID{addDelegatorTx.ID()},
+		},
+		{
+			addr:          stakeAddr,
+			expectedTxIDs: []ids.ID{addDelegatorTx.ID(), rewardTx.ID(), spendTx.ID()},
+		},
+		{
+			addr:          rewardAddr,
+			expectedTxIDs: []ids.ID{addDelegatorTx.ID(), rewardTx.ID(), spendTx.ID()},
+		},
+		{
+			addr:          spendAddr,
+			expectedTxIDs: []ids.ID{spendTx.ID()},
+		},
+	}
+	for _, test := range tests {
+		txIDs, err := indexer.Read(test.addr, 0, 10)
+		require.NoError(err)
+		require.Equal(test.expectedTxIDs, txIDs)
+	}
+}
+
+func TestNoIndexer(t *testing.T) {
+	require := require.New(t)
+
+	indexer := NewNoIndexer()
+	require.NoError(indexer.Accept(newTestChain(t)))
+	txIDs, err := indexer.Read(ids.GenerateTestShortID(), 0, 10)
+	require.NoError(err)
+	require.Empty(txIDs)
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"github.com/dioneprotocol/dionego/api"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/pubsub"
+	"github.com/dioneprotocol/dionego/utils/set"
+	"github.com/dioneprotocol/dionego/vms/platformvm/txs"
+)
+
+var _ pubsub.Filterer = (*connector)(nil)
+
+type connector struct {
+	txID  ids.ID
+	addrs set.Set[ids.ShortID]
+}
+
+// NewPubSubFilterer returns a filterer that matches the addresses involved in
+// [tx], which must have been accepted on [chain].
+// See AddressTxsIndexer for the definition of involvement.
+func NewPubSubFilterer(chain Chain, tx *txs.Tx) (pubsub.Filterer, error) {
+	addrs, err := txAddresses(chain, tx)
+	if err != nil {
+		return nil, err
+	}
+	return &connector{
+		txID:  tx.ID(),
+		addrs: addrs,
+	}, nil
+}
+
+// Apply the filter on the addresses.
+func (f *connector) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
+	resp := make([]bool, len(filters))
+	for address := range f.addrs {
+		for i, c := range filters {
+			if resp[i] {
+				continue
+			}
+			resp[i] = c.Check(address[:])
+		}
+	}
+	return resp, api.JSONTxID{
+		TxID: f.txID,
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/api"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/pubsub"
+	"github.com/dioneprotocol/dionego/vms/components/dione"
+	"github.com/dioneprotocol/dionego/vms/platformvm/status"
+	"github.com/dioneprotocol/dionego/vms/platformvm/txs"
+)
+
+type mockFilter struct {
+	addr []byte
+}
+
+func (f *mockFilter) Check(addr []byte) bool {
+	return bytes.Equal(addr, f.addr)
+}
+
+func TestFilter(t *testing.T) {
+	require := require.New(t)
+
+	spentAddr := ids.GenerateTestShortID()
+	outAddr := ids.GenerateTestShortID()
+	stakeAddr := ids.GenerateTestShortID()
+	otherAddr := ids.GenerateTestShortID()
+	chain := newTestChain(t)
+
+	tx1 := newTx(t, &txs.CreateSubnetTx{
+		BaseTx: newBaseTx(
+			nil,
+			[]*dione.TransferableOutput{newOutput(spentAddr)},
+		),
+		Owner: newOwner(spentAddr),
+	})
+	chain.accept(t, status.Committed, tx1)
+
+	tx2 := newTx(t, &txs.AddDelegatorTx{
+		BaseTx: newBaseTx(
+			[]*dione.TransferableInput{newInput(tx1.ID(), 0)},
+			[]*dione.TransferableOutput{newOutput(outAddr)},
+		),
+		StakeOuts:              []*dione.TransferableOutput{newOutput(stakeAddr)},
+		DelegationRewardsOwner: newOwner(stakeAddr),
+	})
+	chain.accept(t, status.Committed, tx2)
+
+	fp := pubsub.NewFilterParam()
+	require.NoError(fp.Add(spentAddr[:]))
+
+	filterer, err := NewPubSubFilterer(chain, tx2)
+	require.NoError(err)
+	fr, msg := filterer.Filter([]pubsub.Filter{
+		&mockFilter{addr: spentAddr[:]},
+		&mockFilter{addr: outAddr[:]},
+		&mockFilter{addr: stakeAddr[:]},
+		&mockFilter{addr: otherAddr[:]},
+		fp,
+	})
+	require.Equal([]bool{true, true, true, false, true}, fr)
+	require.Equal(api.JSONTxID{TxID: tx2.ID()}, msg)
+}
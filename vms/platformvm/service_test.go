@@ -13,6 +13,8 @@ import (
 
 	stdjson "encoding/json"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/stretchr/testify/require"
 
 	"github.com/dioneprotocol/dionego/api"
@@ -20,6 +22,7 @@ import (
 	"github.com/dioneprotocol/dionego/cache"
 	"github.com/dioneprotocol/dionego/chains/atomic"
 	"github.com/dioneprotocol/dionego/database/manager"
+	"github.com/dioneprotocol/dionego/database/memdb"
 	"github.com/dioneprotocol/dionego/database/prefixdb"
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/snow/consensus/snowman"
@@ -31,6 +34,8 @@ import (
 	"github.com/dioneprotocol/dionego/version"
 	"github.com/dioneprotocol/dionego/vms/components/dione"
 	"github.com/dioneprotocol/dionego/vms/platformvm/blocks"
+	"github.com/dioneprotocol/dionego/vms/platformvm/index"
+	"github.com/dioneprotocol/dionego/vms/platformvm/reward"
 	"github.com/dioneprotocol/dionego/vms/platformvm/state"
 	"github.com/dioneprotocol/dionego/vms/platformvm/status"
 	"github.com/dioneprotocol/dionego/vms/platformvm/txs"
@@ -686,6 +691,60 @@ func TestGetCurrentValidators(t *testing.T) {
 	require.True(found)
 }
 
+func TestGetAddressTxs(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	startTime := service.vm.clock.Time().Add(txexecutor.SyncBound).Add(1 * time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	rewardAddr := ids.GenerateTestShortID()
+	addValidatorTx, err := service.vm.txBuilder.NewAddValidatorTx(
+		service.vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		ids.GenerateTestNodeID(),
+		rewardAddr,
+		reward.PercentDenominator,
+		[]*secp256k1.PrivateKey{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	require.NoError(err)
+	require.NoError(service.vm.Builder.AddUnverifiedTx(addValidatorTx))
+	blk, err := service.vm.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+
+	// Backfill an index of the chain that was accepted without one.
+	indexer, err := index.NewIndexer(memdb.New(), logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(indexer.Accept(service.vm.state))
+	service.vm.addressTxsIndexer = indexer
+
+	rewardAddrStr, err := service.addrManager.FormatLocalAddress(rewardAddr)
+	require.NoError(err)
+	args := GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: rewardAddrStr},
+	}
+	reply := GetAddressTxsReply{}
+	require.NoError(service.GetAddressTxs(nil, &args, &reply))
+	require.Equal([]ids.ID{addValidatorTx.ID()}, reply.TxIDs)
+	require.Equal(json.Uint64(1), reply.Cursor)
+
+	args.Cursor = reply.Cursor
+	require.NoError(service.GetAddressTxs(nil, &args, &reply))
+	require.Empty(reply.TxIDs)
+	require.Equal(json.Uint64(1), reply.Cursor)
+
+	args.PageSize = maxGetAddressTxsPageSize + 1
+	require.Error(service.GetAddressTxs(nil, &args, &reply))
+}
+
 func TestGetTimestamp(t *testing.T) {
 	require := require.New(t)
 	service, _ := defaultService(t)
@@ -5,12 +5,11 @@ package platformvm
 
 import (
 	"context"
+	stdjson "encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/prometheus/client_golang/prometheus"
 
 	"go.uber.org/zap"
@@ -20,7 +19,9 @@ import (
 	"github.com/dioneprotocol/dionego/codec/linearcodec"
 	"github.com/dioneprotocol/dionego/database"
 	"github.com/dioneprotocol/dionego/database/manager"
+	"github.com/dioneprotocol/dionego/database/prefixdb"
 	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/pubsub"
 	"github.com/dioneprotocol/dionego/snow"
 	"github.com/dioneprotocol/dionego/snow/consensus/snowman"
 	"github.com/dioneprotocol/dionego/snow/engine/common"
@@ -40,6 +41,7 @@ import (
 	"github.com/dioneprotocol/dionego/vms/platformvm/api"
 	"github.com/dioneprotocol/dionego/vms/platformvm/blocks"
 	"github.com/dioneprotocol/dionego/vms/platformvm/fx"
+	"github.com/dioneprotocol/dionego/vms/platformvm/index"
 	"github.com/dioneprotocol/dionego/vms/platformvm/metrics"
 	"github.com/dioneprotocol/dionego/vms/platformvm/reward"
 	"github.com/dioneprotocol/dionego/vms/platformvm/state"
@@ -66,10 +68,22 @@ var (
 	_ validators.State           = (*VM)(nil)
 	_ validators.SubnetConnector = (*VM)(nil)
 
+	addressTxsIndexPrefix = []byte("addressTxsIndex")
+
 	errMissingValidatorSet = errors.New("missing validator set")
 	errMissingValidator    = errors.New("missing validator")
 )
 
+// ChainConfig is the configuration of the P-Chain that is given to the VM
+// when it's initialized, as opposed to the configuration given when the VM is
+// created.
+type ChainConfig struct {
+	// If true, the transactions that involved each address are indexed.
+	// Enabling this on a node that was run without it indexes all of the
+	// accepted history before the VM finishes initializing.
+	IndexTransactions bool `json:"index-transactions"`
+}
+
 type VM struct {
 	Factory
 	blockbuilder.Builder
@@ -105,6 +119,12 @@ type VM struct {
 	txBuilder         txbuilder.Builder
 	txExecutorBackend *txexecutor.Backend
 	manager           blockexecutor.Manager
+
+	addressTxsIndexer index.AddressTxsIndexer
+
+	// Notifies subscribers of accepted transactions that involve their
+	// addresses
+	pubsub *pubsub.Server
 }
 
 // Initialize this blockchain.
@@ -115,13 +135,23 @@ func (vm *VM) Initialize(
 	dbManager manager.Manager,
 	genesisBytes []byte,
 	_ []byte,
-	_ []byte,
+	configBytes []byte,
 	toEngine chan<- common.Message,
 	_ []*common.Fx,
 	appSender common.AppSender,
 ) error {
 	chainCtx.Log.Verbo("initializing platform chain")
 
+	chainConfig := ChainConfig{}
+	if len(configBytes) > 0 {
+		if err := stdjson.Unmarshal(configBytes, &chainConfig); err != nil {
+			return fmt.Errorf("failed to parse chain config: %w", err)
+		}
+		chainCtx.Log.Info("VM config initialized",
+			zap.Reflect("config", chainConfig),
+		)
+	}
+
 	registerer := prometheus.NewRegistry()
 	if err := chainCtx.Metrics.Register(registerer); err != nil {
 		return err
@@ -166,6 +196,29 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	// use no op impl when disabled in config
+	if chainConfig.IndexTransactions {
+		chainCtx.Log.Info("address transaction indexing is enabled")
+		vm.addressTxsIndexer, err = index.NewIndexer(
+			prefixdb.New(addressTxsIndexPrefix, vm.dbManager.Current().Database),
+			chainCtx.Log,
+			"",
+			registerer,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to initialize address transaction indexer: %w", err)
+		}
+	} else {
+		chainCtx.Log.Info("address transaction indexing is disabled")
+		vm.addressTxsIndexer = index.NewNoIndexer()
+	}
+	// Index the blocks that were accepted while indexing was disabled.
+	if err := vm.addressTxsIndexer.Accept(vm.state); err != nil {
+		return fmt.Errorf("failed to index address transactions: %w", err)
+	}
+
+	vm.pubsub = pubsub.New(chainCtx.Log)
+
 	vm.atomicUtxosManager = dione.NewAtomicUTXOManager(chainCtx.SharedMemory, txs.Codec)
 	utxoHandler := utxo.NewHandler(vm.ctx, &vm.clock, vm.fx)
 	vm.uptimeManager = uptime.NewManager(vm.state)
@@ -205,6 +258,8 @@ func (vm *VM) Initialize(
 		vm.state,
 		vm.txExecutorBackend,
 		vm.recentlyAccepted,
+		vm.addressTxsIndexer,
+		vm.pubsub,
 	)
 	vm.Builder = blockbuilder.New(
 		mempool,
@@ -415,7 +470,7 @@ func (*VM) Version(context.Context) (string, error) {
 // * keys are API endpoint extensions
 // * values are API handlers
 func (vm *VM) CreateHandlers(context.Context) (map[string]*common.HTTPHandler, error) {
-	server := rpc.NewServer()
+	server := json.NewServer()
 	server.RegisterCodec(json.NewCodec(), "application/json")
 	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
 	server.RegisterInterceptFunc(vm.metrics.InterceptRequest)
@@ -437,6 +492,10 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]*common.HTTPHandler, e
 		"": {
 			Handler: server,
 		},
+		"/events": {
+			LockOptions: common.NoLock,
+			Handler:     vm.pubsub,
+		},
 	}, nil
 }
 
@@ -444,7 +503,7 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]*common.HTTPHandler, e
 // * keys are API endpoint extensions
 // * values are API handlers
 func (*VM) CreateStaticHandlers(context.Context) (map[string]*common.HTTPHandler, error) {
-	server := rpc.NewServer()
+	server := json.NewServer()
 	server.RegisterCodec(json.NewCodec(), "application/json")
 	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
 	if err := server.RegisterService(&api.StaticService{}, "platform"); err != nil {
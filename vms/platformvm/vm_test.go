@@ -40,6 +40,7 @@ import (
 	"github.com/dioneprotocol/dionego/snow/uptime"
 	"github.com/dioneprotocol/dionego/snow/validators"
 	"github.com/dioneprotocol/dionego/subnets"
+	"github.com/dioneprotocol/dionego/utils/compression"
 	"github.com/dioneprotocol/dionego/utils/constants"
 	"github.com/dioneprotocol/dionego/utils/crypto/bls"
 	"github.com/dioneprotocol/dionego/utils/crypto/secp256k1"
@@ -1731,7 +1732,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 	chainRouter := &router.ChainRouter{}
 
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
+	mc, err := message.NewCreator(metrics, "dummyNamespace", compression.TypeGzip, 10*time.Second)
 	require.NoError(err)
 
 	err = chainRouter.Initialize(
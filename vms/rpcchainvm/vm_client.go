@@ -384,8 +384,9 @@ func (vm *VMClient) CreateHandlers(ctx context.Context) (map[string]*common.HTTP
 
 		vm.conns = append(vm.conns, clientConn)
 		handlers[handler.Prefix] = &common.HTTPHandler{
-			LockOptions: common.LockOption(handler.LockOptions),
-			Handler:     ghttp.NewClient(httppb.NewHTTPClient(clientConn)),
+			LockOptions:   common.LockOption(handler.LockOptions),
+			Handler:       ghttp.NewClient(httppb.NewHTTPClient(clientConn)),
+			BatchRequests: handler.BatchRequests,
 		}
 	}
 	return handlers, nil
@@ -406,8 +407,9 @@ func (vm *VMClient) CreateStaticHandlers(ctx context.Context) (map[string]*commo
 
 		vm.conns = append(vm.conns, clientConn)
 		handlers[handler.Prefix] = &common.HTTPHandler{
-			LockOptions: common.LockOption(handler.LockOptions),
-			Handler:     ghttp.NewClient(httppb.NewHTTPClient(clientConn)),
+			LockOptions:   common.LockOption(handler.LockOptions),
+			Handler:       ghttp.NewClient(httppb.NewHTTPClient(clientConn)),
+			BatchRequests: handler.BatchRequests,
 		}
 	}
 	return handlers, nil
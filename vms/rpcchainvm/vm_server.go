@@ -343,9 +343,10 @@ func (vm *VMServer) CreateHandlers(ctx context.Context, _ *emptypb.Empty) (*vmpb
 		go grpcutils.Serve(serverListener, server)
 
 		resp.Handlers = append(resp.Handlers, &vmpb.Handler{
-			Prefix:      prefix,
-			LockOptions: uint32(handler.LockOptions),
-			ServerAddr:  serverListener.Addr().String(),
+			Prefix:        prefix,
+			LockOptions:   uint32(handler.LockOptions),
+			ServerAddr:    serverListener.Addr().String(),
+			BatchRequests: handler.BatchRequests,
 		})
 	}
 	return resp, nil
@@ -372,9 +373,10 @@ func (vm *VMServer) CreateStaticHandlers(ctx context.Context, _ *emptypb.Empty)
 		go grpcutils.Serve(serverListener, server)
 
 		resp.Handlers = append(resp.Handlers, &vmpb.Handler{
-			Prefix:      prefix,
-			LockOptions: uint32(handler.LockOptions),
-			ServerAddr:  serverListener.Addr().String(),
+			Prefix:        prefix,
+			LockOptions:   uint32(handler.LockOptions),
+			ServerAddr:    serverListener.Addr().String(),
+			BatchRequests: handler.BatchRequests,
 		})
 	}
 	return resp, nil
@@ -12,6 +12,9 @@ import (
 	"math"
 	"sync"
 
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/utils/hashing"
 )
@@ -33,10 +36,15 @@ const (
 	minProofLen          = minProofPathLen + minByteSliceLen
 	minChangeProofLen    = boolLen + 2*minProofPathLen + 2*minVarIntLen
 	minRangeProofLen     = 2*minProofPathLen + minVarIntLen
+	minMultiProofLen     = minProofPathLen + minVarIntLen
 	minDBNodeLen         = minMaybeByteSliceLen + minVarIntLen
 	minHashValuesLen     = minVarIntLen + minMaybeByteSliceLen + minSerializedPathLen
 	minProofNodeChildLen = minVarIntLen + idLen
 	minChildLen          = minVarIntLen + minSerializedPathLen + idLen
+	minMaybeNodeLen      = boolLen
+	minNodeChangeLen     = minSerializedPathLen + 2*minMaybeNodeLen
+	minValueChangeLen    = minSerializedPathLen + 2*minMaybeByteSliceLen
+	minChangeSummaryLen  = idLen + 2*minVarIntLen
 )
 
 var (
@@ -54,6 +62,8 @@ var (
 	errChildIndexTooLarge     = fmt.Errorf("invalid child index. Must be less than branching factor of %d", NodeBranchFactor)
 	errNegativeNibbleLength   = errors.New("nibble length is negative")
 	errNegativeNumKeyValues   = errors.New("negative number of key values")
+	errNegativeNumChanges     = errors.New("negative number of changes")
+	errNegativeNumKeys        = errors.New("negative number of keys")
 	errIntTooLarge            = errors.New("integer too large to be decoded")
 	errLeadingZeroes          = errors.New("varint has leading zeroes")
 	errInvalidBool            = errors.New("decoded bool is neither true nor false")
@@ -74,17 +84,21 @@ type Encoder interface {
 	EncodeProof(version uint16, p *Proof) ([]byte, error)
 	EncodeChangeProof(version uint16, p *ChangeProof) ([]byte, error)
 	EncodeRangeProof(version uint16, p *RangeProof) ([]byte, error)
+	EncodeMultiProof(version uint16, p *MultiProof) ([]byte, error)
 
 	encodeDBNode(version uint16, n *dbNode) ([]byte, error)
 	encodeHashValues(version uint16, hv *hashValues) ([]byte, error)
+	encodeChangeSummary(version uint16, cs *changeSummary) ([]byte, error)
 }
 
 type Decoder interface {
 	DecodeProof(bytes []byte, p *Proof) (uint16, error)
 	DecodeChangeProof(bytes []byte, p *ChangeProof) (uint16, error)
 	DecodeRangeProof(bytes []byte, p *RangeProof) (uint16, error)
+	DecodeMultiProof(bytes []byte, p *MultiProof) (uint16, error)
 
 	decodeDBNode(bytes []byte, n *dbNode) (uint16, error)
+	decodeChangeSummary(bytes []byte, cs *changeSummary) (uint16, error)
 }
 
 func newCodec() (EncoderDecoder, uint16) {
@@ -188,6 +202,30 @@ func (c *codecImpl) EncodeRangeProof(version uint16, proof *RangeProof) ([]byte,
 	return buf.Bytes(), nil
 }
 
+func (c *codecImpl) EncodeMultiProof(version uint16, proof *MultiProof) ([]byte, error) {
+	if proof == nil {
+		return nil, errEncodeNil
+	}
+
+	if version != codecVersion {
+		return nil, errUnknownVersion
+	}
+
+	buf := &bytes.Buffer{}
+	if err := c.encodeProofPath(buf, proof.Nodes); err != nil {
+		return nil, err
+	}
+	if err := c.encodeInt(buf, len(proof.Keys)); err != nil {
+		return nil, err
+	}
+	for _, key := range proof.Keys {
+		if err := c.encodeByteSlice(buf, key); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
 func (c *codecImpl) encodeDBNode(version uint16, n *dbNode) ([]byte, error) {
 	if n == nil {
 		return nil, errEncodeNil
@@ -258,6 +296,63 @@ func (c *codecImpl) encodeHashValues(version uint16, hv *hashValues) ([]byte, er
 	return buf.Bytes(), nil
 }
 
+func (c *codecImpl) encodeChangeSummary(version uint16, cs *changeSummary) ([]byte, error) {
+	if cs == nil {
+		return nil, errEncodeNil
+	}
+
+	if version != codecVersion {
+		return nil, errUnknownVersion
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err := buf.Write(cs.rootID[:]); err != nil {
+		return nil, err
+	}
+
+	// ensure that the order of entries is consistent
+	nodeKeys := maps.Keys(cs.nodes)
+	slices.SortFunc(nodeKeys, func(i, j path) bool {
+		return i.Compare(j) < 0
+	})
+	if err := c.encodeInt(buf, len(nodeKeys)); err != nil {
+		return nil, err
+	}
+	for _, key := range nodeKeys {
+		nodeChange := cs.nodes[key]
+		if err := c.encodeSerializedPath(key.Serialize(), buf); err != nil {
+			return nil, err
+		}
+		if err := c.encodeMaybeNode(buf, nodeChange.before); err != nil {
+			return nil, err
+		}
+		if err := c.encodeMaybeNode(buf, nodeChange.after); err != nil {
+			return nil, err
+		}
+	}
+
+	valueKeys := maps.Keys(cs.values)
+	slices.SortFunc(valueKeys, func(i, j path) bool {
+		return i.Compare(j) < 0
+	})
+	if err := c.encodeInt(buf, len(valueKeys)); err != nil {
+		return nil, err
+	}
+	for _, key := range valueKeys {
+		valueChange := cs.values[key]
+		if err := c.encodeSerializedPath(key.Serialize(), buf); err != nil {
+			return nil, err
+		}
+		if err := c.encodeMaybeByteSlice(buf, valueChange.before); err != nil {
+			return nil, err
+		}
+		if err := c.encodeMaybeByteSlice(buf, valueChange.after); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
 func (c *codecImpl) DecodeProof(b []byte, proof *Proof) (uint16, error) {
 	if proof == nil {
 		return 0, errDecodeNil
@@ -387,6 +482,45 @@ func (c *codecImpl) DecodeRangeProof(b []byte, proof *RangeProof) (uint16, error
 	return codecVersion, nil
 }
 
+func (c *codecImpl) DecodeMultiProof(b []byte, proof *MultiProof) (uint16, error) {
+	if proof == nil {
+		return 0, errDecodeNil
+	}
+	if minMultiProofLen > len(b) {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	var (
+		src = bytes.NewReader(b)
+		err error
+	)
+
+	if proof.Nodes, err = c.decodeProofPath(src); err != nil {
+		return 0, err
+	}
+
+	numKeys, err := c.decodeInt(src)
+	if err != nil {
+		return 0, err
+	}
+	if numKeys < 0 {
+		return 0, errNegativeNumKeys
+	}
+	if numKeys > src.Len()/minByteSliceLen {
+		return 0, io.ErrUnexpectedEOF
+	}
+	proof.Keys = make([][]byte, numKeys)
+	for i := range proof.Keys {
+		if proof.Keys[i], err = c.decodeByteSlice(src); err != nil {
+			return 0, err
+		}
+	}
+	if src.Len() != 0 {
+		return 0, errExtraSpace
+	}
+	return codecVersion, nil
+}
+
 func (c *codecImpl) decodeDBNode(b []byte, n *dbNode) (uint16, error) {
 	if n == nil {
 		return 0, errDecodeNil
@@ -448,6 +582,128 @@ func (c *codecImpl) decodeDBNode(b []byte, n *dbNode) (uint16, error) {
 	return codecVersion, err
 }
 
+func (c *codecImpl) decodeChangeSummary(b []byte, cs *changeSummary) (uint16, error) {
+	if cs == nil {
+		return 0, errDecodeNil
+	}
+	if minChangeSummaryLen > len(b) {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	var (
+		src = bytes.NewReader(b)
+		err error
+	)
+
+	if cs.rootID, err = c.decodeID(src); err != nil {
+		return 0, err
+	}
+
+	numNodes, err := c.decodeInt(src)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case numNodes < 0:
+		return 0, errNegativeNumChanges
+	case numNodes > src.Len()/minNodeChangeLen:
+		return 0, io.ErrUnexpectedEOF
+	}
+	cs.nodes = make(map[path]*change[*node], numNodes)
+	for i := 0; i < numNodes; i++ {
+		serializedKey, err := c.decodeSerializedPath(src)
+		if err != nil {
+			return 0, err
+		}
+		key := serializedKey.deserialize()
+		nodeChange := &change[*node]{}
+		if nodeChange.before, err = c.decodeMaybeNode(src, key); err != nil {
+			return 0, err
+		}
+		if nodeChange.after, err = c.decodeMaybeNode(src, key); err != nil {
+			return 0, err
+		}
+		cs.nodes[key] = nodeChange
+	}
+
+	numValues, err := c.decodeInt(src)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case numValues < 0:
+		return 0, errNegativeNumChanges
+	case numValues > src.Len()/minValueChangeLen:
+		return 0, io.ErrUnexpectedEOF
+	}
+	cs.values = make(map[path]*change[Maybe[[]byte]], numValues)
+	for i := 0; i < numValues; i++ {
+		serializedKey, err := c.decodeSerializedPath(src)
+		if err != nil {
+			return 0, err
+		}
+		valueChange := &change[Maybe[[]byte]]{}
+		if valueChange.before, err = c.decodeMaybeByteSlice(src); err != nil {
+			return 0, err
+		}
+		if valueChange.after, err = c.decodeMaybeByteSlice(src); err != nil {
+			return 0, err
+		}
+		cs.values[serializedKey.deserialize()] = valueChange
+	}
+	if src.Len() != 0 {
+		return 0, errExtraSpace
+	}
+	return codecVersion, nil
+}
+
+// Encodes a node that may be nil along with its ID.
+func (c *codecImpl) encodeMaybeNode(dst io.Writer, n *node) error {
+	if err := c.encodeBool(dst, n != nil); err != nil {
+		return err
+	}
+	if n == nil {
+		return nil
+	}
+	if _, err := dst.Write(n.id[:]); err != nil {
+		return err
+	}
+	nodeBytes, err := c.encodeDBNode(codecVersion, &n.dbNode)
+	if err != nil {
+		return err
+	}
+	return c.encodeByteSlice(dst, nodeBytes)
+}
+
+// Decodes a node that may be nil and sets its key to [key].
+func (c *codecImpl) decodeMaybeNode(src *bytes.Reader, key path) (*node, error) {
+	if minMaybeNodeLen > src.Len() {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if hasNode, err := c.decodeBool(src); err != nil || !hasNode {
+		return nil, err
+	}
+
+	id, err := c.decodeID(src)
+	if err != nil {
+		return nil, err
+	}
+	nodeBytes, err := c.decodeByteSlice(src)
+	if err != nil {
+		return nil, err
+	}
+	// The node bytes aren't cached in the node because they may not match
+	// the node encoding of the database.
+	n := &node{
+		id:  id,
+		key: key,
+	}
+	if _, err := c.decodeDBNode(nodeBytes, &n.dbNode); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
 func (c *codecImpl) decodeKeyValue(src *bytes.Reader) (KeyValue, error) {
 	if minKeyValueLen > src.Len() {
 		return KeyValue{}, io.ErrUnexpectedEOF
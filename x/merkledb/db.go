@@ -46,19 +46,38 @@ var (
 	rootKey                 = []byte{}
 	nodePrefix              = []byte("node")
 	metadataPrefix          = []byte("metadata")
+	historyPrefix           = []byte("history")
 	cleanShutdownKey        = []byte("cleanShutdown")
+	hasherKey               = []byte("hasher")
+	nodeEncodingKey         = []byte("nodeEncoding")
 	hadCleanShutdown        = []byte{1}
 	didNotHaveCleanShutdown = []byte{0}
 
+	ErrHasherMismatch       = errors.New("database was created with a different hasher")
+	ErrNodeEncodingMismatch = errors.New("database was created with a different node encoding")
+
 	errSameRoot = errors.New("start and end root are the same")
 )
 
 type Config struct {
 	// The number of changes to the database that we store in memory in order to
 	// serve change proofs.
-	HistoryLength  int
+	HistoryLength int
+	// The number of changes to the database that we persist to disk in order
+	// to serve change proofs and historical range proofs for roots that are no
+	// longer in memory, including roots from before a restart. If 0, history
+	// isn't persisted and any previously persisted history is deleted.
+	PersistedHistoryLength int
+
 	ValueCacheSize int
 	NodeCacheSize  int
+	// Hashes nodes to compute their IDs. Defaults to DefaultHasher if nil.
+	// A database can't be reopened with a different hasher.
+	Hasher Hasher
+	// Serializes nodes to be stored and hashed. Defaults to
+	// DefaultNodeEncoding if nil. A database can't be reopened with a
+	// different node encoding.
+	NodeEncoding NodeEncoding
 	// If [Reg] is nil, metrics are collected locally but not exported through
 	// Prometheus.
 	// This may be useful for testing.
@@ -78,6 +97,9 @@ type Database struct {
 	// Stores data about the database's current state.
 	metadataDB database.Database
 
+	// Stores the persisted change history.
+	historyDB database.Database
+
 	// If a value is nil, the corresponding key isn't in the trie.
 	nodeCache cache.LRU[path, *node]
 
@@ -94,6 +116,9 @@ type Database struct {
 
 	tracer trace.Tracer
 
+	hasher       Hasher
+	nodeEncoding NodeEncoding
+
 	// The root of this trie.
 	root *node
 }
@@ -104,13 +129,23 @@ func newDatabase(
 	config Config,
 	metrics merkleMetrics,
 ) (*Database, error) {
+	if config.Hasher == nil {
+		config.Hasher = DefaultHasher
+	}
+	if config.NodeEncoding == nil {
+		config.NodeEncoding = DefaultNodeEncoding
+	}
+
 	trieDB := &Database{
-		metrics:    metrics,
-		nodeDB:     versiondb.New(prefixdb.New(nodePrefix, db)),
-		metadataDB: prefixdb.New(metadataPrefix, db),
-		history:    newTrieHistory(config.HistoryLength),
-		tracer:     config.Tracer,
-		valueCache: cache.LRU[string, Maybe[[]byte]]{Size: config.ValueCacheSize},
+		metrics:      metrics,
+		nodeDB:       versiondb.New(prefixdb.New(nodePrefix, db)),
+		metadataDB:   prefixdb.New(metadataPrefix, db),
+		historyDB:    prefixdb.New(historyPrefix, db),
+		history:      newTrieHistory(config.HistoryLength),
+		tracer:       config.Tracer,
+		valueCache:   cache.LRU[string, Maybe[[]byte]]{Size: config.ValueCacheSize},
+		hasher:       config.Hasher,
+		nodeEncoding: config.NodeEncoding,
 	}
 
 	// Note: trieDB.OnEviction is responsible for writing intermediary nodes to
@@ -120,22 +155,31 @@ func newDatabase(
 		OnEviction: trieDB.OnEviction,
 	}
 
+	if err := trieDB.checkNodeFormat(); err != nil {
+		return nil, err
+	}
+
 	root, err := trieDB.initializeRootIfNeeded(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// add current root to history (has no changes)
-	trieDB.history.record(&changeSummary{
+	currentRootChanges := &changeSummary{
 		rootID: root,
 		values: map[path]*change[Maybe[[]byte]]{},
 		nodes:  map[path]*change[*node]{},
-	})
+	}
+	if err := trieDB.history.record(currentRootChanges); err != nil {
+		return nil, err
+	}
 
+	hadCleanShutdown := true
 	shutdownType, err := trieDB.metadataDB.Get(cleanShutdownKey)
 	switch err {
 	case nil:
 		if bytes.Equal(shutdownType, didNotHaveCleanShutdown) {
+			hadCleanShutdown = false
 			if err := trieDB.rebuild(ctx); err != nil {
 				return nil, err
 			}
@@ -147,11 +191,95 @@ func newDatabase(
 		return nil, err
 	}
 
+	if err := trieDB.initializeDiskHistory(config.PersistedHistoryLength, hadCleanShutdown); err != nil {
+		return nil, err
+	}
+
 	// mark that the db has not yet been cleanly closed
 	err = trieDB.metadataDB.Put(cleanShutdownKey, didNotHaveCleanShutdown)
 	return trieDB, err
 }
 
+// Returns an error if the database was created with a different hasher or
+// node encoding than [db]'s. Otherwise, persists them so they are checked the
+// next time the database is opened.
+func (db *Database) checkNodeFormat() error {
+	// Databases created before the hasher and node encoding were persisted
+	// used the defaults.
+	_, err := db.nodeDB.Get(rootKey)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		// The database is being created, so any hasher and node encoding may
+		// be used.
+		return db.putNodeFormat()
+	default:
+		return err
+	}
+
+	if err := checkNodeFormatName(db.metadataDB, hasherKey, DefaultHasher.Name(), db.hasher.Name(), ErrHasherMismatch); err != nil {
+		return err
+	}
+	if err := checkNodeFormatName(db.metadataDB, nodeEncodingKey, DefaultNodeEncoding.Name(), db.nodeEncoding.Name(), ErrNodeEncodingMismatch); err != nil {
+		return err
+	}
+	return db.putNodeFormat()
+}
+
+// Returns [errMismatch] if the name stored at [key], or [defaultName] if
+// there isn't one, isn't [name].
+func checkNodeFormatName(db database.KeyValueReader, key []byte, defaultName, name string, errMismatch error) error {
+	storedName, err := db.Get(key)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		storedName = []byte(defaultName)
+	default:
+		return err
+	}
+	if string(storedName) != name {
+		return fmt.Errorf("%w: created with %q but opened with %q", errMismatch, storedName, name)
+	}
+	return nil
+}
+
+func (db *Database) putNodeFormat() error {
+	if err := db.metadataDB.Put(hasherKey, []byte(db.hasher.Name())); err != nil {
+		return err
+	}
+	return db.metadataDB.Put(nodeEncodingKey, []byte(db.nodeEncoding.Name()))
+}
+
+// Loads the persisted history, which is only trusted if the database was
+// cleanly shutdown. Otherwise, the history may not match the trie and it is
+// deleted.
+func (db *Database) initializeDiskHistory(persistedHistoryLength int, hadCleanShutdown bool) error {
+	if persistedHistoryLength <= 0 {
+		return clearHistory(db.historyDB)
+	}
+
+	disk, err := newDiskHistory(db.historyDB, persistedHistoryLength)
+	if err != nil {
+		return err
+	}
+	if !hadCleanShutdown {
+		if err := disk.clear(); err != nil {
+			return err
+		}
+	}
+
+	// Make sure the current root is in the persisted history.
+	if err := disk.record(&changeSummary{
+		rootID: db.root.id,
+		values: map[path]*change[Maybe[[]byte]]{},
+		nodes:  map[path]*change[*node]{},
+	}); err != nil {
+		return err
+	}
+	db.history.disk = disk
+	return nil
+}
+
 // Only grabs a read lock because views built atop this database can't mutate it.
 // The database is only mutated by a view when the view is committed, and
 // trieView grabs a write lock when committing.
@@ -197,7 +325,7 @@ func (db *Database) rebuild(ctx context.Context) error {
 		key := it.Key()
 		path := path(key)
 		value := it.Value()
-		n, err := parseNode(path, value)
+		n, err := parseNode(db.nodeEncoding, path, value)
 		if err != nil {
 			return err
 		}
@@ -263,6 +391,7 @@ func (db *Database) Close() error {
 	db.lock.Lock()
 	defer func() {
 		_ = db.metadataDB.Close() // TODO add logger and log error
+		_ = db.historyDB.Close()  // TODO add logger and log error
 		_ = db.nodeDB.Close()     // TODO add logger and log error
 		db.lock.Unlock()
 	}()
@@ -381,6 +510,20 @@ func (db *Database) getProof(ctx context.Context, key []byte) (*Proof, error) {
 	return view.getProof(ctx, key)
 }
 
+// Returns a proof of the existence/non-existence of each of [keys] in this
+// trie.
+func (db *Database) GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	view, err := db.newView(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Don't need to lock [view] because nobody else has a reference to it.
+	return view.getMultiProof(ctx, keys)
+}
+
 // Returns a proof for the key/value pairs in this trie within the range
 // [start, end].
 func (db *Database) GetRangeProof(
@@ -640,7 +783,7 @@ func (db *Database) OnEviction(node *node) {
 		// only persist intermediary nodes
 		return
 	}
-	nodeBytes, err := node.marshal()
+	nodeBytes, err := node.marshal(db.nodeEncoding)
 	if err != nil {
 		// TODO: Handle this error correctly
 		panic(err)
@@ -727,7 +870,7 @@ func (db *Database) commitChanges(ctx context.Context, changes *changeSummary) e
 			// Otherwise, intermediary nodes are persisted on cache eviction or
 			// shutdown.
 			db.metrics.IOKeyWrite()
-			nodeBytes, err := nodeChange.after.marshal()
+			nodeBytes, err := nodeChange.after.marshal(db.nodeEncoding)
 			if err != nil {
 				db.nodeDB.Abort()
 				nodesSpan.End()
@@ -766,8 +909,7 @@ func (db *Database) commitChanges(ctx context.Context, changes *changeSummary) e
 	}
 	valuesSpan.End()
 
-	db.history.record(changes)
-	return nil
+	return db.history.record(changes)
 }
 
 func (db *Database) initializeRootIfNeeded(_ context.Context) (ids.ID, error) {
@@ -775,11 +917,11 @@ func (db *Database) initializeRootIfNeeded(_ context.Context) (ids.ID, error) {
 	nodeBytes, err := db.nodeDB.Get(rootKey)
 	if err == nil {
 		// Root already exists, so parse it and set the in-mem copy
-		db.root, err = parseNode(RootPath, nodeBytes)
+		db.root, err = parseNode(db.nodeEncoding, RootPath, nodeBytes)
 		if err != nil {
 			return ids.Empty, err
 		}
-		if err := db.root.calculateID(db.metrics); err != nil {
+		if err := db.root.calculateID(db.nodeEncoding, db.hasher, db.metrics); err != nil {
 			return ids.Empty, err
 		}
 		return db.root.id, nil
@@ -792,12 +934,12 @@ func (db *Database) initializeRootIfNeeded(_ context.Context) (ids.ID, error) {
 	db.root = newNode(nil, RootPath)
 
 	// update its ID
-	if err := db.root.calculateID(db.metrics); err != nil {
+	if err := db.root.calculateID(db.nodeEncoding, db.hasher, db.metrics); err != nil {
 		return ids.Empty, err
 	}
 
 	// write the newly constructed root to the DB
-	rootBytes, err := db.root.marshal()
+	rootBytes, err := db.root.marshal(db.nodeEncoding)
 	if err != nil {
 		return ids.Empty, err
 	}
@@ -878,7 +1020,7 @@ func (db *Database) getNode(_ context.Context, key path) (*node, error) {
 		return nil, err
 	}
 
-	node, err := parseNode(key, rawBytes)
+	node, err := parseNode(db.nodeEncoding, key, rawBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -669,6 +669,8 @@ func runRandDBTest(require *require.Assertions, db *Database, r *rand.Rand, rt r
 				step.key,
 				step.value,
 				root,
+				DefaultHasher,
+				DefaultNodeEncoding,
 			)
 			require.NoError(err)
 		case opWriteBatch:
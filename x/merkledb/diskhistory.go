@@ -0,0 +1,337 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/utils/wrappers"
+)
+
+const (
+	// Prefixes of the keys in the persisted history.
+	historyChangePrefix    byte = 0x00
+	historyRootPrefix      byte = 0x01
+	historyNextIndexPrefix byte = 0x02
+
+	historyIndexLen = wrappers.LongLen
+
+	// Number of bytes of deletions to batch together when clearing the
+	// history.
+	clearHistoryBatchSize = 1024 * 1024
+)
+
+var (
+	historyNextIndexKey = []byte{historyNextIndexPrefix}
+
+	errInvalidHistoryKey = errors.New("invalid persisted history key")
+)
+
+// Persists the change history of the trie to a database so that older roots
+// can be served and the history survives restarts.
+//
+// The database contains:
+//   - historyChangePrefix ++ index --> serialized change summary
+//   - historyRootPrefix ++ root ID ++ index --> empty
+//   - historyNextIndexPrefix --> index of the next change
+//
+// Indices are contiguous, so the history contains exactly the changes with
+// indices in [oldestIndex, nextIndex).
+type diskHistory struct {
+	db database.Database
+
+	// Maximum number of changes to persist.
+	maxHistoryLen int
+
+	oldestIndex uint64
+	nextIndex   uint64
+}
+
+func newDiskHistory(db database.Database, maxHistoryLen int) (*diskHistory, error) {
+	h := &diskHistory{
+		db:            db,
+		maxHistoryLen: maxHistoryLen,
+	}
+
+	nextIndexBytes, err := db.Get(historyNextIndexKey)
+	switch err {
+	case nil:
+		if len(nextIndexBytes) != historyIndexLen {
+			return nil, errInvalidHistoryKey
+		}
+		h.nextIndex = binary.BigEndian.Uint64(nextIndexBytes)
+	case database.ErrNotFound:
+		// The history is empty.
+		return h, nil
+	default:
+		return nil, err
+	}
+
+	it := db.NewIteratorWithPrefix([]byte{historyChangePrefix})
+	defer it.Release()
+
+	if it.Next() {
+		h.oldestIndex, err = parseHistoryChangeKey(it.Key())
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		h.oldestIndex = h.nextIndex
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	// [maxHistoryLen] may have been reduced since the history was written.
+	batch := db.NewBatch()
+	oldestIndex, err := h.prune(batch, h.nextIndex)
+	if err != nil {
+		return nil, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	h.oldestIndex = oldestIndex
+	return h, nil
+}
+
+// Persists [changes] and prunes the oldest changes so that at most
+// [maxHistoryLen] changes are persisted.
+// Changes that don't modify any nodes are only persisted if the history is
+// empty, so that the initial root of the trie is known.
+func (h *diskHistory) record(changes *changeSummary) error {
+	if len(changes.nodes) == 0 && h.oldestIndex != h.nextIndex {
+		return nil
+	}
+
+	changesBytes, err := Codec.encodeChangeSummary(Version, changes)
+	if err != nil {
+		return err
+	}
+
+	var (
+		batch     = h.db.NewBatch()
+		index     = h.nextIndex
+		nextIndex = index + 1
+	)
+	if err := batch.Put(historyChangeKey(index), changesBytes); err != nil {
+		return err
+	}
+	if err := batch.Put(historyRootKey(changes.rootID, index), nil); err != nil {
+		return err
+	}
+	if err := batch.Put(historyNextIndexKey, historyIndexBytes(nextIndex)); err != nil {
+		return err
+	}
+	oldestIndex, err := h.prune(batch, nextIndex)
+	if err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	h.oldestIndex = oldestIndex
+	h.nextIndex = nextIndex
+	return nil
+}
+
+// Adds to [batch] the deletion of the oldest changes so that at most
+// [maxHistoryLen] changes remain once the history ends at [nextIndex].
+// Returns the index of the oldest change that remains.
+func (h *diskHistory) prune(batch database.Batch, nextIndex uint64) (uint64, error) {
+	oldestIndex := h.oldestIndex
+	for nextIndex-oldestIndex > uint64(h.maxHistoryLen) {
+		changeKey := historyChangeKey(oldestIndex)
+		changesBytes, err := h.db.Get(changeKey)
+		if err != nil {
+			return 0, err
+		}
+		if len(changesBytes) < idLen {
+			return 0, errInvalidHistoryKey
+		}
+		rootID, err := ids.ToID(changesBytes[:idLen])
+		if err != nil {
+			return 0, err
+		}
+
+		if err := batch.Delete(changeKey); err != nil {
+			return 0, err
+		}
+		if err := batch.Delete(historyRootKey(rootID, oldestIndex)); err != nil {
+			return 0, err
+		}
+		oldestIndex++
+	}
+	return oldestIndex, nil
+}
+
+// Returns up to [maxLength] key-value pair changes with keys in [start, end]
+// that occurred between [startRoot] and [endRoot].
+func (h *diskHistory) getValueChanges(startRoot, endRoot ids.ID, start, end []byte, maxLength int) (*changeSummary, error) {
+	// [endIndex] is the last change resulting in [endRoot].
+	endIndex, ok, err := h.lastIndexOf(endRoot, h.nextIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrRootIDNotPresent
+	}
+
+	// [startIndex] is the last change resulting in [startRoot] before
+	// [endIndex].
+	startIndex, ok, err := h.lastIndexOf(startRoot, endIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrStartRootNotFound
+	}
+
+	combiner := newValueChangesCombiner(start, end, maxLength)
+	err = h.ascend(startIndex+1, endIndex+1, func(changes *changeSummary) {
+		combiner.add(changes)
+	})
+	return combiner.combinedChanges, err
+}
+
+// Returns the changes to go from the current trie state back to the requested
+// [rootID] for the keys in [start, end].
+func (h *diskHistory) getChangesToGetToRoot(rootID ids.ID, start, end []byte) (*changeSummary, error) {
+	index, ok, err := h.lastIndexOf(rootID, h.nextIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrRootIDNotPresent
+	}
+
+	combiner := newRevertChangesCombiner(start, end)
+	err = h.ascend(index+1, h.nextIndex, func(changes *changeSummary) {
+		combiner.add(changes)
+	})
+	return combiner.combinedChanges, err
+}
+
+// Returns the index of the last change resulting in [rootID] with an index
+// less than [before]. Returns false if there is no such change.
+func (h *diskHistory) lastIndexOf(rootID ids.ID, before uint64) (uint64, bool, error) {
+	prefix := make([]byte, 1+idLen)
+	prefix[0] = historyRootPrefix
+	copy(prefix[1:], rootID[:])
+
+	it := h.db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	var (
+		lastIndex uint64
+		found     bool
+	)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+historyIndexLen {
+			return 0, false, errInvalidHistoryKey
+		}
+		index := binary.BigEndian.Uint64(key[len(prefix):])
+		if index >= before {
+			break
+		}
+		lastIndex = index
+		found = true
+	}
+	return lastIndex, found, it.Error()
+}
+
+// Calls [f] on each change with an index in [start, end) in increasing order
+// of index.
+func (h *diskHistory) ascend(start, end uint64, f func(*changeSummary)) error {
+	if start >= end {
+		return nil
+	}
+
+	it := h.db.NewIteratorWithStartAndPrefix(historyChangeKey(start), []byte{historyChangePrefix})
+	defer it.Release()
+
+	for it.Next() {
+		index, err := parseHistoryChangeKey(it.Key())
+		if err != nil {
+			return err
+		}
+		if index >= end {
+			break
+		}
+
+		changes := &changeSummary{}
+		if _, err := Codec.decodeChangeSummary(it.Value(), changes); err != nil {
+			return err
+		}
+		f(changes)
+	}
+	return it.Error()
+}
+
+// Deletes the persisted history.
+func (h *diskHistory) clear() error {
+	if err := clearHistory(h.db); err != nil {
+		return err
+	}
+	h.oldestIndex = 0
+	h.nextIndex = 0
+	return nil
+}
+
+// Deletes every key in [db].
+func clearHistory(db database.Database) error {
+	it := db.NewIterator()
+	defer it.Release()
+
+	batch := db.NewBatch()
+	for it.Next() {
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+		if batch.Size() < clearHistoryBatchSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func historyIndexBytes(index uint64) []byte {
+	indexBytes := make([]byte, historyIndexLen)
+	binary.BigEndian.PutUint64(indexBytes, index)
+	return indexBytes
+}
+
+func historyChangeKey(index uint64) []byte {
+	key := make([]byte, 1+historyIndexLen)
+	key[0] = historyChangePrefix
+	binary.BigEndian.PutUint64(key[1:], index)
+	return key
+}
+
+func parseHistoryChangeKey(key []byte) (uint64, error) {
+	if len(key) != 1+historyIndexLen || key[0] != historyChangePrefix {
+		return 0, errInvalidHistoryKey
+	}
+	return binary.BigEndian.Uint64(key[1:]), nil
+}
+
+func historyRootKey(rootID ids.ID, index uint64) []byte {
+	key := make([]byte, 1+idLen+historyIndexLen)
+	key[0] = historyRootPrefix
+	copy(key[1:], rootID[:])
+	binary.BigEndian.PutUint64(key[1+idLen:], index)
+	return key
+}
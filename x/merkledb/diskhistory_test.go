@@ -0,0 +1,174 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/database/memdb"
+	"github.com/dioneprotocol/dionego/ids"
+)
+
+func newPersistedHistoryDB(t *testing.T, baseDB database.Database, persistedHistoryLength int) *Database {
+	db, err := New(
+		context.Background(),
+		baseDB,
+		Config{
+			Tracer:                 newNoopTracer(),
+			HistoryLength:          1,
+			PersistedHistoryLength: persistedHistoryLength,
+			ValueCacheSize:         minCacheSize,
+			NodeCacheSize:          minCacheSize,
+		},
+	)
+	require.NoError(t, err)
+	return db
+}
+
+// Writes [numBatches] batches to [db] and returns the root after each batch.
+func writeTestBatches(t *testing.T, db *Database, numBatches int) []ids.ID {
+	require := require.New(t)
+
+	roots := make([]ids.ID, 0, numBatches)
+	for i := 0; i < numBatches; i++ {
+		batch := db.NewBatch()
+		require.NoError(batch.Put([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i))))
+		if i > 0 {
+			require.NoError(batch.Put([]byte(fmt.Sprintf("key%d", i-1)), []byte(fmt.Sprintf("updated%d", i-1))))
+		}
+		require.NoError(batch.Write())
+
+		root, err := db.GetMerkleRoot(context.Background())
+		require.NoError(err)
+		roots = append(roots, root)
+	}
+	return roots
+}
+
+func Test_DiskHistory_SurvivesRestart(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := newPersistedHistoryDB(t, baseDB, 100)
+	roots := writeTestBatches(t, db, 10)
+	require.NoError(db.Close())
+
+	db = newPersistedHistoryDB(t, baseDB, 100)
+
+	// The old roots are no longer in memory, so they must be served from disk.
+	require.NotContains(db.history.lastChanges, roots[2])
+	proof, err := db.GetRangeProofAtRoot(context.Background(), roots[2], nil, nil, 100)
+	require.NoError(err)
+	require.Len(proof.KeyValues, 3)
+	require.NoError(proof.Verify(context.Background(), nil, nil, roots[2], DefaultHasher, DefaultNodeEncoding))
+
+	// Build a database at roots[2] to verify a change proof against.
+	clientDB := newPersistedHistoryDB(t, memdb.New(), 0)
+	require.Equal(roots[2:3], writeTestBatches(t, clientDB, 3)[2:])
+
+	changeProof, err := db.GetChangeProof(context.Background(), roots[2], roots[9], nil, nil, 100)
+	require.NoError(err)
+	require.True(changeProof.HadRootsInHistory)
+	require.NoError(changeProof.Verify(context.Background(), clientDB, nil, nil, roots[9]))
+	require.NoError(clientDB.CommitChangeProof(context.Background(), changeProof))
+
+	clientRoot, err := clientDB.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(roots[9], clientRoot)
+}
+
+func Test_DiskHistory_Pruning(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := newPersistedHistoryDB(t, baseDB, 5)
+	roots := writeTestBatches(t, db, 10)
+
+	_, err := db.GetRangeProofAtRoot(context.Background(), roots[4], nil, nil, 100)
+	require.ErrorIs(err, ErrRootIDNotPresent)
+	_, err = db.GetRangeProofAtRoot(context.Background(), roots[5], nil, nil, 100)
+	require.NoError(err)
+	require.NoError(db.Close())
+
+	// Reducing the retention prunes the history when the database is opened.
+	db = newPersistedHistoryDB(t, baseDB, 2)
+	require.Equal(uint64(2), db.history.disk.nextIndex-db.history.disk.oldestIndex)
+	_, err = db.GetRangeProofAtRoot(context.Background(), roots[7], nil, nil, 100)
+	require.ErrorIs(err, ErrRootIDNotPresent)
+	_, err = db.GetRangeProofAtRoot(context.Background(), roots[8], nil, nil, 100)
+	require.NoError(err)
+	require.NoError(db.Close())
+
+	// Disabling the persisted history deletes it.
+	db = newPersistedHistoryDB(t, baseDB, 0)
+	require.Nil(db.history.disk)
+	it := db.historyDB.NewIterator()
+	require.False(it.Next())
+	it.Release()
+}
+
+func Test_DiskHistory_ClearedAfterUncleanShutdown(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := newPersistedHistoryDB(t, baseDB, 100)
+	roots := writeTestBatches(t, db, 5)
+
+	// Reopen the database without closing it.
+	db = newPersistedHistoryDB(t, baseDB, 100)
+	_, err := db.GetRangeProofAtRoot(context.Background(), roots[2], nil, nil, 100)
+	require.ErrorIs(err, ErrRootIDNotPresent)
+
+	// The current root is still in the history.
+	require.Equal(uint64(1), db.history.disk.nextIndex-db.history.disk.oldestIndex)
+	_, err = db.GetRangeProofAtRoot(context.Background(), roots[4], nil, nil, 100)
+	require.NoError(err)
+}
+
+func Test_Codec_ChangeSummary(t *testing.T) {
+	require := require.New(t)
+
+	n := newNode(nil, newPath([]byte{1}))
+	n.setValue(Some([]byte{2}))
+	n.addChild(newNode(nil, newPath([]byte{1, 2})))
+	require.NoError(n.calculateID(DefaultNodeEncoding, DefaultHasher, &mockMetrics{}))
+
+	expected := &changeSummary{
+		rootID: ids.GenerateTestID(),
+		nodes: map[path]*change[*node]{
+			n.key: {
+				before: nil,
+				after:  n,
+			},
+		},
+		values: map[path]*change[Maybe[[]byte]]{
+			newPath([]byte{1}): {
+				before: Nothing[[]byte](),
+				after:  Some([]byte{2}),
+			},
+		},
+	}
+
+	changesBytes, err := Codec.encodeChangeSummary(Version, expected)
+	require.NoError(err)
+
+	got := &changeSummary{}
+	_, err = Codec.decodeChangeSummary(changesBytes, got)
+	require.NoError(err)
+	require.Equal(expected.rootID, got.rootID)
+	require.Equal(expected.values, got.values)
+	require.Len(got.nodes, 1)
+	require.Nil(got.nodes[n.key].before)
+	require.Equal(n.id, got.nodes[n.key].after.id)
+	require.Equal(n.key, got.nodes[n.key].after.key)
+	require.Equal(n.dbNode, got.nodes[n.key].after.dbNode)
+
+	_, err = Codec.decodeChangeSummary(changesBytes[:len(changesBytes)-1], got)
+	require.Error(err)
+}
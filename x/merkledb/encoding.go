@@ -0,0 +1,346 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/utils/hashing"
+	"github.com/dioneprotocol/dionego/utils/wrappers"
+)
+
+const (
+	fixedWidthLenLen       = wrappers.IntLen
+	minFixedWidthDBNodeLen = boolLen + wrappers.ByteLen
+	minFixedWidthChildLen  = wrappers.ByteLen + fixedWidthLenLen + idLen
+)
+
+var (
+	_ Hasher = sha256Hasher{}
+	_ Hasher = keccak256Hasher{}
+	_ Hasher = blake2b256Hasher{}
+
+	_ NodeEncoding = varIntNodeEncoding{}
+	_ NodeEncoding = fixedWidthNodeEncoding{}
+
+	// SHA256Hasher hashes nodes with SHA-256.
+	SHA256Hasher Hasher = sha256Hasher{}
+	// Keccak256Hasher hashes nodes with the legacy Keccak-256 used by
+	// Ethereum.
+	Keccak256Hasher Hasher = keccak256Hasher{}
+	// BLAKE2b256Hasher hashes nodes with BLAKE2b-256.
+	BLAKE2b256Hasher Hasher = blake2b256Hasher{}
+
+	// DefaultHasher is used if Config.Hasher isn't set.
+	DefaultHasher = SHA256Hasher
+
+	// VarIntNodeEncoding encodes lengths and child indices as varints.
+	VarIntNodeEncoding NodeEncoding = varIntNodeEncoding{codecImpl: Codec.(*codecImpl)}
+	// FixedWidthNodeEncoding encodes lengths as 4 byte big-endian integers and
+	// child indices as single bytes, which is simpler to reproduce in other
+	// tooling. See fixedWidthNodeEncoding for the exact format.
+	FixedWidthNodeEncoding NodeEncoding = fixedWidthNodeEncoding{}
+
+	// DefaultNodeEncoding is used if Config.NodeEncoding isn't set.
+	DefaultNodeEncoding = VarIntNodeEncoding
+)
+
+// Hasher computes the ID of a node from the encoding of its hash values.
+type Hasher interface {
+	// Name uniquely identifies the hash function. It's persisted so that a
+	// database can't be reopened with a different hash function.
+	Name() string
+	Hash(b []byte) ids.ID
+}
+
+type sha256Hasher struct{}
+
+func (sha256Hasher) Name() string {
+	return "sha256"
+}
+
+func (sha256Hasher) Hash(b []byte) ids.ID {
+	return hashing.ComputeHash256Array(b)
+}
+
+type keccak256Hasher struct{}
+
+func (keccak256Hasher) Name() string {
+	return "keccak256"
+}
+
+func (keccak256Hasher) Hash(b []byte) ids.ID {
+	h := sha3.NewLegacyKeccak256()
+	_, _ = h.Write(b)
+
+	var id ids.ID
+	h.Sum(id[:0])
+	return id
+}
+
+type blake2b256Hasher struct{}
+
+func (blake2b256Hasher) Name() string {
+	return "blake2b256"
+}
+
+func (blake2b256Hasher) Hash(b []byte) ids.ID {
+	return blake2b.Sum256(b)
+}
+
+// NodeEncoding serializes nodes both to be stored and to be hashed.
+type NodeEncoding interface {
+	// Name uniquely identifies the encoding. It's persisted so that a
+	// database can't be reopened with a different encoding.
+	Name() string
+
+	encodeDBNode(version uint16, n *dbNode) ([]byte, error)
+	decodeDBNode(bytes []byte, n *dbNode) (uint16, error)
+	encodeHashValues(version uint16, hv *hashValues) ([]byte, error)
+}
+
+type varIntNodeEncoding struct {
+	*codecImpl
+}
+
+func (varIntNodeEncoding) Name() string {
+	return "varint"
+}
+
+// fixedWidthNodeEncoding encodes a node to be stored as:
+//
+//	has value (1 byte) || [value length (4 bytes) || value]
+//	number of children (1 byte)
+//	for each child in increasing order of index:
+//	  index (1 byte) || compressed path || ID (32 bytes)
+//
+// and the hash values of a node as:
+//
+//	number of children (1 byte)
+//	for each child in increasing order of index:
+//	  index (1 byte) || ID (32 bytes)
+//	has value (1 byte) || [value length (4 bytes) || value]
+//	key path
+//
+// where a path is encoded as its length in nibbles (4 bytes) followed by the
+// nibbles packed two per byte, with the last nibble padded with 0 if the
+// length is odd. All integers are big-endian.
+type fixedWidthNodeEncoding struct{}
+
+func (fixedWidthNodeEncoding) Name() string {
+	return "fixedwidth"
+}
+
+func (e fixedWidthNodeEncoding) encodeDBNode(version uint16, n *dbNode) ([]byte, error) {
+	if n == nil {
+		return nil, errEncodeNil
+	}
+	if version != codecVersion {
+		return nil, errUnknownVersion
+	}
+
+	buf := &bytes.Buffer{}
+	if err := e.encodeMaybeByteSlice(buf, n.value); err != nil {
+		return nil, err
+	}
+	_ = buf.WriteByte(byte(len(n.children)))
+	for index := byte(0); index < NodeBranchFactor; index++ {
+		entry, ok := n.children[index]
+		if !ok {
+			continue
+		}
+		_ = buf.WriteByte(index)
+		if err := e.encodeSerializedPath(buf, entry.compressedPath.Serialize()); err != nil {
+			return nil, err
+		}
+		_, _ = buf.Write(entry.id[:])
+	}
+	return buf.Bytes(), nil
+}
+
+func (e fixedWidthNodeEncoding) decodeDBNode(b []byte, n *dbNode) (uint16, error) {
+	if n == nil {
+		return 0, errDecodeNil
+	}
+	if minFixedWidthDBNodeLen > len(b) {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	var (
+		src = bytes.NewReader(b)
+		err error
+	)
+	if n.value, err = e.decodeMaybeByteSlice(src); err != nil {
+		return 0, err
+	}
+
+	numChildren, err := src.ReadByte()
+	if err != nil {
+		return 0, io.ErrUnexpectedEOF
+	}
+	switch {
+	case numChildren > NodeBranchFactor:
+		return 0, errTooManyChildren
+	case int(numChildren) > src.Len()/minFixedWidthChildLen:
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	n.children = make(map[byte]child, NodeBranchFactor)
+	previousChild := -1
+	for i := 0; i < int(numChildren); i++ {
+		index, err := src.ReadByte()
+		if err != nil {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if int(index) <= previousChild || index > NodeBranchFactor-1 {
+			return 0, errChildIndexTooLarge
+		}
+		previousChild = int(index)
+
+		compressedPath, err := e.decodeSerializedPath(src)
+		if err != nil {
+			return 0, err
+		}
+		var childID ids.ID
+		if _, err := io.ReadFull(src, childID[:]); err != nil {
+			return 0, io.ErrUnexpectedEOF
+		}
+		n.children[index] = child{
+			compressedPath: compressedPath.deserialize(),
+			id:             childID,
+		}
+	}
+	if src.Len() != 0 {
+		return 0, errExtraSpace
+	}
+	return codecVersion, nil
+}
+
+func (e fixedWidthNodeEncoding) encodeHashValues(version uint16, hv *hashValues) ([]byte, error) {
+	if hv == nil {
+		return nil, errEncodeNil
+	}
+	if version != codecVersion {
+		return nil, errUnknownVersion
+	}
+
+	buf := &bytes.Buffer{}
+	_ = buf.WriteByte(byte(len(hv.Children)))
+	for index := byte(0); index < NodeBranchFactor; index++ {
+		entry, ok := hv.Children[index]
+		if !ok {
+			continue
+		}
+		_ = buf.WriteByte(index)
+		_, _ = buf.Write(entry.id[:])
+	}
+	if err := e.encodeMaybeByteSlice(buf, hv.Value); err != nil {
+		return nil, err
+	}
+	if err := e.encodeSerializedPath(buf, hv.Key); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (e fixedWidthNodeEncoding) encodeMaybeByteSlice(buf *bytes.Buffer, maybeValue Maybe[[]byte]) error {
+	if maybeValue.IsNothing() {
+		_ = buf.WriteByte(falseByte)
+		return nil
+	}
+	_ = buf.WriteByte(trueByte)
+	value := maybeValue.Value()
+	if err := e.encodeLen(buf, len(value)); err != nil {
+		return err
+	}
+	_, _ = buf.Write(value)
+	return nil
+}
+
+func (e fixedWidthNodeEncoding) decodeMaybeByteSlice(src *bytes.Reader) (Maybe[[]byte], error) {
+	hasValue, err := src.ReadByte()
+	if err != nil {
+		return Nothing[[]byte](), io.ErrUnexpectedEOF
+	}
+	switch hasValue {
+	case falseByte:
+		return Nothing[[]byte](), nil
+	case trueByte:
+	default:
+		return Nothing[[]byte](), errInvalidBool
+	}
+
+	length, err := e.decodeLen(src)
+	if err != nil {
+		return Nothing[[]byte](), err
+	}
+	if length > src.Len() {
+		return Nothing[[]byte](), io.ErrUnexpectedEOF
+	}
+	value := make([]byte, length)
+	if _, err := io.ReadFull(src, value); err != nil {
+		return Nothing[[]byte](), io.ErrUnexpectedEOF
+	}
+	return Some(value), nil
+}
+
+func (e fixedWidthNodeEncoding) encodeSerializedPath(buf *bytes.Buffer, s SerializedPath) error {
+	if err := e.encodeLen(buf, s.NibbleLength); err != nil {
+		return err
+	}
+	_, _ = buf.Write(s.Value)
+	return nil
+}
+
+func (e fixedWidthNodeEncoding) decodeSerializedPath(src *bytes.Reader) (SerializedPath, error) {
+	nibbleLength, err := e.decodeLen(src)
+	if err != nil {
+		return SerializedPath{}, err
+	}
+	byteLength := (nibbleLength + 1) / 2
+	if byteLength > src.Len() {
+		return SerializedPath{}, io.ErrUnexpectedEOF
+	}
+
+	result := SerializedPath{
+		NibbleLength: nibbleLength,
+		Value:        make([]byte, byteLength),
+	}
+	if _, err := io.ReadFull(src, result.Value); err != nil {
+		return SerializedPath{}, io.ErrUnexpectedEOF
+	}
+	if result.hasOddLength() && result.Value[byteLength-1]&0x0F != 0 {
+		return SerializedPath{}, errNonZeroNibblePadding
+	}
+	return result, nil
+}
+
+func (fixedWidthNodeEncoding) encodeLen(buf *bytes.Buffer, length int) error {
+	if length > math.MaxUint32 {
+		return errIntTooLarge
+	}
+	var lengthBytes [fixedWidthLenLen]byte
+	binary.BigEndian.PutUint32(lengthBytes[:], uint32(length))
+	_, _ = buf.Write(lengthBytes[:])
+	return nil
+}
+
+func (fixedWidthNodeEncoding) decodeLen(src *bytes.Reader) (int, error) {
+	var lengthBytes [fixedWidthLenLen]byte
+	if _, err := io.ReadFull(src, lengthBytes[:]); err != nil {
+		return 0, io.ErrUnexpectedEOF
+	}
+	length := binary.BigEndian.Uint32(lengthBytes[:])
+	if uint64(length) > math.MaxInt32 {
+		return 0, errIntTooLarge
+	}
+	return int(length), nil
+}
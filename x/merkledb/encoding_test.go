@@ -0,0 +1,215 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/database/memdb"
+	"github.com/dioneprotocol/dionego/database/prefixdb"
+)
+
+func newNodeFormatDB(baseDB database.Database, hasher Hasher, encoding NodeEncoding) (*Database, error) {
+	return New(
+		context.Background(),
+		baseDB,
+		Config{
+			Tracer:         newNoopTracer(),
+			HistoryLength:  100,
+			ValueCacheSize: minCacheSize,
+			NodeCacheSize:  minCacheSize,
+			Hasher:         hasher,
+			NodeEncoding:   encoding,
+		},
+	)
+}
+
+func TestHashers(t *testing.T) {
+	tests := []struct {
+		hasher   Hasher
+		expected string
+	}{
+		{
+			hasher:   SHA256Hasher,
+			expected: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+		{
+			hasher:   Keccak256Hasher,
+			expected: "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		},
+		{
+			hasher:   BLAKE2b256Hasher,
+			expected: "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hasher.Name(), func(t *testing.T) {
+			id := tt.hasher.Hash(nil)
+			require.Equal(t, tt.expected, hex.EncodeToString(id[:]))
+		})
+	}
+}
+
+func TestNodeFormats(t *testing.T) {
+	for _, hasher := range []Hasher{SHA256Hasher, Keccak256Hasher, BLAKE2b256Hasher} {
+		for _, encoding := range []NodeEncoding{VarIntNodeEncoding, FixedWidthNodeEncoding} {
+			t.Run(fmt.Sprintf("%s_%s", hasher.Name(), encoding.Name()), func(t *testing.T) {
+				testNodeFormat(t, hasher, encoding)
+			})
+		}
+	}
+}
+
+func testNodeFormat(t *testing.T, hasher Hasher, encoding NodeEncoding) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	isDefault := hasher == DefaultHasher && encoding == DefaultNodeEncoding
+
+	baseDB := memdb.New()
+	db, err := newNodeFormatDB(baseDB, hasher, encoding)
+	require.NoError(err)
+	startRoot, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	// [clientDB] is at [startRoot] to verify change proofs against.
+	clientDB, err := newNodeFormatDB(memdb.New(), hasher, encoding)
+	require.NoError(err)
+
+	batch := db.NewBatch()
+	for i := 0; i < 100; i++ {
+		require.NoError(batch.Put([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i))))
+	}
+	require.NoError(batch.Write())
+	root, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	proof, err := db.GetProof(ctx, []byte("key5"))
+	require.NoError(err)
+	require.NoError(proof.Verify(ctx, root, hasher, encoding))
+
+	rangeProof, err := db.GetRangeProof(ctx, []byte("key1"), []byte("key5"), 10)
+	require.NoError(err)
+	require.NoError(rangeProof.Verify(ctx, []byte("key1"), []byte("key5"), root, hasher, encoding))
+
+	multiProof, err := db.GetMultiProof(ctx, [][]byte{[]byte("key1"), []byte("key50"), []byte("missing")})
+	require.NoError(err)
+	require.NoError(multiProof.Verify(ctx, root, hasher, encoding))
+
+	changeProof, err := db.GetChangeProof(ctx, startRoot, root, nil, nil, 200)
+	require.NoError(err)
+	require.NoError(changeProof.Verify(ctx, clientDB, nil, nil, root))
+	require.NoError(clientDB.CommitChangeProof(ctx, changeProof))
+	clientRoot, err := clientDB.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(root, clientRoot)
+
+	if !isDefault {
+		// Proofs don't verify with a different format.
+		require.ErrorIs(proof.Verify(ctx, root, DefaultHasher, DefaultNodeEncoding), ErrInvalidProof)
+		require.ErrorIs(rangeProof.Verify(ctx, []byte("key1"), []byte("key5"), root, DefaultHasher, DefaultNodeEncoding), ErrInvalidProof)
+		require.ErrorIs(multiProof.Verify(ctx, root, DefaultHasher, DefaultNodeEncoding), ErrInvalidProof)
+	}
+
+	// The root is the same after reopening the database.
+	require.NoError(db.Close())
+	db, err = newNodeFormatDB(baseDB, hasher, encoding)
+	require.NoError(err)
+	reopenedRoot, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(root, reopenedRoot)
+	value, err := db.Get([]byte("key5"))
+	require.NoError(err)
+	require.Equal([]byte("value5"), value)
+	require.NoError(db.Close())
+}
+
+func TestNodeFormatMismatch(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := newNodeFormatDB(baseDB, Keccak256Hasher, FixedWidthNodeEncoding)
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+	require.NoError(db.Close())
+
+	_, err = newNodeFormatDB(baseDB, nil, FixedWidthNodeEncoding)
+	require.ErrorIs(err, ErrHasherMismatch)
+	_, err = newNodeFormatDB(baseDB, Keccak256Hasher, nil)
+	require.ErrorIs(err, ErrNodeEncodingMismatch)
+
+	db, err = newNodeFormatDB(baseDB, Keccak256Hasher, FixedWidthNodeEncoding)
+	require.NoError(err)
+	require.NoError(db.Close())
+}
+
+func TestNodeFormatMissingMetadata(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := newNodeFormatDB(baseDB, nil, nil)
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+	require.NoError(db.Close())
+
+	// Databases created before the node format was persisted used the
+	// defaults.
+	metadataDB := prefixdb.New(metadataPrefix, baseDB)
+	require.NoError(metadataDB.Delete(hasherKey))
+	require.NoError(metadataDB.Delete(nodeEncodingKey))
+
+	_, err = newNodeFormatDB(baseDB, Keccak256Hasher, nil)
+	require.ErrorIs(err, ErrHasherMismatch)
+
+	db, err = newNodeFormatDB(baseDB, nil, nil)
+	require.NoError(err)
+	hasherName, err := metadataDB.Get(hasherKey)
+	require.NoError(err)
+	require.Equal([]byte(DefaultHasher.Name()), hasherName)
+	require.NoError(db.Close())
+}
+
+func TestFixedWidthNodeEncoding(t *testing.T) {
+	require := require.New(t)
+
+	n := newNode(nil, newPath([]byte{1}))
+	n.setValue(Some([]byte{2, 3}))
+	child := newNode(nil, newPath([]byte{1, 0x23}))
+	require.NoError(child.calculateID(FixedWidthNodeEncoding, SHA256Hasher, &mockMetrics{}))
+	n.addChild(child)
+
+	nodeBytes, err := n.marshal(FixedWidthNodeEncoding)
+	require.NoError(err)
+
+	expected := []byte{
+		1, 0, 0, 0, 2, 2, 3, // value
+		1,          // number of children
+		2,          // child index
+		0, 0, 0, 1, // compressed path length
+		0x30, // compressed path
+	}
+	expected = append(expected, child.id[:]...)
+	require.Equal(expected, nodeBytes)
+
+	parsed, err := parseNode(FixedWidthNodeEncoding, n.key, nodeBytes)
+	require.NoError(err)
+	require.Equal(n.dbNode, parsed.dbNode)
+
+	_, err = parseNode(FixedWidthNodeEncoding, n.key, nodeBytes[:len(nodeBytes)-1])
+	require.ErrorIs(err, io.ErrUnexpectedEOF)
+	_, err = parseNode(FixedWidthNodeEncoding, n.key, append(nodeBytes, 0))
+	require.ErrorIs(err, errExtraSpace)
+
+	badPadding := append([]byte{}, nodeBytes...)
+	badPadding[12] = 0x31
+	_, err = parseNode(FixedWidthNodeEncoding, n.key, badPadding)
+	require.ErrorIs(err, errNonZeroNibblePadding)
+}
@@ -31,6 +31,11 @@ type trieHistory struct {
 	history *btree.BTreeG[*changeSummaryAndIndex]
 
 	nextIndex uint64
+
+	// If non-nil, every change is also persisted to [disk] so that roots
+	// which are no longer in [history], including roots from before a
+	// restart, can still be served.
+	disk *diskHistory
 }
 
 // Tracks the beginning and ending state of a value.
@@ -88,6 +93,16 @@ func (th *trieHistory) getValueChanges(startRoot, endRoot ids.ID, start, end []b
 		return newChangeSummary(maxLength), nil
 	}
 
+	changes, err := th.getValueChangesInMemory(startRoot, endRoot, start, end, maxLength)
+	if th.disk == nil || (err != ErrRootIDNotPresent && err != ErrStartRootNotFound) {
+		return changes, err
+	}
+	return th.disk.getValueChanges(startRoot, endRoot, start, end, maxLength)
+}
+
+// Returns up to [maxLength] key-value pair changes with keys in [start, end] that
+// occurred between [startRoot] and [endRoot] using only the in-memory history.
+func (th *trieHistory) getValueChangesInMemory(startRoot, endRoot ids.ID, start, end []byte, maxLength int) (*changeSummary, error) {
 	// Confirm there's a change resulting in [startRoot] before
 	// a change resulting in [endRoot] in the history.
 	// [lastEndRootChange] is the last change in the history resulting in [endRoot].
@@ -118,25 +133,10 @@ func (th *trieHistory) getValueChanges(startRoot, endRoot ids.ID, start, end []b
 		return nil, ErrStartRootNotFound
 	}
 
-	// Keep changes sorted so the largest can be removed in order to stay within the maxLength limit.
-	sortedKeys := btree.NewG(
-		2,
-		func(a, b path) bool {
-			return a.Compare(b) < 0
-		},
-	)
-
-	startPath := newPath(start)
-	endPath := newPath(end)
-
-	// For each element in the history in the range between [startRoot]'s
-	// last appearance (exclusive) and [endRoot]'s last appearance (inclusive),
-	// add the changes to keys in [start, end] to [combinedChanges].
-	// Only the key-value pairs with the greatest [maxLength] keys will be kept.
-	combinedChanges := newChangeSummary(maxLength)
+	combiner := newValueChangesCombiner(start, end, maxLength)
 
 	// For each change after [lastStartRootChange] up to and including
-	// [lastEndRootChange], record the change in [combinedChanges].
+	// [lastEndRootChange], record the change in [combiner].
 	th.history.AscendGreaterOrEqual(
 		lastStartRootChange,
 		func(item *changeSummaryAndIndex) bool {
@@ -149,32 +149,11 @@ func (th *trieHistory) getValueChanges(startRoot, endRoot ids.ID, start, end []b
 				return false
 			}
 
-			for key, valueChange := range item.values {
-				if (len(startPath) == 0 || key.Compare(startPath) >= 0) &&
-					(len(endPath) == 0 || key.Compare(endPath) <= 0) {
-					if existing, ok := combinedChanges.values[key]; ok {
-						existing.after = valueChange.after
-					} else {
-						combinedChanges.values[key] = &change[Maybe[[]byte]]{
-							before: valueChange.before,
-							after:  valueChange.after,
-						}
-					}
-					sortedKeys.ReplaceOrInsert(key)
-				}
-			}
-
-			// Keep only the smallest [maxLength] items in [combinedChanges.values].
-			for sortedKeys.Len() > maxLength {
-				if greatestKey, found := sortedKeys.DeleteMax(); found {
-					delete(combinedChanges.values, greatestKey)
-				}
-			}
-
+			combiner.add(item.changeSummary)
 			return true
 		},
 	)
-	return combinedChanges, nil
+	return combiner.combinedChanges, nil
 }
 
 // Returns the changes to go from the current trie state back to the requested [rootID]
@@ -185,53 +164,40 @@ func (th *trieHistory) getChangesToGetToRoot(rootID ids.ID, start, end []byte) (
 	// [lastRootChange] is the last change in the history resulting in [rootID].
 	lastRootChange, ok := th.lastChanges[rootID]
 	if !ok {
-		return nil, ErrRootIDNotPresent
+		if th.disk == nil {
+			return nil, ErrRootIDNotPresent
+		}
+		return th.disk.getChangesToGetToRoot(rootID, start, end)
 	}
 
-	var (
-		startPath       = newPath(start)
-		endPath         = newPath(end)
-		combinedChanges = newChangeSummary(defaultPreallocationSize)
-	)
+	combiner := newRevertChangesCombiner(start, end)
 
-	// Go backward from the most recent change in the history up to but
-	// not including the last change resulting in [rootID].
-	// Record each change in [combinedChanges].
-	th.history.Descend(
+	// Go forward from the change after the last change resulting in
+	// [rootID] up to the most recent change in the history.
+	// Record each change in [combiner].
+	th.history.AscendGreaterOrEqual(
+		lastRootChange,
 		func(item *changeSummaryAndIndex) bool {
-			if item == lastRootChange {
-				return false
-			}
-			for key, changedNode := range item.nodes {
-				combinedChanges.nodes[key] = &change[*node]{
-					after: changedNode.before,
-				}
-			}
-
-			for key, valueChange := range item.values {
-				if (len(startPath) == 0 || key.Compare(startPath) >= 0) &&
-					(len(endPath) == 0 || key.Compare(endPath) <= 0) {
-					if existing, ok := combinedChanges.values[key]; ok {
-						existing.after = valueChange.before
-					} else {
-						combinedChanges.values[key] = &change[Maybe[[]byte]]{
-							before: valueChange.after,
-							after:  valueChange.before,
-						}
-					}
-				}
+			if item != lastRootChange {
+				combiner.add(item.changeSummary)
 			}
 			return true
 		},
 	)
-	return combinedChanges, nil
+	return combiner.combinedChanges, nil
 }
 
 // record the provided set of changes in the history
-func (th *trieHistory) record(changes *changeSummary) {
-	// we aren't recording history so noop
+func (th *trieHistory) record(changes *changeSummary) error {
+	if th.disk != nil {
+		if err := th.disk.record(changes); err != nil {
+			return err
+		}
+	}
+
+	// we aren't recording history in memory so noop
 	if th.maxHistoryLen == 0 {
-		return
+		return nil
 	}
 
 	for th.history.Len() == th.maxHistoryLen {
@@ -255,4 +221,109 @@ func (th *trieHistory) record(changes *changeSummary) {
 	_, _ = th.history.ReplaceOrInsert(changesAndIndex)
 	// Mark that this is the most recent change resulting in [changes.rootID].
 	th.lastChanges[changes.rootID] = changesAndIndex
+	return nil
+}
+
+// Combines consecutive change summaries, in the order they were made, into
+// the key-value pair changes with keys in [start, end] between the state
+// before the first change and the state after the last change.
+// Only the changes to the smallest [maxLength] keys are kept.
+type valueChangesCombiner struct {
+	startPath       path
+	endPath         path
+	maxLength       int
+	combinedChanges *changeSummary
+
+	// Keep changes sorted so the largest can be removed in order to stay
+	// within the maxLength limit.
+	sortedKeys *btree.BTreeG[path]
+}
+
+func newValueChangesCombiner(start, end []byte, maxLength int) *valueChangesCombiner {
+	return &valueChangesCombiner{
+		startPath:       newPath(start),
+		endPath:         newPath(end),
+		maxLength:       maxLength,
+		combinedChanges: newChangeSummary(maxLength),
+		sortedKeys: btree.NewG(
+			2,
+			func(a, b path) bool {
+				return a.Compare(b) < 0
+			},
+		),
+	}
+}
+
+func (c *valueChangesCombiner) add(changes *changeSummary) {
+	for key, valueChange := range changes.values {
+		if !inRange(key, c.startPath, c.endPath) {
+			continue
+		}
+		if existing, ok := c.combinedChanges.values[key]; ok {
+			existing.after = valueChange.after
+		} else {
+			c.combinedChanges.values[key] = &change[Maybe[[]byte]]{
+				before: valueChange.before,
+				after:  valueChange.after,
+			}
+		}
+		c.sortedKeys.ReplaceOrInsert(key)
+	}
+
+	// Keep only the smallest [maxLength] items in [combinedChanges.values].
+	for c.sortedKeys.Len() > c.maxLength {
+		if greatestKey, found := c.sortedKeys.DeleteMax(); found {
+			delete(c.combinedChanges.values, greatestKey)
+		}
+	}
+}
+
+// Combines consecutive change summaries, in the order they were made, into
+// the changes needed to revert the state after the last change to the state
+// before the first change. Node changes are always included. Value changes
+// are only included for keys in [start, end].
+type revertChangesCombiner struct {
+	startPath       path
+	endPath         path
+	combinedChanges *changeSummary
+}
+
+func newRevertChangesCombiner(start, end []byte) *revertChangesCombiner {
+	return &revertChangesCombiner{
+		startPath:       newPath(start),
+		endPath:         newPath(end),
+		combinedChanges: newChangeSummary(defaultPreallocationSize),
+	}
+}
+
+func (c *revertChangesCombiner) add(changes *changeSummary) {
+	for key, changedNode := range changes.nodes {
+		// Only the state before the first change to the node is needed.
+		if _, ok := c.combinedChanges.nodes[key]; !ok {
+			c.combinedChanges.nodes[key] = &change[*node]{
+				after: changedNode.before,
+			}
+		}
+	}
+
+	for key, valueChange := range changes.values {
+		if !inRange(key, c.startPath, c.endPath) {
+			continue
+		}
+		if existing, ok := c.combinedChanges.values[key]; ok {
+			existing.before = valueChange.after
+		} else {
+			c.combinedChanges.values[key] = &change[Maybe[[]byte]]{
+				before: valueChange.after,
+				after:  valueChange.before,
+			}
+		}
+	}
+}
+
+// Returns true iff [key] is in [start, end].
+// An empty [start] or [end] means the range is unbounded on that side.
+func inRange(key, start, end path) bool {
+	return (len(start) == 0 || key.Compare(start) >= 0) &&
+		(len(end) == 0 || key.Compare(end) <= 0)
 }
@@ -42,7 +42,7 @@ func Test_History_Simple(t *testing.T) {
 	require.NoError(err)
 	require.NotNil(origProof)
 	origRootID := db.root.id
-	err = origProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = origProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 
 	batch = db.NewBatch()
@@ -53,7 +53,7 @@ func Test_History_Simple(t *testing.T) {
 	newProof, err := db.GetRangeProofAtRoot(context.Background(), origRootID, []byte("k"), []byte("key3"), 10)
 	require.NoError(err)
 	require.NotNil(newProof)
-	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 
 	batch = db.NewBatch()
@@ -66,7 +66,7 @@ func Test_History_Simple(t *testing.T) {
 	newProof, err = db.GetRangeProofAtRoot(context.Background(), origRootID, []byte("k"), []byte("key3"), 10)
 	require.NoError(err)
 	require.NotNil(newProof)
-	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 
 	batch = db.NewBatch()
@@ -78,7 +78,7 @@ func Test_History_Simple(t *testing.T) {
 	newProof, err = db.GetRangeProofAtRoot(context.Background(), origRootID, []byte("k"), []byte("key3"), 10)
 	require.NoError(err)
 	require.NotNil(newProof)
-	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 
 	batch = db.NewBatch()
@@ -105,7 +105,7 @@ func Test_History_Simple(t *testing.T) {
 	newProof, err = db.GetRangeProofAtRoot(context.Background(), origRootID, []byte("k"), []byte("key3"), 10)
 	require.NoError(err)
 	require.NotNil(newProof)
-	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 }
 
@@ -164,7 +164,7 @@ func Test_History_Large(t *testing.T) {
 		require.NoError(err)
 		require.NotNil(proof)
 
-		err = proof.Verify(context.Background(), nil, nil, roots[0])
+		err = proof.Verify(context.Background(), nil, nil, roots[0], DefaultHasher, DefaultNodeEncoding)
 		require.NoError(err)
 	}
 }
@@ -277,6 +277,8 @@ func Test_History_Trigger_History_Queue_Looping(t *testing.T) {
 		[]byte("k"),
 		[]byte("key3"),
 		origRootID,
+		DefaultHasher,
+		DefaultNodeEncoding,
 	)
 	require.NoError(err)
 
@@ -296,6 +298,8 @@ func Test_History_Trigger_History_Queue_Looping(t *testing.T) {
 		[]byte("k"),
 		[]byte("key3"),
 		origRootID,
+		DefaultHasher,
+		DefaultNodeEncoding,
 	)
 	require.NoError(err)
 
@@ -400,7 +404,7 @@ func Test_History_RepeatedRoot(t *testing.T) {
 	require.NoError(err)
 	require.NotNil(origProof)
 	origRootID := db.root.id
-	err = origProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = origProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 
 	batch = db.NewBatch()
@@ -415,7 +419,7 @@ func Test_History_RepeatedRoot(t *testing.T) {
 	newProof, err := db.GetRangeProofAtRoot(context.Background(), origRootID, []byte("k"), []byte("key3"), 10)
 	require.NoError(err)
 	require.NotNil(newProof)
-	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 
 	// revert state to be the same as in orig proof
@@ -432,7 +436,7 @@ func Test_History_RepeatedRoot(t *testing.T) {
 	newProof, err = db.GetRangeProofAtRoot(context.Background(), origRootID, []byte("k"), []byte("key3"), 10)
 	require.NoError(err)
 	require.NotNil(newProof)
-	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 }
 
@@ -460,7 +464,7 @@ func Test_History_ExcessDeletes(t *testing.T) {
 	require.NoError(err)
 	require.NotNil(origProof)
 	origRootID := db.root.id
-	err = origProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = origProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 
 	batch = db.NewBatch()
@@ -479,7 +483,7 @@ func Test_History_ExcessDeletes(t *testing.T) {
 	newProof, err := db.GetRangeProofAtRoot(context.Background(), origRootID, []byte("k"), []byte("key3"), 10)
 	require.NoError(err)
 	require.NotNil(newProof)
-	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 }
 
@@ -507,7 +511,7 @@ func Test_History_DontIncludeAllNodes(t *testing.T) {
 	require.NoError(err)
 	require.NotNil(origProof)
 	origRootID := db.root.id
-	err = origProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = origProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 
 	batch = db.NewBatch()
@@ -518,7 +522,7 @@ func Test_History_DontIncludeAllNodes(t *testing.T) {
 	newProof, err := db.GetRangeProofAtRoot(context.Background(), origRootID, []byte("k"), []byte("key3"), 10)
 	require.NoError(err)
 	require.NotNil(newProof)
-	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 }
 
@@ -546,7 +550,7 @@ func Test_History_Branching2Nodes(t *testing.T) {
 	require.NoError(err)
 	require.NotNil(origProof)
 	origRootID := db.root.id
-	err = origProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = origProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 
 	batch = db.NewBatch()
@@ -557,7 +561,7 @@ func Test_History_Branching2Nodes(t *testing.T) {
 	newProof, err := db.GetRangeProofAtRoot(context.Background(), origRootID, []byte("k"), []byte("key3"), 10)
 	require.NoError(err)
 	require.NotNil(newProof)
-	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 }
 
@@ -585,7 +589,7 @@ func Test_History_Branching3Nodes(t *testing.T) {
 	require.NoError(err)
 	require.NotNil(origProof)
 	origRootID := db.root.id
-	err = origProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = origProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 
 	batch = db.NewBatch()
@@ -596,7 +600,7 @@ func Test_History_Branching3Nodes(t *testing.T) {
 	newProof, err := db.GetRangeProofAtRoot(context.Background(), origRootID, []byte("k"), []byte("key3"), 10)
 	require.NoError(err)
 	require.NotNil(newProof)
-	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID)
+	err = newProof.Verify(context.Background(), []byte("k"), []byte("key3"), origRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(err)
 }
 
@@ -0,0 +1,216 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/ids"
+)
+
+var (
+	ErrNoKeys                 = errors.New("multi-proof has no keys")
+	ErrNoRootProofNode        = errors.New("first proof node must be the root")
+	ErrUnsortedProofNodes     = errors.New("proof nodes must be sorted by increasing key path with no duplicates")
+	ErrMissingProofNode       = errors.New("proof is missing a node on the path to a key")
+	ErrUnsortedMultiProofKeys = errors.New("multi-proof keys must be sorted in increasing order with no duplicates")
+)
+
+// A proof that each of a set of keys exists/doesn't exist in a trie.
+// Nodes shared by the paths to several keys are only included once, so this
+// is smaller than a separate Proof for each key.
+type MultiProof struct {
+	// The union of the nodes in the proof paths of [Keys].
+	// Sorted by increasing key path. Must always be non-empty (i.e. have the
+	// root node).
+	Nodes []ProofNode
+	// The keys this is a proof of.
+	// Sorted in increasing order.
+	Keys [][]byte
+}
+
+// Returns nil if the trie given in [proof] has root [expectedRootID] when its
+// nodes are hashed with [hasher] and [encoding], and [proof] proves the
+// existence/non-existence of each of [proof.Keys].
+func (proof *MultiProof) Verify(ctx context.Context, expectedRootID ids.ID, hasher Hasher, encoding NodeEncoding) error {
+	// Make sure the proof is well-formed.
+	if len(proof.Nodes) == 0 {
+		return ErrNoProof
+	}
+	if len(proof.Keys) == 0 {
+		return ErrNoKeys
+	}
+	if proof.Nodes[0].KeyPath.NibbleLength != 0 {
+		return ErrNoRootProofNode
+	}
+	for i := 1; i < len(proof.Nodes); i++ {
+		if proof.Nodes[i-1].KeyPath.deserialize().Compare(proof.Nodes[i].KeyPath.deserialize()) >= 0 {
+			return ErrUnsortedProofNodes
+		}
+	}
+	for i := 1; i < len(proof.Keys); i++ {
+		if bytes.Compare(proof.Keys[i-1], proof.Keys[i]) >= 0 {
+			return ErrUnsortedMultiProofKeys
+		}
+	}
+	// Make sure the path to each key is in the proof.
+	for _, key := range proof.Keys {
+		if _, err := proof.getValue(key); err != nil {
+			return err
+		}
+	}
+
+	db, err := newVerificationDatabase(ctx, hasher, encoding)
+	if err != nil {
+		return err
+	}
+
+	view, err := db.NewView(ctx)
+	if err != nil {
+		return err
+	}
+	// Don't need to lock [view] because nobody else has a reference to it.
+
+	// Insert the proof nodes in decreasing order of key path so that every
+	// node is inserted after all of its descendants in the proof. Then any
+	// child of a node that isn't already in the trie isn't in the proof.
+	for i := len(proof.Nodes) - 1; i >= 0; i-- {
+		proofNode := proof.Nodes[i]
+		keyPath := proofNode.KeyPath.deserialize()
+
+		if len(keyPath)&1 == 1 && !proofNode.Value.IsNothing() {
+			// a value cannot have an odd number of nibbles in its key
+			return ErrOddLengthWithValue
+		}
+
+		n, err := view.insertIntoTrie(ctx, keyPath, proofNode.Value)
+		if err != nil {
+			return err
+		}
+		for index, childID := range proofNode.Children {
+			if _, ok := n.children[index]; !ok {
+				n.addChildWithoutNode(index, EmptyPath, childID)
+			}
+		}
+	}
+
+	gotRootID, err := view.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if expectedRootID != gotRootID {
+		return fmt.Errorf("%w:[%s], expected:[%s]", ErrInvalidProof, gotRootID, expectedRootID)
+	}
+	return nil
+}
+
+// GetValue returns the value of [key] proven by [proof].
+// Returns database.ErrNotFound if [proof] proves that [key] isn't in the trie.
+// Returns ErrMissingProofNode if [proof] doesn't prove whether [key] is in
+// the trie.
+// Only meaningful if [proof] has been verified.
+func (proof *MultiProof) GetValue(key []byte) ([]byte, error) {
+	if len(proof.Nodes) == 0 {
+		return nil, ErrNoProof
+	}
+	value, err := proof.getValue(key)
+	if err != nil {
+		return nil, err
+	}
+	if value.IsNothing() {
+		return nil, database.ErrNotFound
+	}
+	return slices.Clone(value.Value()), nil
+}
+
+// Follows the path from the root to [key] through the nodes of [proof].
+// Returns Nothing if [key] isn't in the trie.
+// Assumes [proof.Nodes] is non-empty and sorted.
+func (proof *MultiProof) getValue(key []byte) (Maybe[[]byte], error) {
+	var (
+		keyPath     = newPath(key)
+		current     = proof.Nodes[0]
+		currentPath = current.KeyPath.deserialize()
+	)
+	if len(currentPath) != 0 {
+		return Nothing[[]byte](), ErrNoRootProofNode
+	}
+	for {
+		if currentPath == keyPath {
+			return current.Value, nil
+		}
+
+		// [currentPath] is a strict prefix of [keyPath].
+		nextIndex := keyPath[len(currentPath)]
+		if _, ok := current.Children[nextIndex]; !ok {
+			// There is no child where [key] would be.
+			return Nothing[[]byte](), nil
+		}
+
+		// The child is the node with the shortest key path that starts
+		// with [childPrefix], which is the first such node in sorted order.
+		childPrefix := currentPath.Append(nextIndex)
+		i := sort.Search(len(proof.Nodes), func(i int) bool {
+			return proof.Nodes[i].KeyPath.deserialize().Compare(childPrefix) >= 0
+		})
+		if i == len(proof.Nodes) {
+			return Nothing[[]byte](), ErrMissingProofNode
+		}
+		childPath := proof.Nodes[i].KeyPath.deserialize()
+		if !childPath.HasPrefix(childPrefix) {
+			return Nothing[[]byte](), ErrMissingProofNode
+		}
+		if !keyPath.HasPrefix(childPath) {
+			// The child's key path diverges from [key] or is longer than it,
+			// so [key] isn't in the trie.
+			return Nothing[[]byte](), nil
+		}
+		current = proof.Nodes[i]
+		currentPath = childPath
+	}
+}
+
+// Returns a multi-proof of [keys] made from the proofs of each key.
+// [keys] must be sorted and unique.
+func newMultiProof(keys [][]byte, proofs []*Proof) *MultiProof {
+	nodes := make(map[path]ProofNode)
+	for _, proof := range proofs {
+		for _, proofNode := range proof.Path {
+			nodes[proofNode.KeyPath.deserialize()] = proofNode
+		}
+	}
+
+	paths := make([]path, 0, len(nodes))
+	for p := range nodes {
+		paths = append(paths, p)
+	}
+	slices.SortFunc(paths, func(i, j path) bool {
+		return i < j
+	})
+
+	multiProof := &MultiProof{
+		Nodes: make([]ProofNode, len(paths)),
+		Keys:  keys,
+	}
+	for i, p := range paths {
+		multiProof.Nodes[i] = nodes[p]
+	}
+	return multiProof
+}
+
+// Returns a sorted copy of [keys] with duplicates removed.
+func sortedUniqueKeys(keys [][]byte) [][]byte {
+	sorted := slices.Clone(keys)
+	slices.SortFunc(sorted, func(i, j []byte) bool {
+		return bytes.Compare(i, j) < 0
+	})
+	return slices.CompactFunc(sorted, bytes.Equal)
+}
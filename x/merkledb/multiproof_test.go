@@ -0,0 +1,309 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/database/memdb"
+	"github.com/dioneprotocol/dionego/ids"
+)
+
+// Returns a database containing [numKeys] random key-value pairs and the keys.
+func newRandomMultiProofDB(t testing.TB, r *rand.Rand, numKeys int) (*Database, [][]byte) {
+	db, err := New(
+		context.Background(),
+		memdb.New(),
+		Config{
+			Tracer:         newNoopTracer(),
+			HistoryLength:  10,
+			ValueCacheSize: minCacheSize,
+			NodeCacheSize:  minCacheSize,
+		},
+	)
+	require.NoError(t, err)
+
+	keys := make([][]byte, numKeys)
+	batch := db.NewBatch()
+	for i := range keys {
+		keys[i] = make([]byte, r.Intn(32)+1)
+		_, _ = r.Read(keys[i])
+		value := make([]byte, r.Intn(64))
+		_, _ = r.Read(value)
+		require.NoError(t, batch.Put(keys[i], value))
+	}
+	require.NoError(t, batch.Write())
+	return db, keys
+}
+
+func Test_MultiProof(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+
+	db, keys := newRandomMultiProofDB(t, r, 1000)
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	// Prove a mix of keys in the trie and keys that aren't, with duplicates.
+	proofKeys := [][]byte{keys[0], keys[0], {}, []byte("not in the trie")}
+	for i := 0; i < 50; i++ {
+		proofKeys = append(proofKeys, keys[r.Intn(len(keys))])
+		key := make([]byte, r.Intn(32)+1)
+		_, _ = r.Read(key)
+		proofKeys = append(proofKeys, key)
+	}
+
+	proof, err := db.GetMultiProof(context.Background(), proofKeys)
+	require.NoError(err)
+	require.NoError(proof.Verify(context.Background(), root, DefaultHasher, DefaultNodeEncoding))
+
+	for _, key := range proofKeys {
+		expectedValue, expectedErr := db.Get(key)
+		value, err := proof.GetValue(key)
+		require.ErrorIs(err, expectedErr)
+		require.True(bytes.Equal(expectedValue, value))
+
+		// The multi-proof must agree with the proof of each key.
+		singleProof, err := db.GetProof(context.Background(), key)
+		require.NoError(err)
+		for _, proofNode := range singleProof.Path {
+			require.Contains(proof.Nodes, proofNode)
+		}
+	}
+
+	_, err = db.GetMultiProof(context.Background(), nil)
+	require.ErrorIs(err, ErrNoKeys)
+}
+
+func Test_MultiProof_Invalid(t *testing.T) {
+	r := rand.New(rand.NewSource(1)) // #nosec G404
+
+	db, keys := newRandomMultiProofDB(t, r, 100)
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(t, err)
+	proofKeys := [][]byte{keys[0], keys[1], keys[2], []byte("not in the trie")}
+
+	type test struct {
+		name        string
+		modify      func(*MultiProof)
+		rootID      ids.ID
+		expectedErr error
+	}
+	tests := []test{
+		{
+			name:        "wrong root",
+			modify:      func(*MultiProof) {},
+			rootID:      ids.GenerateTestID(),
+			expectedErr: ErrInvalidProof,
+		},
+		{
+			name: "no nodes",
+			modify: func(proof *MultiProof) {
+				proof.Nodes = nil
+			},
+			rootID:      root,
+			expectedErr: ErrNoProof,
+		},
+		{
+			name: "no keys",
+			modify: func(proof *MultiProof) {
+				proof.Keys = nil
+			},
+			rootID:      root,
+			expectedErr: ErrNoKeys,
+		},
+		{
+			name: "no root",
+			modify: func(proof *MultiProof) {
+				proof.Nodes = proof.Nodes[1:]
+			},
+			rootID:      root,
+			expectedErr: ErrNoRootProofNode,
+		},
+		{
+			name: "unsorted nodes",
+			modify: func(proof *MultiProof) {
+				last := len(proof.Nodes) - 1
+				proof.Nodes[last], proof.Nodes[last-1] = proof.Nodes[last-1], proof.Nodes[last]
+			},
+			rootID:      root,
+			expectedErr: ErrUnsortedProofNodes,
+		},
+		{
+			name: "unsorted keys",
+			modify: func(proof *MultiProof) {
+				proof.Keys[0], proof.Keys[1] = proof.Keys[1], proof.Keys[0]
+			},
+			rootID:      root,
+			expectedErr: ErrUnsortedMultiProofKeys,
+		},
+		{
+			name: "missing node",
+			modify: func(proof *MultiProof) {
+				proof.Nodes = proof.Nodes[:len(proof.Nodes)-1]
+			},
+			rootID:      root,
+			expectedErr: ErrMissingProofNode,
+		},
+		{
+			name: "unproven key",
+			modify: func(proof *MultiProof) {
+				// The greatest key in the trie.
+				sortedKeys := sortedUniqueKeys(keys)
+				proof.Keys = append(proof.Keys, sortedKeys[len(sortedKeys)-1])
+			},
+			rootID:      root,
+			expectedErr: ErrMissingProofNode,
+		},
+		{
+			name: "modified value",
+			modify: func(proof *MultiProof) {
+				for i, proofNode := range proof.Nodes {
+					if proofNode.Value.IsNothing() {
+						continue
+					}
+					proof.Nodes[i].Value = Some(append(proofNode.Value.Value(), 0))
+					return
+				}
+			},
+			rootID:      root,
+			expectedErr: ErrInvalidProof,
+		},
+		{
+			name: "removed value",
+			modify: func(proof *MultiProof) {
+				for i, proofNode := range proof.Nodes {
+					if proofNode.Value.IsNothing() {
+						continue
+					}
+					proof.Nodes[i].Value = Nothing[[]byte]()
+					return
+				}
+			},
+			rootID:      root,
+			expectedErr: ErrInvalidProof,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proof, err := db.GetMultiProof(context.Background(), proofKeys)
+			require.NoError(t, err)
+			tt.modify(proof)
+			require.ErrorIs(t, proof.Verify(context.Background(), tt.rootID, DefaultHasher, DefaultNodeEncoding), tt.expectedErr)
+		})
+	}
+}
+
+func Test_MultiProof_View(t *testing.T) {
+	require := require.New(t)
+
+	db, err := newDatabase(
+		context.Background(),
+		memdb.New(),
+		Config{
+			Tracer:         newNoopTracer(),
+			ValueCacheSize: minCacheSize,
+			NodeCacheSize:  minCacheSize,
+		},
+		&mockMetrics{},
+	)
+	require.NoError(err)
+	view, err := db.NewView(context.Background())
+	require.NoError(err)
+	require.NoError(view.Insert(context.Background(), []byte("key0"), []byte("value0")))
+	require.NoError(view.Insert(context.Background(), []byte("key1"), []byte("value1")))
+	require.NoError(view.Insert(context.Background(), []byte("key10"), []byte("value10")))
+
+	root, err := view.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	proof, err := view.GetMultiProof(context.Background(), [][]byte{[]byte("key10"), []byte("key"), []byte("key1")})
+	require.NoError(err)
+	require.Equal([][]byte{[]byte("key"), []byte("key1"), []byte("key10")}, proof.Keys)
+	require.NoError(proof.Verify(context.Background(), root, DefaultHasher, DefaultNodeEncoding))
+
+	_, err = proof.GetValue([]byte("key"))
+	require.ErrorIs(err, database.ErrNotFound)
+	value, err := proof.GetValue([]byte("key10"))
+	require.NoError(err)
+	require.Equal([]byte("value10"), value)
+}
+
+func Test_MultiProof_Marshal(t *testing.T) {
+	require := require.New(t)
+
+	r := rand.New(rand.NewSource(1)) // #nosec G404
+	db, keys := newRandomMultiProofDB(t, r, 100)
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	proof, err := db.GetMultiProof(context.Background(), keys[:10])
+	require.NoError(err)
+
+	proofBytes, err := Codec.EncodeMultiProof(Version, proof)
+	require.NoError(err)
+
+	parsedProof := &MultiProof{}
+	_, err = Codec.DecodeMultiProof(proofBytes, parsedProof)
+	require.NoError(err)
+	require.Equal(proof.Keys, parsedProof.Keys)
+	verifyPath(t, proof.Nodes, parsedProof.Nodes)
+	require.NoError(parsedProof.Verify(context.Background(), root, DefaultHasher, DefaultNodeEncoding))
+
+	_, err = Codec.DecodeMultiProof(proofBytes[:len(proofBytes)-1], parsedProof)
+	require.ErrorIs(err, io.ErrUnexpectedEOF)
+	_, err = Codec.DecodeMultiProof(append(proofBytes, 0), parsedProof)
+	require.ErrorIs(err, errExtraSpace)
+}
+
+func Benchmark_MultiProof(b *testing.B) {
+	r := rand.New(rand.NewSource(1)) // #nosec G404
+	db, keys := newRandomMultiProofDB(b, r, 10_000)
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(b, err)
+
+	for _, numKeys := range []int{1, 10, 100, 1000} {
+		proofKeys := keys[:numKeys]
+
+		b.Run(fmt.Sprintf("multi_proof_%d_keys", numKeys), func(b *testing.B) {
+			size := 0
+			for i := 0; i < b.N; i++ {
+				proof, err := db.GetMultiProof(context.Background(), proofKeys)
+				require.NoError(b, err)
+				require.NoError(b, proof.Verify(context.Background(), root, DefaultHasher, DefaultNodeEncoding))
+				proofBytes, err := Codec.EncodeMultiProof(Version, proof)
+				require.NoError(b, err)
+				size = len(proofBytes)
+			}
+			b.ReportMetric(float64(size), "proof_bytes")
+		})
+
+		b.Run(fmt.Sprintf("single_proofs_%d_keys", numKeys), func(b *testing.B) {
+			size := 0
+			for i := 0; i < b.N; i++ {
+				size = 0
+				for _, key := range proofKeys {
+					proof, err := db.GetProof(context.Background(), key)
+					require.NoError(b, err)
+					require.NoError(b, proof.Verify(context.Background(), root, DefaultHasher, DefaultNodeEncoding))
+					proofBytes, err := Codec.EncodeProof(Version, proof)
+					require.NoError(b, err)
+					size += len(proofBytes)
+				}
+			}
+			b.ReportMetric(float64(size), "proof_bytes")
+		})
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"golang.org/x/exp/maps"
 
 	"github.com/dioneprotocol/dionego/ids"
-	"github.com/dioneprotocol/dionego/utils/hashing"
 )
 
 const NodeBranchFactor = 16
@@ -53,10 +52,10 @@ func newNode(parent *node, key path) *node {
 	return newNode
 }
 
-// Parse [nodeBytes] to a node and set its key to [key].
-func parseNode(key path, nodeBytes []byte) (*node, error) {
+// Parse [nodeBytes], encoded with [encoding], to a node and set its key to [key].
+func parseNode(encoding NodeEncoding, key path, nodeBytes []byte) (*node, error) {
 	n := dbNode{}
-	if _, err := Codec.decodeDBNode(nodeBytes, &n); err != nil {
+	if _, err := encoding.decodeDBNode(nodeBytes, &n); err != nil {
 		return nil, err
 	}
 	return &node{
@@ -72,12 +71,13 @@ func (n *node) hasValue() bool {
 }
 
 // Returns the byte representation of this node.
-func (n *node) marshal() ([]byte, error) {
+// [encoding] must be the same each time this is called.
+func (n *node) marshal(encoding NodeEncoding) ([]byte, error) {
 	if n.nodeBytes != nil {
 		return n.nodeBytes, nil
 	}
 
-	nodeBytes, err := Codec.encodeDBNode(Version, &(n.dbNode))
+	nodeBytes, err := encoding.encodeDBNode(Version, &(n.dbNode))
 	if err != nil {
 		return nil, err
 	}
@@ -92,8 +92,9 @@ func (n *node) onNodeChanged() {
 	n.nodeBytes = nil
 }
 
-// Returns and caches the ID of this node.
-func (n *node) calculateID(metrics merkleMetrics) error {
+// Returns and caches the ID of this node, which is the hash by [hasher] of
+// its hash values encoded with [encoding].
+func (n *node) calculateID(encoding NodeEncoding, hasher Hasher, metrics merkleMetrics) error {
 	if n.id != ids.Empty {
 		return nil
 	}
@@ -103,13 +104,13 @@ func (n *node) calculateID(metrics merkleMetrics) error {
 		Value:    n.value,
 		Key:      n.key.Serialize(),
 	}
-	bytes, err := Codec.encodeHashValues(Version, hv)
+	bytes, err := encoding.encodeHashValues(Version, hv)
 	if err != nil {
 		return err
 	}
 
 	metrics.HashCalculated()
-	n.id = hashing.ComputeHash256Array(bytes)
+	n.id = hasher.Hash(bytes)
 	return nil
 }
 
@@ -19,13 +19,13 @@ func Test_Node_Marshal(t *testing.T) {
 	childNode.setValue(Some([]byte("value")))
 	require.NotNil(t, childNode)
 
-	err := childNode.calculateID(&mockMetrics{})
+	err := childNode.calculateID(DefaultNodeEncoding, DefaultHasher, &mockMetrics{})
 	require.NoError(t, err)
 	root.addChild(childNode)
 
-	data, err := root.marshal()
+	data, err := root.marshal(DefaultNodeEncoding)
 	require.NoError(t, err)
-	rootParsed, err := parseNode(newPath([]byte("")), data)
+	rootParsed, err := parseNode(DefaultNodeEncoding, newPath([]byte("")), data)
 	require.NoError(t, err)
 	require.Equal(t, 1, len(rootParsed.children))
 
@@ -45,7 +45,7 @@ func Test_Node_Marshal_Errors(t *testing.T) {
 	childNode1.setValue(Some([]byte("value1")))
 	require.NotNil(t, childNode1)
 
-	err := childNode1.calculateID(&mockMetrics{})
+	err := childNode1.calculateID(DefaultNodeEncoding, DefaultHasher, &mockMetrics{})
 	require.NoError(t, err)
 	root.addChild(childNode1)
 
@@ -54,16 +54,16 @@ func Test_Node_Marshal_Errors(t *testing.T) {
 	childNode2.setValue(Some([]byte("value2")))
 	require.NotNil(t, childNode2)
 
-	err = childNode2.calculateID(&mockMetrics{})
+	err = childNode2.calculateID(DefaultNodeEncoding, DefaultHasher, &mockMetrics{})
 	require.NoError(t, err)
 	root.addChild(childNode2)
 
-	data, err := root.marshal()
+	data, err := root.marshal(DefaultNodeEncoding)
 	require.NoError(t, err)
 
 	for i := 1; i < len(data); i++ {
 		broken := data[:i]
-		_, err = parseNode(newPath([]byte("")), broken)
+		_, err = parseNode(DefaultNodeEncoding, newPath([]byte("")), broken)
 		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
 	}
 }
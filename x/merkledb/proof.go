@@ -48,10 +48,11 @@ type Proof struct {
 	Key []byte
 }
 
-// Returns nil if the trie given in [proof] has root [expectedRootID].
+// Returns nil if the trie given in [proof] has root [expectedRootID] when its
+// nodes are hashed with [hasher] and [encoding].
 // That is, this is a valid proof that [proof.Key] exists/doesn't exist
 // in the trie with root [expectedRootID].
-func (proof *Proof) Verify(ctx context.Context, expectedRootID ids.ID) error {
+func (proof *Proof) Verify(ctx context.Context, expectedRootID ids.ID, hasher Hasher, encoding NodeEncoding) error {
 	// Make sure the proof is well-formed.
 	if len(proof.Path) == 0 {
 		return ErrNoProof
@@ -60,20 +61,7 @@ func (proof *Proof) Verify(ctx context.Context, expectedRootID ids.ID) error {
 		return err
 	}
 
-	tracer, err := trace.New(trace.Config{Enabled: false})
-	if err != nil {
-		return err
-	}
-	db, err := newDatabase(
-		ctx,
-		memdb.New(),
-		Config{
-			Tracer:         tracer,
-			ValueCacheSize: verificationCacheSize,
-			NodeCacheSize:  verificationCacheSize,
-		},
-		&mockMetrics{},
-	)
+	db, err := newVerificationDatabase(ctx, hasher, encoding)
 	if err != nil {
 		return err
 	}
@@ -103,6 +91,27 @@ func (proof *Proof) Verify(ctx context.Context, expectedRootID ids.ID) error {
 	return nil
 }
 
+// Returns an empty database that hashes nodes with [hasher] and [encoding]
+// to verify proofs with. Nil values are replaced by the defaults.
+func newVerificationDatabase(ctx context.Context, hasher Hasher, encoding NodeEncoding) (*Database, error) {
+	tracer, err := trace.New(trace.Config{Enabled: false})
+	if err != nil {
+		return nil, err
+	}
+	return newDatabase(
+		ctx,
+		memdb.New(),
+		Config{
+			Tracer:         tracer,
+			ValueCacheSize: verificationCacheSize,
+			NodeCacheSize:  verificationCacheSize,
+			Hasher:         hasher,
+			NodeEncoding:   encoding,
+		},
+		&mockMetrics{},
+	)
+}
+
 type KeyValue struct {
 	Key   []byte
 	Value []byte
@@ -141,12 +150,14 @@ type RangeProof struct {
 //       [proof.EndProof] is just the root.
 //     - [end] is non-empty and [proof.EndProof] is a valid proof of a key <= [end].
 //  - [expectedRootID] is the root of the trie containing the given key-value pairs
-//    and start/end proofs.
+//    and start/end proofs when its nodes are hashed with [hasher] and [encoding].
 func (proof *RangeProof) Verify(
 	ctx context.Context,
 	start []byte,
 	end []byte,
 	expectedRootID ids.ID,
+	hasher Hasher,
+	encoding NodeEncoding,
 ) error {
 	switch {
 	case len(end) > 0 && bytes.Compare(start, end) > 0:
@@ -186,20 +197,7 @@ func (proof *RangeProof) Verify(
 		}
 	}
 
-	tracer, err := trace.New(trace.Config{Enabled: false})
-	if err != nil {
-		return err
-	}
-	db, err := newDatabase(
-		ctx,
-		memdb.New(),
-		Config{
-			Tracer:         tracer,
-			ValueCacheSize: verificationCacheSize,
-			NodeCacheSize:  verificationCacheSize,
-		},
-		&mockMetrics{},
-	)
+	db, err := newVerificationDatabase(ctx, hasher, encoding)
 	if err != nil {
 		return err
 	}
@@ -61,7 +61,7 @@ func Test_Proof_Marshal(t *testing.T) {
 
 func Test_Proof_Empty(t *testing.T) {
 	proof := &Proof{}
-	err := proof.Verify(context.Background(), ids.Empty)
+	err := proof.Verify(context.Background(), ids.Empty, DefaultHasher, DefaultNodeEncoding)
 	require.ErrorIs(t, err, ErrNoProof)
 }
 
@@ -207,6 +207,8 @@ func Test_RangeProof_Extra_State(t *testing.T) {
 		[]byte("key1"),
 		[]byte("key55"),
 		db.root.id,
+		DefaultHasher,
+		DefaultNodeEncoding,
 	)
 	require.NoError(t, err)
 
@@ -218,6 +220,8 @@ func Test_RangeProof_Extra_State(t *testing.T) {
 		[]byte("key1"),
 		[]byte("key55"),
 		db.root.id,
+		DefaultHasher,
+		DefaultNodeEncoding,
 	)
 	require.ErrorIs(t, err, ErrInvalidProof)
 }
@@ -290,12 +294,12 @@ func Test_Proof(t *testing.T) {
 
 	expectedRootID, err := trie.GetMerkleRoot(context.Background())
 	require.NoError(t, err)
-	err = proof.Verify(context.Background(), expectedRootID)
+	err = proof.Verify(context.Background(), expectedRootID, DefaultHasher, DefaultNodeEncoding)
 	require.NoError(t, err)
 
 	proof.Path[2].Value = Some([]byte("value2"))
 
-	err = proof.Verify(context.Background(), expectedRootID)
+	err = proof.Verify(context.Background(), expectedRootID, DefaultHasher, DefaultNodeEncoding)
 	require.ErrorIs(t, err, ErrInvalidProof)
 }
 
@@ -464,7 +468,7 @@ func Test_RangeProof_Syntactic_Verify(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			require := require.New(t)
 
-			err := tt.proof.Verify(context.Background(), tt.start, tt.end, ids.Empty)
+			err := tt.proof.Verify(context.Background(), tt.start, tt.end, ids.Empty, DefaultHasher, DefaultNodeEncoding)
 			require.ErrorIs(err, tt.expectedErr)
 		})
 	}
@@ -521,6 +525,8 @@ func Test_RangeProof(t *testing.T) {
 		[]byte("key1"),
 		[]byte("key35"),
 		db.root.id,
+		DefaultHasher,
+		DefaultNodeEncoding,
 	)
 	require.NoError(err)
 }
@@ -606,6 +612,8 @@ func Test_RangeProof_NilStart(t *testing.T) {
 		nil,
 		[]byte("key35"),
 		db.root.id,
+		DefaultHasher,
+		DefaultNodeEncoding,
 	)
 	require.NoError(t, err)
 }
@@ -661,6 +669,8 @@ func Test_RangeProof_NilEnd(t *testing.T) {
 		[]byte("key1"),
 		nil,
 		db.root.id,
+		DefaultHasher,
+		DefaultNodeEncoding,
 	)
 	require.NoError(t, err)
 }
@@ -715,6 +725,8 @@ func Test_RangeProof_EmptyValues(t *testing.T) {
 		[]byte("key1"),
 		[]byte("key2"),
 		db.root.id,
+		DefaultHasher,
+		DefaultNodeEncoding,
 	)
 	require.NoError(t, err)
 }
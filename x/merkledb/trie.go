@@ -47,6 +47,9 @@ type ReadOnlyTrie interface {
 	// generate a proof of the value associated with a particular key, or a proof of its absence from the trie
 	GetProof(ctx context.Context, bytesPath []byte) (*Proof, error)
 
+	// generate a single proof of the values associated with the given keys, or of their absence from the trie
+	GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error)
+
 	// generate a proof of up to maxLength smallest key/values with keys between start and end
 	GetRangeProof(ctx context.Context, start, end []byte, maxLength int) (*RangeProof, error)
 
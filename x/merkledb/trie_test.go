@@ -198,7 +198,7 @@ func Test_Trie_WriteToDB(t *testing.T) {
 	p := newPath([]byte("key"))
 	rawBytes, err := dbTrie.nodeDB.Get(p.Bytes())
 	require.NoError(t, err)
-	node, err := parseNode(p, rawBytes)
+	node, err := parseNode(DefaultNodeEncoding, p, rawBytes)
 	require.NoError(t, err)
 	require.Equal(t, []byte("value"), node.value.value)
 }
@@ -281,6 +281,39 @@ func (t *trieView) GetProof(ctx context.Context, key []byte) (*Proof, error) {
 	return t.getProof(ctx, key)
 }
 
+// Returns a proof that each of [keys] is in or not in trie [t].
+func (t *trieView) GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error) {
+	ctx, span := t.db.tracer.Start(ctx, "MerkleDB.trieview.GetMultiProof")
+	defer span.End()
+
+	t.lockStack()
+	defer t.unlockStack()
+
+	if err := t.calculateIDs(ctx); err != nil {
+		return nil, err
+	}
+	return t.getMultiProof(ctx, keys)
+}
+
+// Returns a proof that each of [keys] is in or not in trie [t].
+// Assumes this view stack is locked.
+func (t *trieView) getMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	keys = sortedUniqueKeys(keys)
+	proofs := make([]*Proof, len(keys))
+	for i, key := range keys {
+		proof, err := t.getProof(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		proofs[i] = proof
+	}
+	return newMultiProof(keys, proofs), nil
+}
+
 // Returns a proof that [bytesPath] is in or not in trie [t].
 // Assumes this view stack is locked.
 func (t *trieView) getProof(ctx context.Context, key []byte) (*Proof, error) {
@@ -1011,7 +1044,7 @@ func (t *trieView) calculateIDsConcurrent(
 		eg.Go(func() error {
 			defer allHashed.Done()
 			for currentNode := range readyNodesChan {
-				if err := currentNode.calculateID(t.db.metrics); err != nil {
+				if err := currentNode.calculateID(t.db.nodeEncoding, t.db.hasher, t.db.metrics); err != nil {
 					return err
 				}
 
@@ -1085,7 +1118,7 @@ func (t *trieView) calculateIDsSync(
 	for len(readyNodes) > 0 {
 		for key, currentNode := range readyNodes {
 			delete(readyNodes, key)
-			if err := currentNode.calculateID(t.db.metrics); err != nil {
+			if err := currentNode.calculateID(t.db.nodeEncoding, t.db.hasher, t.db.metrics); err != nil {
 				return err
 			}
 
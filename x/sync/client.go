@@ -50,6 +50,8 @@ type client struct {
 	stateSyncMinVersion *version.Application
 	log                 logging.Logger
 	metrics             SyncMetrics
+	hasher              merkledb.Hasher
+	nodeEncoding        merkledb.NodeEncoding
 }
 
 type ClientConfig struct {
@@ -58,6 +60,10 @@ type ClientConfig struct {
 	StateSyncMinVersion *version.Application
 	Log                 logging.Logger
 	Metrics             SyncMetrics
+	// The hasher and node encoding of the database being synced, used to
+	// verify range proofs. The merkledb defaults are used if nil.
+	Hasher       merkledb.Hasher
+	NodeEncoding merkledb.NodeEncoding
 }
 
 func NewClient(config *ClientConfig) Client {
@@ -67,6 +73,8 @@ func NewClient(config *ClientConfig) Client {
 		stateSyncMinVersion: config.StateSyncMinVersion,
 		log:                 config.Log,
 		metrics:             config.Metrics,
+		hasher:              config.Hasher,
+		nodeEncoding:        config.NodeEncoding,
 	}
 	return c
 }
@@ -115,6 +123,8 @@ func (c *client) GetRangeProof(ctx context.Context, req *RangeProofRequest) (*me
 			req.Start,
 			req.End,
 			req.Root,
+			c.hasher,
+			c.nodeEncoding,
 		); err != nil {
 			return nil, fmt.Errorf("%s due to %w", errInvalidRangeProof, err)
 		}
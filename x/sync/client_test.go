@@ -12,6 +12,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/stretchr/testify/require"
 
 	"github.com/dioneprotocol/dionego/ids"
@@ -39,10 +41,11 @@ func sendRequest(
 	defer ctrl.Finish()
 
 	sender := common.NewMockSender(ctrl)
-	handler := NewNetworkServer(sender, db, logging.NoLog{})
+	handler, err := NewNetworkServer(sender, db, logging.NoLog{}, NetworkServerConfig{}, "", prometheus.NewRegistry())
+	require.NoError(err)
 	clientNodeID, serverNodeID := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
 	networkClient := NewNetworkClient(sender, clientNodeID, 1, logging.NoLog{})
-	err := networkClient.Connected(context.Background(), serverNodeID, version.CurrentApp)
+	err = networkClient.Connected(context.Background(), serverNodeID, version.CurrentApp)
 	require.NoError(err)
 	client := NewClient(&ClientConfig{
 		NetworkClient: networkClient,
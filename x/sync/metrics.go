@@ -94,3 +94,46 @@ func (m *metrics) RequestMade() {
 func (m *metrics) RequestSucceeded() {
 	m.requestsSucceeded.Inc()
 }
+
+type serverMetrics struct {
+	requestsServed  prometheus.Counter
+	bytesServed     prometheus.Counter
+	processingTime  prometheus.Counter
+	requestsDropped *prometheus.CounterVec
+}
+
+func newServerMetrics(namespace string, reg prometheus.Registerer) (*serverMetrics, error) {
+	m := serverMetrics{
+		requestsServed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_served",
+			Help:      "cumulative amount of proof requests handled for peers",
+		}),
+		bytesServed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bytes_served",
+			Help:      "cumulative amount of bytes sent in proof responses to peers",
+		}),
+		processingTime: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "request_processing_time",
+			Help:      "cumulative time (in ns) spent handling proof requests for peers",
+		}),
+		requestsDropped: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "requests_dropped",
+				Help:      "cumulative amount of proof requests from peers dropped due to rate limiting",
+			},
+			[]string{"reason"},
+		),
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.requestsServed),
+		reg.Register(m.bytesServed),
+		reg.Register(m.processingTime),
+		reg.Register(m.requestsDropped),
+	)
+	return &m, errs.Err
+}
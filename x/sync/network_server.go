@@ -9,6 +9,8 @@ import (
 	"errors"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"go.uber.org/zap"
 
 	"google.golang.org/grpc/codes"
@@ -31,14 +33,32 @@ type NetworkServer struct {
 	appSender common.AppSender // Used to respond to peer requests via AppResponse.
 	db        *merkledb.Database
 	log       logging.Logger
+	throttler *serverThrottler
+	metrics   *serverMetrics
 }
 
-func NewNetworkServer(appSender common.AppSender, db *merkledb.Database, log logging.Logger) *NetworkServer {
+func NewNetworkServer(
+	appSender common.AppSender,
+	db *merkledb.Database,
+	log logging.Logger,
+	config NetworkServerConfig,
+	namespace string,
+	reg prometheus.Registerer,
+) (*NetworkServer, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	metrics, err := newServerMetrics(namespace, reg)
+	if err != nil {
+		return nil, err
+	}
 	return &NetworkServer{
 		appSender: appSender,
 		db:        db,
 		log:       log,
-	}
+		throttler: newServerThrottler(config),
+		metrics:   metrics,
+	}, nil
 }
 
 // AppRequest is called by dionego -> VM when there is an incoming AppRequest from a peer.
@@ -87,6 +107,26 @@ func (s *NetworkServer) AppRequest(
 		return nil
 	}
 
+	release, reason := s.throttler.acquire(nodeID)
+	if reason != "" {
+		s.log.Debug(
+			"dropping AppRequest due to rate limiting",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Stringer("req", req),
+			zap.String("reason", reason),
+		)
+		s.metrics.requestsDropped.WithLabelValues(reason).Inc()
+		return nil
+	}
+	startTime := s.throttler.clock.Time()
+	defer func() {
+		processingTime := s.throttler.clock.Time().Sub(startTime)
+		release(processingTime)
+		s.metrics.requestsServed.Inc()
+		s.metrics.processingTime.Add(float64(processingTime))
+	}()
+
 	// TODO danlaine: Why don't we use the passed in context instead of [context.Background()]?
 	handleCtx, cancel := context.WithDeadline(context.Background(), bufferedDeadline)
 	defer cancel()
@@ -118,6 +158,13 @@ func isTimeout(err error) bool {
 	return errors.Is(err, context.DeadlineExceeded)
 }
 
+// Sends [response] to [nodeID] and charges it to [nodeID]'s bandwidth budget.
+func (s *NetworkServer) sendResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, response []byte) error {
+	s.throttler.consumeBandwidth(nodeID, len(response))
+	s.metrics.bytesServed.Add(float64(len(response)))
+	return s.appSender.SendAppResponse(ctx, nodeID, requestID, response)
+}
+
 // Generates a change proof and sends it to [nodeID].
 func (s *NetworkServer) HandleChangeProofRequest(
 	ctx context.Context,
@@ -161,7 +208,7 @@ func (s *NetworkServer) HandleChangeProofRequest(
 	if err != nil {
 		return err
 	}
-	return s.appSender.SendAppResponse(ctx, nodeID, requestID, proofBytes)
+	return s.sendResponse(ctx, nodeID, requestID, proofBytes)
 }
 
 // Generates a range proof and sends it to [nodeID].
@@ -208,5 +255,5 @@ func (s *NetworkServer) HandleRangeProofRequest(
 	if err != nil {
 		return err
 	}
-	return s.appSender.SendAppResponse(ctx, nodeID, requestID, proofBytes)
+	return s.sendResponse(ctx, nodeID, requestID, proofBytes)
 }
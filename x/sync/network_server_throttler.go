@@ -0,0 +1,324 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/snow/validators"
+	"github.com/dioneprotocol/dionego/utils/math"
+	"github.com/dioneprotocol/dionego/utils/timer/mockable"
+)
+
+// Reasons a request is dropped by the serverThrottler.
+const (
+	dropReasonTooManyRequests = "too_many_requests"
+	dropReasonPeerBandwidth   = "peer_bandwidth"
+	dropReasonPeerCPU         = "peer_cpu"
+	dropReasonBandwidth       = "bandwidth"
+	dropReasonCPU             = "cpu"
+)
+
+// If more than this many peers have budgets, the budgets of peers that
+// haven't used any of their allocation are forgotten.
+const maxTrackedPeerBudgets = 1024
+
+var (
+	errTooManyValidatorReservedRequests = errors.New("validator reserved requests must not exceed max processing requests")
+	errZeroMaxBurstSize                 = errors.New("max burst size must be at least 1 when the refill rate is non-zero")
+)
+
+// NetworkServerConfig limits the resources the NetworkServer uses to serve
+// proofs to peers. Requests that would exceed a limit are dropped.
+//
+// Bandwidth and CPU are token bucket budgets, as in network/throttling, that
+// are charged for each request after it's served. Bandwidth is the number of
+// bytes sent in responses. CPU is the time spent handling requests. A budget
+// with a refill rate of 0 is unlimited.
+//
+// As with the validator and at-large allocations of network/throttling, the
+// bandwidth and CPU shared by all peers are split into a budget that any peer
+// can use, and a budget reserved for validators. Validators use their reserved
+// budget once the shared budget is in debt, so non-validators can't use up
+// all of the bandwidth and CPU. A reserved budget with a refill rate of 0
+// reserves nothing.
+type NetworkServerConfig struct {
+	// Validators in [Validators] can use the [ValidatorReservedRequests]
+	// that other peers can't. Requests are otherwise handled in the order
+	// they are received, regardless of the peer that sent them. If nil, no
+	// peer can use the reserved requests.
+	Validators validators.Set `json:"-"`
+	// Max number of requests handled at once. 0 means unlimited.
+	MaxProcessingRequests int `json:"maxProcessingRequests"`
+	// Number of the [MaxProcessingRequests] that only validators can use.
+	ValidatorReservedRequests int `json:"validatorReservedRequests"`
+
+	// Rate, in bytes per second, at which a peer's bandwidth budget refills.
+	PeerBandwidthRefillRate uint64 `json:"peerBandwidthRefillRate"`
+	// Max number of bytes that can accumulate in a peer's bandwidth budget.
+	PeerBandwidthMaxBurstSize uint64 `json:"peerBandwidthMaxBurstSize"`
+	// Rate, in bytes per second, at which the bandwidth budget shared by all
+	// peers refills.
+	BandwidthRefillRate uint64 `json:"bandwidthRefillRate"`
+	// Max number of bytes that can accumulate in the bandwidth budget shared
+	// by all peers.
+	BandwidthMaxBurstSize uint64 `json:"bandwidthMaxBurstSize"`
+	// Rate, in bytes per second, at which the bandwidth budget reserved for
+	// validators refills.
+	ValidatorBandwidthRefillRate uint64 `json:"validatorBandwidthRefillRate"`
+	// Max number of bytes that can accumulate in the bandwidth budget reserved
+	// for validators.
+	ValidatorBandwidthMaxBurstSize uint64 `json:"validatorBandwidthMaxBurstSize"`
+
+	// Processing time per second at which a peer's CPU budget refills.
+	PeerCPURefillRate time.Duration `json:"peerCPURefillRate"`
+	// Max processing time that can accumulate in a peer's CPU budget.
+	PeerCPUMaxBurstSize time.Duration `json:"peerCPUMaxBurstSize"`
+	// Processing time per second at which the CPU budget shared by all peers
+	// refills.
+	CPURefillRate time.Duration `json:"cpuRefillRate"`
+	// Max processing time that can accumulate in the CPU budget shared by all
+	// peers.
+	CPUMaxBurstSize time.Duration `json:"cpuMaxBurstSize"`
+	// Processing time per second at which the CPU budget reserved for
+	// validators refills.
+	ValidatorCPURefillRate time.Duration `json:"validatorCPURefillRate"`
+	// Max processing time that can accumulate in the CPU budget reserved for
+	// validators.
+	ValidatorCPUMaxBurstSize time.Duration `json:"validatorCPUMaxBurstSize"`
+}
+
+func (c *NetworkServerConfig) Verify() error {
+	switch {
+	case c.MaxProcessingRequests > 0 && c.ValidatorReservedRequests > c.MaxProcessingRequests:
+		return errTooManyValidatorReservedRequests
+	case c.PeerBandwidthRefillRate > 0 && c.PeerBandwidthMaxBurstSize < 1:
+		return fmt.Errorf("%w: peer bandwidth", errZeroMaxBurstSize)
+	case c.BandwidthRefillRate > 0 && c.BandwidthMaxBurstSize < 1:
+		return fmt.Errorf("%w: bandwidth", errZeroMaxBurstSize)
+	case c.ValidatorBandwidthRefillRate > 0 && c.ValidatorBandwidthMaxBurstSize < 1:
+		return fmt.Errorf("%w: validator bandwidth", errZeroMaxBurstSize)
+	case c.PeerCPURefillRate > 0 && c.PeerCPUMaxBurstSize < 1:
+		return fmt.Errorf("%w: peer CPU", errZeroMaxBurstSize)
+	case c.CPURefillRate > 0 && c.CPUMaxBurstSize < 1:
+		return fmt.Errorf("%w: CPU", errZeroMaxBurstSize)
+	case c.ValidatorCPURefillRate > 0 && c.ValidatorCPUMaxBurstSize < 1:
+		return fmt.Errorf("%w: validator CPU", errZeroMaxBurstSize)
+	}
+	return nil
+}
+
+// A token bucket that refills at [refillRate] tokens per second up to
+// [maxBurstSize] tokens. Unlike typical uses of rate.Limiter, tokens are
+// consumed after the fact, because the cost of serving a request isn't known
+// until it has been served. This can put the budget into debt, in which case
+// nothing more can be consumed until it has refilled.
+// A nil budget is unlimited.
+type budget struct {
+	limiter *rate.Limiter
+}
+
+// Returns a full budget, or nil if [refillRate] is 0.
+func newBudget(refillRate float64, maxBurstSize uint64, now time.Time) *budget {
+	if refillRate == 0 {
+		return nil
+	}
+	limiter := rate.NewLimiter(rate.Limit(refillRate), int(maxBurstSize))
+	// The limiter starts full, but is otherwise lazily refilled relative to
+	// the last time it was used, so it must be initialized at [now].
+	limiter.ReserveN(now, 0)
+	return &budget{
+		limiter: limiter,
+	}
+}
+
+// Returns true if the budget isn't in debt at [now].
+func (b *budget) available(now time.Time) bool {
+	if b == nil {
+		return true
+	}
+	return b.limiter.ReserveN(now, 0).DelayFrom(now) == 0
+}
+
+// Returns true if the budget is full at [now].
+func (b *budget) full(now time.Time) bool {
+	if b == nil {
+		return true
+	}
+	r := b.limiter.ReserveN(now, b.limiter.Burst())
+	isFull := r.DelayFrom(now) == 0
+	r.CancelAt(now)
+	return isFull
+}
+
+func (b *budget) consume(now time.Time, amount uint64) {
+	if b == nil {
+		return
+	}
+	// A reservation can't be larger than the burst size, so larger amounts
+	// are consumed in multiple reservations, each of which can go further
+	// into debt.
+	burst := uint64(b.limiter.Burst())
+	for amount > 0 {
+		n := math.Min(amount, burst)
+		b.limiter.ReserveN(now, int(n))
+		amount -= n
+	}
+}
+
+// Returns the budget that a request from a peer is charged to: [shared], or,
+// if [shared] is in debt and the peer is a validator, [reserved]. Returns false
+// if neither is available to the peer. Unlike other budgets, a nil [reserved]
+// budget reserves nothing.
+func chooseBudget(now time.Time, shared, reserved *budget, isValidator bool) (*budget, bool) {
+	switch {
+	case shared.available(now):
+		return shared, true
+	case isValidator && reserved != nil && reserved.available(now):
+		return reserved, true
+	default:
+		return shared, false
+	}
+}
+
+type peerBudgets struct {
+	bandwidth *budget
+	cpu       *budget
+}
+
+// serverThrottler decides which requests the NetworkServer handles.
+type serverThrottler struct {
+	config NetworkServerConfig
+	clock  mockable.Clock
+
+	lock sync.Mutex
+	// Number of requests being handled.
+	processingRequests int
+	// Number of requests from non-validators being handled.
+	processingNonValidatorRequests int
+	// Budgets shared by all peers.
+	bandwidth *budget
+	cpu       *budget
+	// Budgets reserved for validators, or nil if nothing is reserved.
+	validatorBandwidth *budget
+	validatorCPU       *budget
+	// Node ID --> The peer's budgets
+	peers map[ids.NodeID]*peerBudgets
+}
+
+func newServerThrottler(config NetworkServerConfig) *serverThrottler {
+	t := &serverThrottler{
+		config: config,
+		peers:  make(map[ids.NodeID]*peerBudgets),
+	}
+	now := t.clock.Time()
+	t.bandwidth = newBudget(float64(config.BandwidthRefillRate), config.BandwidthMaxBurstSize, now)
+	t.cpu = newBudget(float64(config.CPURefillRate), uint64(config.CPUMaxBurstSize), now)
+	t.validatorBandwidth = newBudget(float64(config.ValidatorBandwidthRefillRate), config.ValidatorBandwidthMaxBurstSize, now)
+	t.validatorCPU = newBudget(float64(config.ValidatorCPURefillRate), uint64(config.ValidatorCPUMaxBurstSize), now)
+	return t
+}
+
+// Returns the reason a request from [nodeID] should be dropped, or the empty
+// string if it should be handled. If the request is handled, the returned
+// function must be called with the time it took to handle once it's done.
+func (t *serverThrottler) acquire(nodeID ids.NodeID) (func(processingTime time.Duration), string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	isValidator := t.isValidator(nodeID)
+	if t.config.MaxProcessingRequests > 0 {
+		if t.processingRequests >= t.config.MaxProcessingRequests {
+			return nil, dropReasonTooManyRequests
+		}
+		maxNonValidatorRequests := t.config.MaxProcessingRequests - t.config.ValidatorReservedRequests
+		if !isValidator && t.processingNonValidatorRequests >= maxNonValidatorRequests {
+			return nil, dropReasonTooManyRequests
+		}
+	}
+
+	now := t.clock.Time()
+	peer := t.getPeer(nodeID, now)
+	_, bandwidthAvailable := chooseBudget(now, t.bandwidth, t.validatorBandwidth, isValidator)
+	_, cpuAvailable := chooseBudget(now, t.cpu, t.validatorCPU, isValidator)
+	switch {
+	case !peer.bandwidth.available(now):
+		return nil, dropReasonPeerBandwidth
+	case !peer.cpu.available(now):
+		return nil, dropReasonPeerCPU
+	case !bandwidthAvailable:
+		return nil, dropReasonBandwidth
+	case !cpuAvailable:
+		return nil, dropReasonCPU
+	}
+
+	t.processingRequests++
+	if !isValidator {
+		t.processingNonValidatorRequests++
+	}
+	return func(processingTime time.Duration) {
+		t.release(nodeID, isValidator, processingTime)
+	}, ""
+}
+
+func (t *serverThrottler) release(nodeID ids.NodeID, isValidator bool, processingTime time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.processingRequests--
+	if !isValidator {
+		t.processingNonValidatorRequests--
+	}
+
+	now := t.clock.Time()
+	t.getPeer(nodeID, now).cpu.consume(now, uint64(processingTime))
+	cpu, _ := chooseBudget(now, t.cpu, t.validatorCPU, isValidator)
+	cpu.consume(now, uint64(processingTime))
+}
+
+// Charges [numBytes] sent to [nodeID] to the bandwidth budgets.
+func (t *serverThrottler) consumeBandwidth(nodeID ids.NodeID, numBytes int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.clock.Time()
+	t.getPeer(nodeID, now).bandwidth.consume(now, uint64(numBytes))
+	bandwidth, _ := chooseBudget(now, t.bandwidth, t.validatorBandwidth, t.isValidator(nodeID))
+	bandwidth.consume(now, uint64(numBytes))
+}
+
+// Returns true if [nodeID] can use the budgets reserved for validators.
+func (t *serverThrottler) isValidator(nodeID ids.NodeID) bool {
+	return t.config.Validators != nil && t.config.Validators.Contains(nodeID)
+}
+
+// Returns the budgets of [nodeID], creating them if they don't exist.
+// Assumes [t.lock] is held.
+func (t *serverThrottler) getPeer(nodeID ids.NodeID, now time.Time) *peerBudgets {
+	if peer, ok := t.peers[nodeID]; ok {
+		return peer
+	}
+
+	if len(t.peers) >= maxTrackedPeerBudgets {
+		// Forgetting a full budget is the same as keeping it.
+		for peerID, peer := range t.peers {
+			if peer.bandwidth.full(now) && peer.cpu.full(now) {
+				delete(t.peers, peerID)
+			}
+		}
+	}
+
+	peer := &peerBudgets{
+		bandwidth: newBudget(float64(t.config.PeerBandwidthRefillRate), t.config.PeerBandwidthMaxBurstSize, now),
+		cpu:       newBudget(float64(t.config.PeerCPURefillRate), uint64(t.config.PeerCPUMaxBurstSize), now),
+	}
+	t.peers[nodeID] = peer
+	return peer
+}
@@ -0,0 +1,252 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/snow/validators"
+)
+
+// Returns the function to release the request from [nodeID], which must not be
+// dropped.
+func mustAcquire(t *testing.T, throttler *serverThrottler, nodeID ids.NodeID) func(time.Duration) {
+	release, reason := throttler.acquire(nodeID)
+	require.Empty(t, reason)
+	return release
+}
+
+// Returns the reason the request from [nodeID] is dropped.
+func dropReason(throttler *serverThrottler, nodeID ids.NodeID) string {
+	_, reason := throttler.acquire(nodeID)
+	return reason
+}
+
+func TestServerThrottlerUnlimited(t *testing.T) {
+	require := require.New(t)
+
+	throttler := newServerThrottler(NetworkServerConfig{})
+	nodeID := ids.GenerateTestNodeID()
+	releases := make([]func(time.Duration), 0, 100)
+	for i := 0; i < 100; i++ {
+		releases = append(releases, mustAcquire(t, throttler, nodeID))
+		throttler.consumeBandwidth(nodeID, 1024*1024)
+	}
+	for _, release := range releases {
+		release(time.Second)
+	}
+	require.Zero(throttler.processingRequests)
+	require.Zero(throttler.processingNonValidatorRequests)
+}
+
+func TestServerThrottlerMaxProcessingRequests(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := validators.NewSet()
+	vdrID := ids.GenerateTestNodeID()
+	require.NoError(vdrs.Add(vdrID, nil, ids.Empty, 1))
+	nonVdrID := ids.GenerateTestNodeID()
+
+	throttler := newServerThrottler(NetworkServerConfig{
+		Validators:                vdrs,
+		MaxProcessingRequests:     3,
+		ValidatorReservedRequests: 1,
+	})
+
+	// Non-validators can't use the reserved request.
+	releaseNonVdr := mustAcquire(t, throttler, nonVdrID)
+	_ = mustAcquire(t, throttler, nonVdrID)
+	require.Equal(dropReasonTooManyRequests, dropReason(throttler, nonVdrID))
+
+	// Validators can.
+	releaseVdr := mustAcquire(t, throttler, vdrID)
+	require.Equal(dropReasonTooManyRequests, dropReason(throttler, vdrID))
+
+	// A validator request doesn't count against the non-validator requests.
+	releaseNonVdr(0)
+	_ = mustAcquire(t, throttler, nonVdrID)
+	require.Equal(dropReasonTooManyRequests, dropReason(throttler, nonVdrID))
+
+	releaseVdr(0)
+	require.Equal(dropReasonTooManyRequests, dropReason(throttler, nonVdrID))
+	_ = mustAcquire(t, throttler, vdrID)
+}
+
+func TestServerThrottlerPeerBudgets(t *testing.T) {
+	require := require.New(t)
+
+	throttler := newServerThrottler(NetworkServerConfig{
+		PeerBandwidthRefillRate:   100,
+		PeerBandwidthMaxBurstSize: 1000,
+		PeerCPURefillRate:         100 * time.Millisecond,
+		PeerCPUMaxBurstSize:       time.Second,
+	})
+	now := time.Now()
+	throttler.clock.Set(now)
+
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+
+	// [nodeID1] goes into debt by sending a response larger than its budget.
+	release := mustAcquire(t, throttler, nodeID1)
+	throttler.consumeBandwidth(nodeID1, 1100)
+	release(0)
+	require.Equal(dropReasonPeerBandwidth, dropReason(throttler, nodeID1))
+
+	// Other peers aren't affected.
+	release = mustAcquire(t, throttler, nodeID2)
+	release(0)
+
+	// The budget refills over time.
+	now = now.Add(990 * time.Millisecond)
+	throttler.clock.Set(now)
+	require.Equal(dropReasonPeerBandwidth, dropReason(throttler, nodeID1))
+	now = now.Add(10 * time.Millisecond)
+	throttler.clock.Set(now)
+	release = mustAcquire(t, throttler, nodeID1)
+
+	// [nodeID1] goes into debt by using more than its CPU budget.
+	release(time.Second + 10*time.Millisecond)
+	require.Equal(dropReasonPeerCPU, dropReason(throttler, nodeID1))
+	now = now.Add(100 * time.Millisecond)
+	throttler.clock.Set(now)
+	release = mustAcquire(t, throttler, nodeID1)
+	release(0)
+}
+
+func TestServerThrottlerGlobalBudgets(t *testing.T) {
+	require := require.New(t)
+
+	throttler := newServerThrottler(NetworkServerConfig{
+		BandwidthRefillRate:   100,
+		BandwidthMaxBurstSize: 1000,
+		CPURefillRate:         100 * time.Millisecond,
+		CPUMaxBurstSize:       time.Second,
+	})
+	now := time.Now()
+	throttler.clock.Set(now)
+
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+
+	// The global budgets are shared by all peers.
+	release := mustAcquire(t, throttler, nodeID1)
+	throttler.consumeBandwidth(nodeID1, 1001)
+	release(0)
+	require.Equal(dropReasonBandwidth, dropReason(throttler, nodeID2))
+
+	now = now.Add(time.Second)
+	throttler.clock.Set(now)
+	release = mustAcquire(t, throttler, nodeID2)
+	release(time.Second + time.Millisecond)
+	require.Equal(dropReasonCPU, dropReason(throttler, nodeID1))
+}
+
+func TestServerThrottlerValidatorBudgets(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := validators.NewSet()
+	vdrID := ids.GenerateTestNodeID()
+	require.NoError(vdrs.Add(vdrID, nil, ids.Empty, 1))
+	nonVdrID := ids.GenerateTestNodeID()
+
+	throttler := newServerThrottler(NetworkServerConfig{
+		Validators:                     vdrs,
+		BandwidthRefillRate:            100,
+		BandwidthMaxBurstSize:          1000,
+		ValidatorBandwidthRefillRate:   100,
+		ValidatorBandwidthMaxBurstSize: 1000,
+		CPURefillRate:                  100 * time.Millisecond,
+		CPUMaxBurstSize:                time.Second,
+		ValidatorCPURefillRate:         100 * time.Millisecond,
+		ValidatorCPUMaxBurstSize:       time.Second,
+	})
+	now := time.Now()
+	throttler.clock.Set(now)
+
+	// A non-validator uses up the shared bandwidth budget.
+	release := mustAcquire(t, throttler, nonVdrID)
+	throttler.consumeBandwidth(nonVdrID, 1001)
+	release(0)
+	require.Equal(dropReasonBandwidth, dropReason(throttler, nonVdrID))
+
+	// Validators are still served from their reserved budget, which they
+	// then use up.
+	release = mustAcquire(t, throttler, vdrID)
+	throttler.consumeBandwidth(vdrID, 1001)
+	release(0)
+	require.Equal(dropReasonBandwidth, dropReason(throttler, vdrID))
+	require.Equal(dropReasonBandwidth, dropReason(throttler, nonVdrID))
+
+	// The same goes for the CPU budgets.
+	now = now.Add(20 * time.Second)
+	throttler.clock.Set(now)
+	release = mustAcquire(t, throttler, nonVdrID)
+	release(time.Second + time.Millisecond)
+	require.Equal(dropReasonCPU, dropReason(throttler, nonVdrID))
+	release = mustAcquire(t, throttler, vdrID)
+	release(time.Second + time.Millisecond)
+	require.Equal(dropReasonCPU, dropReason(throttler, vdrID))
+}
+
+func TestServerThrottlerForgetsFullBudgets(t *testing.T) {
+	require := require.New(t)
+
+	throttler := newServerThrottler(NetworkServerConfig{
+		PeerBandwidthRefillRate:   100,
+		PeerBandwidthMaxBurstSize: 1000,
+	})
+	now := time.Now()
+	throttler.clock.Set(now)
+
+	inDebtNodeID := ids.GenerateTestNodeID()
+	release := mustAcquire(t, throttler, inDebtNodeID)
+	throttler.consumeBandwidth(inDebtNodeID, 2000)
+	release(0)
+
+	for i := 1; i < maxTrackedPeerBudgets; i++ {
+		release := mustAcquire(t, throttler, ids.GenerateTestNodeID())
+		release(0)
+	}
+	require.Len(throttler.peers, maxTrackedPeerBudgets)
+
+	// Only the budget that isn't full is kept.
+	release = mustAcquire(t, throttler, ids.GenerateTestNodeID())
+	release(0)
+	require.Len(throttler.peers, 2)
+	require.Equal(dropReasonPeerBandwidth, dropReason(throttler, inDebtNodeID))
+}
+
+func TestNetworkServerConfigVerify(t *testing.T) {
+	require := require.New(t)
+
+	config := NetworkServerConfig{
+		MaxProcessingRequests:     1,
+		ValidatorReservedRequests: 2,
+	}
+	require.ErrorIs(config.Verify(), errTooManyValidatorReservedRequests)
+
+	config.ValidatorReservedRequests = 1
+	require.NoError(config.Verify())
+
+	// A budget that refills must be able to hold a token.
+	config.PeerBandwidthRefillRate = 1
+	require.ErrorIs(config.Verify(), errZeroMaxBurstSize)
+	config.PeerBandwidthMaxBurstSize = 1
+	require.NoError(config.Verify())
+
+	config.CPURefillRate = time.Millisecond
+	require.ErrorIs(config.Verify(), errZeroMaxBurstSize)
+	config.CPUMaxBurstSize = time.Second
+	require.NoError(config.Verify())
+
+	config.ValidatorBandwidthRefillRate = 1
+	require.ErrorIs(config.Verify(), errZeroMaxBurstSize)
+	config.ValidatorBandwidthMaxBurstSize = 1
+	require.NoError(config.Verify())
+}
@@ -13,14 +13,22 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/dioneprotocol/dionego/database"
 	"github.com/dioneprotocol/dionego/ids"
 	"github.com/dioneprotocol/dionego/utils/logging"
+	"github.com/dioneprotocol/dionego/utils/set"
 	"github.com/dioneprotocol/dionego/x/merkledb"
 )
 
 const (
 	defaultLeafRequestLimit = 1024
 	maxTokenWaitTime        = 5 * time.Second
+
+	// Sync progress is persisted once this many work items have been
+	// completed since it was last persisted, or once it was last persisted
+	// this long ago, whichever comes first.
+	progressPersistWorkItems = 64
+	progressPersistInterval  = 10 * time.Second
 )
 
 var (
@@ -86,6 +94,9 @@ type StateSyncManager struct {
 	unprocessedWorkCond sync.Cond
 	// [workLock] must be held while accessing [processedWork].
 	processedWork *syncWorkHeap
+	// The work items currently being processed.
+	// [workLock] must be held while accessing [processingWork].
+	processingWork set.Set[*syncWorkItem]
 
 	// When this is closed:
 	// - [closed] is true.
@@ -107,6 +118,12 @@ type StateSyncManager struct {
 	// Set to true when StartSyncing is called.
 	syncing   bool
 	closeOnce sync.Once
+
+	// The number of work items completed since the progress was last
+	// persisted, and the time it was last persisted.
+	// [workLock] must be held when accessing these.
+	unpersistedWorkItems int
+	lastPersistTime      time.Time
 }
 
 type StateSyncConfig struct {
@@ -115,6 +132,17 @@ type StateSyncConfig struct {
 	SimultaneousWorkLimit int
 	Log                   logging.Logger
 	TargetRoot            ids.ID
+	// If non-nil, the ranges of keys that have been synced are persisted to
+	// [ProgressDB] so that syncing [SyncDB] resumes from where it left off
+	// after a restart. Ranges that were synced to a root other than
+	// [TargetRoot] are updated with change proofs.
+	//
+	// The progress is persisted periodically while syncing, and when the sync
+	// is closed before it completes. It isn't written atomically with the
+	// proofs committed to [SyncDB], so after a crash the persisted progress
+	// may not match [SyncDB]. Such progress is discarded, and the sync starts
+	// over.
+	ProgressDB database.Database
 }
 
 func NewStateSyncManager(config StateSyncConfig) (*StateSyncManager, error) {
@@ -153,9 +181,9 @@ func (m *StateSyncManager) StartSyncing(ctx context.Context) error {
 		return ErrAlreadyStarted
 	}
 
-	// Add work item to fetch the entire key range.
-	// Note that this will be the first work item to be processed.
-	m.enqueueWork(newWorkItem(ids.Empty, nil, nil, lowPriority))
+	if err := m.enqueueInitialWork(ctx); err != nil {
+		return err
+	}
 
 	m.syncing = true
 	ctx, m.cancelCtx = context.WithCancel(ctx)
@@ -164,6 +192,122 @@ func (m *StateSyncManager) StartSyncing(ctx context.Context) error {
 	return nil
 }
 
+// Adds the work needed to sync the entire key range, resuming from the
+// progress in [m.config.ProgressDB] if there is any.
+// Assumes [m.workLock] is held.
+func (m *StateSyncManager) enqueueInitialWork(ctx context.Context) error {
+	if m.config.ProgressDB == nil {
+		// Add work item to fetch the entire key range.
+		// Note that this will be the first work item to be processed.
+		m.enqueueWork(newWorkItem(ids.Empty, nil, nil, lowPriority))
+		return nil
+	}
+
+	progress, ok, err := getSyncProgress(m.config.ProgressDB)
+	if err != nil {
+		return err
+	}
+	if ok {
+		localRoot, err := m.config.SyncDB.GetMerkleRoot(ctx)
+		if err != nil {
+			return err
+		}
+		if localRoot != progress.LocalRoot {
+			// The sync database was modified after the progress was
+			// persisted, so the synced ranges may no longer be in it.
+			m.config.Log.Info("discarding stale sync progress",
+				zap.Stringer("expectedLocalRoot", progress.LocalRoot),
+				zap.Stringer("localRoot", localRoot),
+			)
+			ok = false
+		}
+	}
+	if !ok {
+		m.enqueueWork(newWorkItem(ids.Empty, nil, nil, lowPriority))
+		return m.persistProgress(ctx)
+	}
+
+	processed, changed, missing := progress.workItems(m.config.TargetRoot)
+	for _, item := range processed {
+		m.processedWork.MergeInsert(item)
+	}
+	for _, item := range changed {
+		m.unprocessedWork.Insert(item)
+	}
+	for _, item := range missing {
+		m.unprocessedWork.Insert(item)
+	}
+	m.config.Log.Info("resuming sync",
+		zap.Stringer("previousTargetRoot", progress.TargetRoot),
+		zap.Stringer("targetRoot", m.config.TargetRoot),
+		zap.Int("numSyncedRanges", len(processed)),
+		zap.Int("numChangedRanges", len(changed)),
+		zap.Int("numMissingRanges", len(missing)),
+	)
+	return m.persistProgress(ctx)
+}
+
+// Persists the progress if enough work items have been completed, or enough
+// time has passed, since it was last persisted.
+// Assumes [m.workLock] is held.
+func (m *StateSyncManager) maybePersistProgress(ctx context.Context) error {
+	m.unpersistedWorkItems++
+	if m.unpersistedWorkItems < progressPersistWorkItems && time.Since(m.lastPersistTime) < progressPersistInterval {
+		return nil
+	}
+	return m.persistProgress(ctx)
+}
+
+// Persists the ranges of keys that are in [m.config.SyncDB] and the root
+// they were synced to, if [m.config.ProgressDB] is non-nil.
+// Assumes [m.workLock] is held.
+func (m *StateSyncManager) persistProgress(ctx context.Context) error {
+	if m.config.ProgressDB == nil {
+		return nil
+	}
+	m.unpersistedWorkItems = 0
+	m.lastPersistTime = time.Now()
+
+	localRoot, err := m.config.SyncDB.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	progress := &syncProgress{
+		TargetRoot: m.getTargetRoot(),
+		LocalRoot:  localRoot,
+	}
+	addSyncedRange := func(item *syncWorkItem) {
+		if item.LocalRootID == ids.Empty {
+			// The keys in this range haven't been synced.
+			return
+		}
+		progress.SyncedRanges = append(progress.SyncedRanges, syncedRange{
+			Start:  item.start,
+			End:    item.end,
+			RootID: item.LocalRootID,
+		})
+	}
+	m.processedWork.forEach(addSyncedRange)
+	m.unprocessedWork.forEach(addSyncedRange)
+	for item := range m.processingWork {
+		addSyncedRange(item)
+	}
+	return putSyncProgress(m.config.ProgressDB, progress)
+}
+
+// Deletes the persisted progress once the sync has completed, so that a later
+// sync doesn't resume from it.
+// Assumes [m.workLock] is held.
+func (m *StateSyncManager) deleteProgress() {
+	if m.config.ProgressDB == nil || m.Error() != nil {
+		// If the sync failed, the progress is kept so that it can be resumed.
+		return
+	}
+	if err := deleteSyncProgress(m.config.ProgressDB); err != nil {
+		m.setError(err)
+	}
+}
+
 // Repeatedly awaits signal on [m.unprocessedWorkCond] that there
 // is work to do or we're done, and dispatches a goroutine to do
 // the work.
@@ -187,6 +331,7 @@ func (m *StateSyncManager) sync(ctx context.Context) {
 			if m.processingWorkItems == 0 {
 				// There's no work to do, and there are no work items being processed
 				// which could cause work to be added, so we're done.
+				m.deleteProgress()
 				return // [m.workLock] released by defer.
 			}
 			// There's no work to do.
@@ -198,6 +343,7 @@ func (m *StateSyncManager) sync(ctx context.Context) {
 		}
 		m.processingWorkItems++
 		workItem := m.unprocessedWork.GetWork()
+		m.processingWork.Add(workItem)
 		// TODO danlaine: We won't release [m.workLock] until
 		// we've started a goroutine for each available work item.
 		// We can't apply proofs we receive until we release [m.workLock].
@@ -212,6 +358,17 @@ func (m *StateSyncManager) Close() {
 		m.workLock.Lock()
 		defer m.workLock.Unlock()
 
+		// Proofs are only committed to [m.config.SyncDB] while [m.workLock]
+		// is held and before [m.syncDoneChan] is closed, so the progress
+		// persisted here matches [m.config.SyncDB]. If the sync completed,
+		// its progress was deleted.
+		completed := m.unprocessedWork.Len() == 0 && m.processingWorkItems == 0
+		if m.syncing && !completed {
+			if err := m.persistProgress(context.TODO()); err != nil {
+				m.config.Log.Warn("couldn't persist sync progress", zap.Error(err))
+			}
+		}
+
 		// Don't process any more work items.
 		// Drop currently processing work items.
 		if m.cancelCtx != nil {
@@ -243,6 +400,7 @@ func (m *StateSyncManager) doWork(ctx context.Context, item *syncWorkItem) {
 		m.workTokens <- token
 		m.workLock.Lock()
 		m.processingWorkItems--
+		m.processingWork.Remove(item)
 		if m.processingWorkItems == 0 && m.unprocessedWork.Len() == 0 {
 			// There are no processing or unprocessed work items so we're done.
 			m.unprocessedWorkCond.Signal()
@@ -526,7 +684,7 @@ func (m *StateSyncManager) UpdateSyncTarget(syncTargetRoot ids.ID) error {
 		// waiting on [m.unprocessedWorkCond].
 		m.unprocessedWorkCond.Signal()
 	}
-	return nil
+	return m.persistProgress(context.TODO())
 }
 
 func (m *StateSyncManager) getTargetRoot() ids.ID {
@@ -581,6 +739,13 @@ func (m *StateSyncManager) completeWorkItem(ctx context.Context, workItem *syncW
 		// the root has changed, so reinsert with high priority
 		m.enqueueWork(newWorkItem(rootID, workItem.start, largestHandledKey, highPriority))
 	}
+
+	// The range of [workItem] is covered by the work items added above, so
+	// don't persist it as well.
+	m.processingWork.Remove(workItem)
+	if err := m.maybePersistProgress(ctx); err != nil {
+		m.setError(err)
+	}
 }
 
 // Queue the given key range to be fetched and applied.
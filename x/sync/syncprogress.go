@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"bytes"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/dioneprotocol/dionego/database"
+	"github.com/dioneprotocol/dionego/ids"
+)
+
+var syncProgressKey = []byte("syncProgress")
+
+// The progress of a sync, persisted so that it can be resumed after a
+// restart.
+type syncProgress struct {
+	// The root that was being synced to.
+	TargetRoot ids.ID `serialize:"true"`
+	// The root of the sync database when the progress was persisted. If the
+	// sync database no longer has this root, it was modified after the
+	// progress was persisted, so [SyncedRanges] can't be trusted.
+	LocalRoot ids.ID `serialize:"true"`
+	// The ranges of keys that are in the sync database, sorted by start.
+	// Ranges that aren't in [SyncedRanges] haven't been synced.
+	SyncedRanges []syncedRange `serialize:"true"`
+}
+
+// Signifies that the keys in [Start, End] in the sync database are the same
+// as in the trie with root [RootID].
+// An empty [Start] means there is no lower bound.
+// An empty [End] means there is no upper bound.
+type syncedRange struct {
+	Start  []byte `serialize:"true"`
+	End    []byte `serialize:"true"`
+	RootID ids.ID `serialize:"true"`
+}
+
+// Returns the progress stored in [db], or false if there isn't any.
+func getSyncProgress(db database.KeyValueReader) (*syncProgress, bool, error) {
+	progressBytes, err := db.Get(syncProgressKey)
+	if err == database.ErrNotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	progress := &syncProgress{}
+	if _, err := syncCodec.Unmarshal(progressBytes, progress); err != nil {
+		return nil, false, err
+	}
+	return progress, true, nil
+}
+
+func putSyncProgress(db database.KeyValueWriter, progress *syncProgress) error {
+	progressBytes, err := syncCodec.Marshal(Version, progress)
+	if err != nil {
+		return err
+	}
+	return db.Put(syncProgressKey, progressBytes)
+}
+
+func deleteSyncProgress(db database.KeyValueDeleter) error {
+	return db.Delete(syncProgressKey)
+}
+
+// Returns the work items needed to resume syncing to [targetRoot] from
+// [progress]:
+//   - [processed] contains the ranges that are already synced to [targetRoot].
+//   - [changed] contains the ranges that were synced to a different root, and
+//     need a change proof.
+//   - [missing] contains the ranges that weren't synced, and need a range
+//     proof.
+func (progress *syncProgress) workItems(targetRoot ids.ID) (processed, changed, missing []*syncWorkItem) {
+	ranges := slices.Clone(progress.SyncedRanges)
+	slices.SortFunc(ranges, func(a, b syncedRange) bool {
+		return bytes.Compare(a.Start, b.Start) < 0
+	})
+
+	// The end of the previous synced range. The key space starts at nil.
+	var (
+		previousEnd []byte
+		isFirst     = true
+	)
+	for _, r := range ranges {
+		if isFirst && len(r.Start) > 0 || !isFirst && !bytes.Equal(previousEnd, r.Start) {
+			missing = append(missing, newWorkItem(ids.Empty, previousEnd, r.Start, lowPriority))
+		}
+		if r.RootID == targetRoot {
+			processed = append(processed, newWorkItem(r.RootID, r.Start, r.End, lowPriority))
+		} else {
+			changed = append(changed, newWorkItem(r.RootID, r.Start, r.End, highPriority))
+		}
+		previousEnd = r.End
+		isFirst = false
+		if len(r.End) == 0 {
+			// This range has no upper bound, so it's the last one.
+			return processed, changed, missing
+		}
+	}
+	// The key space ends at nil.
+	missing = append(missing, newWorkItem(ids.Empty, previousEnd, nil, lowPriority))
+	return processed, changed, missing
+}
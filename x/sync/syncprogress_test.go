@@ -0,0 +1,326 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dioneprotocol/dionego/database/memdb"
+	"github.com/dioneprotocol/dionego/ids"
+	"github.com/dioneprotocol/dionego/utils/logging"
+	"github.com/dioneprotocol/dionego/x/merkledb"
+)
+
+func Test_SyncProgress_Marshal(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	_, ok, err := getSyncProgress(db)
+	require.NoError(err)
+	require.False(ok)
+
+	progress := &syncProgress{
+		TargetRoot: ids.GenerateTestID(),
+		LocalRoot:  ids.GenerateTestID(),
+		SyncedRanges: []syncedRange{
+			{
+				Start:  []byte{},
+				End:    []byte{1},
+				RootID: ids.GenerateTestID(),
+			},
+			{
+				Start:  []byte{2},
+				End:    []byte{},
+				RootID: ids.GenerateTestID(),
+			},
+		},
+	}
+	require.NoError(putSyncProgress(db, progress))
+
+	gotProgress, ok, err := getSyncProgress(db)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(progress, gotProgress)
+}
+
+func Test_SyncProgress_WorkItems(t *testing.T) {
+	targetRoot := ids.GenerateTestID()
+	otherRoot := ids.GenerateTestID()
+
+	type test struct {
+		name              string
+		ranges            []syncedRange
+		expectedProcessed []*syncWorkItem
+		expectedChanged   []*syncWorkItem
+		expectedMissing   []*syncWorkItem
+	}
+	tests := []test{
+		{
+			name:   "nothing synced",
+			ranges: nil,
+			expectedMissing: []*syncWorkItem{
+				newWorkItem(ids.Empty, nil, nil, lowPriority),
+			},
+		},
+		{
+			name: "everything synced",
+			ranges: []syncedRange{
+				{Start: nil, End: nil, RootID: targetRoot},
+			},
+			expectedProcessed: []*syncWorkItem{
+				newWorkItem(targetRoot, nil, nil, lowPriority),
+			},
+		},
+		{
+			name: "everything synced to another root",
+			ranges: []syncedRange{
+				{Start: nil, End: nil, RootID: otherRoot},
+			},
+			expectedChanged: []*syncWorkItem{
+				newWorkItem(otherRoot, nil, nil, highPriority),
+			},
+		},
+		{
+			name: "gaps",
+			ranges: []syncedRange{
+				{Start: []byte{5}, End: []byte{6}, RootID: otherRoot},
+				{Start: []byte{1}, End: []byte{2}, RootID: targetRoot},
+			},
+			expectedProcessed: []*syncWorkItem{
+				newWorkItem(targetRoot, []byte{1}, []byte{2}, lowPriority),
+			},
+			expectedChanged: []*syncWorkItem{
+				newWorkItem(otherRoot, []byte{5}, []byte{6}, highPriority),
+			},
+			expectedMissing: []*syncWorkItem{
+				newWorkItem(ids.Empty, nil, []byte{1}, lowPriority),
+				newWorkItem(ids.Empty, []byte{2}, []byte{5}, lowPriority),
+				newWorkItem(ids.Empty, []byte{6}, nil, lowPriority),
+			},
+		},
+		{
+			name: "contiguous",
+			ranges: []syncedRange{
+				{Start: nil, End: []byte{1}, RootID: targetRoot},
+				{Start: []byte{1}, End: nil, RootID: otherRoot},
+			},
+			expectedProcessed: []*syncWorkItem{
+				newWorkItem(targetRoot, nil, []byte{1}, lowPriority),
+			},
+			expectedChanged: []*syncWorkItem{
+				newWorkItem(otherRoot, []byte{1}, nil, highPriority),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			progress := &syncProgress{
+				TargetRoot:   targetRoot,
+				SyncedRanges: tt.ranges,
+			}
+			processed, changed, missing := progress.workItems(targetRoot)
+			require.Equal(tt.expectedProcessed, processed)
+			require.Equal(tt.expectedChanged, changed)
+			require.Equal(tt.expectedMissing, missing)
+		})
+	}
+}
+
+func Test_Sync_Resume_After_Restart(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := rand.New(rand.NewSource(1)) // #nosec G404
+	dbToSync, err := generateTrie(t, r, 5000)
+	require.NoError(err)
+	syncRoot, err := dbToSync.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	baseDB := memdb.New()
+	newSyncDB := func() *merkledb.Database {
+		db, err := merkledb.New(
+			context.Background(),
+			baseDB,
+			merkledb.Config{
+				Tracer:         newNoopTracer(),
+				HistoryLength:  0,
+				ValueCacheSize: 1000,
+				NodeCacheSize:  1000,
+			},
+		)
+		require.NoError(err)
+		return db
+	}
+	progressDB := memdb.New()
+
+	// Only let a few range proofs through before the restart.
+	var numRangeProofs int32
+	client := NewMockClient(ctrl)
+	client.EXPECT().GetRangeProof(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, request *RangeProofRequest) (*merkledb.RangeProof, error) {
+			if atomic.AddInt32(&numRangeProofs, 1) > 3 {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return dbToSync.GetRangeProofAtRoot(ctx, request.Root, request.Start, request.End, int(request.Limit))
+		},
+	).AnyTimes()
+
+	db := newSyncDB()
+	syncer, err := NewStateSyncManager(StateSyncConfig{
+		SyncDB:                db,
+		Client:                client,
+		TargetRoot:            syncRoot,
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		ProgressDB:            progressDB,
+	})
+	require.NoError(err)
+	require.NoError(syncer.StartSyncing(context.Background()))
+
+	// Wait until we've processed some work before restarting.
+	require.Eventually(
+		func() bool {
+			syncer.workLock.Lock()
+			defer syncer.workLock.Unlock()
+
+			return syncer.processedWork.Len() > 0
+		},
+		3*time.Second,
+		10*time.Millisecond,
+	)
+	syncer.Close()
+	require.NoError(db.Close())
+
+	progress, ok, err := getSyncProgress(progressDB)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(syncRoot, progress.TargetRoot)
+	require.NotEmpty(progress.SyncedRanges)
+
+	// Move the target root while the node is down.
+	batch := dbToSync.NewBatch()
+	for i := 0; i < 50; i++ {
+		key := make([]byte, r.Intn(50))
+		_, err = r.Read(key)
+		require.NoError(err)
+		val := make([]byte, r.Intn(50))
+		_, err = r.Read(val)
+		require.NoError(err)
+		require.NoError(batch.Put(key, val))
+	}
+	require.NoError(batch.Write())
+	syncRoot, err = dbToSync.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	// The ranges synced before the restart are updated with change proofs.
+	var numChangeProofs int32
+	client = NewMockClient(ctrl)
+	client.EXPECT().GetRangeProof(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, request *RangeProofRequest) (*merkledb.RangeProof, error) {
+			return dbToSync.GetRangeProofAtRoot(ctx, request.Root, request.Start, request.End, int(request.Limit))
+		},
+	).AnyTimes()
+	client.EXPECT().GetChangeProof(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, request *ChangeProofRequest, _ *merkledb.Database) (*merkledb.ChangeProof, error) {
+			atomic.AddInt32(&numChangeProofs, 1)
+			return dbToSync.GetChangeProof(ctx, request.StartingRoot, request.EndingRoot, request.Start, request.End, int(request.Limit))
+		},
+	).AnyTimes()
+
+	db = newSyncDB()
+	syncer, err = NewStateSyncManager(StateSyncConfig{
+		SyncDB:                db,
+		Client:                client,
+		TargetRoot:            syncRoot,
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		ProgressDB:            progressDB,
+	})
+	require.NoError(err)
+	require.NoError(syncer.StartSyncing(context.Background()))
+	require.NoError(syncer.Wait(context.Background()))
+	require.NoError(syncer.Error())
+	require.Positive(atomic.LoadInt32(&numChangeProofs))
+
+	newRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(syncRoot, newRoot)
+
+	// The progress is deleted once the sync completes.
+	_, ok, err = getSyncProgress(progressDB)
+	require.NoError(err)
+	require.False(ok)
+}
+
+func Test_Sync_Discard_Stale_Progress(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db, err := merkledb.New(
+		context.Background(),
+		memdb.New(),
+		merkledb.Config{
+			Tracer:         newNoopTracer(),
+			HistoryLength:  0,
+			ValueCacheSize: 1000,
+			NodeCacheSize:  1000,
+		},
+	)
+	require.NoError(err)
+	localRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	// The progress was persisted before the sync database was modified.
+	progressDB := memdb.New()
+	targetRoot := ids.GenerateTestID()
+	require.NoError(putSyncProgress(progressDB, &syncProgress{
+		TargetRoot: targetRoot,
+		LocalRoot:  ids.GenerateTestID(),
+		SyncedRanges: []syncedRange{
+			{
+				Start:  []byte{},
+				End:    []byte{},
+				RootID: targetRoot,
+			},
+		},
+	}))
+
+	syncer, err := NewStateSyncManager(StateSyncConfig{
+		SyncDB:                db,
+		Client:                NewMockClient(ctrl),
+		TargetRoot:            targetRoot,
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		ProgressDB:            progressDB,
+	})
+	require.NoError(err)
+
+	syncer.workLock.Lock()
+	require.NoError(syncer.enqueueInitialWork(context.Background()))
+	require.Zero(syncer.processedWork.Len())
+	require.Positive(syncer.unprocessedWork.Len())
+	syncer.unprocessedWork.forEach(func(item *syncWorkItem) {
+		require.Equal(ids.Empty, item.LocalRootID)
+	})
+	syncer.workLock.Unlock()
+
+	progress, ok, err := getSyncProgress(progressDB)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(localRoot, progress.LocalRoot)
+	require.Empty(progress.SyncedRanges)
+}
@@ -148,6 +148,14 @@ func (wh *syncWorkHeap) MergeInsert(item *syncWorkItem) {
 	}
 }
 
+// Calls [f] on each work item in the heap in order of increasing range start.
+func (wh *syncWorkHeap) forEach(f func(*syncWorkItem)) {
+	wh.sortedItems.Ascend(func(item *heapItem) bool {
+		f(item.workItem)
+		return true
+	})
+}
+
 // Deletes [item] from the heap.
 func (wh *syncWorkHeap) remove(item *heapItem) {
 	oldIndex := item.heapIndex